@@ -28,13 +28,18 @@ import (
 	"zpmeow/internal/application"
 	"zpmeow/internal/config"
 	"zpmeow/internal/domain/session"
+	"zpmeow/internal/infra/campaigns"
 	"zpmeow/internal/infra/chatwoot"
 	"zpmeow/internal/infra/database"
 	"zpmeow/internal/infra/database/repository"
+	"zpmeow/internal/infra/eventstream"
 	"zpmeow/internal/infra/http/handlers"
 	"zpmeow/internal/infra/http/middleware"
 	"zpmeow/internal/infra/http/routes"
 	"zpmeow/internal/infra/logging"
+	"zpmeow/internal/infra/scheduler"
+	"zpmeow/internal/infra/storage"
+	"zpmeow/internal/infra/templates"
 	"zpmeow/internal/infra/webhooks"
 	"zpmeow/internal/infra/wmeow"
 
@@ -60,6 +65,8 @@ import (
 // @tag.description WhatsApp community management endpoints
 // @tag.name Newsletters
 // @tag.description Newsletter management and broadcasting endpoints
+// @tag.name Media
+// @tag.description Media storage endpoints (upload, download, list and delete)
 // @tag.name Webhooks
 // @tag.description Webhook configuration and event management endpoints
 // @tag.name Chatwoot
@@ -102,6 +109,28 @@ func main() {
 	httpClient := webhooks.NewWebhookHTTPClient(30 * time.Second)
 	_ = webhooks.NewService(httpClient)
 
+	// Fila persistente de entregas de webhook (sobrevive a restarts e quedas do receptor)
+	webhookCfg := cfg.GetWebhook()
+	webhookRepo := repository.NewWebhookRepository(db)
+	webhookDeliveryRepo := repository.NewWebhookDeliveryRepository(db)
+	webhookQueue := webhooks.NewDeliveryQueue(
+		webhookDeliveryRepo,
+		webhooks.NewWebhookHTTPClient(webhookCfg.GetTimeout()),
+		webhookRepo,
+		&webhooks.RetryConfig{
+			MaxRetries:        webhookCfg.GetMaxRetries(),
+			InitialBackoff:    webhookCfg.GetInitialBackoff(),
+			MaxBackoff:        webhookCfg.GetMaxBackoff(),
+			BackoffMultiplier: webhookCfg.GetBackoffMultiplier(),
+		},
+		&webhooks.QueueConfig{
+			Workers:      webhookCfg.GetQueueWorkers(),
+			BatchSize:    webhookCfg.GetQueueBatchSize(),
+			PollInterval: webhookCfg.GetQueuePollInterval(),
+		},
+	)
+	webhookQueue.Start()
+
 	waLogger := logging.GetWALogger("WhatsApp")
 
 	// Chatwoot integration (criar antes do wmeowService)
@@ -110,6 +139,8 @@ func main() {
 	messageRepo := repository.NewMessageRepository(db)
 	zpCwRepo := repository.NewZpCwMessageRepository(db)
 	chatRepo := repository.NewChatRepository(db)
+	historySyncRepo := repository.NewHistorySyncRepository(db)
+	receiptRepo := repository.NewMessageReceiptRepository(db)
 	chatwootIntegration := chatwoot.NewIntegration(chatwootLogger, messageRepo, zpCwRepo, chatRepo)
 
 	// Carregar configurações Chatwoot automaticamente no startup
@@ -117,8 +148,36 @@ func main() {
 		log.Errorf("Failed to load Chatwoot configurations: %v", err)
 	}
 
+	storageCfg := cfg.GetStorage()
+	mediaStorage, err := storage.NewMediaStorage(storageCfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize media storage: %v", err)
+	}
+	log.Infof("Media storage initialized (driver: %s)", mediaStorage.Driver())
+
+	mediaLimits := wmeow.MediaLimits{
+		MaxFileSize: storageCfg.GetMaxFileSize(),
+		PerType: map[string]int64{
+			wmeow.MediaTypeImage:    storageCfg.GetMaxImageSize(),
+			wmeow.MediaTypeVideo:    storageCfg.GetMaxVideoSize(),
+			wmeow.MediaTypeAudio:    storageCfg.GetMaxAudioSize(),
+			wmeow.MediaTypeDocument: storageCfg.GetMaxDocumentSize(),
+			wmeow.MediaTypeSticker:  storageCfg.GetMaxStickerSize(),
+		},
+	}
+
+	historyCfg := cfg.GetHistory()
+	historySync := wmeow.HistorySyncOptions{
+		Enabled: historyCfg.GetSyncEnabled(),
+		MaxDays: historyCfg.GetSyncDays(),
+	}
+
+	// Eventos das sessões também são entregues por SSE/WebSocket
+	streamCfg := cfg.GetStream()
+	eventHub := eventstream.NewHub(streamCfg.GetBufferSize(), streamCfg.GetClientBuffer())
+
 	// Criar wmeowService com integração Chatwoot
-	wmeowService := wmeow.NewMeowServiceWithChatwoot(container, waLogger, sessionRepo, chatwootIntegration, chatwootRepo, db)
+	wmeowService := wmeow.NewMeowServiceWithChatwoot(container, waLogger, sessionRepo, chatwootIntegration, chatwootRepo, db, webhookQueue, mediaStorage, mediaLimits, historySync, eventHub)
 
 	domainService := session.NewService()
 
@@ -138,27 +197,84 @@ func main() {
 
 	log.Info("Session service initialized")
 
-	authMiddleware := middleware.NewAuthMiddleware(cfg, sessionRepo, log)
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
+	authMiddleware := middleware.NewAuthMiddleware(cfg, sessionRepo, apiKeyRepo, log)
 
 	appContactService := application.NewContactApp(sessionRepo, wmeowService)
 	appChatService := application.NewChatApp(sessionRepo, wmeowService)
 	appGroupService := application.NewGroupApp(sessionRepo, wmeowService)
 
 	healthHandler := handlers.NewHealthHandler(db)
-	sessionHandler := handlers.NewSessionHandler(appSessionService, wmeowService)
-	messageHandler := handlers.NewMessageHandler(appSessionService, wmeowService)
+	sessionHandler := handlers.NewSessionHandler(appSessionService, wmeowService, eventHub)
+	mediaResolver := storage.NewMediaResolver(wmeowService, storageCfg.GetMaxFileSize())
+	messageHandler := handlers.NewMessageHandler(appSessionService, wmeowService, messageRepo, receiptRepo, mediaResolver)
+
+	// Templates de mensagem por sessão, também referenciados por agendamentos e campanhas
+	templateService := templates.NewService(repository.NewMessageTemplateRepository(db), sessionRepo, wmeowService, mediaResolver)
+	templateHandler := handlers.NewTemplateHandler(appSessionService, templateService)
+
+	// Mensagens agendadas: persistidas em zpScheduledMessages e enviadas pelo worker mesmo após restarts
+	schedulerCfg := cfg.GetScheduler()
+	messageScheduler := scheduler.NewScheduler(
+		repository.NewScheduledMessageRepository(db),
+		sessionRepo,
+		wmeowService,
+		mediaResolver,
+		&scheduler.Config{
+			Workers:      schedulerCfg.GetWorkers(),
+			PollInterval: schedulerCfg.GetPollInterval(),
+			MaxAttempts:  schedulerCfg.GetMaxAttempts(),
+			RetryDelay:   schedulerCfg.GetRetryDelay(),
+		},
+	)
+	messageScheduler.Start()
+	scheduleHandler := handlers.NewScheduleHandler(appSessionService, messageScheduler, templateService)
+
+	// Campanhas: envio em massa com ritmo por sessão; progresso publicado nos webhooks
+	campaignCfg := cfg.GetCampaign()
+	campaignRunner := campaigns.NewRunner(
+		repository.NewCampaignRepository(db),
+		sessionRepo,
+		wmeowService,
+		webhookQueue,
+		&campaigns.Config{
+			DefaultRatePerMinute: campaignCfg.GetDefaultRatePerMinute(),
+			MaxRatePerMinute:     campaignCfg.GetMaxRatePerMinute(),
+			DefaultJitter:        campaignCfg.GetDefaultJitter(),
+			MaxRecipients:        campaignCfg.GetMaxRecipients(),
+			ProgressEvery:        campaignCfg.GetProgressEvery(),
+		},
+	)
+	campaignRunner.Start()
+	campaignHandler := handlers.NewCampaignHandler(appSessionService, campaignRunner, templateService)
 	privacyHandler := handlers.NewPrivacyHandler(appSessionService, wmeowService)
-	chatHandler := handlers.NewChatHandler(appChatService, wmeowService)
+	chatHandler := handlers.NewChatHandler(appChatService, wmeowService, appSessionService, messageRepo, historySyncRepo)
 	contactHandler := handlers.NewContactHandler(appContactService, wmeowService)
 	groupHandler := handlers.NewGroupHandler(appGroupService, wmeowService)
 	communityHandler := handlers.NewCommunityHandler(appSessionService, wmeowService)
 	newsletterHandler := handlers.NewNewsletterHandler(appSessionService, wmeowService)
-	webhookHandler := handlers.NewWebhookHandler(appSessionService, webhookAppService, wmeowService)
+	webhookHandler := handlers.NewWebhookHandler(appSessionService, webhookAppService, wmeowService, webhookRepo, webhookQueue)
+	mediaPolicyRepo := repository.NewMediaPolicyRepository(db)
+	mediaHandler := handlers.NewMediaHandler(appSessionService, wmeowService, mediaPolicyRepo)
 
 	// Chatwoot handler (usando as instâncias já criadas)
 	chatwootHandler := handlers.NewChatwootHandler(appSessionService, chatwootIntegration, chatwootRepo, wmeowService)
 
+	callPolicyRepo := repository.NewCallPolicyRepository(db)
+	callRepo := repository.NewCallRepository(db)
+	callHandler := handlers.NewCallHandler(appSessionService, wmeowService, callPolicyRepo, callRepo)
+
+	labelHandler := handlers.NewLabelHandler(appSessionService, wmeowService)
+
+	statusHandler := handlers.NewStatusHandler(appSessionService, wmeowService, repository.NewStatusRepository(db), mediaResolver)
+
+	apiKeyHandler := handlers.NewAPIKeyHandler(appSessionService, apiKeyRepo)
+
+	streamHandler := handlers.NewEventStreamHandler(appSessionService, eventHub, streamCfg.GetHeartbeatInterval())
+
 	app := fiber.New(fiber.Config{
+		// Uploads chegam em base64 (~4/3 do tamanho original), com folga para o restante do JSON
+		BodyLimit: int(storageCfg.GetMaxFileSize()*4/3) + 1024*1024,
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
 			code := fiber.StatusInternalServerError
 			if e, ok := err.(*fiber.Error); ok {
@@ -180,6 +296,9 @@ func main() {
 		HealthHandler:     healthHandler,
 		SessionHandler:    sessionHandler,
 		MessageHandler:    messageHandler,
+		ScheduleHandler:   scheduleHandler,
+		CampaignHandler:   campaignHandler,
+		TemplateHandler:   templateHandler,
 		PrivacyHandler:    privacyHandler,
 		ChatHandler:       chatHandler,
 		ContactHandler:    contactHandler,
@@ -187,7 +306,13 @@ func main() {
 		CommunityHandler:  communityHandler,
 		NewsletterHandler: newsletterHandler,
 		WebhookHandler:    webhookHandler,
+		MediaHandler:      mediaHandler,
 		ChatwootHandler:   chatwootHandler,
+		StreamHandler:     streamHandler,
+		CallHandler:       callHandler,
+		LabelHandler:      labelHandler,
+		StatusHandler:     statusHandler,
+		APIKeyHandler:     apiKeyHandler,
 	}
 
 	routes.SetupRoutes(app, handlerDeps, authMiddleware)
@@ -206,10 +331,17 @@ func main() {
 	<-quit
 	log.Info("Shutting down server...")
 
+	// Encerra os streams abertos, senão o Shutdown espera as conexões indefinidamente
+	eventHub.Close()
+
 	if err := app.Shutdown(); err != nil {
 		log.Errorf("Server forced to shutdown: %v", err)
 	}
 
+	campaignRunner.Stop()
+	messageScheduler.Stop()
+	webhookQueue.Stop()
+
 	log.Info("Server exited")
 }
 
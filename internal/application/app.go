@@ -59,13 +59,34 @@ func (s *SessionApp) CreateSessionWithRequest(ctx context.Context, req CreateSes
 		return nil, err
 	}
 
-	return s.sessionRepo.GetByID(ctx, generatedID)
+	created, err := s.sessionRepo.GetByID(ctx, generatedID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Só o hash da chave é guardado, então ela vem da criação e é devolvida uma única vez
+	if err := created.SetApiKey(sess.ApiKey().Value()); err != nil {
+		return nil, err
+	}
+
+	return created, nil
 }
 
 func (s *SessionApp) DeleteSession(ctx context.Context, sessionID string) error {
 	return s.sessionRepo.Delete(ctx, sessionID)
 }
 
+// UpdateSessionProxy persiste o proxy da sessão; uma URL vazia remove o proxy
+func (s *SessionApp) UpdateSessionProxy(ctx context.Context, sess *session.Session, proxyURL string) error {
+	if strings.TrimSpace(proxyURL) == "" {
+		sess.ClearProxy()
+	} else if err := sess.SetProxyConfiguration(proxyURL); err != nil {
+		return err
+	}
+
+	return s.sessionRepo.Update(ctx, sess)
+}
+
 func (s *SessionApp) GetSessionByDeviceJID(ctx context.Context, deviceJID string) (*session.Session, error) {
 	return nil, fmt.Errorf("GetSessionByDeviceJID not implemented")
 }
@@ -198,6 +219,20 @@ func (w *WebhookApp) ListEvents(ctx context.Context) ([]string, error) {
 
 		"ManualLoginReconnect",
 
+		"CampaignProgress",
+		"CampaignCompleted",
+
+		"HistorySyncProgress",
+
+		"message.edited",
+		"message.deleted",
+		"message.reaction",
+
+		"status.received",
+
+		"privacy.updated",
+		"chat.updated",
+
 		"All",
 	}
 	return events, nil
@@ -231,29 +266,21 @@ type GetChatHistoryRequest struct {
 	SessionID string
 	Phone     string
 	Limit     int
-	Offset    int
+	Before    string
+	After     string
+	Types     []string
+	Sender    string
 }
 
 type GetChatHistoryResponse struct {
-	SessionID string
-	Phone     string
-	Messages  []ChatMessage
-	Count     int
-	Limit     int
-	Offset    int
-}
-
-type ChatMessage struct {
-	ID        string
-	ChatJID   string
-	FromJID   string
-	Content   string
-	Type      string
-	Timestamp string
-	IsFromMe  bool
-	IsRead    bool
-	MediaURL  string
-	Caption   string
+	SessionID    string
+	Phone        string
+	Messages     []ports.ChatMessage
+	Count        int
+	Limit        int
+	HasMore      bool
+	BeforeCursor string
+	AfterCursor  string
 }
 
 func (app *ChatApp) GetChatHistory(ctx context.Context, req GetChatHistoryRequest) (*GetChatHistoryResponse, error) {
@@ -269,34 +296,27 @@ func (app *ChatApp) GetChatHistory(ctx context.Context, req GetChatHistoryReques
 		chatJID = req.Phone
 	}
 
-	messages, err := app.chatManager.GetChatHistory(ctx, req.SessionID, chatJID, req.Limit, req.Offset)
+	page, err := app.chatManager.GetChatHistory(ctx, req.SessionID, ports.ChatHistoryQuery{
+		ChatJID: chatJID,
+		Limit:   req.Limit,
+		Before:  req.Before,
+		After:   req.After,
+		Types:   req.Types,
+		Sender:  req.Sender,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get chat history: %w", err)
 	}
 
-	chatMessages := make([]ChatMessage, len(messages))
-	for i, message := range messages {
-		chatMessages[i] = ChatMessage{
-			ID:        message.ID,
-			ChatJID:   message.ChatJID,
-			FromJID:   message.FromJID,
-			Content:   message.Content,
-			Type:      message.Type,
-			Timestamp: fmt.Sprintf("%d", message.Timestamp.Unix()),
-			IsFromMe:  false,
-			IsRead:    false,
-			MediaURL:  "",
-			Caption:   "",
-		}
-	}
-
 	return &GetChatHistoryResponse{
-		SessionID: req.SessionID,
-		Phone:     req.Phone,
-		Messages:  chatMessages,
-		Count:     len(chatMessages),
-		Limit:     req.Limit,
-		Offset:    req.Offset,
+		SessionID:    req.SessionID,
+		Phone:        req.Phone,
+		Messages:     page.Messages,
+		Count:        len(page.Messages),
+		Limit:        req.Limit,
+		HasMore:      page.HasMore,
+		BeforeCursor: page.BeforeCursor,
+		AfterCursor:  page.AfterCursor,
 	}, nil
 }
 
@@ -18,6 +18,16 @@ var (
 	ErrOperationFailed        = errors.New("operation failed")
 	ErrConcurrentModification = errors.New("concurrent modification detected")
 	ErrResourceUnavailable    = errors.New("resource unavailable")
+
+	ErrMediaNotFound      = errors.New("media not found")
+	ErrMediaTooLarge      = errors.New("media too large")
+	ErrUnsupportedMediaOp = errors.New("media operation not supported")
+
+	ErrMessageNotFound = errors.New("message not found")
+
+	ErrCallNotFound = errors.New("call not found")
+
+	ErrLabelNotFound = errors.New("label not found")
 )
 
 type ValidationError struct {
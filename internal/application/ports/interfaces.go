@@ -20,6 +20,7 @@ type SessionManager interface {
 	ConnectOnStartup(ctx context.Context) error
 	ConnectSession(ctx context.Context, sessionID string) (string, error)
 	DisconnectSession(ctx context.Context, sessionID string) error
+	UpdateSessionProxy(ctx context.Context, sessionID string, proxy session.ProxyConfiguration) error
 }
 
 type ButtonData struct {
@@ -52,21 +53,33 @@ type ContactData struct {
 	Phone string `json:"phone"`
 }
 
+// SendOptions são os campos opcionais de contexto de um envio: resposta a uma mensagem, menções e encaminhamento
+type SendOptions struct {
+	QuotedMessageID string   // ID WhatsApp da mensagem respondida, buscada em zpMessages
+	Mentions        []string // telefones ou JIDs mencionados
+	MentionAll      bool     // menciona todos os participantes do grupo
+	Forwarded       bool
+}
+
+func (o SendOptions) IsEmpty() bool {
+	return o.QuotedMessageID == "" && len(o.Mentions) == 0 && !o.MentionAll && !o.Forwarded
+}
+
 type MessageSender interface {
-	SendTextMessage(ctx context.Context, sessionID, phone, text string) (*whatsmeow.SendResponse, error)
-	SendMediaMessage(ctx context.Context, sessionID, phone string, media MediaMessage) (*whatsmeow.SendResponse, error)
-	SendImageMessage(ctx context.Context, sessionID, phone string, data []byte, caption, mimeType string) (*whatsmeow.SendResponse, error)
-	SendAudioMessage(ctx context.Context, sessionID, phone string, data []byte, mimeType string) (*whatsmeow.SendResponse, error)
-	SendAudioMessageWithPTT(ctx context.Context, sessionID, phone string, data []byte, mimeType string, ptt bool) (*whatsmeow.SendResponse, error)
-	SendVideoMessage(ctx context.Context, sessionID, phone string, data []byte, caption, mimeType string) (*whatsmeow.SendResponse, error)
-	SendDocumentMessage(ctx context.Context, sessionID, phone string, data []byte, filename, caption, mimeType string) (*whatsmeow.SendResponse, error)
-	SendStickerMessage(ctx context.Context, sessionID, phone string, data []byte, mimeType string) (*whatsmeow.SendResponse, error)
-
-	SendContactsMessage(ctx context.Context, sessionID, phone string, contacts []ContactData) (*whatsmeow.SendResponse, error)
-	SendLocationMessage(ctx context.Context, sessionID, phone string, latitude, longitude float64, name, address string) (*whatsmeow.SendResponse, error)
+	SendTextMessage(ctx context.Context, sessionID, phone, text string, opts ...SendOptions) (*whatsmeow.SendResponse, error)
+	SendMediaMessage(ctx context.Context, sessionID, phone string, media MediaMessage, opts ...SendOptions) (*whatsmeow.SendResponse, error)
+	SendImageMessage(ctx context.Context, sessionID, phone string, data []byte, caption, mimeType string, opts ...SendOptions) (*whatsmeow.SendResponse, error)
+	SendAudioMessage(ctx context.Context, sessionID, phone string, data []byte, mimeType string, opts ...SendOptions) (*whatsmeow.SendResponse, error)
+	SendAudioMessageWithPTT(ctx context.Context, sessionID, phone string, data []byte, mimeType string, ptt bool, opts ...SendOptions) (*whatsmeow.SendResponse, error)
+	SendVideoMessage(ctx context.Context, sessionID, phone string, data []byte, caption, mimeType string, opts ...SendOptions) (*whatsmeow.SendResponse, error)
+	SendDocumentMessage(ctx context.Context, sessionID, phone string, data []byte, filename, caption, mimeType string, opts ...SendOptions) (*whatsmeow.SendResponse, error)
+	SendStickerMessage(ctx context.Context, sessionID, phone string, data []byte, mimeType string, opts ...SendOptions) (*whatsmeow.SendResponse, error)
+
+	SendContactsMessage(ctx context.Context, sessionID, phone string, contacts []ContactData, opts ...SendOptions) (*whatsmeow.SendResponse, error)
+	SendLocationMessage(ctx context.Context, sessionID, phone string, latitude, longitude float64, name, address string, opts ...SendOptions) (*whatsmeow.SendResponse, error)
 	SendButtonMessage(ctx context.Context, sessionID, phone, title string, buttons []ButtonData) (*whatsmeow.SendResponse, error)
 	SendListMessage(ctx context.Context, sessionID, phone, title, description, buttonText, footerText string, sections []ListSection) (*whatsmeow.SendResponse, error)
-	SendPollMessage(ctx context.Context, sessionID, phone, name string, options []string, selectableCount int) (*whatsmeow.SendResponse, error)
+	SendPollMessage(ctx context.Context, sessionID, phone, name string, options []string, selectableCount int, opts ...SendOptions) (*whatsmeow.SendResponse, error)
 }
 
 type MessageActions interface {
@@ -179,6 +192,8 @@ type ChatInfo struct {
 	IsPinned      bool      `json:"is_pinned"`
 	IsMuted       bool      `json:"is_muted"`
 	IsArchived    bool      `json:"is_archived"`
+	MutedUntil    string    `json:"muted_until,omitempty"`
+	MarkedUnread  bool      `json:"marked_unread"`
 	UnreadCount   int       `json:"unread_count"`
 	LastMessage   string    `json:"last_message,omitempty"`
 	LastMessageAt string    `json:"last_message_at,omitempty"`
@@ -187,24 +202,72 @@ type ChatInfo struct {
 }
 
 type ChatMessage struct {
-	ID        string    `json:"id"`
-	ChatJID   string    `json:"chat_jid"`
-	FromJID   string    `json:"from_jid"`
-	Text      string    `json:"text"`
-	Content   string    `json:"content"`
-	Timestamp time.Time `json:"timestamp"`
-	Type      string    `json:"type"`
+	ID          string                `json:"id"`
+	ChatJID     string                `json:"chat_jid"`
+	FromJID     string                `json:"from_jid"`
+	SenderName  string                `json:"sender_name,omitempty"`
+	Text        string                `json:"text"`
+	Content     string                `json:"content"`
+	Timestamp   time.Time             `json:"timestamp"`
+	Type        string                `json:"type"`
+	IsFromMe    bool                  `json:"is_from_me"`
+	IsForwarded bool                  `json:"is_forwarded"`
+	Status      string                `json:"status"`
+	Media       *ChatMessageMedia     `json:"media,omitempty"`
+	Quoted      *ChatMessageQuote     `json:"quoted,omitempty"`
+	Mentions    []string              `json:"mentions,omitempty"`
+	Reactions   []ChatMessageReaction `json:"reactions,omitempty"`
+	EditedAt    *time.Time            `json:"edited_at,omitempty"`
+	IsDeleted   bool                  `json:"is_deleted"`
+	DeletedAt   *time.Time            `json:"deleted_at,omitempty"`
+	Cursor      string                `json:"cursor"`
+}
+
+type ChatMessageMedia struct {
+	MimeType   string `json:"mime_type,omitempty"`
+	FileName   string `json:"file_name,omitempty"`
+	FileLength int64  `json:"file_length,omitempty"`
+	MediaID    string `json:"media_id,omitempty"` // mídia arquivada no storage
+}
+
+type ChatMessageQuote struct {
+	MessageID string `json:"message_id"`
+	Content   string `json:"content,omitempty"`
+}
+
+type ChatMessageReaction struct {
+	Emoji     string `json:"emoji"`
+	SenderJID string `json:"sender_jid,omitempty"`
+}
+
+// ChatHistoryQuery pagina o histórico por cursor; Before e After aceitam o cursor de uma mensagem ou o seu ID do WhatsApp
+type ChatHistoryQuery struct {
+	ChatJID string
+	Limit   int
+	Before  string
+	After   string
+	Types   []string
+	Sender  string
+}
+
+type ChatHistoryPage struct {
+	Messages     []ChatMessage
+	HasMore      bool   // há mais mensagens na direção pedida
+	BeforeCursor string // mensagens mais antigas que a página
+	AfterCursor  string // mensagens mais novas que a página
 }
 
 type ChatManager interface {
 	SetDisappearingTimer(ctx context.Context, sessionID, chatJID string, duration time.Duration) error
-	ListChats(ctx context.Context, sessionID, chatType string) ([]ChatInfo, error)
+	// ListChats lista os chats guardados, opcionalmente só os que têm a etiqueta labelID
+	ListChats(ctx context.Context, sessionID, chatType, labelID string) ([]ChatInfo, error)
 	GetChats(ctx context.Context, sessionID string, limit, offset int) ([]ChatInfo, error)
 	GetChatInfo(ctx context.Context, sessionID, chatJID string) (*ChatInfo, error)
 	PinChat(ctx context.Context, sessionID, chatJID string, pinned bool) error
 	MuteChat(ctx context.Context, sessionID, chatJID string, muted bool, duration time.Duration) error
 	ArchiveChat(ctx context.Context, sessionID, chatJID string, archived bool) error
-	GetChatHistory(ctx context.Context, sessionID, chatJID string, limit, offset int) ([]ChatMessage, error)
+	MarkChatRead(ctx context.Context, sessionID, chatJID string, read bool) error
+	GetChatHistory(ctx context.Context, sessionID string, query ChatHistoryQuery) (*ChatHistoryPage, error)
 }
 
 type NewsletterInfo struct {
@@ -258,16 +321,71 @@ type PrivacySettings struct {
 	ReadReceipts         bool   `json:"read_receipts"`
 	GroupsAddMe          string `json:"groups_add_me"`
 	CallsAddMe           string `json:"calls_add_me"`
+	Online               string `json:"online"`
 	DisappearingMessages string `json:"disappearing_messages"`
+	// DisappearingTimer é o timer padrão em segundos; nil enquanto não for definido pela API,
+	// já que o WhatsApp não permite consultá-lo
+	DisappearingTimer *int      `json:"disappearing_timer"`
+	UpdatedAt         time.Time `json:"updated_at"`
 }
 
 type PrivacyManager interface {
 	GetPrivacySettings(ctx context.Context, sessionID string) (*PrivacySettings, error)
 	SetPrivacySetting(ctx context.Context, sessionID, setting, value string) error
+	// SetDisappearingMessagesDefault define o timer padrão das novas conversas, em segundos (0, 24h, 7d ou 90d)
+	SetDisappearingMessagesDefault(ctx context.Context, sessionID string, duration int) error
 	GetBlocklist(ctx context.Context, sessionID string) ([]string, error)
 	UpdateBlocklist(ctx context.Context, sessionID string, action string, contacts []string) error
 }
 
+type CallManager interface {
+	// RejectCall rejeita uma chamada recebida; sem from, quem ligou é buscado no registro de chamadas
+	RejectCall(ctx context.Context, sessionID, callID, from string) error
+}
+
+// Label é uma etiqueta do WhatsApp Business; ID é o ID da etiqueta no WhatsApp
+type Label struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Color        int       `json:"color"`
+	PredefinedID *int      `json:"predefined_id,omitempty"`
+	ChatCount    int       `json:"chat_count"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type LabelManager interface {
+	ListLabels(ctx context.Context, sessionID string) ([]Label, error)
+	CreateLabel(ctx context.Context, sessionID, name string, color int) (*Label, error)
+	// EditLabel altera o nome e, se informada, a cor da etiqueta
+	EditLabel(ctx context.Context, sessionID, labelID, name string, color *int) (*Label, error)
+	DeleteLabel(ctx context.Context, sessionID, labelID string) error
+	LabelChat(ctx context.Context, sessionID, labelID, chatJID string, labeled bool) error
+	LabelMessage(ctx context.Context, sessionID, labelID, chatJID, messageID string, labeled bool) error
+}
+
+// TextStatus é um status de texto; as cores aceitam #RRGGBB ou #AARRGGBB
+type TextStatus struct {
+	Text            string
+	BackgroundColor string
+	TextColor       string
+	Font            string // nome da fonte do WhatsApp (system, fb_script, system_bold...)
+}
+
+// StatusAudience é quem recebe os status publicados, conforme a privacidade de status da conta
+type StatusAudience struct {
+	Type string   `json:"type"`           // contacts, whitelist (só a lista) ou blacklist (contatos exceto a lista)
+	List []string `json:"list,omitempty"` // JIDs da lista da whitelist ou da blacklist
+}
+
+// StatusManager publica status (stories) em status@broadcast. O WhatsApp entrega cada status ao
+// público da privacidade de status da conta; não é possível escolher os destinatários por publicação.
+type StatusManager interface {
+	PostTextStatus(ctx context.Context, sessionID string, status TextStatus) (*whatsmeow.SendResponse, error)
+	PostImageStatus(ctx context.Context, sessionID string, data []byte, caption, mimeType string) (*whatsmeow.SendResponse, error)
+	PostVideoStatus(ctx context.Context, sessionID string, data []byte, caption, mimeType string) (*whatsmeow.SendResponse, error)
+	GetStatusAudience(ctx context.Context, sessionID string) (*StatusAudience, error)
+}
+
 type WebhookManager interface {
 	UpdateSessionWebhook(sessionID, webhookURL string) error
 	UpdateSessionSubscriptions(sessionID string, events []string) error
@@ -282,14 +400,48 @@ type ProfileManager interface {
 }
 
 type MediaManager interface {
-	UploadMedia(ctx context.Context, sessionID string, data []byte, mediaType string) (string, error)
+	UploadMedia(ctx context.Context, sessionID string, data []byte, mediaType, fileName string) (string, error)
 	GetMediaInfo(ctx context.Context, sessionID, mediaID string) (map[string]interface{}, error)
+	GetMediaContent(ctx context.Context, sessionID, mediaID string) ([]byte, map[string]interface{}, error)
 	DeleteMedia(ctx context.Context, sessionID, mediaID string) error
 	ListMedia(ctx context.Context, sessionID string, limit, offset int) ([]map[string]interface{}, error)
 	GetMediaProgress(ctx context.Context, sessionID, mediaID string) (map[string]interface{}, error)
 	ConvertMedia(ctx context.Context, sessionID, mediaID, targetFormat string) (string, error)
 	CompressMedia(ctx context.Context, sessionID, mediaID string, quality int) (string, error)
 	GetMediaMetadata(ctx context.Context, sessionID, mediaID string) (map[string]interface{}, error)
+	GetMediaStorageUsage(ctx context.Context, sessionID string) (int64, error)
+}
+
+// MediaSource descreve a origem da mídia de um envio: bytes já lidos (upload multipart) ou uma referência
+type MediaSource struct {
+	Data      []byte // conteúdo já carregado; tem prioridade sobre Reference
+	Reference string // data URL, base64 puro, URL http(s) ou media ID do storage
+	FileName  string
+	MimeType  string
+}
+
+func (s MediaSource) IsEmpty() bool {
+	return len(s.Data) == 0 && s.Reference == ""
+}
+
+type ResolvedMedia struct {
+	Data     []byte
+	MimeType string
+	FileName string
+}
+
+// MediaResolver converte qualquer MediaSource nos bytes prontos para envio
+type MediaResolver interface {
+	Resolve(ctx context.Context, sessionID, mediaType string, source MediaSource) (*ResolvedMedia, error)
+}
+
+// MediaStorage é o backend onde o conteúdo das mídias é gravado (filesystem local, S3...)
+type MediaStorage interface {
+	Driver() string
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+	Exists(ctx context.Context, key string) (bool, error)
 }
 
 type WameowService interface {
@@ -301,6 +453,9 @@ type WameowService interface {
 	ChatManager
 	NewsletterManager
 	PrivacyManager
+	CallManager
+	LabelManager
+	StatusManager
 	WebhookManager
 	ProfileManager
 	MediaManager
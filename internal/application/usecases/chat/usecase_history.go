@@ -14,7 +14,10 @@ type GetChatHistoryQuery struct {
 	SessionID string
 	ChatJID   string
 	Limit     int
-	Offset    int
+	Before    string
+	After     string
+	Types     []string
+	Sender    string
 }
 
 func (q GetChatHistoryQuery) Validate() error {
@@ -34,33 +37,22 @@ func (q GetChatHistoryQuery) Validate() error {
 		return common.NewValidationError("limit", q.Limit, "limit cannot exceed 1000")
 	}
 
-	if q.Offset < 0 {
-		return common.NewValidationError("offset", q.Offset, "offset cannot be negative")
+	if q.Before != "" && q.After != "" {
+		return common.NewValidationError("after", q.After, "before and after cannot be used together")
 	}
 
 	return nil
 }
 
-type MessageView struct {
-	ID        string
-	ChatJID   string
-	FromJID   string
-	Content   string
-	Type      string
-	Timestamp string
-	IsFromMe  bool
-	IsRead    bool
-	MediaURL  string
-	Caption   string
-}
-
 type GetChatHistoryResult struct {
-	SessionID string
-	ChatJID   string
-	Messages  []MessageView
-	Total     int
-	Limit     int
-	Offset    int
+	SessionID    string
+	ChatJID      string
+	Messages     []ports.ChatMessage
+	Total        int
+	Limit        int
+	HasMore      bool
+	BeforeCursor string
+	AfterCursor  string
 }
 
 type GetChatHistoryUseCase struct {
@@ -100,7 +92,14 @@ func (uc *GetChatHistoryUseCase) Handle(ctx context.Context, query GetChatHistor
 		)
 	}
 
-	messages, err := uc.whatsappService.GetChatHistory(ctx, query.SessionID, query.ChatJID, query.Limit, query.Offset)
+	page, err := uc.whatsappService.GetChatHistory(ctx, query.SessionID, ports.ChatHistoryQuery{
+		ChatJID: query.ChatJID,
+		Limit:   query.Limit,
+		Before:  query.Before,
+		After:   query.After,
+		Types:   query.Types,
+		Sender:  query.Sender,
+	})
 	if err != nil {
 		uc.logger.Error(ctx, "Failed to get chat history",
 			"sessionID", query.SessionID,
@@ -109,34 +108,20 @@ func (uc *GetChatHistoryUseCase) Handle(ctx context.Context, query GetChatHistor
 		return nil, fmt.Errorf("failed to get chat history: %w", err)
 	}
 
-	messageViews := make([]MessageView, len(messages))
-	for i, message := range messages {
-		messageViews[i] = MessageView{
-			ID:        message.ID,
-			ChatJID:   message.ChatJID,
-			FromJID:   message.FromJID,
-			Content:   message.Content,
-			Type:      message.Type,
-			Timestamp: fmt.Sprintf("%d", message.Timestamp.Unix()),
-			IsFromMe:  false,
-			IsRead:    false,
-			MediaURL:  "",
-			Caption:   "",
-		}
-	}
-
 	uc.logger.Debug(ctx, "Chat history retrieved successfully",
 		"sessionID", query.SessionID,
 		"chatJID", query.ChatJID,
-		"messageCount", len(messageViews))
+		"messageCount", len(page.Messages))
 
 	return &GetChatHistoryResult{
-		SessionID: query.SessionID,
-		ChatJID:   query.ChatJID,
-		Messages:  messageViews,
-		Total:     len(messageViews),
-		Limit:     query.Limit,
-		Offset:    query.Offset,
+		SessionID:    query.SessionID,
+		ChatJID:      query.ChatJID,
+		Messages:     page.Messages,
+		Total:        len(page.Messages),
+		Limit:        query.Limit,
+		HasMore:      page.HasMore,
+		BeforeCursor: page.BeforeCursor,
+		AfterCursor:  page.AfterCursor,
 	}, nil
 }
 
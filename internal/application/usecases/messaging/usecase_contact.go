@@ -40,6 +40,7 @@ type SendContactMessageCommand struct {
 	SessionID string
 	ChatJID   string
 	Contacts  []ContactInfo
+	Options   ports.SendOptions
 }
 
 func (c SendContactMessageCommand) Validate() error {
@@ -128,7 +129,7 @@ func (uc *SendContactMessageUseCase) Handle(ctx context.Context, cmd SendContact
 		})
 	}
 
-	_, err = uc.whatsappService.SendContactsMessage(ctx, cmd.SessionID, cmd.ChatJID, contactData)
+	sendResp, err := uc.whatsappService.SendContactsMessage(ctx, cmd.SessionID, cmd.ChatJID, contactData, cmd.Options)
 	if err != nil {
 		uc.logger.Error(ctx, "Failed to send contact message",
 			"sessionID", cmd.SessionID,
@@ -147,7 +148,7 @@ func (uc *SendContactMessageUseCase) Handle(ctx context.Context, cmd SendContact
 		SessionID:    cmd.SessionID,
 		ChatJID:      cmd.ChatJID,
 		ContactCount: len(cmd.Contacts),
-		MessageID:    "",
+		MessageID:    string(sendResp.ID),
 		Sent:         true,
 	}, nil
 }
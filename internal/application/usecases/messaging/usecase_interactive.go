@@ -0,0 +1,308 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"zpmeow/internal/application/common"
+	"zpmeow/internal/application/ports"
+	"zpmeow/internal/domain/session"
+)
+
+type ButtonData = ports.ButtonData
+type ListSection = ports.ListSection
+
+// ensureSessionCanSend verifica se a sessão está conectada e autenticada para enviar mensagens
+func ensureSessionCanSend(ctx context.Context, sessionRepo session.Repository, sessionID string) error {
+	sessionEntity, err := sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+
+	if !sessionEntity.IsConnected() {
+		return common.NewBusinessRuleError(
+			"session_not_connected",
+			fmt.Sprintf("session must be connected to send messages, current status: %s", sessionEntity.Status()),
+		)
+	}
+
+	if !sessionEntity.IsAuthenticated() {
+		return common.NewBusinessRuleError(
+			"session_not_authenticated",
+			"session must be authenticated to send messages",
+		)
+	}
+
+	return nil
+}
+
+type SendPollMessageCommand struct {
+	SessionID       string
+	ChatJID         string
+	Name            string
+	Options         []string
+	SelectableCount int
+	SendOptions     ports.SendOptions
+}
+
+func (c SendPollMessageCommand) Validate() error {
+	if strings.TrimSpace(c.SessionID) == "" {
+		return common.NewValidationError("sessionID", c.SessionID, "session ID is required")
+	}
+
+	if strings.TrimSpace(c.ChatJID) == "" {
+		return common.NewValidationError("chatJID", c.ChatJID, "chat JID is required")
+	}
+
+	if strings.TrimSpace(c.Name) == "" {
+		return common.NewValidationError("name", c.Name, "poll name is required")
+	}
+
+	if len(c.Options) < 2 || len(c.Options) > 12 {
+		return common.NewValidationError("options", len(c.Options), "poll must have between 2 and 12 options")
+	}
+
+	if c.SelectableCount < 0 || c.SelectableCount > len(c.Options) {
+		return common.NewValidationError("selectableCount", c.SelectableCount, "selectable count cannot be greater than number of options")
+	}
+
+	return nil
+}
+
+type SendPollMessageResult struct {
+	SessionID string
+	ChatJID   string
+	MessageID string
+	Sent      bool
+}
+
+type SendPollMessageUseCase struct {
+	sessionRepo     session.Repository
+	whatsappService ports.WhatsAppService
+	logger          ports.Logger
+}
+
+func NewSendPollMessageUseCase(
+	sessionRepo session.Repository,
+	whatsappService ports.WhatsAppService,
+	logger ports.Logger,
+) *SendPollMessageUseCase {
+	return &SendPollMessageUseCase{
+		sessionRepo:     sessionRepo,
+		whatsappService: whatsappService,
+		logger:          logger,
+	}
+}
+
+func (uc *SendPollMessageUseCase) Handle(ctx context.Context, cmd SendPollMessageCommand) (*SendPollMessageResult, error) {
+	if err := cmd.Validate(); err != nil {
+		uc.logger.Warn(ctx, "Invalid send poll message command", "error", err)
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := ensureSessionCanSend(ctx, uc.sessionRepo, cmd.SessionID); err != nil {
+		return nil, err
+	}
+
+	selectableCount := cmd.SelectableCount
+	if selectableCount == 0 {
+		selectableCount = 1
+	}
+
+	sendResp, err := uc.whatsappService.SendPollMessage(ctx, cmd.SessionID, cmd.ChatJID, cmd.Name, cmd.Options, selectableCount, cmd.SendOptions)
+	if err != nil {
+		uc.logger.Error(ctx, "Failed to send poll message",
+			"sessionID", cmd.SessionID,
+			"chatJID", cmd.ChatJID,
+			"error", err)
+		return nil, fmt.Errorf("failed to send poll message: %w", err)
+	}
+
+	uc.logger.Info(ctx, "Poll message sent successfully",
+		"sessionID", cmd.SessionID,
+		"chatJID", cmd.ChatJID,
+		"options", len(cmd.Options))
+
+	return &SendPollMessageResult{
+		SessionID: cmd.SessionID,
+		ChatJID:   cmd.ChatJID,
+		MessageID: string(sendResp.ID),
+		Sent:      true,
+	}, nil
+}
+
+type SendButtonMessageCommand struct {
+	SessionID string
+	ChatJID   string
+	Title     string
+	Buttons   []ButtonData
+}
+
+func (c SendButtonMessageCommand) Validate() error {
+	if strings.TrimSpace(c.SessionID) == "" {
+		return common.NewValidationError("sessionID", c.SessionID, "session ID is required")
+	}
+
+	if strings.TrimSpace(c.ChatJID) == "" {
+		return common.NewValidationError("chatJID", c.ChatJID, "chat JID is required")
+	}
+
+	if strings.TrimSpace(c.Title) == "" {
+		return common.NewValidationError("title", c.Title, "title is required")
+	}
+
+	if len(c.Buttons) == 0 || len(c.Buttons) > 3 {
+		return common.NewValidationError("buttons", len(c.Buttons), "between 1 and 3 buttons are required")
+	}
+
+	return nil
+}
+
+type SendButtonMessageResult struct {
+	SessionID string
+	ChatJID   string
+	MessageID string
+	Sent      bool
+}
+
+type SendButtonMessageUseCase struct {
+	sessionRepo     session.Repository
+	whatsappService ports.WhatsAppService
+	logger          ports.Logger
+}
+
+func NewSendButtonMessageUseCase(
+	sessionRepo session.Repository,
+	whatsappService ports.WhatsAppService,
+	logger ports.Logger,
+) *SendButtonMessageUseCase {
+	return &SendButtonMessageUseCase{
+		sessionRepo:     sessionRepo,
+		whatsappService: whatsappService,
+		logger:          logger,
+	}
+}
+
+func (uc *SendButtonMessageUseCase) Handle(ctx context.Context, cmd SendButtonMessageCommand) (*SendButtonMessageResult, error) {
+	if err := cmd.Validate(); err != nil {
+		uc.logger.Warn(ctx, "Invalid send button message command", "error", err)
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := ensureSessionCanSend(ctx, uc.sessionRepo, cmd.SessionID); err != nil {
+		return nil, err
+	}
+
+	sendResp, err := uc.whatsappService.SendButtonMessage(ctx, cmd.SessionID, cmd.ChatJID, cmd.Title, cmd.Buttons)
+	if err != nil {
+		uc.logger.Error(ctx, "Failed to send button message",
+			"sessionID", cmd.SessionID,
+			"chatJID", cmd.ChatJID,
+			"error", err)
+		return nil, fmt.Errorf("failed to send button message: %w", err)
+	}
+
+	uc.logger.Info(ctx, "Button message sent successfully",
+		"sessionID", cmd.SessionID,
+		"chatJID", cmd.ChatJID,
+		"buttons", len(cmd.Buttons))
+
+	return &SendButtonMessageResult{
+		SessionID: cmd.SessionID,
+		ChatJID:   cmd.ChatJID,
+		MessageID: string(sendResp.ID),
+		Sent:      true,
+	}, nil
+}
+
+type SendListMessageCommand struct {
+	SessionID   string
+	ChatJID     string
+	Title       string
+	Description string
+	ButtonText  string
+	FooterText  string
+	Sections    []ListSection
+}
+
+func (c SendListMessageCommand) Validate() error {
+	if strings.TrimSpace(c.SessionID) == "" {
+		return common.NewValidationError("sessionID", c.SessionID, "session ID is required")
+	}
+
+	if strings.TrimSpace(c.ChatJID) == "" {
+		return common.NewValidationError("chatJID", c.ChatJID, "chat JID is required")
+	}
+
+	if strings.TrimSpace(c.Title) == "" {
+		return common.NewValidationError("title", c.Title, "title is required")
+	}
+
+	if strings.TrimSpace(c.ButtonText) == "" {
+		return common.NewValidationError("buttonText", c.ButtonText, "button text is required")
+	}
+
+	if len(c.Sections) == 0 {
+		return common.NewValidationError("sections", "", "at least one section is required")
+	}
+
+	return nil
+}
+
+type SendListMessageResult struct {
+	SessionID string
+	ChatJID   string
+	MessageID string
+	Sent      bool
+}
+
+type SendListMessageUseCase struct {
+	sessionRepo     session.Repository
+	whatsappService ports.WhatsAppService
+	logger          ports.Logger
+}
+
+func NewSendListMessageUseCase(
+	sessionRepo session.Repository,
+	whatsappService ports.WhatsAppService,
+	logger ports.Logger,
+) *SendListMessageUseCase {
+	return &SendListMessageUseCase{
+		sessionRepo:     sessionRepo,
+		whatsappService: whatsappService,
+		logger:          logger,
+	}
+}
+
+func (uc *SendListMessageUseCase) Handle(ctx context.Context, cmd SendListMessageCommand) (*SendListMessageResult, error) {
+	if err := cmd.Validate(); err != nil {
+		uc.logger.Warn(ctx, "Invalid send list message command", "error", err)
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := ensureSessionCanSend(ctx, uc.sessionRepo, cmd.SessionID); err != nil {
+		return nil, err
+	}
+
+	sendResp, err := uc.whatsappService.SendListMessage(ctx, cmd.SessionID, cmd.ChatJID, cmd.Title, cmd.Description, cmd.ButtonText, cmd.FooterText, cmd.Sections)
+	if err != nil {
+		uc.logger.Error(ctx, "Failed to send list message",
+			"sessionID", cmd.SessionID,
+			"chatJID", cmd.ChatJID,
+			"error", err)
+		return nil, fmt.Errorf("failed to send list message: %w", err)
+	}
+
+	uc.logger.Info(ctx, "List message sent successfully",
+		"sessionID", cmd.SessionID,
+		"chatJID", cmd.ChatJID,
+		"sections", len(cmd.Sections))
+
+	return &SendListMessageResult{
+		SessionID: cmd.SessionID,
+		ChatJID:   cmd.ChatJID,
+		MessageID: string(sendResp.ID),
+		Sent:      true,
+	}, nil
+}
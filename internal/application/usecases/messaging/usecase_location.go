@@ -17,6 +17,7 @@ type SendLocationMessageCommand struct {
 	Longitude float64
 	Name      string
 	Address   string
+	Options   ports.SendOptions
 }
 
 func (c SendLocationMessageCommand) Validate() error {
@@ -100,7 +101,7 @@ func (uc *SendLocationMessageUseCase) Handle(ctx context.Context, cmd SendLocati
 		)
 	}
 
-	sendResp, err := uc.whatsappService.SendLocationMessage(ctx, cmd.SessionID, cmd.ChatJID, cmd.Latitude, cmd.Longitude, cmd.Name, cmd.Address)
+	sendResp, err := uc.whatsappService.SendLocationMessage(ctx, cmd.SessionID, cmd.ChatJID, cmd.Latitude, cmd.Longitude, cmd.Name, cmd.Address, cmd.Options)
 	if err != nil {
 		uc.logger.Error(ctx, "Failed to send location message",
 			"sessionID", cmd.SessionID,
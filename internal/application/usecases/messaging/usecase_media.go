@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"strings"
 
+	"go.mau.fi/whatsmeow"
+
 	"zpmeow/internal/application/common"
 	"zpmeow/internal/application/ports"
 	"zpmeow/internal/domain/session"
@@ -37,6 +39,11 @@ type SendMediaMessageCommand struct {
 	MimeType  string
 	Caption   string
 	Filename  string
+	// PTT envia áudio como mensagem de voz (ignorado para os demais tipos)
+	PTT bool
+	// Source é resolvido pelo MediaResolver quando MediaData não é informado (URL, upload, media ID)
+	Source  ports.MediaSource
+	Options ports.SendOptions
 }
 
 func (c SendMediaMessageCommand) Validate() error {
@@ -52,7 +59,7 @@ func (c SendMediaMessageCommand) Validate() error {
 		return common.NewValidationError("mediaType", c.MediaType, "invalid media type")
 	}
 
-	if len(c.MediaData) == 0 {
+	if len(c.MediaData) == 0 && c.Source.IsEmpty() {
 		return common.NewValidationError("mediaData", "", "media data is required")
 	}
 
@@ -60,7 +67,7 @@ func (c SendMediaMessageCommand) Validate() error {
 		return common.NewValidationError("mediaData", "", "media data exceeds 100MB limit")
 	}
 
-	if strings.TrimSpace(c.MimeType) == "" {
+	if len(c.MediaData) > 0 && strings.TrimSpace(c.MimeType) == "" {
 		return common.NewValidationError("mimeType", c.MimeType, "mime type is required")
 	}
 
@@ -82,17 +89,20 @@ type SendMediaMessageResult struct {
 type SendMediaMessageUseCase struct {
 	sessionRepo     session.Repository
 	whatsappService ports.WhatsAppService
+	mediaResolver   ports.MediaResolver
 	logger          ports.Logger
 }
 
 func NewSendMediaMessageUseCase(
 	sessionRepo session.Repository,
 	whatsappService ports.WhatsAppService,
+	mediaResolver ports.MediaResolver,
 	logger ports.Logger,
 ) *SendMediaMessageUseCase {
 	return &SendMediaMessageUseCase{
 		sessionRepo:     sessionRepo,
 		whatsappService: whatsappService,
+		mediaResolver:   mediaResolver,
 		logger:          logger,
 	}
 }
@@ -123,6 +133,24 @@ func (uc *SendMediaMessageUseCase) Handle(ctx context.Context, cmd SendMediaMess
 		)
 	}
 
+	if len(cmd.MediaData) == 0 {
+		if uc.mediaResolver == nil {
+			return nil, fmt.Errorf("media resolver not configured")
+		}
+
+		resolved, err := uc.mediaResolver.Resolve(ctx, cmd.SessionID, string(cmd.MediaType), cmd.Source)
+		if err != nil {
+			uc.logger.Warn(ctx, "Failed to resolve media", "sessionID", cmd.SessionID, "mediaType", cmd.MediaType, "error", err)
+			return nil, fmt.Errorf("failed to resolve media: %w", err)
+		}
+
+		cmd.MediaData = resolved.Data
+		cmd.MimeType = resolved.MimeType
+		if cmd.Filename == "" {
+			cmd.Filename = resolved.FileName
+		}
+	}
+
 	mediaMessage := ports.MediaMessage{
 		Type:     string(cmd.MediaType),
 		Data:     cmd.MediaData,
@@ -131,7 +159,12 @@ func (uc *SendMediaMessageUseCase) Handle(ctx context.Context, cmd SendMediaMess
 		Filename: cmd.Filename,
 	}
 
-	_, err = uc.whatsappService.SendMediaMessage(ctx, cmd.SessionID, cmd.ChatJID, mediaMessage)
+	var sendResp *whatsmeow.SendResponse
+	if cmd.MediaType == MediaTypeAudio && cmd.PTT {
+		sendResp, err = uc.whatsappService.SendAudioMessageWithPTT(ctx, cmd.SessionID, cmd.ChatJID, cmd.MediaData, cmd.MimeType, true, cmd.Options)
+	} else {
+		sendResp, err = uc.whatsappService.SendMediaMessage(ctx, cmd.SessionID, cmd.ChatJID, mediaMessage, cmd.Options)
+	}
 	if err != nil {
 		uc.logger.Error(ctx, "Failed to send media message",
 			"sessionID", cmd.SessionID,
@@ -151,7 +184,7 @@ func (uc *SendMediaMessageUseCase) Handle(ctx context.Context, cmd SendMediaMess
 		SessionID: cmd.SessionID,
 		ChatJID:   cmd.ChatJID,
 		MediaType: string(cmd.MediaType),
-		MessageID: "",
+		MessageID: string(sendResp.ID),
 		Sent:      true,
 	}, nil
 }
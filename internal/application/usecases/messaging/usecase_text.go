@@ -14,6 +14,7 @@ type SendTextMessageCommand struct {
 	SessionID string
 	ChatJID   string
 	Message   string
+	Options   ports.SendOptions
 }
 
 func (c SendTextMessageCommand) Validate() error {
@@ -87,7 +88,7 @@ func (uc *SendTextMessageUseCase) Handle(ctx context.Context, cmd SendTextMessag
 		)
 	}
 
-	_, err = uc.whatsappService.SendTextMessage(ctx, cmd.SessionID, cmd.ChatJID, cmd.Message)
+	sendResp, err := uc.whatsappService.SendTextMessage(ctx, cmd.SessionID, cmd.ChatJID, cmd.Message, cmd.Options)
 	if err != nil {
 		uc.logger.Error(ctx, "Failed to send text message",
 			"sessionID", cmd.SessionID,
@@ -104,7 +105,7 @@ func (uc *SendTextMessageUseCase) Handle(ctx context.Context, cmd SendTextMessag
 	return &SendTextMessageResult{
 		SessionID: cmd.SessionID,
 		ChatJID:   cmd.ChatJID,
-		MessageID: "",
+		MessageID: string(sendResp.ID),
 		Sent:      true,
 	}, nil
 }
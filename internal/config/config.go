@@ -11,15 +11,20 @@ import (
 )
 
 type Config struct {
-	Database DatabaseConfig `json:"database"`
-	Server   ServerConfig   `json:"server"`
-	Auth     AuthConfig     `json:"auth"`
-	Logging  LoggingConfig  `json:"logging"`
-	CORS     CORSConfig     `json:"cors"`
-	Webhook  WebhookConfig  `json:"webhook"`
-	Meow     MeowConfig     `json:"meow"`
-	Security SecurityConfig `json:"security"`
-	Cache    CacheConfig    `json:"cache"`
+	Database  DatabaseConfig  `json:"database"`
+	Server    ServerConfig    `json:"server"`
+	Auth      AuthConfig      `json:"auth"`
+	Logging   LoggingConfig   `json:"logging"`
+	CORS      CORSConfig      `json:"cors"`
+	Webhook   WebhookConfig   `json:"webhook"`
+	Meow      MeowConfig      `json:"meow"`
+	Security  SecurityConfig  `json:"security"`
+	Cache     CacheConfig     `json:"cache"`
+	Storage   StorageConfig   `json:"storage"`
+	Scheduler SchedulerConfig `json:"scheduler"`
+	Campaign  CampaignConfig  `json:"campaign"`
+	History   HistoryConfig   `json:"history"`
+	Stream    StreamConfig    `json:"stream"`
 }
 
 type DatabaseConfig struct {
@@ -78,6 +83,9 @@ type WebhookConfig struct {
 	InitialBackoff    time.Duration `json:"initial_backoff"`
 	MaxBackoff        time.Duration `json:"max_backoff"`
 	BackoffMultiplier float64       `json:"backoff_multiplier"`
+	QueueWorkers      int           `json:"queue_workers"`
+	QueueBatchSize    int           `json:"queue_batch_size"`
+	QueuePollInterval time.Duration `json:"queue_poll_interval"`
 }
 
 type MeowConfig struct {
@@ -95,6 +103,52 @@ type SecurityConfig struct {
 	MaxRequestSize   int64         `json:"max_request_size"`
 }
 
+type StorageConfig struct {
+	Driver      string `json:"driver"`
+	LocalPath   string `json:"local_path"`
+	MaxFileSize int64  `json:"max_file_size"`
+	S3Endpoint  string `json:"s3_endpoint"`
+	S3Region    string `json:"s3_region"`
+	S3Bucket    string `json:"s3_bucket"`
+	S3AccessKey string `json:"s3_access_key"`
+	S3SecretKey string `json:"s3_secret_key"`
+	S3UseSSL    bool   `json:"s3_use_ssl"`
+	S3PathStyle bool   `json:"s3_path_style"`
+
+	// Limites por tipo aplicados ao download automático de mídias recebidas
+	MaxImageSize    int64 `json:"max_image_size"`
+	MaxVideoSize    int64 `json:"max_video_size"`
+	MaxAudioSize    int64 `json:"max_audio_size"`
+	MaxDocumentSize int64 `json:"max_document_size"`
+	MaxStickerSize  int64 `json:"max_sticker_size"`
+}
+
+type SchedulerConfig struct {
+	Workers      int           `json:"workers"`
+	PollInterval time.Duration `json:"poll_interval"`
+	MaxAttempts  int           `json:"max_attempts"`
+	RetryDelay   time.Duration `json:"retry_delay"`
+}
+
+type CampaignConfig struct {
+	DefaultRatePerMinute int           `json:"default_rate_per_minute"`
+	MaxRatePerMinute     int           `json:"max_rate_per_minute"`
+	DefaultJitter        time.Duration `json:"default_jitter"`
+	MaxRecipients        int           `json:"max_recipients"`
+	ProgressEvery        int           `json:"progress_every"`
+}
+
+type HistoryConfig struct {
+	SyncEnabled bool `json:"sync_enabled"`
+	SyncDays    int  `json:"sync_days"`
+}
+
+type StreamConfig struct {
+	BufferSize        int           `json:"buffer_size"`
+	ClientBuffer      int           `json:"client_buffer"`
+	HeartbeatInterval time.Duration `json:"heartbeat_interval"`
+}
+
 type CacheConfig struct {
 	Enabled       bool          `json:"enabled"`
 	RedisURL      string        `json:"redis_url"`
@@ -119,15 +173,20 @@ func LoadConfig() (*Config, error) {
 	_ = godotenv.Load()
 
 	cfg := &Config{
-		Database: loadDatabaseConfig(),
-		Server:   loadServerConfig(),
-		Auth:     loadAuthConfig(),
-		Logging:  loadLoggingConfig(),
-		CORS:     loadCORSConfig(),
-		Webhook:  loadWebhookConfig(),
-		Meow:     loadMeowConfig(),
-		Security: loadSecurityConfig(),
-		Cache:    loadCacheConfig(),
+		Database:  loadDatabaseConfig(),
+		Server:    loadServerConfig(),
+		Auth:      loadAuthConfig(),
+		Logging:   loadLoggingConfig(),
+		CORS:      loadCORSConfig(),
+		Webhook:   loadWebhookConfig(),
+		Meow:      loadMeowConfig(),
+		Security:  loadSecurityConfig(),
+		Cache:     loadCacheConfig(),
+		Storage:   loadStorageConfig(),
+		Scheduler: loadSchedulerConfig(),
+		Campaign:  loadCampaignConfig(),
+		History:   loadHistoryConfig(),
+		Stream:    loadStreamConfig(),
 	}
 
 	if err := cfg.Validate(); err != nil {
@@ -150,6 +209,12 @@ func (c *Config) Validate() error {
 	if c.Auth.GlobalAPIKey == "" {
 		return fmt.Errorf("global API key is required")
 	}
+	if c.Storage.Driver != "local" && c.Storage.Driver != "s3" {
+		return fmt.Errorf("storage driver must be 'local' or 's3'")
+	}
+	if c.Storage.Driver == "s3" && (c.Storage.S3Endpoint == "" || c.Storage.S3Bucket == "") {
+		return fmt.Errorf("S3 endpoint and bucket are required for the s3 storage driver")
+	}
 	return nil
 }
 
@@ -240,6 +305,9 @@ func loadWebhookConfig() WebhookConfig {
 		InitialBackoff:    getDurationEnvOrDefault("WEBHOOK_INITIAL_BACKOFF", 1*time.Second),
 		MaxBackoff:        getDurationEnvOrDefault("WEBHOOK_MAX_BACKOFF", 30*time.Second),
 		BackoffMultiplier: getFloat64EnvOrDefault("WEBHOOK_BACKOFF_MULTIPLIER", 2.0),
+		QueueWorkers:      getIntEnvOrDefault("WEBHOOK_QUEUE_WORKERS", 4),
+		QueueBatchSize:    getIntEnvOrDefault("WEBHOOK_QUEUE_BATCH_SIZE", 50),
+		QueuePollInterval: getDurationEnvOrDefault("WEBHOOK_QUEUE_POLL_INTERVAL", 2*time.Second),
 	}
 }
 
@@ -293,6 +361,61 @@ func loadCacheConfig() CacheConfig {
 	return cfg
 }
 
+func loadStorageConfig() StorageConfig {
+	return StorageConfig{
+		Driver:      getEnvOrDefault("STORAGE_DRIVER", "local"),
+		LocalPath:   getEnvOrDefault("STORAGE_LOCAL_PATH", "data/media"),
+		MaxFileSize: getInt64EnvOrDefault("STORAGE_MAX_FILE_SIZE", 64*1024*1024),
+		S3Endpoint:  getEnvOrDefault("S3_ENDPOINT", "localhost:9000"),
+		S3Region:    getEnvOrDefault("S3_REGION", "us-east-1"),
+		S3Bucket:    getEnvOrDefault("S3_BUCKET", "zpmeow-media"),
+		S3AccessKey: getEnvOrDefault("S3_ACCESS_KEY", ""),
+		S3SecretKey: getEnvOrDefault("S3_SECRET_KEY", ""),
+		S3UseSSL:    getBoolEnvOrDefault("S3_USE_SSL", false),
+		S3PathStyle: getBoolEnvOrDefault("S3_PATH_STYLE", true),
+
+		MaxImageSize:    getInt64EnvOrDefault("STORAGE_MAX_IMAGE_SIZE", 16*1024*1024),
+		MaxVideoSize:    getInt64EnvOrDefault("STORAGE_MAX_VIDEO_SIZE", 64*1024*1024),
+		MaxAudioSize:    getInt64EnvOrDefault("STORAGE_MAX_AUDIO_SIZE", 16*1024*1024),
+		MaxDocumentSize: getInt64EnvOrDefault("STORAGE_MAX_DOCUMENT_SIZE", 64*1024*1024),
+		MaxStickerSize:  getInt64EnvOrDefault("STORAGE_MAX_STICKER_SIZE", 1024*1024),
+	}
+}
+
+func loadSchedulerConfig() SchedulerConfig {
+	return SchedulerConfig{
+		Workers:      getIntEnvOrDefault("SCHEDULER_WORKERS", 2),
+		PollInterval: getDurationEnvOrDefault("SCHEDULER_POLL_INTERVAL", 5*time.Second),
+		MaxAttempts:  getIntEnvOrDefault("SCHEDULER_MAX_ATTEMPTS", 3),
+		RetryDelay:   getDurationEnvOrDefault("SCHEDULER_RETRY_DELAY", time.Minute),
+	}
+}
+
+func loadCampaignConfig() CampaignConfig {
+	return CampaignConfig{
+		DefaultRatePerMinute: getIntEnvOrDefault("CAMPAIGN_DEFAULT_RATE_PER_MINUTE", 10),
+		MaxRatePerMinute:     getIntEnvOrDefault("CAMPAIGN_MAX_RATE_PER_MINUTE", 30),
+		DefaultJitter:        getDurationEnvOrDefault("CAMPAIGN_DEFAULT_JITTER", 5*time.Second),
+		MaxRecipients:        getIntEnvOrDefault("CAMPAIGN_MAX_RECIPIENTS", 10000),
+		ProgressEvery:        getIntEnvOrDefault("CAMPAIGN_PROGRESS_EVERY", 25),
+	}
+}
+
+func loadHistoryConfig() HistoryConfig {
+	return HistoryConfig{
+		SyncEnabled: getBoolEnvOrDefault("HISTORY_SYNC_ENABLED", true),
+		SyncDays:    getIntEnvOrDefault("HISTORY_SYNC_DAYS", 30),
+	}
+}
+
+func loadStreamConfig() StreamConfig {
+	return StreamConfig{
+		BufferSize:        getIntEnvOrDefault("EVENT_STREAM_BUFFER_SIZE", 500),
+		ClientBuffer:      getIntEnvOrDefault("EVENT_STREAM_CLIENT_BUFFER", 256),
+		HeartbeatInterval: getDurationEnvOrDefault("EVENT_STREAM_HEARTBEAT", 25*time.Second),
+	}
+}
+
 func getEnvOrDefault(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -4,15 +4,20 @@ import "time"
 
 func DefaultConfig() *Config {
 	return &Config{
-		Database: DefaultDatabaseConfig(),
-		Server:   DefaultServerConfig(),
-		Auth:     DefaultAuthConfig(),
-		Logging:  DefaultLoggingConfig(),
-		CORS:     DefaultCORSConfig(),
-		Webhook:  DefaultWebhookConfig(),
-		Meow:     DefaultMeowConfig(),
-		Security: DefaultSecurityConfig(),
-		Cache:    DefaultCacheConfig(),
+		Database:  DefaultDatabaseConfig(),
+		Server:    DefaultServerConfig(),
+		Auth:      DefaultAuthConfig(),
+		Logging:   DefaultLoggingConfig(),
+		CORS:      DefaultCORSConfig(),
+		Webhook:   DefaultWebhookConfig(),
+		Meow:      DefaultMeowConfig(),
+		Security:  DefaultSecurityConfig(),
+		Cache:     DefaultCacheConfig(),
+		Storage:   DefaultStorageConfig(),
+		Scheduler: DefaultSchedulerConfig(),
+		Campaign:  DefaultCampaignConfig(),
+		History:   DefaultHistoryConfig(),
+		Stream:    DefaultStreamConfig(),
 	}
 }
 
@@ -86,6 +91,9 @@ func DefaultWebhookConfig() WebhookConfig {
 		InitialBackoff:    1 * time.Second,
 		MaxBackoff:        30 * time.Second,
 		BackoffMultiplier: 2.0,
+		QueueWorkers:      4,
+		QueueBatchSize:    50,
+		QueuePollInterval: 2 * time.Second,
 	}
 }
 
@@ -129,6 +137,59 @@ func DefaultCacheConfig() CacheConfig {
 	}
 }
 
+func DefaultStorageConfig() StorageConfig {
+	return StorageConfig{
+		Driver:      "local",
+		LocalPath:   "data/media",
+		MaxFileSize: 64 * 1024 * 1024,
+		S3Endpoint:  "localhost:9000",
+		S3Region:    "us-east-1",
+		S3Bucket:    "zpmeow-media",
+		S3UseSSL:    false,
+		S3PathStyle: true,
+
+		MaxImageSize:    16 * 1024 * 1024,
+		MaxVideoSize:    64 * 1024 * 1024,
+		MaxAudioSize:    16 * 1024 * 1024,
+		MaxDocumentSize: 64 * 1024 * 1024,
+		MaxStickerSize:  1024 * 1024,
+	}
+}
+
+func DefaultSchedulerConfig() SchedulerConfig {
+	return SchedulerConfig{
+		Workers:      2,
+		PollInterval: 5 * time.Second,
+		MaxAttempts:  3,
+		RetryDelay:   time.Minute,
+	}
+}
+
+func DefaultCampaignConfig() CampaignConfig {
+	return CampaignConfig{
+		DefaultRatePerMinute: 10,
+		MaxRatePerMinute:     30,
+		DefaultJitter:        5 * time.Second,
+		MaxRecipients:        10000,
+		ProgressEvery:        25,
+	}
+}
+
+func DefaultHistoryConfig() HistoryConfig {
+	return HistoryConfig{
+		SyncEnabled: true,
+		SyncDays:    30,
+	}
+}
+
+func DefaultStreamConfig() StreamConfig {
+	return StreamConfig{
+		BufferSize:        500,
+		ClientBuffer:      256,
+		HeartbeatInterval: 25 * time.Second,
+	}
+}
+
 func ProductionConfig() *Config {
 	cfg := DefaultConfig()
 
@@ -12,6 +12,11 @@ type ConfigProvider interface {
 	GetMeow() MeowConfigProvider
 	GetSecurity() SecurityConfigProvider
 	GetCache() CacheConfigProvider
+	GetStorage() StorageConfigProvider
+	GetScheduler() SchedulerConfigProvider
+	GetCampaign() CampaignConfigProvider
+	GetHistory() HistoryConfigProvider
+	GetStream() StreamConfigProvider
 }
 
 type DatabaseConfigProvider interface {
@@ -70,6 +75,9 @@ type WebhookConfigProvider interface {
 	GetInitialBackoff() time.Duration
 	GetMaxBackoff() time.Duration
 	GetBackoffMultiplier() float64
+	GetQueueWorkers() int
+	GetQueueBatchSize() int
+	GetQueuePollInterval() time.Duration
 }
 
 type MeowConfigProvider interface {
@@ -107,6 +115,50 @@ type CacheConfigProvider interface {
 	GetStatusTTL() time.Duration
 }
 
+type StorageConfigProvider interface {
+	GetDriver() string
+	GetLocalPath() string
+	GetMaxFileSize() int64
+	GetMaxImageSize() int64
+	GetMaxVideoSize() int64
+	GetMaxAudioSize() int64
+	GetMaxDocumentSize() int64
+	GetMaxStickerSize() int64
+	GetS3Endpoint() string
+	GetS3Region() string
+	GetS3Bucket() string
+	GetS3AccessKey() string
+	GetS3SecretKey() string
+	GetS3UseSSL() bool
+	GetS3PathStyle() bool
+}
+
+type SchedulerConfigProvider interface {
+	GetWorkers() int
+	GetPollInterval() time.Duration
+	GetMaxAttempts() int
+	GetRetryDelay() time.Duration
+}
+
+type CampaignConfigProvider interface {
+	GetDefaultRatePerMinute() int
+	GetMaxRatePerMinute() int
+	GetDefaultJitter() time.Duration
+	GetMaxRecipients() int
+	GetProgressEvery() int
+}
+
+type HistoryConfigProvider interface {
+	GetSyncEnabled() bool
+	GetSyncDays() int
+}
+
+type StreamConfigProvider interface {
+	GetBufferSize() int
+	GetClientBuffer() int
+	GetHeartbeatInterval() time.Duration
+}
+
 func (c *Config) GetDatabase() DatabaseConfigProvider {
 	return &c.Database
 }
@@ -143,6 +195,26 @@ func (c *Config) GetCache() CacheConfigProvider {
 	return &c.Cache
 }
 
+func (c *Config) GetStorage() StorageConfigProvider {
+	return &c.Storage
+}
+
+func (c *Config) GetScheduler() SchedulerConfigProvider {
+	return &c.Scheduler
+}
+
+func (c *Config) GetCampaign() CampaignConfigProvider {
+	return &c.Campaign
+}
+
+func (c *Config) GetHistory() HistoryConfigProvider {
+	return &c.History
+}
+
+func (c *Config) GetStream() StreamConfigProvider {
+	return &c.Stream
+}
+
 func (d *DatabaseConfig) GetHost() string                   { return d.Host }
 func (d *DatabaseConfig) GetPort() string                   { return d.Port }
 func (d *DatabaseConfig) GetUser() string                   { return d.User }
@@ -183,11 +255,14 @@ func (c *CORSConfig) GetExposeHeaders() []string { return c.ExposeHeaders }
 func (c *CORSConfig) GetAllowCredentials() bool  { return c.AllowCredentials }
 func (c *CORSConfig) GetMaxAge() int             { return c.MaxAge }
 
-func (w *WebhookConfig) GetTimeout() time.Duration        { return w.Timeout }
-func (w *WebhookConfig) GetMaxRetries() int               { return w.MaxRetries }
-func (w *WebhookConfig) GetInitialBackoff() time.Duration { return w.InitialBackoff }
-func (w *WebhookConfig) GetMaxBackoff() time.Duration     { return w.MaxBackoff }
-func (w *WebhookConfig) GetBackoffMultiplier() float64    { return w.BackoffMultiplier }
+func (w *WebhookConfig) GetTimeout() time.Duration           { return w.Timeout }
+func (w *WebhookConfig) GetMaxRetries() int                  { return w.MaxRetries }
+func (w *WebhookConfig) GetInitialBackoff() time.Duration    { return w.InitialBackoff }
+func (w *WebhookConfig) GetMaxBackoff() time.Duration        { return w.MaxBackoff }
+func (w *WebhookConfig) GetBackoffMultiplier() float64       { return w.BackoffMultiplier }
+func (w *WebhookConfig) GetQueueWorkers() int                { return w.QueueWorkers }
+func (w *WebhookConfig) GetQueueBatchSize() int              { return w.QueueBatchSize }
+func (w *WebhookConfig) GetQueuePollInterval() time.Duration { return w.QueuePollInterval }
 
 func (w *MeowConfig) GetMaxRetries() int                  { return w.MaxRetries }
 func (w *MeowConfig) GetRetryInterval() time.Duration     { return w.RetryInterval }
@@ -217,3 +292,37 @@ func (c *CacheConfig) GetSessionTTL() time.Duration    { return c.SessionTTL }
 func (c *CacheConfig) GetQRCodeTTL() time.Duration     { return c.QRCodeTTL }
 func (c *CacheConfig) GetCredentialTTL() time.Duration { return c.CredentialTTL }
 func (c *CacheConfig) GetStatusTTL() time.Duration     { return c.StatusTTL }
+
+func (s *StorageConfig) GetDriver() string         { return s.Driver }
+func (s *StorageConfig) GetLocalPath() string      { return s.LocalPath }
+func (s *StorageConfig) GetMaxFileSize() int64     { return s.MaxFileSize }
+func (s *StorageConfig) GetMaxImageSize() int64    { return s.MaxImageSize }
+func (s *StorageConfig) GetMaxVideoSize() int64    { return s.MaxVideoSize }
+func (s *StorageConfig) GetMaxAudioSize() int64    { return s.MaxAudioSize }
+func (s *StorageConfig) GetMaxDocumentSize() int64 { return s.MaxDocumentSize }
+func (s *StorageConfig) GetMaxStickerSize() int64  { return s.MaxStickerSize }
+func (s *StorageConfig) GetS3Endpoint() string     { return s.S3Endpoint }
+func (s *StorageConfig) GetS3Region() string       { return s.S3Region }
+func (s *StorageConfig) GetS3Bucket() string       { return s.S3Bucket }
+func (s *StorageConfig) GetS3AccessKey() string    { return s.S3AccessKey }
+func (s *StorageConfig) GetS3SecretKey() string    { return s.S3SecretKey }
+func (s *StorageConfig) GetS3UseSSL() bool         { return s.S3UseSSL }
+func (s *StorageConfig) GetS3PathStyle() bool      { return s.S3PathStyle }
+
+func (s *SchedulerConfig) GetWorkers() int                { return s.Workers }
+func (s *SchedulerConfig) GetPollInterval() time.Duration { return s.PollInterval }
+func (s *SchedulerConfig) GetMaxAttempts() int            { return s.MaxAttempts }
+func (s *SchedulerConfig) GetRetryDelay() time.Duration   { return s.RetryDelay }
+
+func (c *CampaignConfig) GetDefaultRatePerMinute() int    { return c.DefaultRatePerMinute }
+func (c *CampaignConfig) GetMaxRatePerMinute() int        { return c.MaxRatePerMinute }
+func (c *CampaignConfig) GetDefaultJitter() time.Duration { return c.DefaultJitter }
+func (c *CampaignConfig) GetMaxRecipients() int           { return c.MaxRecipients }
+func (c *CampaignConfig) GetProgressEvery() int           { return c.ProgressEvery }
+
+func (h *HistoryConfig) GetSyncEnabled() bool { return h.SyncEnabled }
+func (h *HistoryConfig) GetSyncDays() int     { return h.SyncDays }
+
+func (s *StreamConfig) GetBufferSize() int                  { return s.BufferSize }
+func (s *StreamConfig) GetClientBuffer() int                { return s.ClientBuffer }
+func (s *StreamConfig) GetHeartbeatInterval() time.Duration { return s.HeartbeatInterval }
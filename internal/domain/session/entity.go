@@ -259,7 +259,7 @@ func (s *Session) SetProxyConfiguration(proxyConfig string) error {
 	s.updateTimestamp()
 
 	changes := map[string]interface{}{
-		"proxy_configuration": proxyConfig,
+		"proxy_configuration": proxy.Redacted(),
 	}
 	event := NewSessionConfigurationChangedEvent(s.id.Value(), changes)
 	s.AddEvent(event)
@@ -2,6 +2,8 @@ package session
 
 import (
 	"fmt"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"zpmeow/internal/domain/common"
@@ -160,25 +162,44 @@ func NewProxyConfiguration(value string) (ProxyConfiguration, error) {
 		return ProxyConfiguration{}, fmt.Errorf("proxy configuration too long")
 	}
 
-	if !strings.Contains(trimmed, ":") {
-		return ProxyConfiguration{}, fmt.Errorf("proxy configuration must contain scheme (:)")
+	if !strings.Contains(trimmed, "://") {
+		return ProxyConfiguration{}, fmt.Errorf("proxy configuration must contain scheme (e.g. socks5://host:port)")
 	}
 
-	parts := strings.Split(trimmed, ":")
-	if len(parts) != 2 {
-		return ProxyConfiguration{}, fmt.Errorf("invalid proxy configuration format")
+	parsed, err := url.Parse(trimmed)
+	if err != nil {
+		return ProxyConfiguration{}, fmt.Errorf("invalid proxy configuration format: %w", err)
 	}
 
-	scheme := strings.ToLower(parts[0])
+	scheme := strings.ToLower(parsed.Scheme)
 	if scheme != "http" && scheme != "https" && scheme != "socks5" {
 		return ProxyConfiguration{}, fmt.Errorf("unsupported proxy scheme: %s (supported: http, https, socks5)", scheme)
 	}
 
-	if parts[1] == "" {
+	if parsed.Hostname() == "" {
 		return ProxyConfiguration{}, fmt.Errorf("proxy configuration must have host")
 	}
 
-	return ProxyConfiguration{value: trimmed}, nil
+	if port := parsed.Port(); port != "" {
+		number, err := strconv.Atoi(port)
+		if err != nil || number < 1 || number > 65535 {
+			return ProxyConfiguration{}, fmt.Errorf("invalid proxy port: %s", port)
+		}
+	} else if scheme == "socks5" {
+		return ProxyConfiguration{}, fmt.Errorf("socks5 proxy configuration must have port")
+	}
+
+	if (parsed.Path != "" && parsed.Path != "/") || parsed.RawQuery != "" || parsed.Fragment != "" {
+		return ProxyConfiguration{}, fmt.Errorf("proxy configuration must not contain path, query or fragment")
+	}
+
+	if parsed.User != nil && parsed.User.Username() == "" {
+		return ProxyConfiguration{}, fmt.Errorf("proxy credentials must have username")
+	}
+
+	parsed.Scheme = scheme
+	parsed.Path = ""
+	return ProxyConfiguration{value: parsed.String()}, nil
 }
 
 func (p ProxyConfiguration) Value() string {
@@ -186,7 +207,7 @@ func (p ProxyConfiguration) Value() string {
 }
 
 func (p ProxyConfiguration) String() string {
-	return p.value
+	return p.Redacted()
 }
 
 func (p ProxyConfiguration) IsEmpty() bool {
@@ -197,11 +218,31 @@ func (p ProxyConfiguration) Scheme() string {
 	if p.value == "" {
 		return ""
 	}
-	parts := strings.Split(p.value, ":")
-	if len(parts) < 2 {
+	parsed, err := url.Parse(p.value)
+	if err != nil {
+		return ""
+	}
+	return parsed.Scheme
+}
+
+func (p ProxyConfiguration) HasCredentials() bool {
+	if p.value == "" {
+		return false
+	}
+	parsed, err := url.Parse(p.value)
+	return err == nil && parsed.User != nil
+}
+
+// Redacted returns the proxy URL with the password masked, safe for logs and API responses
+func (p ProxyConfiguration) Redacted() string {
+	if p.value == "" {
+		return ""
+	}
+	parsed, err := url.Parse(p.value)
+	if err != nil {
 		return ""
 	}
-	return strings.ToLower(parts[0])
+	return parsed.Redacted()
 }
 
 // WebhookEndpoint represents a webhook URL endpoint
@@ -0,0 +1,593 @@
+package campaigns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+	_ "time/tzdata" // fusos horários disponíveis mesmo em imagens sem tzdata
+
+	"zpmeow/internal/application/common"
+	"zpmeow/internal/application/ports"
+	"zpmeow/internal/application/usecases/messaging"
+	"zpmeow/internal/domain/session"
+	"zpmeow/internal/infra/database/models"
+	"zpmeow/internal/infra/database/repository"
+	"zpmeow/internal/infra/logging"
+)
+
+const (
+	EventCampaignProgress  = "CampaignProgress"
+	EventCampaignCompleted = "CampaignCompleted"
+)
+
+// checkFailureBackoff é a pausa da sessão quando a verificação do número falha (ex.: timeout do WhatsApp)
+const checkFailureBackoff = 30 * time.Second
+
+type Config struct {
+	PollInterval         time.Duration
+	StaleAfter           time.Duration
+	DefaultRatePerMinute int
+	MaxRatePerMinute     int
+	DefaultJitter        time.Duration
+	MaxRecipients        int
+	ProgressEvery        int
+}
+
+func defaultConfig() *Config {
+	return &Config{
+		PollInterval:         time.Second,
+		StaleAfter:           5 * time.Minute,
+		DefaultRatePerMinute: 10,
+		MaxRatePerMinute:     30,
+		DefaultJitter:        5 * time.Second,
+		MaxRecipients:        10000,
+		ProgressEvery:        25,
+	}
+}
+
+// EventPublisher publica eventos de progresso nos webhooks da sessão
+type EventPublisher interface {
+	Publish(ctx context.Context, sessionID, event string, data interface{}) error
+}
+
+// Spec descreve uma campanha a ser criada
+type Spec struct {
+	Name          string
+	Template      string
+	Recipients    []Recipient
+	RatePerMinute int
+	JitterSeconds *int
+	WindowStart   string
+	WindowEnd     string
+	Timezone      string
+	CheckNumbers  *bool
+}
+
+// Runner sends campaigns one recipient at a time per session, pacing sends with
+// the campaign rate limit plus random jitter and only inside its working-hour window.
+// All campaigns of a session share the same pace, so running two campaigns does not
+// double the send rate.
+type Runner struct {
+	repo      *repository.CampaignRepository
+	sendText  *messaging.SendTextMessageUseCase
+	whatsapp  ports.WhatsAppService
+	publisher EventPublisher
+	config    *Config
+	logger    logging.Logger
+
+	// Estado de ritmo por sessão: próxima janela de envio e envio em andamento
+	nextSendAt map[string]time.Time
+	busy       map[string]bool
+	random     *rand.Rand
+
+	stop    chan struct{}
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	running bool
+}
+
+func NewRunner(repo *repository.CampaignRepository, sessionRepo session.Repository, whatsappService ports.WhatsAppService, publisher EventPublisher, config *Config) *Runner {
+	if config == nil {
+		config = defaultConfig()
+	}
+	defaults := defaultConfig()
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaults.PollInterval
+	}
+	if config.StaleAfter <= 0 {
+		config.StaleAfter = defaults.StaleAfter
+	}
+	if config.DefaultRatePerMinute <= 0 {
+		config.DefaultRatePerMinute = defaults.DefaultRatePerMinute
+	}
+	if config.MaxRatePerMinute <= 0 {
+		config.MaxRatePerMinute = defaults.MaxRatePerMinute
+	}
+	if config.DefaultJitter < 0 {
+		config.DefaultJitter = defaults.DefaultJitter
+	}
+	if config.MaxRecipients <= 0 {
+		config.MaxRecipients = defaults.MaxRecipients
+	}
+	if config.ProgressEvery <= 0 {
+		config.ProgressEvery = defaults.ProgressEvery
+	}
+
+	return &Runner{
+		repo:       repo,
+		sendText:   messaging.NewSendTextMessageUseCase(sessionRepo, whatsappService, logging.NewUseCaseLogger("campaigns")),
+		whatsapp:   whatsappService,
+		publisher:  publisher,
+		config:     config,
+		logger:     logging.GetLogger().Sub("campaigns"),
+		nextSendAt: make(map[string]time.Time),
+		busy:       make(map[string]bool),
+		random:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Create valida a especificação e grava a campanha, que começa a ser enviada no próximo ciclo
+func (r *Runner) Create(ctx context.Context, sessionID string, spec Spec) (*models.CampaignModel, error) {
+	campaign, recipients, err := r.buildCampaign(sessionID, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.repo.Create(ctx, campaign, recipients); err != nil {
+		return nil, err
+	}
+
+	r.logger.Infof("Created campaign %s with %d recipients at %d/min (session: %s)", campaign.ID, len(recipients), campaign.RatePerMinute, sessionID)
+	return campaign, nil
+}
+
+func (r *Runner) buildCampaign(sessionID string, spec Spec) (*models.CampaignModel, []*models.CampaignRecipientModel, error) {
+	spec.Name = strings.TrimSpace(spec.Name)
+	if spec.Name == "" {
+		return nil, nil, fmt.Errorf("%w: name is required", common.ErrInvalidInput)
+	}
+	if strings.TrimSpace(spec.Template) == "" {
+		return nil, nil, fmt.Errorf("%w: template is required", common.ErrInvalidInput)
+	}
+	if len(spec.Template) > 4096 {
+		return nil, nil, fmt.Errorf("%w: template must not exceed 4096 characters", common.ErrInvalidInput)
+	}
+
+	recipients, err := prepareRecipients(spec.Template, spec.Recipients)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(recipients) == 0 {
+		return nil, nil, fmt.Errorf("%w: at least one recipient is required", common.ErrInvalidInput)
+	}
+	if len(recipients) > r.config.MaxRecipients {
+		return nil, nil, fmt.Errorf("%w: maximum %d recipients per campaign", common.ErrInvalidInput, r.config.MaxRecipients)
+	}
+
+	rate := spec.RatePerMinute
+	if rate == 0 {
+		rate = r.config.DefaultRatePerMinute
+	}
+	if rate < 1 || rate > r.config.MaxRatePerMinute {
+		return nil, nil, fmt.Errorf("%w: rate_per_minute must be between 1 and %d", common.ErrInvalidInput, r.config.MaxRatePerMinute)
+	}
+
+	jitter := int(r.config.DefaultJitter / time.Second)
+	if spec.JitterSeconds != nil {
+		jitter = *spec.JitterSeconds
+	}
+	if jitter < 0 || jitter > 3600 {
+		return nil, nil, fmt.Errorf("%w: jitter_seconds must be between 0 and 3600", common.ErrInvalidInput)
+	}
+
+	timezone := strings.TrimSpace(spec.Timezone)
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return nil, nil, fmt.Errorf("%w: invalid timezone %q", common.ErrInvalidInput, timezone)
+	}
+
+	campaign := &models.CampaignModel{
+		SessionId:     sessionID,
+		Name:          spec.Name,
+		Template:      spec.Template,
+		RatePerMinute: rate,
+		JitterSeconds: jitter,
+		Timezone:      timezone,
+		CheckNumbers:  spec.CheckNumbers == nil || *spec.CheckNumbers,
+	}
+
+	windowStart, windowEnd := strings.TrimSpace(spec.WindowStart), strings.TrimSpace(spec.WindowEnd)
+	if (windowStart == "") != (windowEnd == "") {
+		return nil, nil, fmt.Errorf("%w: window_start and window_end must be provided together", common.ErrInvalidInput)
+	}
+	if windowStart != "" {
+		if _, err := parseClock(windowStart); err != nil {
+			return nil, nil, fmt.Errorf("%w: window_start: %v", common.ErrInvalidInput, err)
+		}
+		if _, err := parseClock(windowEnd); err != nil {
+			return nil, nil, fmt.Errorf("%w: window_end: %v", common.ErrInvalidInput, err)
+		}
+		if windowStart == windowEnd {
+			return nil, nil, fmt.Errorf("%w: window_start and window_end must differ", common.ErrInvalidInput)
+		}
+		campaign.WindowStart = &windowStart
+		campaign.WindowEnd = &windowEnd
+	}
+
+	rows := make([]*models.CampaignRecipientModel, 0, len(recipients))
+	for _, recipient := range recipients {
+		variables := make(models.JSONB, len(recipient.Variables))
+		for key, value := range recipient.Variables {
+			variables[key] = value
+		}
+		rows = append(rows, &models.CampaignRecipientModel{Phone: recipient.Phone, Variables: variables})
+	}
+
+	return campaign, rows, nil
+}
+
+// List returns the campaigns of a session, newest first
+func (r *Runner) List(ctx context.Context, sessionID, status string, limit, offset int) ([]*models.CampaignModel, int, error) {
+	return r.repo.List(ctx, sessionID, status, limit, offset)
+}
+
+// Get returns a campaign, or nil if it does not belong to the session
+func (r *Runner) Get(ctx context.Context, sessionID, id string) (*models.CampaignModel, error) {
+	return r.repo.GetByID(ctx, sessionID, id)
+}
+
+// Stats returns the per-status recipient counters of a campaign
+func (r *Runner) Stats(ctx context.Context, campaignID string) (*models.CampaignStats, error) {
+	return r.repo.Stats(ctx, campaignID)
+}
+
+// ListRecipients returns the per-recipient results of a campaign in send order
+func (r *Runner) ListRecipients(ctx context.Context, campaignID, status string, limit, offset int) ([]*models.CampaignRecipientModel, int, error) {
+	return r.repo.ListRecipients(ctx, campaignID, status, limit, offset)
+}
+
+// Pause stops sending a running campaign; the recipient being sent, if any, is finished
+func (r *Runner) Pause(ctx context.Context, sessionID, id string) (*models.CampaignModel, error) {
+	return r.changeStatus(ctx, sessionID, id, []string{models.CampaignStatusRunning}, models.CampaignStatusPaused)
+}
+
+// Resume continues a paused campaign from the next pending recipient
+func (r *Runner) Resume(ctx context.Context, sessionID, id string) (*models.CampaignModel, error) {
+	return r.changeStatus(ctx, sessionID, id, []string{models.CampaignStatusPaused}, models.CampaignStatusRunning)
+}
+
+// Cancel stops a running or paused campaign for good and marks its pending recipients as canceled
+func (r *Runner) Cancel(ctx context.Context, sessionID, id string) (*models.CampaignModel, error) {
+	campaign, err := r.changeStatus(ctx, sessionID, id,
+		[]string{models.CampaignStatusRunning, models.CampaignStatusPaused}, models.CampaignStatusCanceled)
+	if err != nil || campaign == nil {
+		return campaign, err
+	}
+
+	if _, err := r.repo.CancelPendingRecipients(ctx, campaign.ID); err != nil {
+		return nil, err
+	}
+
+	r.publish(campaign, EventCampaignCompleted)
+	return campaign, nil
+}
+
+func (r *Runner) changeStatus(ctx context.Context, sessionID, id string, from []string, to string) (*models.CampaignModel, error) {
+	campaign, err := r.repo.UpdateStatus(ctx, sessionID, id, from, to)
+	if err != nil || campaign == nil {
+		return campaign, err
+	}
+
+	r.logger.Infof("Campaign %s is now %s (session: %s)", id, to, sessionID)
+	if to != models.CampaignStatusCanceled {
+		r.publish(campaign, EventCampaignProgress)
+	}
+	return campaign, nil
+}
+
+func (r *Runner) Start() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.running {
+		return
+	}
+
+	r.stop = make(chan struct{})
+	r.running = true
+
+	r.wg.Add(1)
+	go r.loop()
+
+	r.logger.Info("Campaign runner started")
+}
+
+// Stop stops starting new sends and waits for in-flight ones to finish
+func (r *Runner) Stop() {
+	r.mu.Lock()
+	if !r.running {
+		r.mu.Unlock()
+		return
+	}
+	r.running = false
+	close(r.stop)
+	r.mu.Unlock()
+
+	r.wg.Wait()
+	r.logger.Info("Campaign runner stopped")
+}
+
+func (r *Runner) loop() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	staleTicker := time.NewTicker(r.config.StaleAfter)
+	defer staleTicker.Stop()
+
+	r.releaseStale()
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-staleTicker.C:
+			r.releaseStale()
+		case <-ticker.C:
+			r.tick()
+		}
+	}
+}
+
+func (r *Runner) releaseStale() {
+	released, err := r.repo.ReleaseStaleRecipients(context.Background(), r.config.StaleAfter)
+	if err != nil {
+		r.logger.Errorf("Failed to release stale campaign recipients: %v", err)
+		return
+	}
+	if released > 0 {
+		r.logger.Warnf("Released %d stale campaign recipients back to the queue", released)
+	}
+}
+
+// tick inicia no máximo um envio por sessão, respeitando o ritmo e a janela de cada campanha
+func (r *Runner) tick() {
+	campaigns, err := r.repo.ListRunning(context.Background())
+	if err != nil {
+		r.logger.Errorf("Failed to list running campaigns: %v", err)
+		return
+	}
+
+	now := time.Now()
+	for _, campaign := range campaigns {
+		if !inWindow(campaign, now) {
+			continue
+		}
+
+		r.mu.Lock()
+		ready := r.running && !r.busy[campaign.SessionId] && !now.Before(r.nextSendAt[campaign.SessionId])
+		if ready && r.whatsapp.IsClientConnected(campaign.SessionId) {
+			r.busy[campaign.SessionId] = true
+			r.wg.Add(1)
+			go r.sendNext(campaign)
+		}
+		r.mu.Unlock()
+	}
+}
+
+// sendNext envia a mensagem do próximo destinatário pendente da campanha
+func (r *Runner) sendNext(campaign *models.CampaignModel) {
+	defer r.wg.Done()
+	ctx := context.Background()
+
+	delay := r.sendDelay(campaign)
+	defer func() {
+		r.mu.Lock()
+		r.busy[campaign.SessionId] = false
+		r.nextSendAt[campaign.SessionId] = time.Now().Add(delay)
+		r.mu.Unlock()
+	}()
+
+	recipient, err := r.repo.ClaimNextRecipient(ctx, campaign.ID)
+	if err != nil {
+		r.logger.Errorf("Failed to claim next recipient of campaign %s: %v", campaign.ID, err)
+		return
+	}
+	if recipient == nil {
+		delay = 0
+		r.completeIfDone(ctx, campaign)
+		return
+	}
+
+	chatJID := recipient.Phone
+	if campaign.CheckNumbers {
+		jid, onWhatsApp, err := r.checkNumber(ctx, campaign.SessionId, recipient.Phone)
+		if err != nil {
+			r.logger.Warnf("Failed to check %s for campaign %s, retrying later: %v", recipient.Phone, campaign.ID, err)
+			r.release(ctx, recipient)
+			delay = checkFailureBackoff
+			return
+		}
+		if !onWhatsApp {
+			reason := "number is not on WhatsApp"
+			r.finish(ctx, campaign, recipient, models.RecipientStatusInvalid, nil, nil, &reason)
+			// A verificação também conta para o ritmo, mas sem o intervalo completo de um envio
+			delay = time.Second
+			return
+		}
+		if jid != "" {
+			chatJID = jid
+		}
+	}
+
+	text := renderTemplate(campaign.Template, recipientFromModel(recipient))
+	result, err := r.sendText.Handle(ctx, messaging.SendTextMessageCommand{
+		SessionID: campaign.SessionId,
+		ChatJID:   chatJID,
+		Message:   text,
+	})
+	if err != nil {
+		var businessErr *common.BusinessRuleError
+		if errors.As(err, &businessErr) {
+			// Sessão caiu entre o tick e o envio: o destinatário volta para a fila
+			r.release(ctx, recipient)
+			delay = 0
+			return
+		}
+
+		lastError := err.Error()
+		r.finish(ctx, campaign, recipient, models.RecipientStatusFailed, &chatJID, nil, &lastError)
+		return
+	}
+
+	r.finish(ctx, campaign, recipient, models.RecipientStatusSent, &chatJID, &result.MessageID, nil)
+	if err := r.repo.TouchLastSent(ctx, campaign.ID); err != nil {
+		r.logger.Warnf("Failed to update last send time of campaign %s: %v", campaign.ID, err)
+	}
+}
+
+func (r *Runner) checkNumber(ctx context.Context, sessionID, phone string) (string, bool, error) {
+	results, err := r.whatsapp.CheckUser(ctx, sessionID, []string{phone})
+	if err != nil {
+		return "", false, err
+	}
+	if len(results) == 0 {
+		return "", false, fmt.Errorf("no result for %s", phone)
+	}
+	return results[0].JID, results[0].IsInWhatsapp, nil
+}
+
+func (r *Runner) release(ctx context.Context, recipient *models.CampaignRecipientModel) {
+	if err := r.repo.ReleaseRecipient(ctx, recipient.ID); err != nil {
+		r.logger.Errorf("Failed to release campaign recipient %s: %v", recipient.ID, err)
+	}
+}
+
+func (r *Runner) finish(ctx context.Context, campaign *models.CampaignModel, recipient *models.CampaignRecipientModel, status string, jid, messageID, lastError *string) {
+	if err := r.repo.FinishRecipient(ctx, recipient.ID, status, jid, messageID, lastError); err != nil {
+		r.logger.Errorf("Failed to record result of campaign recipient %s: %v", recipient.ID, err)
+		return
+	}
+
+	stats, err := r.repo.Stats(ctx, campaign.ID)
+	if err != nil {
+		r.logger.Warnf("Failed to get stats of campaign %s: %v", campaign.ID, err)
+		return
+	}
+
+	if stats.Pending == 0 && stats.Processing == 0 {
+		r.completeIfDone(ctx, campaign)
+		return
+	}
+
+	processed := stats.Sent + stats.Failed + stats.Invalid
+	if processed%r.config.ProgressEvery == 0 {
+		r.publishStats(campaign, EventCampaignProgress, stats)
+	}
+}
+
+func (r *Runner) completeIfDone(ctx context.Context, campaign *models.CampaignModel) {
+	completed, err := r.repo.CompleteIfDone(ctx, campaign.ID)
+	if err != nil {
+		r.logger.Errorf("Failed to complete campaign %s: %v", campaign.ID, err)
+		return
+	}
+	if !completed {
+		return
+	}
+
+	campaign.Status = models.CampaignStatusCompleted
+	r.logger.Infof("Campaign %s completed (session: %s)", campaign.ID, campaign.SessionId)
+	r.publish(campaign, EventCampaignCompleted)
+}
+
+// sendDelay é o intervalo até o próximo envio da sessão: 60s/taxa mais um atraso aleatório de até jitterSeconds
+func (r *Runner) sendDelay(campaign *models.CampaignModel) time.Duration {
+	delay := time.Minute / time.Duration(campaign.RatePerMinute)
+	if campaign.JitterSeconds > 0 {
+		r.mu.Lock()
+		delay += time.Duration(r.random.Int63n(int64(campaign.JitterSeconds) * int64(time.Second)))
+		r.mu.Unlock()
+	}
+	return delay
+}
+
+func (r *Runner) publish(campaign *models.CampaignModel, event string) {
+	stats, err := r.repo.Stats(context.Background(), campaign.ID)
+	if err != nil {
+		r.logger.Warnf("Failed to get stats of campaign %s: %v", campaign.ID, err)
+		return
+	}
+	r.publishStats(campaign, event, stats)
+}
+
+func (r *Runner) publishStats(campaign *models.CampaignModel, event string, stats *models.CampaignStats) {
+	if r.publisher == nil {
+		return
+	}
+
+	data := map[string]interface{}{
+		"campaign_id": campaign.ID,
+		"name":        campaign.Name,
+		"status":      campaign.Status,
+		"stats":       stats,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := r.publisher.Publish(ctx, campaign.SessionId, event, data); err != nil {
+		r.logger.Warnf("Failed to publish %s for campaign %s: %v", event, campaign.ID, err)
+	}
+}
+
+func recipientFromModel(recipient *models.CampaignRecipientModel) Recipient {
+	variables := make(map[string]string, len(recipient.Variables))
+	for key, value := range recipient.Variables {
+		variables[key] = fmt.Sprint(value)
+	}
+	return Recipient{Phone: recipient.Phone, Variables: variables}
+}
+
+// parseClock converte "HH:MM" em minutos desde a meia-noite
+func parseClock(value string) (int, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("must be HH:MM")
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+// inWindow indica se now está dentro da janela diária da campanha; janelas com fim antes do início atravessam a meia-noite
+func inWindow(campaign *models.CampaignModel, now time.Time) bool {
+	if campaign.WindowStart == nil || campaign.WindowEnd == nil {
+		return true
+	}
+
+	start, err := parseClock(*campaign.WindowStart)
+	if err != nil {
+		return true
+	}
+	end, err := parseClock(*campaign.WindowEnd)
+	if err != nil {
+		return true
+	}
+
+	location, err := time.LoadLocation(campaign.Timezone)
+	if err != nil {
+		location = time.UTC
+	}
+	local := now.In(location)
+	minute := local.Hour()*60 + local.Minute()
+
+	if start < end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
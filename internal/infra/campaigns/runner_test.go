@@ -0,0 +1,82 @@
+package campaigns
+
+import (
+	"testing"
+	"time"
+
+	"zpmeow/internal/infra/database/models"
+)
+
+func TestParseClock(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{value: "00:00", want: 0},
+		{value: "09:30", want: 570},
+		{value: "23:59", want: 1439},
+		{value: "9:05", want: 545},
+		{value: "24:00", wantErr: true},
+		{value: "12:60", wantErr: true},
+		{value: "12", wantErr: true},
+		{value: "noon", wantErr: true},
+		{value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseClock(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseClock(%q) = %d, want error", tt.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseClock(%q) error = %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseClock(%q) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInWindow(t *testing.T) {
+	window := func(start, end, timezone string) *models.CampaignModel {
+		return &models.CampaignModel{WindowStart: &start, WindowEnd: &end, Timezone: timezone}
+	}
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, 3, 10, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		campaign *models.CampaignModel
+		now      time.Time
+		want     bool
+	}{
+		{name: "no window", campaign: &models.CampaignModel{Timezone: "UTC"}, now: at(3, 0), want: true},
+		{name: "inside daytime window", campaign: window("09:00", "18:00", "UTC"), now: at(12, 0), want: true},
+		{name: "start is inclusive", campaign: window("09:00", "18:00", "UTC"), now: at(9, 0), want: true},
+		{name: "end is exclusive", campaign: window("09:00", "18:00", "UTC"), now: at(18, 0), want: false},
+		{name: "before daytime window", campaign: window("09:00", "18:00", "UTC"), now: at(8, 59), want: false},
+		{name: "wrapping window before midnight", campaign: window("22:00", "06:00", "UTC"), now: at(23, 30), want: true},
+		{name: "wrapping window after midnight", campaign: window("22:00", "06:00", "UTC"), now: at(2, 0), want: true},
+		{name: "wrapping window end is exclusive", campaign: window("22:00", "06:00", "UTC"), now: at(6, 0), want: false},
+		{name: "outside wrapping window", campaign: window("22:00", "06:00", "UTC"), now: at(12, 0), want: false},
+		{name: "window in the campaign timezone", campaign: window("09:00", "18:00", "America/Sao_Paulo"), now: at(11, 0), want: false},
+		{name: "window in the campaign timezone, open", campaign: window("09:00", "18:00", "America/Sao_Paulo"), now: at(13, 0), want: true},
+		{name: "invalid timezone falls back to UTC", campaign: window("09:00", "18:00", "Mars/Olympus"), now: at(12, 0), want: true},
+		{name: "invalid clock does not block sending", campaign: window("9am", "18:00", "UTC"), now: at(3, 0), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := inWindow(tt.campaign, tt.now); got != tt.want {
+				t.Errorf("inWindow() at %s = %v, want %v", tt.now.Format("15:04"), got, tt.want)
+			}
+		})
+	}
+}
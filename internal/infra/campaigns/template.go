@@ -0,0 +1,123 @@
+package campaigns
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"zpmeow/internal/application/common"
+	"zpmeow/internal/infra/templates"
+)
+
+var phoneSeparators = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "", "+", "")
+
+// Recipient é um destinatário da campanha com os valores das variáveis do template
+type Recipient struct {
+	Phone     string
+	Variables map[string]string
+}
+
+// renderTemplate preenche o template com as variáveis do destinatário; {{phone}} está sempre disponível
+func renderTemplate(template string, recipient Recipient) string {
+	variables := make(map[string]string, len(recipient.Variables)+1)
+	variables["phone"] = recipient.Phone
+	for name, value := range recipient.Variables {
+		variables[name] = value
+	}
+	return templates.Render(template, variables)
+}
+
+// normalizePhone remove a formatação comum de números (espaços, +, -, parênteses) e valida o resultado
+func normalizePhone(phone string) (string, error) {
+	normalized := phoneSeparators.Replace(strings.TrimSpace(phone))
+	if len(normalized) < 8 || len(normalized) > 15 {
+		return "", fmt.Errorf("invalid phone number %q", phone)
+	}
+	for _, r := range normalized {
+		if r < '0' || r > '9' {
+			return "", fmt.Errorf("invalid phone number %q", phone)
+		}
+	}
+	return normalized, nil
+}
+
+// prepareRecipients normaliza os números, descarta duplicados e garante que todas as variáveis do template foram informadas
+func prepareRecipients(template string, recipients []Recipient) ([]Recipient, error) {
+	variables := templates.Placeholders(template)
+	seen := make(map[string]bool, len(recipients))
+	prepared := make([]Recipient, 0, len(recipients))
+
+	for i, recipient := range recipients {
+		phone, err := normalizePhone(recipient.Phone)
+		if err != nil {
+			return nil, fmt.Errorf("%w: recipient %d: %v", common.ErrInvalidInput, i+1, err)
+		}
+		if seen[phone] {
+			continue
+		}
+		seen[phone] = true
+
+		for _, variable := range variables {
+			if _, ok := recipient.Variables[variable]; !ok && variable != "phone" {
+				return nil, fmt.Errorf("%w: recipient %d (%s) is missing variable %q", common.ErrInvalidInput, i+1, recipient.Phone, variable)
+			}
+		}
+
+		prepared = append(prepared, Recipient{Phone: phone, Variables: recipient.Variables})
+	}
+
+	return prepared, nil
+}
+
+// ParseRecipientsCSV lê destinatários de um CSV com cabeçalho; a coluna "phone" é obrigatória
+// e as demais colunas viram variáveis do template (ex.: name -> {{name}})
+func ParseRecipientsCSV(r io.Reader) ([]Recipient, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: CSV file is empty", common.ErrInvalidInput)
+		}
+		return nil, fmt.Errorf("%w: invalid CSV header: %v", common.ErrInvalidInput, err)
+	}
+
+	phoneColumn := -1
+	for i, column := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))
+		if strings.EqualFold(header[i], "phone") {
+			phoneColumn = i
+		}
+	}
+	if phoneColumn == -1 {
+		return nil, fmt.Errorf("%w: CSV header must contain a \"phone\" column", common.ErrInvalidInput)
+	}
+
+	var recipients []Recipient
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid CSV line %d: %v", common.ErrInvalidInput, line, err)
+		}
+		if phoneColumn >= len(record) || strings.TrimSpace(record[phoneColumn]) == "" {
+			continue
+		}
+
+		recipient := Recipient{Phone: record[phoneColumn], Variables: make(map[string]string)}
+		for i, value := range record {
+			if i != phoneColumn && i < len(header) && header[i] != "" {
+				recipient.Variables[header[i]] = strings.TrimSpace(value)
+			}
+		}
+		recipients = append(recipients, recipient)
+	}
+
+	return recipients, nil
+}
@@ -0,0 +1,57 @@
+package campaigns
+
+import "testing"
+
+func TestRenderTemplate(t *testing.T) {
+	tests := []struct {
+		name      string
+		template  string
+		recipient Recipient
+		want      string
+	}{
+		{
+			name:      "variables",
+			template:  "Hi {{name}}, your order {{ order }} has shipped",
+			recipient: Recipient{Phone: "5511999999999", Variables: map[string]string{"name": "Ana", "order": "#42"}},
+			want:      "Hi Ana, your order #42 has shipped",
+		},
+		{
+			name:      "phone is always available",
+			template:  "Sent to {{phone}}",
+			recipient: Recipient{Phone: "5511999999999"},
+			want:      "Sent to 5511999999999",
+		},
+		{
+			name:      "recipient variable overrides phone",
+			template:  "{{phone}}",
+			recipient: Recipient{Phone: "5511999999999", Variables: map[string]string{"phone": "(11) 99999-9999"}},
+			want:      "(11) 99999-9999",
+		},
+		{
+			name:      "missing variable renders empty",
+			template:  "Hi {{name}}!",
+			recipient: Recipient{Phone: "5511999999999"},
+			want:      "Hi !",
+		},
+		{
+			name:      "repeated placeholder",
+			template:  "{{name}} {{name}}",
+			recipient: Recipient{Variables: map[string]string{"name": "Ana"}},
+			want:      "Ana Ana",
+		},
+		{
+			name:      "text without placeholders and malformed braces",
+			template:  "Hello {name} {{ }} {{na-me}}",
+			recipient: Recipient{Variables: map[string]string{"name": "Ana"}},
+			want:      "Hello {name} {{ }} {{na-me}}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderTemplate(tt.template, tt.recipient); got != tt.want {
+				t.Errorf("renderTemplate(%q) = %q, want %q", tt.template, got, tt.want)
+			}
+		})
+	}
+}
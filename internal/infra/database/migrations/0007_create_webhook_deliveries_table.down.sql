@@ -0,0 +1,16 @@
+-- Drop trigger
+DROP TRIGGER IF EXISTS "trigger_zpWebhookDeliveries_updatedAt" ON "zpWebhookDeliveries";
+
+-- Drop function
+DROP FUNCTION IF EXISTS "update_zpWebhookDeliveries_updatedAt"();
+
+-- Drop indexes
+DROP INDEX IF EXISTS "idx_zpWebhookDeliveries_sessionId";
+DROP INDEX IF EXISTS "idx_zpWebhookDeliveries_status_nextAttemptAt";
+DROP INDEX IF EXISTS "idx_zpWebhookDeadLetters_sessionId";
+DROP INDEX IF EXISTS "idx_zpWebhookDeadLetters_failedAt";
+DROP INDEX IF EXISTS "idx_zpWebhookDeadLetters_event";
+
+-- Drop tables
+DROP TABLE IF EXISTS "zpWebhookDeadLetters";
+DROP TABLE IF EXISTS "zpWebhookDeliveries";
@@ -0,0 +1,68 @@
+-- Create zpWebhookDeliveries table (persistent outbox for webhook deliveries)
+CREATE TABLE IF NOT EXISTS "zpWebhookDeliveries" (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"(id) ON DELETE CASCADE,
+    url VARCHAR(500) NOT NULL,
+    event VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}'::jsonb,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- 'pending', 'processing'
+    attempts INTEGER NOT NULL DEFAULT 0,
+    "nextAttemptAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "lockedAt" TIMESTAMP WITH TIME ZONE,
+    "lastError" TEXT,
+    "lastStatusCode" INTEGER,
+    "createdAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS "idx_zpWebhookDeliveries_sessionId" ON "zpWebhookDeliveries"("sessionId");
+CREATE INDEX IF NOT EXISTS "idx_zpWebhookDeliveries_status_nextAttemptAt" ON "zpWebhookDeliveries"(status, "nextAttemptAt");
+
+-- Create trigger function for updatedAt
+CREATE OR REPLACE FUNCTION "update_zpWebhookDeliveries_updatedAt"()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW."updatedAt" = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+-- Create trigger
+CREATE TRIGGER "trigger_zpWebhookDeliveries_updatedAt"
+    BEFORE UPDATE ON "zpWebhookDeliveries"
+    FOR EACH ROW
+    EXECUTE FUNCTION "update_zpWebhookDeliveries_updatedAt"();
+
+-- Create zpWebhookDeadLetters table (deliveries that exhausted their retries)
+CREATE TABLE IF NOT EXISTS "zpWebhookDeadLetters" (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"(id) ON DELETE CASCADE,
+    "deliveryId" UUID NOT NULL,
+    url VARCHAR(500) NOT NULL,
+    event VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}'::jsonb,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    "lastError" TEXT,
+    "lastStatusCode" INTEGER,
+    "enqueuedAt" TIMESTAMP WITH TIME ZONE NOT NULL,
+    "failedAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    "replayedAt" TIMESTAMP WITH TIME ZONE,
+    "replayCount" INTEGER NOT NULL DEFAULT 0,
+    "createdAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS "idx_zpWebhookDeadLetters_sessionId" ON "zpWebhookDeadLetters"("sessionId");
+CREATE INDEX IF NOT EXISTS "idx_zpWebhookDeadLetters_failedAt" ON "zpWebhookDeadLetters"("failedAt");
+CREATE INDEX IF NOT EXISTS "idx_zpWebhookDeadLetters_event" ON "zpWebhookDeadLetters"(event);
+
+-- Comments
+COMMENT ON TABLE "zpWebhookDeliveries" IS 'Persistent outbox of pending webhook deliveries (camelCase)';
+COMMENT ON COLUMN "zpWebhookDeliveries".status IS 'Delivery state: pending (waiting for a worker) or processing (claimed by a worker)';
+COMMENT ON COLUMN "zpWebhookDeliveries".attempts IS 'Number of delivery attempts already made';
+COMMENT ON COLUMN "zpWebhookDeliveries"."nextAttemptAt" IS 'Earliest time the next attempt may run (exponential backoff)';
+COMMENT ON COLUMN "zpWebhookDeliveries"."lockedAt" IS 'When a worker claimed the delivery; stale locks are released on recovery';
+COMMENT ON TABLE "zpWebhookDeadLetters" IS 'Webhook deliveries that exhausted their retries (camelCase)';
+COMMENT ON COLUMN "zpWebhookDeadLetters"."deliveryId" IS 'Original zpWebhookDeliveries identifier';
+COMMENT ON COLUMN "zpWebhookDeadLetters"."replayedAt" IS 'Last time the delivery was re-enqueued through the API';
@@ -0,0 +1,5 @@
+-- Drop HMAC signing secret columns
+ALTER TABLE "zpWebhooks" DROP COLUMN IF EXISTS "secretRotatedAt";
+ALTER TABLE "zpWebhooks" DROP COLUMN IF EXISTS "previousSecretExpiresAt";
+ALTER TABLE "zpWebhooks" DROP COLUMN IF EXISTS "previousSecret";
+ALTER TABLE "zpWebhooks" DROP COLUMN IF EXISTS secret;
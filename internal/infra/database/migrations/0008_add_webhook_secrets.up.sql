@@ -0,0 +1,18 @@
+-- Add HMAC signing secrets to zpWebhooks
+ALTER TABLE "zpWebhooks" ADD COLUMN IF NOT EXISTS secret VARCHAR(128);
+ALTER TABLE "zpWebhooks" ADD COLUMN IF NOT EXISTS "previousSecret" VARCHAR(128);
+ALTER TABLE "zpWebhooks" ADD COLUMN IF NOT EXISTS "previousSecretExpiresAt" TIMESTAMP WITH TIME ZONE;
+ALTER TABLE "zpWebhooks" ADD COLUMN IF NOT EXISTS "secretRotatedAt" TIMESTAMP WITH TIME ZONE;
+
+-- Generate a secret for existing webhook configurations
+UPDATE "zpWebhooks"
+SET secret = 'whsec_' || replace(uuid_generate_v4()::text, '-', '') || replace(uuid_generate_v4()::text, '-', '')
+WHERE secret IS NULL;
+
+ALTER TABLE "zpWebhooks" ALTER COLUMN secret SET NOT NULL;
+
+-- Comments
+COMMENT ON COLUMN "zpWebhooks".secret IS 'Current HMAC-SHA256 secret used to sign webhook deliveries';
+COMMENT ON COLUMN "zpWebhooks"."previousSecret" IS 'Secret replaced by the last rotation, still used for signing during the grace window';
+COMMENT ON COLUMN "zpWebhooks"."previousSecretExpiresAt" IS 'When the previous secret stops being used for signing';
+COMMENT ON COLUMN "zpWebhooks"."secretRotatedAt" IS 'Timestamp of the last secret rotation';
@@ -0,0 +1,29 @@
+-- Drop endpoint links from the delivery queue
+DROP INDEX IF EXISTS "idx_zpWebhookDeadLetters_webhookId";
+DROP INDEX IF EXISTS "idx_zpWebhookDeliveries_webhookId";
+ALTER TABLE "zpWebhookDeadLetters" DROP COLUMN IF EXISTS "webhookId";
+ALTER TABLE "zpWebhookDeliveries" DROP COLUMN IF EXISTS "webhookId";
+
+-- Keep only one endpoint per session (the default one, or the oldest)
+DELETE FROM "zpWebhooks" w
+USING "zpWebhooks" keep
+WHERE w."sessionId" = keep."sessionId"
+  AND w.id <> keep.id
+  AND (keep."isDefault", keep."createdAt") > (w."isDefault", w."createdAt");
+
+DROP INDEX IF EXISTS "idx_zpWebhooks_sessionId_default";
+
+ALTER TABLE "zpWebhooks" DROP COLUMN IF EXISTS "lastStatusCode";
+ALTER TABLE "zpWebhooks" DROP COLUMN IF EXISTS "lastError";
+ALTER TABLE "zpWebhooks" DROP COLUMN IF EXISTS "lastFailureAt";
+ALTER TABLE "zpWebhooks" DROP COLUMN IF EXISTS "lastSuccessAt";
+ALTER TABLE "zpWebhooks" DROP COLUMN IF EXISTS "lastDeliveryAt";
+ALTER TABLE "zpWebhooks" DROP COLUMN IF EXISTS "failedCount";
+ALTER TABLE "zpWebhooks" DROP COLUMN IF EXISTS "deliveredCount";
+ALTER TABLE "zpWebhooks" DROP COLUMN IF EXISTS "isDefault";
+ALTER TABLE "zpWebhooks" DROP COLUMN IF EXISTS headers;
+ALTER TABLE "zpWebhooks" DROP COLUMN IF EXISTS name;
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_zpWebhooks_sessionId_unique" ON "zpWebhooks"("sessionId");
+
+COMMENT ON TABLE "zpWebhooks" IS 'Webhook configurations for sessions (camelCase)';
@@ -0,0 +1,43 @@
+-- Allow multiple webhook endpoints per session
+DROP INDEX IF EXISTS "idx_zpWebhooks_sessionId_unique";
+
+ALTER TABLE "zpWebhooks" ADD COLUMN IF NOT EXISTS name VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE "zpWebhooks" ADD COLUMN IF NOT EXISTS headers JSONB NOT NULL DEFAULT '{}'::jsonb;
+ALTER TABLE "zpWebhooks" ADD COLUMN IF NOT EXISTS "isDefault" BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Delivery statistics
+ALTER TABLE "zpWebhooks" ADD COLUMN IF NOT EXISTS "deliveredCount" BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE "zpWebhooks" ADD COLUMN IF NOT EXISTS "failedCount" BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE "zpWebhooks" ADD COLUMN IF NOT EXISTS "lastDeliveryAt" TIMESTAMP WITH TIME ZONE;
+ALTER TABLE "zpWebhooks" ADD COLUMN IF NOT EXISTS "lastSuccessAt" TIMESTAMP WITH TIME ZONE;
+ALTER TABLE "zpWebhooks" ADD COLUMN IF NOT EXISTS "lastFailureAt" TIMESTAMP WITH TIME ZONE;
+ALTER TABLE "zpWebhooks" ADD COLUMN IF NOT EXISTS "lastError" TEXT;
+ALTER TABLE "zpWebhooks" ADD COLUMN IF NOT EXISTS "lastStatusCode" INTEGER;
+
+-- Existing configurations (one per session) become the session's default endpoint
+UPDATE "zpWebhooks" SET "isDefault" = TRUE, name = 'default';
+
+-- At most one default endpoint per session (used by POST/GET /session/{id}/webhook)
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_zpWebhooks_sessionId_default" ON "zpWebhooks"("sessionId") WHERE "isDefault";
+
+-- Link queued deliveries and dead letters to the endpoint they target
+ALTER TABLE "zpWebhookDeliveries" ADD COLUMN IF NOT EXISTS "webhookId" UUID REFERENCES "zpWebhooks"(id) ON DELETE CASCADE;
+ALTER TABLE "zpWebhookDeadLetters" ADD COLUMN IF NOT EXISTS "webhookId" UUID REFERENCES "zpWebhooks"(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS "idx_zpWebhookDeliveries_webhookId" ON "zpWebhookDeliveries"("webhookId");
+CREATE INDEX IF NOT EXISTS "idx_zpWebhookDeadLetters_webhookId" ON "zpWebhookDeadLetters"("webhookId");
+
+-- Comments
+COMMENT ON TABLE "zpWebhooks" IS 'Webhook endpoints for sessions, many per session (camelCase)';
+COMMENT ON COLUMN "zpWebhooks".name IS 'Human readable endpoint name (e.g. crm, analytics)';
+COMMENT ON COLUMN "zpWebhooks".headers IS 'Custom HTTP headers sent with every delivery';
+COMMENT ON COLUMN "zpWebhooks"."isDefault" IS 'Endpoint managed by the legacy single-webhook API';
+COMMENT ON COLUMN "zpWebhooks"."deliveredCount" IS 'Number of successful deliveries';
+COMMENT ON COLUMN "zpWebhooks"."failedCount" IS 'Number of failed delivery attempts';
+COMMENT ON COLUMN "zpWebhooks"."lastDeliveryAt" IS 'Timestamp of the last delivery attempt';
+COMMENT ON COLUMN "zpWebhooks"."lastSuccessAt" IS 'Timestamp of the last successful delivery';
+COMMENT ON COLUMN "zpWebhooks"."lastFailureAt" IS 'Timestamp of the last failed delivery attempt';
+COMMENT ON COLUMN "zpWebhooks"."lastError" IS 'Error of the last failed delivery attempt';
+COMMENT ON COLUMN "zpWebhooks"."lastStatusCode" IS 'HTTP status of the last delivery attempt';
+COMMENT ON COLUMN "zpWebhookDeliveries"."webhookId" IS 'Target webhook endpoint';
+COMMENT ON COLUMN "zpWebhookDeadLetters"."webhookId" IS 'Target webhook endpoint (NULL if it was deleted)';
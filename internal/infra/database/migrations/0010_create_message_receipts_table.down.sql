@@ -0,0 +1,7 @@
+-- Drop indexes
+DROP INDEX IF EXISTS "idx_zpMessageReceipts_messageId";
+DROP INDEX IF EXISTS "idx_zpMessageReceipts_session_msgId";
+DROP INDEX IF EXISTS "idx_zpMessageReceipts_message_recipient_status_unique";
+
+-- Drop table
+DROP TABLE IF EXISTS "zpMessageReceipts";
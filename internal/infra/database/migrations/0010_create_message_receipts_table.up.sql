@@ -0,0 +1,25 @@
+-- Create zpMessageReceipts table (status timeline of messages we sent, one row per recipient and status)
+CREATE TABLE IF NOT EXISTS "zpMessageReceipts" (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "messageId" UUID NOT NULL REFERENCES "zpMessages"(id) ON DELETE CASCADE,
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"(id) ON DELETE CASCADE,
+    "msgId" VARCHAR(255) NOT NULL, -- WhatsApp message ID
+    "recipientJid" VARCHAR(255) NOT NULL, -- participant in groups, contact in direct chats
+    status VARCHAR(20) NOT NULL, -- 'delivered', 'read', 'played', 'failed'
+    timestamp TIMESTAMP WITH TIME ZONE NOT NULL,
+    "createdAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS "idx_zpMessageReceipts_messageId" ON "zpMessageReceipts"("messageId");
+CREATE INDEX IF NOT EXISTS "idx_zpMessageReceipts_session_msgId" ON "zpMessageReceipts"("sessionId", "msgId");
+
+-- A recipient reaches each status only once; repeated receipts are ignored
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_zpMessageReceipts_message_recipient_status_unique" ON "zpMessageReceipts"("messageId", "recipientJid", status);
+
+-- Comments
+COMMENT ON TABLE "zpMessageReceipts" IS 'Per-recipient delivery/read receipts of sent messages (camelCase)';
+COMMENT ON COLUMN "zpMessageReceipts"."messageId" IS 'Reference to the message (UUID)';
+COMMENT ON COLUMN "zpMessageReceipts"."recipientJid" IS 'WhatsApp JID of the recipient that sent the receipt';
+COMMENT ON COLUMN "zpMessageReceipts".status IS 'Status reported by the receipt';
+COMMENT ON COLUMN "zpMessageReceipts".timestamp IS 'When the recipient reached the status';
@@ -0,0 +1,13 @@
+-- Drop trigger
+DROP TRIGGER IF EXISTS "trigger_zpMedia_updatedAt" ON "zpMedia";
+
+-- Drop function
+DROP FUNCTION IF EXISTS "update_zpMedia_updatedAt"();
+
+-- Drop indexes
+DROP INDEX IF EXISTS "idx_zpMedia_session_mediaId_unique";
+DROP INDEX IF EXISTS "idx_zpMedia_storageKey";
+DROP INDEX IF EXISTS "idx_zpMedia_session_createdAt";
+
+-- Drop table
+DROP TABLE IF EXISTS "zpMedia";
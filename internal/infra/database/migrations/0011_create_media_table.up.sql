@@ -0,0 +1,41 @@
+-- Create zpMedia table (metadata of media stored in the configured storage backend)
+CREATE TABLE IF NOT EXISTS "zpMedia" (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"(id) ON DELETE CASCADE,
+    "mediaId" VARCHAR(64) NOT NULL, -- SHA-256 of the content (content-addressed)
+    "mediaType" VARCHAR(20) NOT NULL, -- 'image', 'audio', 'video', 'document', 'sticker'
+    "mimeType" VARCHAR(255) NOT NULL,
+    "fileName" VARCHAR(500),
+    size BIGINT NOT NULL,
+    "storageDriver" VARCHAR(20) NOT NULL, -- 'local', 's3'
+    "storageKey" VARCHAR(500) NOT NULL,
+    metadata JSONB DEFAULT '{}'::jsonb,
+    "createdAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_zpMedia_session_mediaId_unique" ON "zpMedia"("sessionId", "mediaId");
+CREATE INDEX IF NOT EXISTS "idx_zpMedia_storageKey" ON "zpMedia"("storageKey");
+CREATE INDEX IF NOT EXISTS "idx_zpMedia_session_createdAt" ON "zpMedia"("sessionId", "createdAt" DESC);
+
+-- Create trigger function for updatedAt
+CREATE OR REPLACE FUNCTION "update_zpMedia_updatedAt"()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW."updatedAt" = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+-- Create trigger
+CREATE TRIGGER "trigger_zpMedia_updatedAt"
+    BEFORE UPDATE ON "zpMedia"
+    FOR EACH ROW
+    EXECUTE FUNCTION "update_zpMedia_updatedAt"();
+
+-- Comments
+COMMENT ON TABLE "zpMedia" IS 'Media metadata; the content lives in the storage backend (camelCase)';
+COMMENT ON COLUMN "zpMedia"."mediaId" IS 'Content-addressed identifier (hex SHA-256), unique per session';
+COMMENT ON COLUMN "zpMedia"."storageDriver" IS 'Storage backend that holds the content';
+COMMENT ON COLUMN "zpMedia"."storageKey" IS 'Object key in the storage backend; shared by sessions that uploaded the same content';
@@ -0,0 +1,11 @@
+-- Drop trigger
+DROP TRIGGER IF EXISTS "trigger_zpMediaPolicies_updatedAt" ON "zpMediaPolicies";
+
+-- Drop function
+DROP FUNCTION IF EXISTS "update_zpMediaPolicies_updatedAt"();
+
+-- Drop indexes
+DROP INDEX IF EXISTS "idx_zpMediaPolicies_sessionId_unique";
+
+-- Drop table
+DROP TABLE IF EXISTS "zpMediaPolicies";
@@ -0,0 +1,34 @@
+-- Create zpMediaPolicies table (per-session policy for archiving inbound media)
+CREATE TABLE IF NOT EXISTS "zpMediaPolicies" (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"(id) ON DELETE CASCADE,
+    "autoDownload" BOOLEAN NOT NULL DEFAULT false,
+    "mediaTypes" JSONB NOT NULL DEFAULT '["image", "video", "audio", "document", "sticker"]'::jsonb,
+    "maxSizes" JSONB NOT NULL DEFAULT '{}'::jsonb, -- {"video": 10485760, ...} in bytes
+    "createdAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_zpMediaPolicies_sessionId_unique" ON "zpMediaPolicies"("sessionId");
+
+-- Create trigger function for updatedAt
+CREATE OR REPLACE FUNCTION "update_zpMediaPolicies_updatedAt"()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW."updatedAt" = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+-- Create trigger
+CREATE TRIGGER "trigger_zpMediaPolicies_updatedAt"
+    BEFORE UPDATE ON "zpMediaPolicies"
+    FOR EACH ROW
+    EXECUTE FUNCTION "update_zpMediaPolicies_updatedAt"();
+
+-- Comments
+COMMENT ON TABLE "zpMediaPolicies" IS 'Per-session policy for downloading inbound media into the storage backend (camelCase)';
+COMMENT ON COLUMN "zpMediaPolicies"."autoDownload" IS 'Opt-in flag; inbound media is only archived when true';
+COMMENT ON COLUMN "zpMediaPolicies"."mediaTypes" IS 'Media types archived automatically';
+COMMENT ON COLUMN "zpMediaPolicies"."maxSizes" IS 'Per media type size limit in bytes; capped by the server-wide limits';
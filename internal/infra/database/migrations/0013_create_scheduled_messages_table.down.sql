@@ -0,0 +1,13 @@
+-- Drop trigger
+DROP TRIGGER IF EXISTS "trigger_zpScheduledMessages_updatedAt" ON "zpScheduledMessages";
+
+-- Drop function
+DROP FUNCTION IF EXISTS "update_zpScheduledMessages_updatedAt"();
+
+-- Drop indexes
+DROP INDEX IF EXISTS "idx_zpScheduledMessages_sessionId";
+DROP INDEX IF EXISTS "idx_zpScheduledMessages_status_nextAttemptAt";
+DROP INDEX IF EXISTS "idx_zpScheduledMessages_sessionId_sendAt";
+
+-- Drop table
+DROP TABLE IF EXISTS "zpScheduledMessages";
@@ -0,0 +1,49 @@
+-- Create zpScheduledMessages table (messages queued to be sent at a future time)
+CREATE TABLE IF NOT EXISTS "zpScheduledMessages" (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"(id) ON DELETE CASCADE,
+    phone VARCHAR(255) NOT NULL,
+    "messageType" VARCHAR(20) NOT NULL, -- 'text', 'image', 'audio', 'video', 'document', 'sticker', 'location', 'contact', 'poll'
+    payload JSONB NOT NULL DEFAULT '{}'::jsonb,
+    "sendAt" TIMESTAMP WITH TIME ZONE NOT NULL,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    status VARCHAR(20) NOT NULL DEFAULT 'scheduled', -- 'scheduled', 'processing', 'waiting_session', 'sent', 'failed', 'canceled'
+    attempts INTEGER NOT NULL DEFAULT 0,
+    "nextAttemptAt" TIMESTAMP WITH TIME ZONE NOT NULL,
+    "lockedAt" TIMESTAMP WITH TIME ZONE,
+    "lastError" TEXT,
+    "messageId" VARCHAR(255),
+    "sentAt" TIMESTAMP WITH TIME ZONE,
+    "createdAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS "idx_zpScheduledMessages_sessionId" ON "zpScheduledMessages"("sessionId");
+CREATE INDEX IF NOT EXISTS "idx_zpScheduledMessages_status_nextAttemptAt" ON "zpScheduledMessages"(status, "nextAttemptAt");
+CREATE INDEX IF NOT EXISTS "idx_zpScheduledMessages_sessionId_sendAt" ON "zpScheduledMessages"("sessionId", "sendAt");
+
+-- Create trigger function for updatedAt
+CREATE OR REPLACE FUNCTION "update_zpScheduledMessages_updatedAt"()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW."updatedAt" = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+-- Create trigger
+CREATE TRIGGER "trigger_zpScheduledMessages_updatedAt"
+    BEFORE UPDATE ON "zpScheduledMessages"
+    FOR EACH ROW
+    EXECUTE FUNCTION "update_zpScheduledMessages_updatedAt"();
+
+-- Comments
+COMMENT ON TABLE "zpScheduledMessages" IS 'Messages scheduled through the API and dispatched by the scheduler worker (camelCase)';
+COMMENT ON COLUMN "zpScheduledMessages".payload IS 'Message content as accepted by the matching send endpoint';
+COMMENT ON COLUMN "zpScheduledMessages"."sendAt" IS 'Requested send time (stored in UTC)';
+COMMENT ON COLUMN "zpScheduledMessages".timezone IS 'IANA timezone the send time was expressed in';
+COMMENT ON COLUMN "zpScheduledMessages".status IS 'scheduled, processing, waiting_session (retried when the session reconnects), sent, failed or canceled';
+COMMENT ON COLUMN "zpScheduledMessages"."nextAttemptAt" IS 'Earliest time the worker may dispatch the message; moves forward on retries';
+COMMENT ON COLUMN "zpScheduledMessages"."lockedAt" IS 'When a worker claimed the message; stale locks are released on recovery';
+COMMENT ON COLUMN "zpScheduledMessages"."messageId" IS 'WhatsApp message ID once sent';
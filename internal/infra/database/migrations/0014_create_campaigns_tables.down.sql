@@ -0,0 +1,16 @@
+-- Drop triggers
+DROP TRIGGER IF EXISTS "trigger_zpCampaignRecipients_updatedAt" ON "zpCampaignRecipients";
+DROP TRIGGER IF EXISTS "trigger_zpCampaigns_updatedAt" ON "zpCampaigns";
+
+-- Drop functions
+DROP FUNCTION IF EXISTS "update_zpCampaignRecipients_updatedAt"();
+DROP FUNCTION IF EXISTS "update_zpCampaigns_updatedAt"();
+
+-- Drop indexes
+DROP INDEX IF EXISTS "idx_zpCampaignRecipients_campaignId_status_position";
+DROP INDEX IF EXISTS "idx_zpCampaigns_status";
+DROP INDEX IF EXISTS "idx_zpCampaigns_sessionId";
+
+-- Drop tables
+DROP TABLE IF EXISTS "zpCampaignRecipients";
+DROP TABLE IF EXISTS "zpCampaigns";
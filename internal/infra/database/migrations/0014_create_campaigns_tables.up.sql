@@ -0,0 +1,83 @@
+-- Create zpCampaigns table (bulk sends of a templated text message to a recipient list)
+CREATE TABLE IF NOT EXISTS "zpCampaigns" (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    template TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'running', -- 'running', 'paused', 'completed', 'canceled'
+    "ratePerMinute" INTEGER NOT NULL,
+    "jitterSeconds" INTEGER NOT NULL DEFAULT 0,
+    "windowStart" VARCHAR(5),
+    "windowEnd" VARCHAR(5),
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    "checkNumbers" BOOLEAN NOT NULL DEFAULT true,
+    "totalRecipients" INTEGER NOT NULL DEFAULT 0,
+    "lastSentAt" TIMESTAMP WITH TIME ZONE,
+    "completedAt" TIMESTAMP WITH TIME ZONE,
+    "createdAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create zpCampaignRecipients table (per-recipient result of a campaign)
+CREATE TABLE IF NOT EXISTS "zpCampaignRecipients" (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "campaignId" UUID NOT NULL REFERENCES "zpCampaigns"(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    phone VARCHAR(255) NOT NULL,
+    variables JSONB NOT NULL DEFAULT '{}'::jsonb,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- 'pending', 'processing', 'sent', 'failed', 'invalid', 'canceled'
+    jid VARCHAR(255),
+    "messageId" VARCHAR(255),
+    error TEXT,
+    "lockedAt" TIMESTAMP WITH TIME ZONE,
+    "processedAt" TIMESTAMP WITH TIME ZONE,
+    "createdAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE ("campaignId", phone)
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS "idx_zpCampaigns_sessionId" ON "zpCampaigns"("sessionId");
+CREATE INDEX IF NOT EXISTS "idx_zpCampaigns_status" ON "zpCampaigns"(status);
+CREATE INDEX IF NOT EXISTS "idx_zpCampaignRecipients_campaignId_status_position" ON "zpCampaignRecipients"("campaignId", status, position);
+
+-- Create trigger functions for updatedAt
+CREATE OR REPLACE FUNCTION "update_zpCampaigns_updatedAt"()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW."updatedAt" = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE OR REPLACE FUNCTION "update_zpCampaignRecipients_updatedAt"()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW."updatedAt" = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+-- Create triggers
+CREATE TRIGGER "trigger_zpCampaigns_updatedAt"
+    BEFORE UPDATE ON "zpCampaigns"
+    FOR EACH ROW
+    EXECUTE FUNCTION "update_zpCampaigns_updatedAt"();
+
+CREATE TRIGGER "trigger_zpCampaignRecipients_updatedAt"
+    BEFORE UPDATE ON "zpCampaignRecipients"
+    FOR EACH ROW
+    EXECUTE FUNCTION "update_zpCampaignRecipients_updatedAt"();
+
+-- Comments
+COMMENT ON TABLE "zpCampaigns" IS 'Bulk send campaigns paced by the campaign runner (camelCase)';
+COMMENT ON COLUMN "zpCampaigns".template IS 'Message text with {{variable}} placeholders filled per recipient';
+COMMENT ON COLUMN "zpCampaigns"."ratePerMinute" IS 'Maximum messages per minute; the limit is shared by all campaigns of the session';
+COMMENT ON COLUMN "zpCampaigns"."jitterSeconds" IS 'Maximum random delay added between two sends';
+COMMENT ON COLUMN "zpCampaigns"."windowStart" IS 'Start of the daily sending window (HH:MM in timezone); NULL sends at any time';
+COMMENT ON COLUMN "zpCampaigns"."windowEnd" IS 'End of the daily sending window (HH:MM in timezone); may be earlier than windowStart to span midnight';
+COMMENT ON COLUMN "zpCampaigns"."checkNumbers" IS 'Checks each number on WhatsApp before sending; numbers not on WhatsApp are marked invalid';
+COMMENT ON TABLE "zpCampaignRecipients" IS 'Recipients of a campaign with their individual send result (camelCase)';
+COMMENT ON COLUMN "zpCampaignRecipients".variables IS 'Values for the template placeholders of this recipient';
+COMMENT ON COLUMN "zpCampaignRecipients".status IS 'pending, processing, sent, failed, invalid (not on WhatsApp) or canceled';
+COMMENT ON COLUMN "zpCampaignRecipients"."lockedAt" IS 'When the runner claimed the recipient; stale locks are released on recovery';
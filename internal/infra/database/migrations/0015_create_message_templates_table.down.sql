@@ -0,0 +1,11 @@
+-- Drop trigger
+DROP TRIGGER IF EXISTS "trigger_zpMessageTemplates_updatedAt" ON "zpMessageTemplates";
+
+-- Drop function
+DROP FUNCTION IF EXISTS "update_zpMessageTemplates_updatedAt"();
+
+-- Drop indexes
+DROP INDEX IF EXISTS "idx_zpMessageTemplates_sessionId";
+
+-- Drop table
+DROP TABLE IF EXISTS "zpMessageTemplates";
@@ -0,0 +1,36 @@
+-- Create zpMessageTemplates table (reusable per-session message content with named placeholders)
+CREATE TABLE IF NOT EXISTS "zpMessageTemplates" (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    "messageType" VARCHAR(20) NOT NULL, -- 'text', 'media', 'buttons', 'list', 'poll'
+    content JSONB NOT NULL DEFAULT '{}'::jsonb,
+    variables JSONB NOT NULL DEFAULT '[]'::jsonb,
+    "createdAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE ("sessionId", name)
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS "idx_zpMessageTemplates_sessionId" ON "zpMessageTemplates"("sessionId");
+
+-- Create trigger function for updatedAt
+CREATE OR REPLACE FUNCTION "update_zpMessageTemplates_updatedAt"()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW."updatedAt" = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+-- Create trigger
+CREATE TRIGGER "trigger_zpMessageTemplates_updatedAt"
+    BEFORE UPDATE ON "zpMessageTemplates"
+    FOR EACH ROW
+    EXECUTE FUNCTION "update_zpMessageTemplates_updatedAt"();
+
+-- Comments
+COMMENT ON TABLE "zpMessageTemplates" IS 'Reusable message templates rendered with per-send variables (camelCase)';
+COMMENT ON COLUMN "zpMessageTemplates".name IS 'Template name, unique per session';
+COMMENT ON COLUMN "zpMessageTemplates".content IS 'Message body as accepted by the matching send endpoint, without phone; strings may contain {{variable}} placeholders';
+COMMENT ON COLUMN "zpMessageTemplates".variables IS 'Placeholder names found in content, required when rendering';
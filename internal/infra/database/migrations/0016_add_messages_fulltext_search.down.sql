@@ -0,0 +1,9 @@
+-- Drop indexes
+DROP INDEX IF EXISTS "idx_zpMessages_sessionId_timestamp_id";
+DROP INDEX IF EXISTS "idx_zpMessages_searchVector";
+
+-- Drop column
+ALTER TABLE "zpMessages" DROP COLUMN IF EXISTS "searchVector";
+
+-- Drop text search configuration (the unaccent extension is kept, it may be used elsewhere)
+DROP TEXT SEARCH CONFIGURATION IF EXISTS zpmeow_search;
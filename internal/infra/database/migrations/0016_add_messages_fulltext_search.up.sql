@@ -0,0 +1,29 @@
+-- Enable accent-insensitive search
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- Text search configuration used by message search: Portuguese stemming with accents removed,
+-- so "promocao" matches "promoção". To search another language, change the mapping
+-- (ALTER TEXT SEARCH CONFIGURATION ... ALTER MAPPING) and reindex "idx_zpMessages_searchVector".
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = 'zpmeow_search') THEN
+        CREATE TEXT SEARCH CONFIGURATION zpmeow_search (COPY = portuguese);
+        ALTER TEXT SEARCH CONFIGURATION zpmeow_search
+            ALTER MAPPING FOR hword, hword_part, word WITH unaccent, portuguese_stem;
+    END IF;
+END
+$$;
+
+-- Search document: text content (captions are stored in content) plus the document file name
+ALTER TABLE "zpMessages" ADD COLUMN IF NOT EXISTS "searchVector" tsvector
+    GENERATED ALWAYS AS (
+        to_tsvector('zpmeow_search'::regconfig,
+            coalesce(content, '') || ' ' || coalesce("mediaInfo"->>'caption', '') || ' ' || coalesce("mediaInfo"->>'fileName', ''))
+    ) STORED;
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS "idx_zpMessages_searchVector" ON "zpMessages" USING GIN ("searchVector");
+CREATE INDEX IF NOT EXISTS "idx_zpMessages_sessionId_timestamp_id" ON "zpMessages"("sessionId", timestamp DESC, id DESC);
+
+-- Comments
+COMMENT ON COLUMN "zpMessages"."searchVector" IS 'Full-text search document (zpmeow_search config) over content, caption and file name';
@@ -0,0 +1,14 @@
+-- Drop indexes
+DROP INDEX IF EXISTS "idx_zpMessages_chatId_timestamp_id";
+
+-- Restore "quotedMsgId" as a UUID reference; quotes of messages that are not stored are lost
+UPDATE "zpMessages" m SET "quotedMsgId" = (
+    SELECT q.id::text FROM "zpMessages" q
+    WHERE q."sessionId" = m."sessionId" AND q."msgId" = m."quotedMsgId"
+)
+WHERE m."quotedMsgId" IS NOT NULL;
+
+ALTER TABLE "zpMessages" ALTER COLUMN "quotedMsgId" TYPE UUID USING "quotedMsgId"::uuid;
+ALTER TABLE "zpMessages" ADD CONSTRAINT "zpMessages_quotedMsgId_fkey" FOREIGN KEY ("quotedMsgId") REFERENCES "zpMessages"(id);
+
+COMMENT ON COLUMN "zpMessages"."quotedMsgId" IS 'Reference to quoted message (UUID)';
@@ -0,0 +1,15 @@
+-- "quotedMsgId" holds the WhatsApp ID of the quoted message (ContextInfo.StanzaID), which may not be stored locally,
+-- so it can no longer be a UUID reference to "zpMessages"
+ALTER TABLE "zpMessages" DROP CONSTRAINT IF EXISTS "zpMessages_quotedMsgId_fkey";
+ALTER TABLE "zpMessages" ALTER COLUMN "quotedMsgId" TYPE VARCHAR(255) USING "quotedMsgId"::text;
+
+-- Existing rows referenced the quoted row by UUID; convert them to the WhatsApp ID
+UPDATE "zpMessages" m SET "quotedMsgId" = q."msgId"
+FROM "zpMessages" q
+WHERE q.id::text = m."quotedMsgId";
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS "idx_zpMessages_chatId_timestamp_id" ON "zpMessages"("chatId", timestamp DESC, id DESC);
+
+-- Comments
+COMMENT ON COLUMN "zpMessages"."quotedMsgId" IS 'WhatsApp message ID of the quoted message';
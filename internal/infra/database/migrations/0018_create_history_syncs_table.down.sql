@@ -0,0 +1,11 @@
+-- Drop trigger
+DROP TRIGGER IF EXISTS "trigger_zpHistorySyncs_updatedAt" ON "zpHistorySyncs";
+
+-- Drop function
+DROP FUNCTION IF EXISTS "update_zpHistorySyncs_updatedAt"();
+
+-- Drop indexes
+DROP INDEX IF EXISTS "idx_zpHistorySyncs_sessionId_unique";
+
+-- Drop table
+DROP TABLE IF EXISTS "zpHistorySyncs";
@@ -0,0 +1,43 @@
+-- Create zpHistorySyncs table (progress of the history sent by the phone after pairing, one row per session)
+CREATE TABLE IF NOT EXISTS "zpHistorySyncs" (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'running', -- 'running', 'completed'
+    "syncType" VARCHAR(50) NOT NULL, -- last HistorySync type: 'INITIAL_BOOTSTRAP', 'RECENT', 'FULL', 'PUSH_NAME', 'ON_DEMAND', ...
+    progress INTEGER NOT NULL DEFAULT 0,
+    chunks INTEGER NOT NULL DEFAULT 0,
+    conversations INTEGER NOT NULL DEFAULT 0,
+    "messagesStored" INTEGER NOT NULL DEFAULT 0,
+    "messagesSkipped" INTEGER NOT NULL DEFAULT 0,
+    "lastError" TEXT,
+    "startedAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "lastChunkAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "completedAt" TIMESTAMP WITH TIME ZONE,
+    "createdAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_zpHistorySyncs_sessionId_unique" ON "zpHistorySyncs"("sessionId");
+
+-- Create trigger function for updatedAt
+CREATE OR REPLACE FUNCTION "update_zpHistorySyncs_updatedAt"()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW."updatedAt" = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+-- Create trigger
+CREATE TRIGGER "trigger_zpHistorySyncs_updatedAt"
+    BEFORE UPDATE ON "zpHistorySyncs"
+    FOR EACH ROW
+    EXECUTE FUNCTION "update_zpHistorySyncs_updatedAt"();
+
+-- Comments
+COMMENT ON TABLE "zpHistorySyncs" IS 'History sync progress per session: chats and messages imported into zpChats/zpMessages (camelCase)';
+COMMENT ON COLUMN "zpHistorySyncs".progress IS 'Progress percentage reported by the phone (0-100)';
+COMMENT ON COLUMN "zpHistorySyncs".chunks IS 'Number of HistorySync chunks processed';
+COMMENT ON COLUMN "zpHistorySyncs"."messagesStored" IS 'Messages inserted; messages already stored are not counted';
+COMMENT ON COLUMN "zpHistorySyncs"."messagesSkipped" IS 'Messages ignored: older than the day limit, duplicates or without content';
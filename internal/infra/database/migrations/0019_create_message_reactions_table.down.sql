@@ -0,0 +1,12 @@
+-- Drop trigger
+DROP TRIGGER IF EXISTS "trigger_zpMessageReactions_updatedAt" ON "zpMessageReactions";
+
+-- Drop function
+DROP FUNCTION IF EXISTS "update_zpMessageReactions_updatedAt"();
+
+-- Drop indexes
+DROP INDEX IF EXISTS "idx_zpMessageReactions_session_msgId";
+DROP INDEX IF EXISTS "idx_zpMessageReactions_message_sender_unique";
+
+-- Drop table
+DROP TABLE IF EXISTS "zpMessageReactions";
@@ -0,0 +1,40 @@
+-- Create zpMessageReactions table (current reaction of each sender to a stored message)
+CREATE TABLE IF NOT EXISTS "zpMessageReactions" (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "messageId" UUID NOT NULL REFERENCES "zpMessages"(id) ON DELETE CASCADE,
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"(id) ON DELETE CASCADE,
+    "msgId" VARCHAR(255) NOT NULL, -- WhatsApp ID of the reacted message
+    "senderJid" VARCHAR(255) NOT NULL, -- participant in groups, contact in direct chats
+    reaction VARCHAR(64) NOT NULL, -- emoji
+    timestamp TIMESTAMP WITH TIME ZONE NOT NULL,
+    "createdAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS "idx_zpMessageReactions_session_msgId" ON "zpMessageReactions"("sessionId", "msgId");
+
+-- Each sender keeps a single reaction per message; a new reaction replaces the previous one
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_zpMessageReactions_message_sender_unique" ON "zpMessageReactions"("messageId", "senderJid");
+
+-- Create trigger function for updatedAt
+CREATE OR REPLACE FUNCTION "update_zpMessageReactions_updatedAt"()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW."updatedAt" = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+-- Create trigger
+CREATE TRIGGER "trigger_zpMessageReactions_updatedAt"
+    BEFORE UPDATE ON "zpMessageReactions"
+    FOR EACH ROW
+    EXECUTE FUNCTION "update_zpMessageReactions_updatedAt"();
+
+-- Comments
+COMMENT ON TABLE "zpMessageReactions" IS 'Per-sender reactions to stored messages; removed reactions are deleted (camelCase)';
+COMMENT ON COLUMN "zpMessageReactions"."messageId" IS 'Reference to the reacted message (UUID)';
+COMMENT ON COLUMN "zpMessageReactions"."senderJid" IS 'WhatsApp JID of who reacted';
+COMMENT ON COLUMN "zpMessageReactions".reaction IS 'Reaction emoji';
+COMMENT ON COLUMN "zpMessageReactions".timestamp IS 'When the reaction was sent; older reactions arriving late are ignored';
@@ -0,0 +1,5 @@
+-- Drop constraints
+ALTER TABLE "zpWebhooks" DROP CONSTRAINT IF EXISTS "zpWebhooks_payloadFormat_check";
+
+-- Drop column
+ALTER TABLE "zpWebhooks" DROP COLUMN IF EXISTS "payloadFormat";
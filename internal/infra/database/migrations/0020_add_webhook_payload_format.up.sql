@@ -0,0 +1,11 @@
+-- Add payload format; existing endpoints keep the legacy payload with the raw whatsmeow struct
+ALTER TABLE "zpWebhooks" ADD COLUMN IF NOT EXISTS "payloadFormat" VARCHAR(20) NOT NULL DEFAULT 'raw';
+
+-- New endpoints receive the versioned envelope
+ALTER TABLE "zpWebhooks" ALTER COLUMN "payloadFormat" SET DEFAULT 'v1';
+
+-- Constraints
+ALTER TABLE "zpWebhooks" ADD CONSTRAINT "zpWebhooks_payloadFormat_check" CHECK ("payloadFormat" IN ('v1', 'raw'));
+
+-- Comments
+COMMENT ON COLUMN "zpWebhooks"."payloadFormat" IS 'Delivery payload format: v1 (versioned envelope with typed data) or raw (legacy payload with the whatsmeow struct)';
@@ -0,0 +1,17 @@
+-- Drop triggers
+DROP TRIGGER IF EXISTS "trigger_zpCalls_updatedAt" ON "zpCalls";
+DROP TRIGGER IF EXISTS "trigger_zpCallPolicies_updatedAt" ON "zpCallPolicies";
+
+-- Drop functions
+DROP FUNCTION IF EXISTS "update_zpCalls_updatedAt"();
+DROP FUNCTION IF EXISTS "update_zpCallPolicies_updatedAt"();
+
+-- Drop indexes
+DROP INDEX IF EXISTS "idx_zpCalls_session_callerJid";
+DROP INDEX IF EXISTS "idx_zpCalls_session_offeredAt";
+DROP INDEX IF EXISTS "idx_zpCalls_session_callId_unique";
+DROP INDEX IF EXISTS "idx_zpCallPolicies_sessionId_unique";
+
+-- Drop tables
+DROP TABLE IF EXISTS "zpCalls";
+DROP TABLE IF EXISTS "zpCallPolicies";
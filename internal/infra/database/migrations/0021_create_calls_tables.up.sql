@@ -0,0 +1,71 @@
+-- Create zpCallPolicies table (per-session handling of incoming calls)
+CREATE TABLE IF NOT EXISTS "zpCallPolicies" (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"(id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL DEFAULT 'ignore',
+    "rejectMessage" TEXT, -- text sent to the caller when action = 'reject_with_message'
+    "createdAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "zpCallPolicies_action_check" CHECK (action IN ('ignore', 'reject', 'reject_with_message'))
+);
+
+-- Create zpCalls table (call log)
+CREATE TABLE IF NOT EXISTS "zpCalls" (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"(id) ON DELETE CASCADE,
+    "callId" VARCHAR(255) NOT NULL, -- WhatsApp call ID
+    "callerJid" VARCHAR(255) NOT NULL,
+    "groupJid" VARCHAR(255),
+    "isGroup" BOOLEAN NOT NULL DEFAULT false,
+    "isVideo" BOOLEAN NOT NULL DEFAULT false,
+    outcome VARCHAR(20) NOT NULL DEFAULT 'ringing',
+    reason VARCHAR(255), -- terminate reason or reject error
+    "replyMessageId" VARCHAR(255), -- WhatsApp ID of the automatic reply
+    "remotePlatform" VARCHAR(50),
+    "offeredAt" TIMESTAMP WITH TIME ZONE NOT NULL,
+    "endedAt" TIMESTAMP WITH TIME ZONE,
+    "createdAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "zpCalls_outcome_check" CHECK (outcome IN ('ringing', 'accepted', 'rejected', 'auto_rejected', 'missed'))
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_zpCallPolicies_sessionId_unique" ON "zpCallPolicies"("sessionId");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_zpCalls_session_callId_unique" ON "zpCalls"("sessionId", "callId");
+CREATE INDEX IF NOT EXISTS "idx_zpCalls_session_offeredAt" ON "zpCalls"("sessionId", "offeredAt" DESC);
+CREATE INDEX IF NOT EXISTS "idx_zpCalls_session_callerJid" ON "zpCalls"("sessionId", "callerJid");
+
+-- Create trigger functions for updatedAt
+CREATE OR REPLACE FUNCTION "update_zpCallPolicies_updatedAt"()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW."updatedAt" = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE OR REPLACE FUNCTION "update_zpCalls_updatedAt"()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW."updatedAt" = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+-- Create triggers
+CREATE TRIGGER "trigger_zpCallPolicies_updatedAt"
+    BEFORE UPDATE ON "zpCallPolicies"
+    FOR EACH ROW
+    EXECUTE FUNCTION "update_zpCallPolicies_updatedAt"();
+
+CREATE TRIGGER "trigger_zpCalls_updatedAt"
+    BEFORE UPDATE ON "zpCalls"
+    FOR EACH ROW
+    EXECUTE FUNCTION "update_zpCalls_updatedAt"();
+
+-- Comments
+COMMENT ON TABLE "zpCallPolicies" IS 'Per-session policy for incoming calls (camelCase)';
+COMMENT ON COLUMN "zpCallPolicies".action IS 'ignore, reject or reject_with_message';
+COMMENT ON TABLE "zpCalls" IS 'Incoming calls received by each session and how they ended (camelCase)';
+COMMENT ON COLUMN "zpCalls".outcome IS 'ringing, accepted (on a linked device), rejected (via API or another device), auto_rejected (call policy) or missed';
+COMMENT ON COLUMN "zpCalls"."endedAt" IS 'When the call was rejected or terminated';
@@ -0,0 +1,18 @@
+-- Drop indexes
+DROP INDEX IF EXISTS "idx_zpChats_session_pinned";
+
+-- Move the pin/mute state back to metadata
+UPDATE "zpChats" SET metadata = COALESCE(metadata, '{}'::jsonb) || jsonb_strip_nulls(jsonb_build_object(
+    'pinned', "isPinned",
+    'pinnedAt', EXTRACT(EPOCH FROM "pinnedAt")::bigint,
+    'muted', "isMuted",
+    'muteUntil', EXTRACT(EPOCH FROM "mutedUntil")::bigint
+))
+WHERE "isPinned" OR "isMuted";
+
+-- Drop columns
+ALTER TABLE "zpChats" DROP COLUMN IF EXISTS "isMarkedUnread";
+ALTER TABLE "zpChats" DROP COLUMN IF EXISTS "mutedUntil";
+ALTER TABLE "zpChats" DROP COLUMN IF EXISTS "isMuted";
+ALTER TABLE "zpChats" DROP COLUMN IF EXISTS "pinnedAt";
+ALTER TABLE "zpChats" DROP COLUMN IF EXISTS "isPinned";
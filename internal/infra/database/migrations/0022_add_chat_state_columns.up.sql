@@ -0,0 +1,27 @@
+-- Add chat state synced with WhatsApp app state (pin, mute, archive, read)
+ALTER TABLE "zpChats" ADD COLUMN IF NOT EXISTS "isPinned" BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE "zpChats" ADD COLUMN IF NOT EXISTS "pinnedAt" TIMESTAMP WITH TIME ZONE;
+ALTER TABLE "zpChats" ADD COLUMN IF NOT EXISTS "isMuted" BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE "zpChats" ADD COLUMN IF NOT EXISTS "mutedUntil" TIMESTAMP WITH TIME ZONE;
+ALTER TABLE "zpChats" ADD COLUMN IF NOT EXISTS "isMarkedUnread" BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Move the pin/mute state previously kept in metadata
+UPDATE "zpChats" SET
+    "isPinned" = COALESCE((metadata->>'pinned')::boolean, FALSE),
+    "pinnedAt" = CASE WHEN metadata ? 'pinnedAt' THEN to_timestamp((metadata->>'pinnedAt')::bigint) END,
+    "isMuted" = COALESCE((metadata->>'muted')::boolean, FALSE),
+    "mutedUntil" = CASE WHEN metadata ? 'muteUntil' THEN to_timestamp((metadata->>'muteUntil')::bigint) END,
+    metadata = metadata - 'pinned' - 'pinnedAt' - 'muted' - 'muteUntil'
+WHERE metadata ?| ARRAY['pinned', 'pinnedAt', 'muted', 'muteUntil'];
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS "idx_zpChats_session_pinned" ON "zpChats"("sessionId") WHERE "isPinned" = TRUE;
+
+-- Comments
+COMMENT ON COLUMN "zpChats"."isArchived" IS 'Whether the chat is archived (synced with WhatsApp app state)';
+COMMENT ON COLUMN "zpChats"."unreadCount" IS 'Unread messages; reset when the chat is marked as read';
+COMMENT ON COLUMN "zpChats"."isPinned" IS 'Whether the chat is pinned (synced with WhatsApp app state)';
+COMMENT ON COLUMN "zpChats"."pinnedAt" IS 'When the chat was pinned';
+COMMENT ON COLUMN "zpChats"."isMuted" IS 'Whether the chat is muted (synced with WhatsApp app state)';
+COMMENT ON COLUMN "zpChats"."mutedUntil" IS 'When the mute expires; NULL while muted means muted forever';
+COMMENT ON COLUMN "zpChats"."isMarkedUnread" IS 'Whether the chat was manually marked as unread';
@@ -0,0 +1,17 @@
+-- Drop trigger
+DROP TRIGGER IF EXISTS "trigger_zpLabels_updatedAt" ON "zpLabels";
+
+-- Drop function
+DROP FUNCTION IF EXISTS "update_zpLabels_updatedAt"();
+
+-- Drop indexes
+DROP INDEX IF EXISTS "idx_zpMessageLabels_session_message";
+DROP INDEX IF EXISTS "idx_zpMessageLabels_session_label_message_unique";
+DROP INDEX IF EXISTS "idx_zpChatLabels_session_chatJid";
+DROP INDEX IF EXISTS "idx_zpChatLabels_session_label_chat_unique";
+DROP INDEX IF EXISTS "idx_zpLabels_session_labelId_unique";
+
+-- Drop tables
+DROP TABLE IF EXISTS "zpMessageLabels";
+DROP TABLE IF EXISTS "zpChatLabels";
+DROP TABLE IF EXISTS "zpLabels";
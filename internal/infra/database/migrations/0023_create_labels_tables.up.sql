@@ -0,0 +1,58 @@
+-- Create zpLabels table (WhatsApp Business labels synced from app state)
+CREATE TABLE IF NOT EXISTS "zpLabels" (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"(id) ON DELETE CASCADE,
+    "labelId" VARCHAR(50) NOT NULL, -- WhatsApp label ID
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    color INTEGER NOT NULL DEFAULT 0, -- index in the WhatsApp color palette (0-19)
+    "predefinedId" INTEGER, -- set for the labels created by WhatsApp Business (New customer, New order...)
+    "createdAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create zpChatLabels table (labels attached to chats)
+CREATE TABLE IF NOT EXISTS "zpChatLabels" (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"(id) ON DELETE CASCADE,
+    "labelId" VARCHAR(50) NOT NULL,
+    "chatJid" VARCHAR(255) NOT NULL,
+    "createdAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create zpMessageLabels table (labels attached to messages)
+CREATE TABLE IF NOT EXISTS "zpMessageLabels" (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"(id) ON DELETE CASCADE,
+    "labelId" VARCHAR(50) NOT NULL,
+    "chatJid" VARCHAR(255) NOT NULL,
+    "messageId" VARCHAR(255) NOT NULL, -- WhatsApp message ID
+    "createdAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_zpLabels_session_labelId_unique" ON "zpLabels"("sessionId", "labelId");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_zpChatLabels_session_label_chat_unique" ON "zpChatLabels"("sessionId", "labelId", "chatJid");
+CREATE INDEX IF NOT EXISTS "idx_zpChatLabels_session_chatJid" ON "zpChatLabels"("sessionId", "chatJid");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_zpMessageLabels_session_label_message_unique" ON "zpMessageLabels"("sessionId", "labelId", "chatJid", "messageId");
+CREATE INDEX IF NOT EXISTS "idx_zpMessageLabels_session_message" ON "zpMessageLabels"("sessionId", "chatJid", "messageId");
+
+-- Create trigger function for updatedAt
+CREATE OR REPLACE FUNCTION "update_zpLabels_updatedAt"()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW."updatedAt" = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+-- Create trigger
+CREATE TRIGGER "trigger_zpLabels_updatedAt"
+    BEFORE UPDATE ON "zpLabels"
+    FOR EACH ROW
+    EXECUTE FUNCTION "update_zpLabels_updatedAt"();
+
+-- Comments
+COMMENT ON TABLE "zpLabels" IS 'WhatsApp Business labels of each session, kept in sync with the app state (camelCase)';
+COMMENT ON COLUMN "zpLabels"."labelId" IS 'Label ID used by WhatsApp in app-state patches';
+COMMENT ON TABLE "zpChatLabels" IS 'Labels attached to chats; rows may reference labels not synced yet (camelCase)';
+COMMENT ON TABLE "zpMessageLabels" IS 'Labels attached to messages; rows may reference labels not synced yet (camelCase)';
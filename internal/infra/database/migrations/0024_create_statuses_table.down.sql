@@ -0,0 +1,7 @@
+-- Drop indexes
+DROP INDEX IF EXISTS "idx_zpStatuses_session_sender_postedAt";
+DROP INDEX IF EXISTS "idx_zpStatuses_session_postedAt";
+DROP INDEX IF EXISTS "idx_zpStatuses_session_sender_statusId_unique";
+
+-- Drop table
+DROP TABLE IF EXISTS "zpStatuses";
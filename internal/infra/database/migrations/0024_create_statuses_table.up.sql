@@ -0,0 +1,29 @@
+-- Create zpStatuses table (WhatsApp Status posts received from contacts or posted by the session)
+CREATE TABLE IF NOT EXISTS "zpStatuses" (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"(id) ON DELETE CASCADE,
+    "statusId" VARCHAR(255) NOT NULL, -- WhatsApp message ID of the status
+    "senderJid" VARCHAR(255) NOT NULL, -- phone number JID when known, LID otherwise
+    "senderName" VARCHAR(255),
+    type VARCHAR(20) NOT NULL, -- text, image, video, audio...
+    content TEXT, -- text of the status or caption of the media
+    "mediaInfo" JSONB NOT NULL DEFAULT '{}', -- same fields as zpMessages."mediaInfo"
+    "backgroundColor" VARCHAR(9), -- #AARRGGBB of text statuses
+    "textColor" VARCHAR(9),
+    font VARCHAR(50),
+    "isFromMe" BOOLEAN NOT NULL DEFAULT false,
+    "postedAt" TIMESTAMP WITH TIME ZONE NOT NULL,
+    "expiresAt" TIMESTAMP WITH TIME ZONE NOT NULL,
+    "revokedAt" TIMESTAMP WITH TIME ZONE,
+    "createdAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_zpStatuses_session_sender_statusId_unique" ON "zpStatuses"("sessionId", "senderJid", "statusId");
+CREATE INDEX IF NOT EXISTS "idx_zpStatuses_session_postedAt" ON "zpStatuses"("sessionId", "postedAt" DESC);
+CREATE INDEX IF NOT EXISTS "idx_zpStatuses_session_sender_postedAt" ON "zpStatuses"("sessionId", "senderJid", "postedAt" DESC);
+
+-- Comments
+COMMENT ON TABLE "zpStatuses" IS 'WhatsApp Status (stories) received from contacts or posted by each session (camelCase)';
+COMMENT ON COLUMN "zpStatuses"."expiresAt" IS 'Statuses disappear from WhatsApp 24 hours after being posted';
+COMMENT ON COLUMN "zpStatuses"."revokedAt" IS 'When the sender deleted the status';
@@ -0,0 +1,12 @@
+-- Drop trigger
+DROP TRIGGER IF EXISTS "trigger_zpApiKeys_updatedAt" ON "zpApiKeys";
+
+-- Drop function
+DROP FUNCTION IF EXISTS "update_zpApiKeys_updatedAt"();
+
+-- Drop indexes
+DROP INDEX IF EXISTS "idx_zpApiKeys_sessionId";
+DROP INDEX IF EXISTS "idx_zpApiKeys_keyHash_unique";
+
+-- Drop table
+DROP TABLE IF EXISTS "zpApiKeys";
@@ -0,0 +1,40 @@
+-- Create zpApiKeys table (scoped API keys of each session, stored hashed)
+CREATE TABLE IF NOT EXISTS "zpApiKeys" (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    "keyPrefix" VARCHAR(20) NOT NULL, -- first characters of the key, to tell keys apart
+    "keyHash" VARCHAR(64) NOT NULL, -- SHA-256 of the key, hex encoded
+    scopes JSONB NOT NULL DEFAULT '[]'::jsonb,
+    "expiresAt" TIMESTAMP WITH TIME ZONE, -- NULL = never expires
+    "lastUsedAt" TIMESTAMP WITH TIME ZONE,
+    "revokedAt" TIMESTAMP WITH TIME ZONE,
+    "rotatedFromId" UUID, -- key replaced by this one
+    "createdAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_zpApiKeys_keyHash_unique" ON "zpApiKeys"("keyHash");
+CREATE INDEX IF NOT EXISTS "idx_zpApiKeys_sessionId" ON "zpApiKeys"("sessionId", "createdAt" DESC);
+
+-- Create trigger function for updatedAt
+CREATE OR REPLACE FUNCTION "update_zpApiKeys_updatedAt"()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW."updatedAt" = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+-- Create trigger
+CREATE TRIGGER "trigger_zpApiKeys_updatedAt"
+    BEFORE UPDATE ON "zpApiKeys"
+    FOR EACH ROW
+    EXECUTE FUNCTION "update_zpApiKeys_updatedAt"();
+
+-- Comments
+COMMENT ON TABLE "zpApiKeys" IS 'Scoped API keys of each session; only the SHA-256 of the key is stored (camelCase)';
+COMMENT ON COLUMN "zpApiKeys"."keyHash" IS 'SHA-256 of the key; the key itself is only returned when created or rotated';
+COMMENT ON COLUMN "zpApiKeys".scopes IS 'Scopes granted to the key (messages:send, chats:read...) or * for all of them';
+COMMENT ON COLUMN "zpApiKeys"."expiresAt" IS 'The key stops working after this time; set to the end of the grace period when rotated';
@@ -0,0 +1,13 @@
+-- Plaintext keys cannot be restored from their hashes: the column comes back with new random keys and
+-- the keys moved to zpApiKeys keep working from there
+ALTER TABLE "zpSessions" ADD COLUMN IF NOT EXISTS "apiKey" VARCHAR(255);
+
+UPDATE "zpSessions"
+SET "apiKey" = md5(random()::text || clock_timestamp()::text || id::text)
+WHERE "apiKey" IS NULL;
+
+ALTER TABLE "zpSessions" ALTER COLUMN "apiKey" SET NOT NULL;
+ALTER TABLE "zpSessions" ADD CONSTRAINT "zpSessions_apiKey_key" UNIQUE ("apiKey");
+CREATE INDEX IF NOT EXISTS "idx_zpSessions_apiKey" ON "zpSessions"("apiKey");
+
+COMMENT ON COLUMN "zpSessions"."apiKey" IS 'API key for session authentication (unique)';
@@ -0,0 +1,15 @@
+-- Move the original key of each session to zpApiKeys as a hashed key with every scope, so it can be
+-- listed, rotated, revoked and given an expiry like any other key, and stop storing it in plaintext
+INSERT INTO "zpApiKeys" ("sessionId", name, "keyPrefix", "keyHash", scopes, "createdAt")
+SELECT id,
+       'default',
+       LEFT("apiKey", LEAST(12, LENGTH("apiKey") / 2)),
+       encode(sha256(convert_to("apiKey", 'UTF8')), 'hex'),
+       '["*"]'::jsonb,
+       "createdAt"
+FROM "zpSessions"
+WHERE "apiKey" IS NOT NULL AND "apiKey" <> ''
+ON CONFLICT ("keyHash") DO NOTHING;
+
+DROP INDEX IF EXISTS "idx_zpSessions_apiKey";
+ALTER TABLE "zpSessions" DROP COLUMN IF EXISTS "apiKey";
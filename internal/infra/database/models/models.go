@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type SessionModel struct {
@@ -15,7 +17,6 @@ type SessionModel struct {
 	QrCode    string    `db:"qrCode" json:"qrCode"`       // camelCase exato com aspas duplas
 	ProxyUrl  string    `db:"proxyUrl" json:"proxyUrl"`   // camelCase exato com aspas duplas
 	Connected bool      `db:"connected" json:"connected"` // Campo necessário para o repositório
-	ApiKey    string    `db:"apiKey" json:"apiKey"`       // camelCase exato com aspas duplas
 	CreatedAt time.Time `db:"createdAt" json:"createdAt"` // camelCase exato com aspas duplas
 	UpdatedAt time.Time `db:"updatedAt" json:"updatedAt"` // camelCase exato com aspas duplas
 }
@@ -118,6 +119,11 @@ type ChatModel struct {
 	LastMsgAt              *time.Time `db:"lastMsgAt" json:"lastMsgAt"`                           // camelCase exato com aspas duplas
 	UnreadCount            int        `db:"unreadCount" json:"unreadCount"`                       // camelCase exato com aspas duplas
 	IsArchived             bool       `db:"isArchived" json:"isArchived"`                         // camelCase exato com aspas duplas
+	IsPinned               bool       `db:"isPinned" json:"isPinned"`                             // sincronizado com o app state do WhatsApp
+	PinnedAt               *time.Time `db:"pinnedAt" json:"pinnedAt"`                             // camelCase exato com aspas duplas
+	IsMuted                bool       `db:"isMuted" json:"isMuted"`                               // sincronizado com o app state do WhatsApp
+	MutedUntil             *time.Time `db:"mutedUntil" json:"mutedUntil"`                         // nil com IsMuted = silenciado para sempre
+	IsMarkedUnread         bool       `db:"isMarkedUnread" json:"isMarkedUnread"`                 // marcado como não lido manualmente
 	Metadata               JSONB      `db:"metadata" json:"metadata"`                             // Dados adicionais em JSON
 	CreatedAt              time.Time  `db:"createdAt" json:"createdAt"`                           // camelCase exato com aspas duplas
 	UpdatedAt              time.Time  `db:"updatedAt" json:"updatedAt"`                           // camelCase exato com aspas duplas
@@ -160,6 +166,49 @@ func (MessageModel) TableName() string {
 	return "messages"
 }
 
+// Status de entrega de zpMessages.status, em ordem de progressão (failed fica fora da ordem)
+const (
+	MessageStatusPending   = "pending"
+	MessageStatusSent      = "sent"
+	MessageStatusDelivered = "delivered"
+	MessageStatusRead      = "read"
+	MessageStatusPlayed    = "played"
+	MessageStatusFailed    = "failed"
+)
+
+// MessageReceiptModel representa um recibo de entrega/leitura de um destinatário
+type MessageReceiptModel struct {
+	ID           string    `db:"id" json:"id"`
+	MessageId    string    `db:"messageId" json:"messageId"`       // camelCase exato com aspas duplas
+	SessionId    string    `db:"sessionId" json:"sessionId"`       // camelCase exato com aspas duplas
+	MsgId        string    `db:"msgId" json:"msgId"`               // WhatsApp ID
+	RecipientJid string    `db:"recipientJid" json:"recipientJid"` // participante em grupos
+	Status       string    `db:"status" json:"status"`
+	Timestamp    time.Time `db:"timestamp" json:"timestamp"`
+	CreatedAt    time.Time `db:"createdAt" json:"createdAt"` // camelCase exato com aspas duplas
+}
+
+func (MessageReceiptModel) TableName() string {
+	return "zpMessageReceipts"
+}
+
+// MessageReactionModel representa a reação atual de um remetente a uma mensagem
+type MessageReactionModel struct {
+	ID        string    `db:"id" json:"id"`
+	MessageId string    `db:"messageId" json:"messageId"` // camelCase exato com aspas duplas
+	SessionId string    `db:"sessionId" json:"sessionId"` // camelCase exato com aspas duplas
+	MsgId     string    `db:"msgId" json:"msgId"`         // WhatsApp ID
+	SenderJid string    `db:"senderJid" json:"senderJid"` // participante em grupos
+	Reaction  string    `db:"reaction" json:"reaction"`
+	Timestamp time.Time `db:"timestamp" json:"timestamp"`
+	CreatedAt time.Time `db:"createdAt" json:"createdAt"` // camelCase exato com aspas duplas
+	UpdatedAt time.Time `db:"updatedAt" json:"updatedAt"` // camelCase exato com aspas duplas
+}
+
+func (MessageReactionModel) TableName() string {
+	return "zpMessageReactions"
+}
+
 // ZpCwMessageModel representa a relação entre mensagens zpmeow e Chatwoot (OTIMIZADA)
 type ZpCwMessageModel struct {
 	ID             string    `db:"id" json:"id"`
@@ -181,17 +230,405 @@ func (ZpCwMessageModel) TableName() string {
 	return "zp_cw_messages"
 }
 
+// Formatos de payload das entregas de webhook
+const (
+	WebhookPayloadFormatV1  = "v1"  // envelope versionado com payloads tipados
+	WebhookPayloadFormatRaw = "raw" // formato legado com a struct do whatsmeow em "data"
+)
+
 // WebhookModel representa a configuração de webhook no banco de dados
 type WebhookModel struct {
-	ID        string    `db:"id" json:"id"`
-	SessionId string    `db:"sessionId" json:"sessionId"` // camelCase exato com aspas duplas
-	URL       string    `db:"url" json:"url"`
-	Events    []string  `db:"events" json:"events"`       // Array de eventos
-	IsActive  bool      `db:"isActive" json:"isActive"`   // camelCase exato com aspas duplas
-	CreatedAt time.Time `db:"createdAt" json:"createdAt"` // camelCase exato com aspas duplas
-	UpdatedAt time.Time `db:"updatedAt" json:"updatedAt"` // camelCase exato com aspas duplas
+	ID            string         `db:"id" json:"id"`
+	SessionId     string         `db:"sessionId" json:"sessionId"` // camelCase exato com aspas duplas
+	Name          string         `db:"name" json:"name"`
+	URL           string         `db:"url" json:"url"`
+	Events        pq.StringArray `db:"events" json:"events"`               // Array de eventos (TEXT[])
+	Headers       JSONB          `db:"headers" json:"headers"`             // headers HTTP customizados
+	IsActive      bool           `db:"isActive" json:"isActive"`           // camelCase exato com aspas duplas
+	IsDefault     bool           `db:"isDefault" json:"isDefault"`         // endpoint da API legada de webhook único
+	PayloadFormat string         `db:"payloadFormat" json:"payloadFormat"` // v1 (envelope versionado) ou raw (struct do whatsmeow)
+	CreatedAt     time.Time      `db:"createdAt" json:"createdAt"`         // camelCase exato com aspas duplas
+	UpdatedAt     time.Time      `db:"updatedAt" json:"updatedAt"`         // camelCase exato com aspas duplas
+
+	// Segredo HMAC usado para assinar as entregas
+	Secret                  string     `db:"secret" json:"-"`
+	PreviousSecret          *string    `db:"previousSecret" json:"-"`                                // válido até previousSecretExpiresAt
+	PreviousSecretExpiresAt *time.Time `db:"previousSecretExpiresAt" json:"previousSecretExpiresAt"` // camelCase exato com aspas duplas
+	SecretRotatedAt         *time.Time `db:"secretRotatedAt" json:"secretRotatedAt"`                 // camelCase exato com aspas duplas
+
+	// Estatísticas de entrega
+	DeliveredCount int64      `db:"deliveredCount" json:"deliveredCount"` // camelCase exato com aspas duplas
+	FailedCount    int64      `db:"failedCount" json:"failedCount"`       // camelCase exato com aspas duplas
+	LastDeliveryAt *time.Time `db:"lastDeliveryAt" json:"lastDeliveryAt"` // camelCase exato com aspas duplas
+	LastSuccessAt  *time.Time `db:"lastSuccessAt" json:"lastSuccessAt"`   // camelCase exato com aspas duplas
+	LastFailureAt  *time.Time `db:"lastFailureAt" json:"lastFailureAt"`   // camelCase exato com aspas duplas
+	LastError      *string    `db:"lastError" json:"lastError"`           // camelCase exato com aspas duplas
+	LastStatusCode *int       `db:"lastStatusCode" json:"lastStatusCode"` // camelCase exato com aspas duplas
+}
+
+// SubscribesTo indica se o endpoint deve receber o evento (lista vazia ou "All" recebe todos)
+func (w *WebhookModel) SubscribesTo(event string) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, subscribed := range w.Events {
+		if subscribed == "All" || subscribed == event {
+			return true
+		}
+	}
+	return false
 }
 
 func (WebhookModel) TableName() string {
 	return "zpWebhooks"
 }
+
+// WebhookDeliveryModel representa uma entrega de webhook pendente na fila persistente
+type WebhookDeliveryModel struct {
+	ID             string     `db:"id" json:"id"`
+	SessionId      string     `db:"sessionId" json:"sessionId"` // camelCase exato com aspas duplas
+	WebhookId      *string    `db:"webhookId" json:"webhookId"` // endpoint de destino
+	URL            string     `db:"url" json:"url"`
+	Event          string     `db:"event" json:"event"`
+	Payload        []byte     `db:"payload" json:"payload"`               // JSON já serializado
+	Status         string     `db:"status" json:"status"`                 // 'pending', 'processing'
+	Attempts       int        `db:"attempts" json:"attempts"`             // tentativas já realizadas
+	NextAttemptAt  time.Time  `db:"nextAttemptAt" json:"nextAttemptAt"`   // camelCase exato com aspas duplas
+	LockedAt       *time.Time `db:"lockedAt" json:"lockedAt"`             // camelCase exato com aspas duplas
+	LastError      *string    `db:"lastError" json:"lastError"`           // camelCase exato com aspas duplas
+	LastStatusCode *int       `db:"lastStatusCode" json:"lastStatusCode"` // camelCase exato com aspas duplas
+	CreatedAt      time.Time  `db:"createdAt" json:"createdAt"`           // camelCase exato com aspas duplas
+	UpdatedAt      time.Time  `db:"updatedAt" json:"updatedAt"`           // camelCase exato com aspas duplas
+}
+
+func (WebhookDeliveryModel) TableName() string {
+	return "zpWebhookDeliveries"
+}
+
+// WebhookDeadLetterModel representa uma entrega de webhook que esgotou as tentativas
+type WebhookDeadLetterModel struct {
+	ID             string     `db:"id" json:"id"`
+	SessionId      string     `db:"sessionId" json:"sessionId"`   // camelCase exato com aspas duplas
+	DeliveryId     string     `db:"deliveryId" json:"deliveryId"` // camelCase exato com aspas duplas
+	WebhookId      *string    `db:"webhookId" json:"webhookId"`   // NULL se o endpoint foi removido
+	URL            string     `db:"url" json:"url"`
+	Event          string     `db:"event" json:"event"`
+	Payload        []byte     `db:"payload" json:"payload"` // JSON já serializado
+	Attempts       int        `db:"attempts" json:"attempts"`
+	LastError      *string    `db:"lastError" json:"lastError"`           // camelCase exato com aspas duplas
+	LastStatusCode *int       `db:"lastStatusCode" json:"lastStatusCode"` // camelCase exato com aspas duplas
+	EnqueuedAt     time.Time  `db:"enqueuedAt" json:"enqueuedAt"`         // camelCase exato com aspas duplas
+	FailedAt       time.Time  `db:"failedAt" json:"failedAt"`             // camelCase exato com aspas duplas
+	ReplayedAt     *time.Time `db:"replayedAt" json:"replayedAt"`         // camelCase exato com aspas duplas
+	ReplayCount    int        `db:"replayCount" json:"replayCount"`       // camelCase exato com aspas duplas
+	CreatedAt      time.Time  `db:"createdAt" json:"createdAt"`           // camelCase exato com aspas duplas
+}
+
+func (WebhookDeadLetterModel) TableName() string {
+	return "zpWebhookDeadLetters"
+}
+
+// MediaModel representa os metadados de uma mídia armazenada no backend de storage
+type MediaModel struct {
+	ID            string    `db:"id" json:"id"`
+	SessionId     string    `db:"sessionId" json:"sessionId"`         // camelCase exato com aspas duplas
+	MediaId       string    `db:"mediaId" json:"mediaId"`             // SHA-256 do conteúdo
+	MediaType     string    `db:"mediaType" json:"mediaType"`         // camelCase exato com aspas duplas
+	MimeType      string    `db:"mimeType" json:"mimeType"`           // camelCase exato com aspas duplas
+	FileName      *string   `db:"fileName" json:"fileName"`           // camelCase exato com aspas duplas
+	Size          int64     `db:"size" json:"size"`                   // bytes
+	StorageDriver string    `db:"storageDriver" json:"storageDriver"` // 'local', 's3'
+	StorageKey    string    `db:"storageKey" json:"storageKey"`       // chave do objeto no storage
+	Metadata      JSONB     `db:"metadata" json:"metadata"`
+	CreatedAt     time.Time `db:"createdAt" json:"createdAt"` // camelCase exato com aspas duplas
+	UpdatedAt     time.Time `db:"updatedAt" json:"updatedAt"` // camelCase exato com aspas duplas
+}
+
+func (MediaModel) TableName() string {
+	return "zpMedia"
+}
+
+// MediaPolicyModel representa a política de download automático de mídias recebidas de uma sessão
+type MediaPolicyModel struct {
+	ID           string      `db:"id" json:"id"`
+	SessionId    string      `db:"sessionId" json:"sessionId"`       // camelCase exato com aspas duplas
+	AutoDownload bool        `db:"autoDownload" json:"autoDownload"` // camelCase exato com aspas duplas
+	MediaTypes   StringArray `db:"mediaTypes" json:"mediaTypes"`     // camelCase exato com aspas duplas
+	MaxSizes     JSONB       `db:"maxSizes" json:"maxSizes"`         // limite em bytes por tipo de mídia
+	CreatedAt    time.Time   `db:"createdAt" json:"createdAt"`       // camelCase exato com aspas duplas
+	UpdatedAt    time.Time   `db:"updatedAt" json:"updatedAt"`       // camelCase exato com aspas duplas
+}
+
+func (MediaPolicyModel) TableName() string {
+	return "zpMediaPolicies"
+}
+
+// Status de zpScheduledMessages.status
+const (
+	ScheduledStatusScheduled      = "scheduled"
+	ScheduledStatusProcessing     = "processing"
+	ScheduledStatusWaitingSession = "waiting_session"
+	ScheduledStatusSent           = "sent"
+	ScheduledStatusFailed         = "failed"
+	ScheduledStatusCanceled       = "canceled"
+)
+
+// ScheduledMessageModel representa uma mensagem agendada para envio futuro
+type ScheduledMessageModel struct {
+	ID            string     `db:"id" json:"id"`
+	SessionId     string     `db:"sessionId" json:"sessionId"` // camelCase exato com aspas duplas
+	Phone         string     `db:"phone" json:"phone"`
+	MessageType   string     `db:"messageType" json:"messageType"` // camelCase exato com aspas duplas
+	Payload       []byte     `db:"payload" json:"payload"`         // JSON já serializado
+	SendAt        time.Time  `db:"sendAt" json:"sendAt"`           // camelCase exato com aspas duplas
+	Timezone      string     `db:"timezone" json:"timezone"`
+	Status        string     `db:"status" json:"status"`
+	Attempts      int        `db:"attempts" json:"attempts"`
+	NextAttemptAt time.Time  `db:"nextAttemptAt" json:"nextAttemptAt"` // camelCase exato com aspas duplas
+	LockedAt      *time.Time `db:"lockedAt" json:"lockedAt"`           // camelCase exato com aspas duplas
+	LastError     *string    `db:"lastError" json:"lastError"`         // camelCase exato com aspas duplas
+	MessageId     *string    `db:"messageId" json:"messageId"`         // ID WhatsApp após o envio
+	SentAt        *time.Time `db:"sentAt" json:"sentAt"`               // camelCase exato com aspas duplas
+	CreatedAt     time.Time  `db:"createdAt" json:"createdAt"`         // camelCase exato com aspas duplas
+	UpdatedAt     time.Time  `db:"updatedAt" json:"updatedAt"`         // camelCase exato com aspas duplas
+}
+
+func (ScheduledMessageModel) TableName() string {
+	return "zpScheduledMessages"
+}
+
+// Status de zpCampaigns.status
+const (
+	CampaignStatusRunning   = "running"
+	CampaignStatusPaused    = "paused"
+	CampaignStatusCompleted = "completed"
+	CampaignStatusCanceled  = "canceled"
+)
+
+// Status de zpCampaignRecipients.status
+const (
+	RecipientStatusPending    = "pending"
+	RecipientStatusProcessing = "processing"
+	RecipientStatusSent       = "sent"
+	RecipientStatusFailed     = "failed"
+	RecipientStatusInvalid    = "invalid"
+	RecipientStatusCanceled   = "canceled"
+)
+
+// CampaignModel representa um envio em massa de uma mensagem com variáveis por destinatário
+type CampaignModel struct {
+	ID              string     `db:"id" json:"id"`
+	SessionId       string     `db:"sessionId" json:"sessionId"` // camelCase exato com aspas duplas
+	Name            string     `db:"name" json:"name"`
+	Template        string     `db:"template" json:"template"`
+	Status          string     `db:"status" json:"status"`
+	RatePerMinute   int        `db:"ratePerMinute" json:"ratePerMinute"` // camelCase exato com aspas duplas
+	JitterSeconds   int        `db:"jitterSeconds" json:"jitterSeconds"` // camelCase exato com aspas duplas
+	WindowStart     *string    `db:"windowStart" json:"windowStart"`     // HH:MM no timezone da campanha
+	WindowEnd       *string    `db:"windowEnd" json:"windowEnd"`         // HH:MM no timezone da campanha
+	Timezone        string     `db:"timezone" json:"timezone"`
+	CheckNumbers    bool       `db:"checkNumbers" json:"checkNumbers"`       // camelCase exato com aspas duplas
+	TotalRecipients int        `db:"totalRecipients" json:"totalRecipients"` // camelCase exato com aspas duplas
+	LastSentAt      *time.Time `db:"lastSentAt" json:"lastSentAt"`           // camelCase exato com aspas duplas
+	CompletedAt     *time.Time `db:"completedAt" json:"completedAt"`         // camelCase exato com aspas duplas
+	CreatedAt       time.Time  `db:"createdAt" json:"createdAt"`             // camelCase exato com aspas duplas
+	UpdatedAt       time.Time  `db:"updatedAt" json:"updatedAt"`             // camelCase exato com aspas duplas
+}
+
+func (CampaignModel) TableName() string {
+	return "zpCampaigns"
+}
+
+// CampaignRecipientModel representa um destinatário da campanha e o resultado do seu envio
+type CampaignRecipientModel struct {
+	ID          string     `db:"id" json:"id"`
+	CampaignId  string     `db:"campaignId" json:"campaignId"` // camelCase exato com aspas duplas
+	Position    int        `db:"position" json:"position"`
+	Phone       string     `db:"phone" json:"phone"`
+	Variables   JSONB      `db:"variables" json:"variables"`
+	Status      string     `db:"status" json:"status"`
+	Jid         *string    `db:"jid" json:"jid"`
+	MessageId   *string    `db:"messageId" json:"messageId"` // camelCase exato com aspas duplas
+	Error       *string    `db:"error" json:"error"`
+	LockedAt    *time.Time `db:"lockedAt" json:"lockedAt"`       // camelCase exato com aspas duplas
+	ProcessedAt *time.Time `db:"processedAt" json:"processedAt"` // camelCase exato com aspas duplas
+	CreatedAt   time.Time  `db:"createdAt" json:"createdAt"`     // camelCase exato com aspas duplas
+	UpdatedAt   time.Time  `db:"updatedAt" json:"updatedAt"`     // camelCase exato com aspas duplas
+}
+
+func (CampaignRecipientModel) TableName() string {
+	return "zpCampaignRecipients"
+}
+
+// CampaignStats resume os destinatários de uma campanha por status
+type CampaignStats struct {
+	Total      int `json:"total"`
+	Pending    int `json:"pending"`
+	Processing int `json:"processing"`
+	Sent       int `json:"sent"`
+	Failed     int `json:"failed"`
+	Invalid    int `json:"invalid"`
+	Canceled   int `json:"canceled"`
+}
+
+// MessageTemplateModel representa um template de mensagem reutilizável da sessão
+type MessageTemplateModel struct {
+	ID          string      `db:"id" json:"id"`
+	SessionId   string      `db:"sessionId" json:"sessionId"` // camelCase exato com aspas duplas
+	Name        string      `db:"name" json:"name"`
+	MessageType string      `db:"messageType" json:"messageType"` // camelCase exato com aspas duplas
+	Content     []byte      `db:"content" json:"content"`         // JSON já serializado, sem o phone
+	Variables   StringArray `db:"variables" json:"variables"`     // placeholders encontrados no content
+	CreatedAt   time.Time   `db:"createdAt" json:"createdAt"`     // camelCase exato com aspas duplas
+	UpdatedAt   time.Time   `db:"updatedAt" json:"updatedAt"`     // camelCase exato com aspas duplas
+}
+
+func (MessageTemplateModel) TableName() string {
+	return "zpMessageTemplates"
+}
+
+const (
+	HistorySyncStatusRunning   = "running"
+	HistorySyncStatusCompleted = "completed"
+)
+
+// HistorySyncModel acompanha a importação do histórico enviado pelo celular após o pareamento
+type HistorySyncModel struct {
+	ID              string     `db:"id" json:"id"`
+	SessionId       string     `db:"sessionId" json:"sessionId"` // camelCase exato com aspas duplas
+	Status          string     `db:"status" json:"status"`
+	SyncType        string     `db:"syncType" json:"syncType"` // camelCase exato com aspas duplas
+	Progress        int        `db:"progress" json:"progress"` // percentual informado pelo celular
+	Chunks          int        `db:"chunks" json:"chunks"`
+	Conversations   int        `db:"conversations" json:"conversations"`
+	MessagesStored  int        `db:"messagesStored" json:"messagesStored"`   // camelCase exato com aspas duplas
+	MessagesSkipped int        `db:"messagesSkipped" json:"messagesSkipped"` // camelCase exato com aspas duplas
+	LastError       *string    `db:"lastError" json:"lastError"`             // camelCase exato com aspas duplas
+	StartedAt       time.Time  `db:"startedAt" json:"startedAt"`             // camelCase exato com aspas duplas
+	LastChunkAt     time.Time  `db:"lastChunkAt" json:"lastChunkAt"`         // camelCase exato com aspas duplas
+	CompletedAt     *time.Time `db:"completedAt" json:"completedAt"`         // camelCase exato com aspas duplas
+	CreatedAt       time.Time  `db:"createdAt" json:"createdAt"`             // camelCase exato com aspas duplas
+	UpdatedAt       time.Time  `db:"updatedAt" json:"updatedAt"`             // camelCase exato com aspas duplas
+}
+
+func (HistorySyncModel) TableName() string {
+	return "zpHistorySyncs"
+}
+
+// Ações de zpCallPolicies.action
+const (
+	CallActionIgnore            = "ignore"
+	CallActionReject            = "reject"
+	CallActionRejectWithMessage = "reject_with_message"
+)
+
+// CallPolicyModel define o que a sessão faz com as chamadas recebidas
+type CallPolicyModel struct {
+	ID            string    `db:"id" json:"id"`
+	SessionId     string    `db:"sessionId" json:"sessionId"` // camelCase exato com aspas duplas
+	Action        string    `db:"action" json:"action"`
+	RejectMessage *string   `db:"rejectMessage" json:"rejectMessage"` // camelCase exato com aspas duplas
+	CreatedAt     time.Time `db:"createdAt" json:"createdAt"`         // camelCase exato com aspas duplas
+	UpdatedAt     time.Time `db:"updatedAt" json:"updatedAt"`         // camelCase exato com aspas duplas
+}
+
+func (CallPolicyModel) TableName() string {
+	return "zpCallPolicies"
+}
+
+// Resultados de zpCalls.outcome
+const (
+	CallOutcomeRinging      = "ringing"
+	CallOutcomeAccepted     = "accepted"
+	CallOutcomeRejected     = "rejected"
+	CallOutcomeAutoRejected = "auto_rejected"
+	CallOutcomeMissed       = "missed"
+)
+
+// CallModel registra uma chamada recebida pela sessão e como ela terminou
+type CallModel struct {
+	ID             string     `db:"id" json:"id"`
+	SessionId      string     `db:"sessionId" json:"sessionId"` // camelCase exato com aspas duplas
+	CallId         string     `db:"callId" json:"callId"`       // ID da chamada no WhatsApp
+	CallerJid      string     `db:"callerJid" json:"callerJid"` // camelCase exato com aspas duplas
+	GroupJid       *string    `db:"groupJid" json:"groupJid"`   // camelCase exato com aspas duplas
+	IsGroup        bool       `db:"isGroup" json:"isGroup"`     // camelCase exato com aspas duplas
+	IsVideo        bool       `db:"isVideo" json:"isVideo"`     // camelCase exato com aspas duplas
+	Outcome        string     `db:"outcome" json:"outcome"`
+	Reason         *string    `db:"reason" json:"reason"`
+	ReplyMessageId *string    `db:"replyMessageId" json:"replyMessageId"` // resposta automática enviada ao rejeitar
+	RemotePlatform *string    `db:"remotePlatform" json:"remotePlatform"` // camelCase exato com aspas duplas
+	OfferedAt      time.Time  `db:"offeredAt" json:"offeredAt"`           // camelCase exato com aspas duplas
+	EndedAt        *time.Time `db:"endedAt" json:"endedAt"`               // camelCase exato com aspas duplas
+	CreatedAt      time.Time  `db:"createdAt" json:"createdAt"`           // camelCase exato com aspas duplas
+	UpdatedAt      time.Time  `db:"updatedAt" json:"updatedAt"`           // camelCase exato com aspas duplas
+}
+
+func (CallModel) TableName() string {
+	return "zpCalls"
+}
+
+// LabelModel é uma etiqueta do WhatsApp Business sincronizada pelo app state
+type LabelModel struct {
+	ID           string    `db:"id" json:"id"`
+	SessionId    string    `db:"sessionId" json:"sessionId"` // camelCase exato com aspas duplas
+	LabelId      string    `db:"labelId" json:"labelId"`     // ID da etiqueta no WhatsApp
+	Name         string    `db:"name" json:"name"`
+	Color        int       `db:"color" json:"color"`               // índice na paleta de cores do WhatsApp
+	PredefinedId *int      `db:"predefinedId" json:"predefinedId"` // etiquetas criadas pelo WhatsApp Business
+	CreatedAt    time.Time `db:"createdAt" json:"createdAt"`       // camelCase exato com aspas duplas
+	UpdatedAt    time.Time `db:"updatedAt" json:"updatedAt"`       // camelCase exato com aspas duplas
+}
+
+func (LabelModel) TableName() string {
+	return "zpLabels"
+}
+
+// StatusModel é um status (story) recebido de um contato ou publicado pela sessão
+type StatusModel struct {
+	ID              string     `db:"id" json:"id"`
+	SessionId       string     `db:"sessionId" json:"sessionId"`   // camelCase exato com aspas duplas
+	StatusId        string     `db:"statusId" json:"statusId"`     // ID da mensagem no WhatsApp
+	SenderJid       string     `db:"senderJid" json:"senderJid"`   // JID do telefone quando conhecido
+	SenderName      *string    `db:"senderName" json:"senderName"` // camelCase exato com aspas duplas
+	Type            string     `db:"type" json:"type"`
+	Content         *string    `db:"content" json:"content"`
+	MediaInfo       JSONB      `db:"mediaInfo" json:"mediaInfo"`             // camelCase exato com aspas duplas
+	BackgroundColor *string    `db:"backgroundColor" json:"backgroundColor"` // #AARRGGBB dos status de texto
+	TextColor       *string    `db:"textColor" json:"textColor"`             // camelCase exato com aspas duplas
+	Font            *string    `db:"font" json:"font"`
+	IsFromMe        bool       `db:"isFromMe" json:"isFromMe"`   // camelCase exato com aspas duplas
+	PostedAt        time.Time  `db:"postedAt" json:"postedAt"`   // camelCase exato com aspas duplas
+	ExpiresAt       time.Time  `db:"expiresAt" json:"expiresAt"` // camelCase exato com aspas duplas
+	RevokedAt       *time.Time `db:"revokedAt" json:"revokedAt"` // camelCase exato com aspas duplas
+	CreatedAt       time.Time  `db:"createdAt" json:"createdAt"` // camelCase exato com aspas duplas
+}
+
+func (StatusModel) TableName() string {
+	return "zpStatuses"
+}
+
+// APIKeyModel é uma chave de API de uma sessão, com escopos e validade; só o hash da chave é guardado
+type APIKeyModel struct {
+	ID            string      `db:"id" json:"id"`
+	SessionId     string      `db:"sessionId" json:"sessionId"` // camelCase exato com aspas duplas
+	Name          string      `db:"name" json:"name"`
+	KeyPrefix     string      `db:"keyPrefix" json:"keyPrefix"` // primeiros caracteres da chave, para identificá-la
+	KeyHash       string      `db:"keyHash" json:"-"`           // SHA-256 da chave
+	Scopes        StringArray `db:"scopes" json:"scopes"`
+	ExpiresAt     *time.Time  `db:"expiresAt" json:"expiresAt"`         // camelCase exato com aspas duplas
+	LastUsedAt    *time.Time  `db:"lastUsedAt" json:"lastUsedAt"`       // camelCase exato com aspas duplas
+	RevokedAt     *time.Time  `db:"revokedAt" json:"revokedAt"`         // camelCase exato com aspas duplas
+	RotatedFromId *string     `db:"rotatedFromId" json:"rotatedFromId"` // chave substituída por esta na rotação
+	CreatedAt     time.Time   `db:"createdAt" json:"createdAt"`         // camelCase exato com aspas duplas
+	UpdatedAt     time.Time   `db:"updatedAt" json:"updatedAt"`         // camelCase exato com aspas duplas
+}
+
+func (APIKeyModel) TableName() string {
+	return "zpApiKeys"
+}
+
+// IsActive indica se a chave ainda autentica: não revogada e não expirada
+func (k *APIKeyModel) IsActive(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || k.ExpiresAt.After(now))
+}
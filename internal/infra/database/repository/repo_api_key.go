@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"zpmeow/internal/infra/database/models"
+)
+
+const apiKeyColumns = `id, "sessionId", name, "keyPrefix", "keyHash", scopes, "expiresAt", "lastUsedAt", "revokedAt", "rotatedFromId", "createdAt", "updatedAt"`
+
+// apiKeyPrefixLength é quanto da chave fica guardado em claro para identificá-la ("zpk_" + 8 caracteres)
+const apiKeyPrefixLength = 12
+
+// DefaultAPIKeyName é o nome da chave criada junto com a sessão
+const DefaultAPIKeyName = "default"
+
+// lastUsedInterval limita a gravação do lastUsedAt a uma vez por intervalo, em vez de uma a cada requisição
+const lastUsedInterval = time.Minute
+
+// APIKeyRepository guarda as chaves de API das sessões. As chaves são aleatórias e longas, então o
+// SHA-256 basta para guardá-las sem o texto original e ainda permite buscá-las pelo hash.
+type APIKeyRepository struct {
+	db *sqlx.DB
+}
+
+func NewAPIKeyRepository(db *sqlx.DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+// HashAPIKey retorna o hash guardado em "keyHash" para a chave
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Create gera uma chave para a sessão e retorna o registro junto com a chave, que não pode ser recuperada depois
+func (r *APIKeyRepository) Create(ctx context.Context, sessionID, name string, scopes []string, expiresAt *time.Time) (*models.APIKeyModel, string, error) {
+	return r.insert(ctx, r.db, sessionID, name, scopes, expiresAt, nil)
+}
+
+// GetByKey busca a chave pelo hash; retorna nil quando ela não existe
+func (r *APIKeyRepository) GetByKey(ctx context.Context, key string) (*models.APIKeyModel, error) {
+	var apiKey models.APIKeyModel
+	query := `SELECT ` + apiKeyColumns + ` FROM "zpApiKeys" WHERE "keyHash" = $1`
+
+	err := r.db.GetContext(ctx, &apiKey, query, HashAPIKey(key))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	return &apiKey, nil
+}
+
+// GetByID busca a chave da sessão; retorna nil quando ela não existe
+func (r *APIKeyRepository) GetByID(ctx context.Context, sessionID, id string) (*models.APIKeyModel, error) {
+	var apiKey models.APIKeyModel
+	query := `SELECT ` + apiKeyColumns + ` FROM "zpApiKeys" WHERE "sessionId" = $1 AND id = $2`
+
+	err := r.db.GetContext(ctx, &apiKey, query, sessionID, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	return &apiKey, nil
+}
+
+// List retorna as chaves da sessão, das mais recentes para as mais antigas; revogadas só com includeRevoked
+func (r *APIKeyRepository) List(ctx context.Context, sessionID string, includeRevoked bool) ([]*models.APIKeyModel, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM "zpApiKeys" WHERE "sessionId" = $1`
+	if !includeRevoked {
+		query += ` AND "revokedAt" IS NULL`
+	}
+	query += ` ORDER BY "createdAt" DESC`
+
+	var keys []*models.APIKeyModel
+	if err := r.db.SelectContext(ctx, &keys, query, sessionID); err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Rotate cria uma chave com o mesmo nome, escopos e validade e faz a anterior expirar ao fim de gracePeriod,
+// para que os clientes troquem de chave sem indisponibilidade. Retorna nil quando a chave não existe,
+// foi revogada ou já expirou.
+func (r *APIKeyRepository) Rotate(ctx context.Context, sessionID, id string, gracePeriod time.Duration) (*models.APIKeyModel, string, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to begin API key transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var current models.APIKeyModel
+	query := `SELECT ` + apiKeyColumns + ` FROM "zpApiKeys"
+		WHERE "sessionId" = $1 AND id = $2 AND "revokedAt" IS NULL
+			AND ("expiresAt" IS NULL OR "expiresAt" > CURRENT_TIMESTAMP)
+		FOR UPDATE`
+
+	if err := tx.GetContext(ctx, &current, query, sessionID, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, "", nil
+		}
+		return nil, "", fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	// A chave anterior nunca passa a valer por mais tempo do que já valia
+	expireQuery := `UPDATE "zpApiKeys" SET "expiresAt" = LEAST(COALESCE("expiresAt", $2), $2) WHERE id = $1`
+	if _, err := tx.ExecContext(ctx, expireQuery, current.ID, time.Now().Add(gracePeriod)); err != nil {
+		return nil, "", fmt.Errorf("failed to expire rotated API key: %w", err)
+	}
+
+	apiKey, key, err := r.insert(ctx, tx, sessionID, current.Name, current.Scopes, current.ExpiresAt, &current.ID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, "", fmt.Errorf("failed to commit API key rotation: %w", err)
+	}
+
+	return apiKey, key, nil
+}
+
+// Revoke invalida a chave imediatamente; retorna false quando ela não existe ou já estava revogada
+func (r *APIKeyRepository) Revoke(ctx context.Context, sessionID, id string) (bool, error) {
+	query := `UPDATE "zpApiKeys" SET "revokedAt" = CURRENT_TIMESTAMP WHERE "sessionId" = $1 AND id = $2 AND "revokedAt" IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, sessionID, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// TouchLastUsed registra o uso da chave, no máximo uma vez por lastUsedInterval
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, id string) error {
+	query := `
+		UPDATE "zpApiKeys" SET "lastUsedAt" = CURRENT_TIMESTAMP
+		WHERE id = $1 AND ("lastUsedAt" IS NULL OR "lastUsedAt" < $2)`
+
+	if _, err := r.db.ExecContext(ctx, query, id, time.Now().Add(-lastUsedInterval)); err != nil {
+		return fmt.Errorf("failed to update API key last use: %w", err)
+	}
+
+	return nil
+}
+
+func (r *APIKeyRepository) insert(ctx context.Context, db sqlx.QueryerContext, sessionID, name string, scopes []string, expiresAt *time.Time, rotatedFromID *string) (*models.APIKeyModel, string, error) {
+	key, err := generateSessionAPIKey()
+	if err != nil {
+		return nil, "", err
+	}
+
+	apiKey, err := insertAPIKey(ctx, db, key, sessionID, name, scopes, expiresAt, rotatedFromID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return apiKey, key, nil
+}
+
+// insertAPIKey guarda o hash da chave; usado também pelo repositório de sessões na criação da sessão
+func insertAPIKey(ctx context.Context, db sqlx.QueryerContext, key, sessionID, name string, scopes []string, expiresAt *time.Time, rotatedFromID *string) (*models.APIKeyModel, error) {
+	var apiKey models.APIKeyModel
+	query := `
+		INSERT INTO "zpApiKeys" ("sessionId", name, "keyPrefix", "keyHash", scopes, "expiresAt", "rotatedFromId")
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + apiKeyColumns
+
+	err := sqlx.GetContext(ctx, db, &apiKey, query,
+		sessionID, name, apiKeyPrefix(key), HashAPIKey(key), models.StringArray(scopes), expiresAt, rotatedFromID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	return &apiKey, nil
+}
+
+// apiKeyPrefix nunca guarda mais da metade da chave, para as chaves curtas informadas na criação da sessão
+func apiKeyPrefix(key string) string {
+	return key[:min(apiKeyPrefixLength, len(key)/2)]
+}
+
+func generateSessionAPIKey() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	return "zpk_" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"zpmeow/internal/infra/database/models"
+)
+
+const callColumns = `id, "sessionId", "callId", "callerJid", "groupJid", "isGroup", "isVideo", outcome, reason,
+	"replyMessageId", "remotePlatform", "offeredAt", "endedAt", "createdAt", "updatedAt"`
+
+type CallRepository struct {
+	db *sqlx.DB
+}
+
+func NewCallRepository(db *sqlx.DB) *CallRepository {
+	return &CallRepository{db: db}
+}
+
+// Create registra uma chamada recebida; retorna false se ela já estava registrada
+// (o WhatsApp pode enviar CallOffer e CallOfferNotice para a mesma chamada)
+func (r *CallRepository) Create(ctx context.Context, call *models.CallModel) (bool, error) {
+	if call.Outcome == "" {
+		call.Outcome = models.CallOutcomeRinging
+	}
+
+	query := `
+		INSERT INTO "zpCalls" (
+			"sessionId", "callId", "callerJid", "groupJid", "isGroup", "isVideo", outcome, "remotePlatform", "offeredAt"
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9
+		)
+		ON CONFLICT ("sessionId", "callId") DO NOTHING
+		RETURNING id, "createdAt", "updatedAt"`
+
+	err := r.db.QueryRowContext(ctx, query,
+		call.SessionId, call.CallId, call.CallerJid, call.GroupJid, call.IsGroup, call.IsVideo,
+		call.Outcome, call.RemotePlatform, call.OfferedAt,
+	).Scan(&call.ID, &call.CreatedAt, &call.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to create call: %w", err)
+	}
+
+	return true, nil
+}
+
+// GetByCallID busca a chamada pelo ID do WhatsApp; retorna nil quando não está registrada
+func (r *CallRepository) GetByCallID(ctx context.Context, sessionID, callID string) (*models.CallModel, error) {
+	var call models.CallModel
+	query := `SELECT ` + callColumns + ` FROM "zpCalls" WHERE "sessionId" = $1 AND "callId" = $2`
+
+	err := r.db.GetContext(ctx, &call, query, sessionID, callID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get call: %w", err)
+	}
+
+	return &call, nil
+}
+
+// List retorna as chamadas da sessão da mais recente para a mais antiga, com filtros opcionais
+func (r *CallRepository) List(ctx context.Context, sessionID, outcome, callerJid string, limit, offset int) ([]*models.CallModel, int, error) {
+	var calls []*models.CallModel
+	where := `"sessionId" = $1 AND ($2 = '' OR outcome = $2) AND ($3 = '' OR "callerJid" = $3)`
+	query := `
+		SELECT ` + callColumns + `
+		FROM "zpCalls"
+		WHERE ` + where + `
+		ORDER BY "offeredAt" DESC
+		LIMIT $4 OFFSET $5`
+
+	if err := r.db.SelectContext(ctx, &calls, query, sessionID, outcome, callerJid, limit, offset); err != nil {
+		return nil, 0, fmt.Errorf("failed to list calls: %w", err)
+	}
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM "zpCalls" WHERE ` + where
+	if err := r.db.GetContext(ctx, &total, countQuery, sessionID, outcome, callerJid); err != nil {
+		return nil, 0, fmt.Errorf("failed to count calls: %w", err)
+	}
+
+	return calls, total, nil
+}
+
+// SetOutcome registra o resultado de uma chamada que ainda está tocando; endedAt encerra a chamada
+// (rejeições). Retorna nil se a chamada não existir ou já tiver um resultado.
+func (r *CallRepository) SetOutcome(ctx context.Context, sessionID, callID, outcome string, reason *string, endedAt *time.Time) (*models.CallModel, error) {
+	var call models.CallModel
+	query := `
+		UPDATE "zpCalls"
+		SET outcome = $3, reason = COALESCE($4, reason), "endedAt" = COALESCE($5, "endedAt")
+		WHERE "sessionId" = $1 AND "callId" = $2 AND outcome = 'ringing'
+		RETURNING ` + callColumns
+
+	err := r.db.GetContext(ctx, &call, query, sessionID, callID, outcome, reason, endedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to update call outcome: %w", err)
+	}
+
+	return &call, nil
+}
+
+// End encerra uma chamada em andamento; se ninguém atendeu nem rejeitou, ela fica como perdida.
+// Retorna nil se a chamada não existir ou já estiver encerrada.
+func (r *CallRepository) End(ctx context.Context, sessionID, callID string, reason *string, endedAt time.Time) (*models.CallModel, error) {
+	var call models.CallModel
+	query := `
+		UPDATE "zpCalls"
+		SET outcome = CASE WHEN outcome = 'ringing' THEN 'missed' ELSE outcome END,
+			reason = COALESCE($3, reason), "endedAt" = $4
+		WHERE "sessionId" = $1 AND "callId" = $2 AND "endedAt" IS NULL
+		RETURNING ` + callColumns
+
+	err := r.db.GetContext(ctx, &call, query, sessionID, callID, reason, endedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to end call: %w", err)
+	}
+
+	return &call, nil
+}
+
+// SetReplyMessageID guarda o ID da resposta automática enviada ao rejeitar a chamada
+func (r *CallRepository) SetReplyMessageID(ctx context.Context, id, messageID string) error {
+	query := `UPDATE "zpCalls" SET "replyMessageId" = $2 WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id, messageID); err != nil {
+		return fmt.Errorf("failed to set call reply message: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"zpmeow/internal/infra/database/models"
+)
+
+type CallPolicyRepository struct {
+	db *sqlx.DB
+}
+
+func NewCallPolicyRepository(db *sqlx.DB) *CallPolicyRepository {
+	return &CallPolicyRepository{db: db}
+}
+
+// GetBySessionID busca a política de chamadas da sessão; retorna nil quando a sessão nunca configurou uma
+func (r *CallPolicyRepository) GetBySessionID(ctx context.Context, sessionID string) (*models.CallPolicyModel, error) {
+	var policy models.CallPolicyModel
+	query := `
+		SELECT id, "sessionId", action, "rejectMessage", "createdAt", "updatedAt"
+		FROM "zpCallPolicies"
+		WHERE "sessionId" = $1`
+
+	err := r.db.GetContext(ctx, &policy, query, sessionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get call policy: %w", err)
+	}
+
+	return &policy, nil
+}
+
+// Upsert cria ou substitui a política de chamadas da sessão
+func (r *CallPolicyRepository) Upsert(ctx context.Context, policy *models.CallPolicyModel) error {
+	query := `
+		INSERT INTO "zpCallPolicies" ("sessionId", action, "rejectMessage")
+		VALUES ($1, $2, $3)
+		ON CONFLICT ("sessionId") DO UPDATE SET
+			action = EXCLUDED.action,
+			"rejectMessage" = EXCLUDED."rejectMessage"
+		RETURNING id, "createdAt", "updatedAt"`
+
+	err := r.db.QueryRowContext(ctx, query,
+		policy.SessionId, policy.Action, policy.RejectMessage,
+	).Scan(&policy.ID, &policy.CreatedAt, &policy.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert call policy: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,335 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"zpmeow/internal/infra/database/models"
+)
+
+const campaignColumns = `id, "sessionId", name, template, status, "ratePerMinute", "jitterSeconds", "windowStart", "windowEnd",
+	timezone, "checkNumbers", "totalRecipients", "lastSentAt", "completedAt", "createdAt", "updatedAt"`
+
+const campaignRecipientColumns = `id, "campaignId", position, phone, variables, status, jid, "messageId", error,
+	"lockedAt", "processedAt", "createdAt", "updatedAt"`
+
+type CampaignRepository struct {
+	db *sqlx.DB
+}
+
+func NewCampaignRepository(db *sqlx.DB) *CampaignRepository {
+	return &CampaignRepository{db: db}
+}
+
+// Create insere a campanha e seus destinatários numa única transação
+func (r *CampaignRepository) Create(ctx context.Context, campaign *models.CampaignModel, recipients []*models.CampaignRecipientModel) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin campaign transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	campaignQuery := `
+		INSERT INTO "zpCampaigns" (
+			"sessionId", name, template, status, "ratePerMinute", "jitterSeconds", "windowStart", "windowEnd",
+			timezone, "checkNumbers", "totalRecipients"
+		) VALUES (
+			$1, $2, $3, 'running', $4, $5, $6, $7, $8, $9, $10
+		) RETURNING id, status, "createdAt", "updatedAt"`
+
+	if err := tx.QueryRowxContext(ctx, campaignQuery,
+		campaign.SessionId, campaign.Name, campaign.Template, campaign.RatePerMinute, campaign.JitterSeconds,
+		campaign.WindowStart, campaign.WindowEnd, campaign.Timezone, campaign.CheckNumbers, len(recipients),
+	).Scan(&campaign.ID, &campaign.Status, &campaign.CreatedAt, &campaign.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to create campaign: %w", err)
+	}
+	campaign.TotalRecipients = len(recipients)
+
+	stmt, err := tx.PreparexContext(ctx, `
+		INSERT INTO "zpCampaignRecipients" ("campaignId", position, phone, variables)
+		VALUES ($1, $2, $3, $4)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare campaign recipient insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for i, recipient := range recipients {
+		recipient.CampaignId = campaign.ID
+		recipient.Position = i + 1
+		if _, err := stmt.ExecContext(ctx, campaign.ID, recipient.Position, recipient.Phone, recipient.Variables); err != nil {
+			return fmt.Errorf("failed to insert campaign recipient %s: %w", recipient.Phone, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit campaign transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID busca uma campanha por ID dentro da sessão
+func (r *CampaignRepository) GetByID(ctx context.Context, sessionID, id string) (*models.CampaignModel, error) {
+	var campaign models.CampaignModel
+	query := `
+		SELECT ` + campaignColumns + `
+		FROM "zpCampaigns"
+		WHERE "sessionId" = $1 AND id = $2`
+
+	err := r.db.GetContext(ctx, &campaign, query, sessionID, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Não encontrado
+		}
+		return nil, fmt.Errorf("failed to get campaign: %w", err)
+	}
+
+	return &campaign, nil
+}
+
+// List lista as campanhas de uma sessão, mais recentes primeiro, opcionalmente filtradas por status
+func (r *CampaignRepository) List(ctx context.Context, sessionID, status string, limit, offset int) ([]*models.CampaignModel, int, error) {
+	var campaigns []*models.CampaignModel
+	query := `
+		SELECT ` + campaignColumns + `
+		FROM "zpCampaigns"
+		WHERE "sessionId" = $1 AND ($2 = '' OR status = $2)
+		ORDER BY "createdAt" DESC
+		LIMIT $3 OFFSET $4`
+
+	if err := r.db.SelectContext(ctx, &campaigns, query, sessionID, status, limit, offset); err != nil {
+		return nil, 0, fmt.Errorf("failed to list campaigns: %w", err)
+	}
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM "zpCampaigns" WHERE "sessionId" = $1 AND ($2 = '' OR status = $2)`
+	if err := r.db.GetContext(ctx, &total, countQuery, sessionID, status); err != nil {
+		return nil, 0, fmt.Errorf("failed to count campaigns: %w", err)
+	}
+
+	return campaigns, total, nil
+}
+
+// ListRunning lista as campanhas em andamento, começando pelas que estão há mais tempo sem enviar
+func (r *CampaignRepository) ListRunning(ctx context.Context) ([]*models.CampaignModel, error) {
+	var campaigns []*models.CampaignModel
+	query := `
+		SELECT ` + campaignColumns + `
+		FROM "zpCampaigns"
+		WHERE status = 'running'
+		ORDER BY "lastSentAt" NULLS FIRST, "createdAt"`
+
+	if err := r.db.SelectContext(ctx, &campaigns, query); err != nil {
+		return nil, fmt.Errorf("failed to list running campaigns: %w", err)
+	}
+
+	return campaigns, nil
+}
+
+// UpdateStatus muda o status da campanha se o status atual estiver em from; retorna nil se não houve mudança
+func (r *CampaignRepository) UpdateStatus(ctx context.Context, sessionID, id string, from []string, to string) (*models.CampaignModel, error) {
+	var campaign models.CampaignModel
+	query := `
+		UPDATE "zpCampaigns"
+		SET status = $3,
+			"completedAt" = CASE WHEN $3 IN ('completed', 'canceled') THEN CURRENT_TIMESTAMP ELSE "completedAt" END
+		WHERE "sessionId" = $1 AND id = $2 AND status = ANY($4)
+		RETURNING ` + campaignColumns
+
+	err := r.db.GetContext(ctx, &campaign, query, sessionID, id, to, pq.Array(from))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to update campaign status: %w", err)
+	}
+
+	return &campaign, nil
+}
+
+// CompleteIfDone conclui a campanha quando não restam destinatários pendentes
+func (r *CampaignRepository) CompleteIfDone(ctx context.Context, id string) (bool, error) {
+	query := `
+		UPDATE "zpCampaigns"
+		SET status = 'completed', "completedAt" = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'running' AND NOT EXISTS (
+			SELECT 1 FROM "zpCampaignRecipients"
+			WHERE "campaignId" = $1 AND status IN ('pending', 'processing')
+		)`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to complete campaign: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to complete campaign: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// TouchLastSent registra o horário do último envio, usado para alternar entre campanhas da mesma sessão
+func (r *CampaignRepository) TouchLastSent(ctx context.Context, id string) error {
+	query := `UPDATE "zpCampaigns" SET "lastSentAt" = CURRENT_TIMESTAMP WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to update campaign last send time: %w", err)
+	}
+
+	return nil
+}
+
+// Stats conta os destinatários da campanha por status
+func (r *CampaignRepository) Stats(ctx context.Context, campaignID string) (*models.CampaignStats, error) {
+	var rows []struct {
+		Status string `db:"status"`
+		Count  int    `db:"count"`
+	}
+	query := `
+		SELECT status, COUNT(*) AS count
+		FROM "zpCampaignRecipients"
+		WHERE "campaignId" = $1
+		GROUP BY status`
+
+	if err := r.db.SelectContext(ctx, &rows, query, campaignID); err != nil {
+		return nil, fmt.Errorf("failed to get campaign stats: %w", err)
+	}
+
+	stats := &models.CampaignStats{}
+	for _, row := range rows {
+		stats.Total += row.Count
+		switch row.Status {
+		case models.RecipientStatusPending:
+			stats.Pending = row.Count
+		case models.RecipientStatusProcessing:
+			stats.Processing = row.Count
+		case models.RecipientStatusSent:
+			stats.Sent = row.Count
+		case models.RecipientStatusFailed:
+			stats.Failed = row.Count
+		case models.RecipientStatusInvalid:
+			stats.Invalid = row.Count
+		case models.RecipientStatusCanceled:
+			stats.Canceled = row.Count
+		}
+	}
+
+	return stats, nil
+}
+
+// ListRecipients lista os destinatários da campanha na ordem de envio, opcionalmente filtrados por status
+func (r *CampaignRepository) ListRecipients(ctx context.Context, campaignID, status string, limit, offset int) ([]*models.CampaignRecipientModel, int, error) {
+	var recipients []*models.CampaignRecipientModel
+	query := `
+		SELECT ` + campaignRecipientColumns + `
+		FROM "zpCampaignRecipients"
+		WHERE "campaignId" = $1 AND ($2 = '' OR status = $2)
+		ORDER BY position
+		LIMIT $3 OFFSET $4`
+
+	if err := r.db.SelectContext(ctx, &recipients, query, campaignID, status, limit, offset); err != nil {
+		return nil, 0, fmt.Errorf("failed to list campaign recipients: %w", err)
+	}
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM "zpCampaignRecipients" WHERE "campaignId" = $1 AND ($2 = '' OR status = $2)`
+	if err := r.db.GetContext(ctx, &total, countQuery, campaignID, status); err != nil {
+		return nil, 0, fmt.Errorf("failed to count campaign recipients: %w", err)
+	}
+
+	return recipients, total, nil
+}
+
+// ClaimNextRecipient reserva o próximo destinatário pendente da campanha; retorna nil quando não há mais nenhum
+func (r *CampaignRepository) ClaimNextRecipient(ctx context.Context, campaignID string) (*models.CampaignRecipientModel, error) {
+	var recipient models.CampaignRecipientModel
+	query := `
+		UPDATE "zpCampaignRecipients"
+		SET status = 'processing', "lockedAt" = CURRENT_TIMESTAMP
+		WHERE id = (
+			SELECT id FROM "zpCampaignRecipients"
+			WHERE "campaignId" = $1 AND status = 'pending'
+			ORDER BY position
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + campaignRecipientColumns
+
+	err := r.db.GetContext(ctx, &recipient, query, campaignID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim campaign recipient: %w", err)
+	}
+
+	return &recipient, nil
+}
+
+// FinishRecipient registra o resultado do envio para o destinatário
+func (r *CampaignRepository) FinishRecipient(ctx context.Context, id, status string, jid, messageID, lastError *string) error {
+	query := `
+		UPDATE "zpCampaignRecipients"
+		SET status = $2, jid = COALESCE($3, jid), "messageId" = $4, error = $5,
+			"lockedAt" = NULL, "processedAt" = CURRENT_TIMESTAMP
+		WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id, status, jid, messageID, lastError); err != nil {
+		return fmt.Errorf("failed to update campaign recipient: %w", err)
+	}
+
+	return nil
+}
+
+// ReleaseRecipient devolve o destinatário para a fila sem registrar resultado (ex.: sessão desconectada)
+func (r *CampaignRepository) ReleaseRecipient(ctx context.Context, id string) error {
+	query := `
+		UPDATE "zpCampaignRecipients"
+		SET status = 'pending', "lockedAt" = NULL
+		WHERE id = $1 AND status = 'processing'`
+
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to release campaign recipient: %w", err)
+	}
+
+	return nil
+}
+
+// ReleaseStaleRecipients devolve para a fila destinatários presos em processing (ex.: processo reiniciado)
+func (r *CampaignRepository) ReleaseStaleRecipients(ctx context.Context, olderThan time.Duration) (int64, error) {
+	query := `
+		UPDATE "zpCampaignRecipients"
+		SET status = 'pending', "lockedAt" = NULL
+		WHERE status = 'processing' AND "lockedAt" < $1`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now().Add(-olderThan))
+	if err != nil {
+		return 0, fmt.Errorf("failed to release stale campaign recipients: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// CancelPendingRecipients marca como cancelados os destinatários que ainda não foram processados
+func (r *CampaignRepository) CancelPendingRecipients(ctx context.Context, campaignID string) (int64, error) {
+	query := `
+		UPDATE "zpCampaignRecipients"
+		SET status = 'canceled', "lockedAt" = NULL, "processedAt" = CURRENT_TIMESTAMP
+		WHERE "campaignId" = $1 AND status = 'pending'`
+
+	result, err := r.db.ExecContext(ctx, query, campaignID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to cancel campaign recipients: %w", err)
+	}
+
+	return result.RowsAffected()
+}
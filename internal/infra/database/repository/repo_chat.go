@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -54,7 +55,8 @@ func (r *ChatRepository) GetChatBySessionAndJID(ctx context.Context, sessionID,
 	var chat models.ChatModel
 	query := `
 		SELECT id, "sessionId", "chatJid", "chatName", "phoneNumber", "isGroup",
-			   "lastMsgAt", "unreadCount", "isArchived", metadata, "createdAt", "updatedAt"
+			   "lastMsgAt", "unreadCount", "isArchived", "isPinned", "pinnedAt", "isMuted", "mutedUntil",
+			   "isMarkedUnread", metadata, "createdAt", "updatedAt"
 		FROM "zpChats"
 		WHERE "sessionId" = $1 AND "chatJid" = $2`
 
@@ -74,7 +76,8 @@ func (r *ChatRepository) GetChatByID(ctx context.Context, id string) (*models.Ch
 	var chat models.ChatModel
 	query := `
 		SELECT id, "sessionId", "chatJid", "chatName", "phoneNumber", "isGroup",
-			   "lastMsgAt", "unreadCount", "isArchived", metadata, "createdAt", "updatedAt"
+			   "lastMsgAt", "unreadCount", "isArchived", "isPinned", "pinnedAt", "isMuted", "mutedUntil",
+			   "isMarkedUnread", metadata, "createdAt", "updatedAt"
 		FROM "zpChats"
 		WHERE id = $1`
 
@@ -167,12 +170,84 @@ func (r *ChatRepository) UpdateUnreadCount(ctx context.Context, chatID string, c
 	return nil
 }
 
+// ChatStateUpdate contém as alterações de estado de um chat sincronizadas com o app state do WhatsApp;
+// campos nil não são alterados
+type ChatStateUpdate struct {
+	Pinned     *bool
+	PinnedAt   time.Time
+	Muted      *bool
+	MutedUntil *time.Time // nil com Muted = silenciado para sempre
+	Archived   *bool      // arquivar também desafixa o chat, como no WhatsApp
+	Read       *bool      // lido zera o contador; não lido marca o chat como não lido
+}
+
+// UpdateState aplica as alterações de estado ao chat, criando-o se ainda não existir
+func (r *ChatRepository) UpdateState(ctx context.Context, sessionID, chatJID string, isGroup bool, update ChatStateUpdate) (*models.ChatModel, error) {
+	sets := []string{`"updatedAt" = CURRENT_TIMESTAMP`}
+	args := []interface{}{sessionID, chatJID}
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if update.Pinned != nil {
+		var pinnedAt *time.Time
+		if *update.Pinned {
+			pinnedAt = &update.PinnedAt
+		}
+		sets = append(sets, `"isPinned" = `+arg(*update.Pinned), `"pinnedAt" = `+arg(pinnedAt))
+	}
+	if update.Muted != nil {
+		var mutedUntil *time.Time
+		if *update.Muted {
+			mutedUntil = update.MutedUntil
+		}
+		sets = append(sets, `"isMuted" = `+arg(*update.Muted), `"mutedUntil" = `+arg(mutedUntil))
+	}
+	if update.Archived != nil {
+		sets = append(sets, `"isArchived" = `+arg(*update.Archived))
+		if *update.Archived {
+			sets = append(sets, `"isPinned" = FALSE`, `"pinnedAt" = NULL`)
+		}
+	}
+	if update.Read != nil {
+		if *update.Read {
+			sets = append(sets, `"unreadCount" = 0`, `"isMarkedUnread" = FALSE`)
+		} else {
+			sets = append(sets, `"isMarkedUnread" = TRUE`)
+		}
+	}
+
+	insert := `
+		INSERT INTO "zpChats" ("sessionId", "chatJid", "isGroup")
+		VALUES ($1, $2, $3)
+		ON CONFLICT ("sessionId", "chatJid") DO NOTHING`
+	if _, err := r.db.ExecContext(ctx, insert, sessionID, chatJID, isGroup); err != nil {
+		return nil, fmt.Errorf("failed to create chat: %w", err)
+	}
+
+	query := `
+		UPDATE "zpChats" SET ` + strings.Join(sets, ", ") + `
+		WHERE "sessionId" = $1 AND "chatJid" = $2
+		RETURNING id, "sessionId", "chatJid", "chatName", "phoneNumber", "isGroup",
+			"lastMsgAt", "unreadCount", "isArchived", "isPinned", "pinnedAt", "isMuted", "mutedUntil",
+			"isMarkedUnread", metadata, "createdAt", "updatedAt"`
+
+	var chat models.ChatModel
+	if err := r.db.GetContext(ctx, &chat, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to update chat state: %w", err)
+	}
+
+	return &chat, nil
+}
+
 // GetChatsBySessionId busca todos os chats de uma sessão (OTIMIZADA)
 func (r *ChatRepository) GetChatsBySessionID(ctx context.Context, sessionID string, limit, offset int) ([]*models.ChatModel, error) {
 	var chats []*models.ChatModel
 	query := `
 		SELECT id, "sessionId", "chatJid", "chatName", "phoneNumber", "isGroup",
-			   "lastMsgAt", "unreadCount", "isArchived", metadata, "createdAt", "updatedAt"
+			   "lastMsgAt", "unreadCount", "isArchived", "isPinned", "pinnedAt", "isMuted", "mutedUntil",
+			   "isMarkedUnread", metadata, "createdAt", "updatedAt"
 		FROM "zpChats"
 		WHERE "sessionId" = $1 AND "isArchived" = FALSE
 		ORDER BY "lastMsgAt" DESC NULLS LAST
@@ -257,7 +332,8 @@ func (r *ChatRepository) GetChatWithChatwootMapping(ctx context.Context, session
 	query := `
 		SELECT id, "sessionId", "chatJid", "chatName", "phoneNumber", "isGroup",
 			   "groupSubject", "groupDescription", "chatwootConversationId", "chatwootContactId",
-			   "lastMsgAt", "unreadCount", "isArchived", metadata, "createdAt", "updatedAt"
+			   "lastMsgAt", "unreadCount", "isArchived", "isPinned", "pinnedAt", "isMuted", "mutedUntil",
+			   "isMarkedUnread", metadata, "createdAt", "updatedAt"
 		FROM "zpChats"
 		WHERE "sessionId" = $1 AND "chatJid" = $2`
 
@@ -278,7 +354,8 @@ func (r *ChatRepository) GetChatsByPhoneNumber(ctx context.Context, sessionID, p
 	query := `
 		SELECT id, "sessionId", "chatJid", "chatName", "phoneNumber", "isGroup",
 			   "groupSubject", "groupDescription", "chatwootConversationId", "chatwootContactId",
-			   "lastMsgAt", "unreadCount", "isArchived", metadata, "createdAt", "updatedAt"
+			   "lastMsgAt", "unreadCount", "isArchived", "isPinned", "pinnedAt", "isMuted", "mutedUntil",
+			   "isMarkedUnread", metadata, "createdAt", "updatedAt"
 		FROM "zpChats"
 		WHERE "sessionId" = $1 AND "phoneNumber" = $2
 		ORDER BY "lastMsgAt" DESC`
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"zpmeow/internal/infra/database/models"
+)
+
+const historySyncColumns = `id, "sessionId", status, "syncType", progress, chunks, conversations, "messagesStored", "messagesSkipped",
+	"lastError", "startedAt", "lastChunkAt", "completedAt", "createdAt", "updatedAt"`
+
+type HistorySyncRepository struct {
+	db *sqlx.DB
+}
+
+func NewHistorySyncRepository(db *sqlx.DB) *HistorySyncRepository {
+	return &HistorySyncRepository{db: db}
+}
+
+// HistorySyncChunk resume um chunk de HistorySync processado
+type HistorySyncChunk struct {
+	SyncType        string
+	Progress        int
+	Conversations   int
+	MessagesStored  int
+	MessagesSkipped int
+	Error           string
+}
+
+// RecordChunk soma o chunk aos totais da sessão. O progresso nunca regride e a sincronização
+// fica concluída quando ele chega a 100; chunks posteriores (ex: ON_DEMAND) continuam somando aos totais.
+func (r *HistorySyncRepository) RecordChunk(ctx context.Context, sessionID string, chunk HistorySyncChunk) (*models.HistorySyncModel, error) {
+	var lastError *string
+	if chunk.Error != "" {
+		lastError = &chunk.Error
+	}
+
+	var sync models.HistorySyncModel
+	query := `
+		INSERT INTO "zpHistorySyncs" AS h (
+			"sessionId", status, "syncType", progress, chunks, conversations, "messagesStored", "messagesSkipped",
+			"lastError", "completedAt"
+		) VALUES (
+			$1,
+			CASE WHEN $3 >= 100 THEN 'completed' ELSE 'running' END,
+			$2, $3, 1, $4, $5, $6, $7,
+			CASE WHEN $3 >= 100 THEN NOW() END
+		)
+		ON CONFLICT ("sessionId") DO UPDATE SET
+			status = CASE WHEN GREATEST(h.progress, EXCLUDED.progress) >= 100 THEN 'completed' ELSE 'running' END,
+			"syncType" = EXCLUDED."syncType",
+			progress = GREATEST(h.progress, EXCLUDED.progress),
+			chunks = h.chunks + 1,
+			conversations = h.conversations + EXCLUDED.conversations,
+			"messagesStored" = h."messagesStored" + EXCLUDED."messagesStored",
+			"messagesSkipped" = h."messagesSkipped" + EXCLUDED."messagesSkipped",
+			"lastError" = COALESCE(EXCLUDED."lastError", h."lastError"),
+			"lastChunkAt" = NOW(),
+			"completedAt" = COALESCE(h."completedAt", EXCLUDED."completedAt")
+		RETURNING ` + historySyncColumns
+
+	err := r.db.GetContext(ctx, &sync, query,
+		sessionID, chunk.SyncType, chunk.Progress, chunk.Conversations, chunk.MessagesStored, chunk.MessagesSkipped, lastError,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record history sync chunk: %w", err)
+	}
+
+	return &sync, nil
+}
+
+// GetBySessionID retorna o estado da sincronização da sessão, ou nil se nenhum histórico foi recebido
+func (r *HistorySyncRepository) GetBySessionID(ctx context.Context, sessionID string) (*models.HistorySyncModel, error) {
+	var sync models.HistorySyncModel
+	query := `SELECT ` + historySyncColumns + ` FROM "zpHistorySyncs" WHERE "sessionId" = $1`
+
+	err := r.db.GetContext(ctx, &sync, query, sessionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get history sync: %w", err)
+	}
+
+	return &sync, nil
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"zpmeow/internal/infra/database/models"
+)
+
+const labelColumns = `id, "sessionId", "labelId", name, color, "predefinedId", "createdAt", "updatedAt"`
+
+// LabelRepository guarda as etiquetas do WhatsApp Business e as associações delas com chats e mensagens.
+// As associações usam o ID da etiqueta no WhatsApp porque podem chegar pelo app state antes da etiqueta.
+type LabelRepository struct {
+	db *sqlx.DB
+}
+
+func NewLabelRepository(db *sqlx.DB) *LabelRepository {
+	return &LabelRepository{db: db}
+}
+
+// List retorna as etiquetas da sessão ordenadas pelo nome
+func (r *LabelRepository) List(ctx context.Context, sessionID string) ([]*models.LabelModel, error) {
+	var labels []*models.LabelModel
+	query := `SELECT ` + labelColumns + ` FROM "zpLabels" WHERE "sessionId" = $1 ORDER BY name, "labelId"`
+
+	if err := r.db.SelectContext(ctx, &labels, query, sessionID); err != nil {
+		return nil, fmt.Errorf("failed to list labels: %w", err)
+	}
+
+	return labels, nil
+}
+
+// GetByLabelID busca a etiqueta pelo ID do WhatsApp; retorna nil quando ela não existe
+func (r *LabelRepository) GetByLabelID(ctx context.Context, sessionID, labelID string) (*models.LabelModel, error) {
+	var label models.LabelModel
+	query := `SELECT ` + labelColumns + ` FROM "zpLabels" WHERE "sessionId" = $1 AND "labelId" = $2`
+
+	err := r.db.GetContext(ctx, &label, query, sessionID, labelID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get label: %w", err)
+	}
+
+	return &label, nil
+}
+
+// Upsert cria ou atualiza a etiqueta pelo ID do WhatsApp
+func (r *LabelRepository) Upsert(ctx context.Context, label *models.LabelModel) error {
+	query := `
+		INSERT INTO "zpLabels" ("sessionId", "labelId", name, color, "predefinedId")
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT ("sessionId", "labelId") DO UPDATE
+		SET name = EXCLUDED.name, color = EXCLUDED.color,
+			"predefinedId" = COALESCE(EXCLUDED."predefinedId", "zpLabels"."predefinedId")
+		RETURNING id, "predefinedId", "createdAt", "updatedAt"`
+
+	err := r.db.QueryRowContext(ctx, query,
+		label.SessionId, label.LabelId, label.Name, label.Color, label.PredefinedId,
+	).Scan(&label.ID, &label.PredefinedId, &label.CreatedAt, &label.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert label: %w", err)
+	}
+
+	return nil
+}
+
+// Delete remove a etiqueta e as associações dela com chats e mensagens
+func (r *LabelRepository) Delete(ctx context.Context, sessionID, labelID string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin label transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	for _, table := range []string{"zpMessageLabels", "zpChatLabels", "zpLabels"} {
+		query := `DELETE FROM "` + table + `" WHERE "sessionId" = $1 AND "labelId" = $2`
+		if _, err := tx.ExecContext(ctx, query, sessionID, labelID); err != nil {
+			return fmt.Errorf("failed to delete label from %s: %w", table, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit label deletion: %w", err)
+	}
+
+	return nil
+}
+
+// NextLabelID retorna o próximo ID livre para uma etiqueta nova; o WhatsApp usa IDs numéricos sequenciais
+func (r *LabelRepository) NextLabelID(ctx context.Context, sessionID string) (string, error) {
+	var next int64
+	query := `
+		SELECT COALESCE(MAX("labelId"::BIGINT), 0) + 1
+		FROM "zpLabels"
+		WHERE "sessionId" = $1 AND "labelId" ~ '^[0-9]{1,18}$'`
+
+	if err := r.db.GetContext(ctx, &next, query, sessionID); err != nil {
+		return "", fmt.Errorf("failed to get next label ID: %w", err)
+	}
+
+	return fmt.Sprintf("%d", next), nil
+}
+
+// SetChatLabel associa ou desassocia a etiqueta do chat
+func (r *LabelRepository) SetChatLabel(ctx context.Context, sessionID, labelID, chatJID string, labeled bool) error {
+	query := `DELETE FROM "zpChatLabels" WHERE "sessionId" = $1 AND "labelId" = $2 AND "chatJid" = $3`
+	if labeled {
+		query = `
+			INSERT INTO "zpChatLabels" ("sessionId", "labelId", "chatJid")
+			VALUES ($1, $2, $3)
+			ON CONFLICT ("sessionId", "labelId", "chatJid") DO NOTHING`
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, sessionID, labelID, chatJID); err != nil {
+		return fmt.Errorf("failed to set chat label: %w", err)
+	}
+
+	return nil
+}
+
+// SetMessageLabel associa ou desassocia a etiqueta da mensagem
+func (r *LabelRepository) SetMessageLabel(ctx context.Context, sessionID, labelID, chatJID, messageID string, labeled bool) error {
+	query := `DELETE FROM "zpMessageLabels" WHERE "sessionId" = $1 AND "labelId" = $2 AND "chatJid" = $3 AND "messageId" = $4`
+	if labeled {
+		query = `
+			INSERT INTO "zpMessageLabels" ("sessionId", "labelId", "chatJid", "messageId")
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT ("sessionId", "labelId", "chatJid", "messageId") DO NOTHING`
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, sessionID, labelID, chatJID, messageID); err != nil {
+		return fmt.Errorf("failed to set message label: %w", err)
+	}
+
+	return nil
+}
+
+// ListChatJIDs retorna os chats que têm a etiqueta
+func (r *LabelRepository) ListChatJIDs(ctx context.Context, sessionID, labelID string) ([]string, error) {
+	var chatJIDs []string
+	query := `SELECT "chatJid" FROM "zpChatLabels" WHERE "sessionId" = $1 AND "labelId" = $2`
+
+	if err := r.db.SelectContext(ctx, &chatJIDs, query, sessionID, labelID); err != nil {
+		return nil, fmt.Errorf("failed to list labeled chats: %w", err)
+	}
+
+	return chatJIDs, nil
+}
+
+// CountChats retorna quantos chats têm cada etiqueta da sessão
+func (r *LabelRepository) CountChats(ctx context.Context, sessionID string) (map[string]int, error) {
+	var rows []struct {
+		LabelID string `db:"labelId"`
+		Count   int    `db:"count"`
+	}
+	query := `SELECT "labelId", COUNT(*) AS count FROM "zpChatLabels" WHERE "sessionId" = $1 GROUP BY "labelId"`
+
+	if err := r.db.SelectContext(ctx, &rows, query, sessionID); err != nil {
+		return nil, fmt.Errorf("failed to count labeled chats: %w", err)
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.LabelID] = row.Count
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"zpmeow/internal/infra/database/models"
+)
+
+type MediaRepository struct {
+	db *sqlx.DB
+}
+
+func NewMediaRepository(db *sqlx.DB) *MediaRepository {
+	return &MediaRepository{db: db}
+}
+
+const mediaColumns = `id, "sessionId", "mediaId", "mediaType", "mimeType", "fileName", size, "storageDriver", "storageKey", metadata, "createdAt", "updatedAt"`
+
+// Store registra a mídia da sessão com o lock do objeto no storage: putObject grava o conteúdo antes do
+// registro, e uma remoção concorrente do mesmo objeto espera o commit. Reenviar o mesmo conteúdo reaproveita
+// a linha existente.
+func (r *MediaRepository) Store(ctx context.Context, media *models.MediaModel, putObject func() error) error {
+	if media.Metadata == nil {
+		media.Metadata = models.JSONB{}
+	}
+
+	return r.withStorageKeyLock(ctx, media.StorageDriver, media.StorageKey, func(tx *sqlx.Tx) error {
+		if err := putObject(); err != nil {
+			return err
+		}
+
+		query := `
+			INSERT INTO "zpMedia" (
+				"sessionId", "mediaId", "mediaType", "mimeType", "fileName", size, "storageDriver", "storageKey", metadata
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			ON CONFLICT ("sessionId", "mediaId") DO UPDATE SET
+				"mediaType" = EXCLUDED."mediaType",
+				"fileName" = COALESCE(EXCLUDED."fileName", "zpMedia"."fileName")
+			RETURNING id, "fileName", "createdAt", "updatedAt"`
+
+		err := tx.QueryRowContext(ctx, query,
+			media.SessionId, media.MediaId, media.MediaType, media.MimeType, media.FileName,
+			media.Size, media.StorageDriver, media.StorageKey, media.Metadata,
+		).Scan(&media.ID, &media.FileName, &media.CreatedAt, &media.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to upsert media: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetByMediaID busca uma mídia da sessão pelo identificador de conteúdo
+func (r *MediaRepository) GetByMediaID(ctx context.Context, sessionID, mediaID string) (*models.MediaModel, error) {
+	var media models.MediaModel
+	query := `SELECT ` + mediaColumns + ` FROM "zpMedia" WHERE "sessionId" = $1 AND "mediaId" = $2`
+
+	err := r.db.GetContext(ctx, &media, query, sessionID, mediaID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get media: %w", err)
+	}
+
+	return &media, nil
+}
+
+// ListBySession lista as mídias da sessão, mais recentes primeiro
+func (r *MediaRepository) ListBySession(ctx context.Context, sessionID string, limit, offset int) ([]*models.MediaModel, error) {
+	var media []*models.MediaModel
+	query := `
+		SELECT ` + mediaColumns + `
+		FROM "zpMedia"
+		WHERE "sessionId" = $1
+		ORDER BY "createdAt" DESC
+		LIMIT $2 OFFSET $3`
+
+	err := r.db.SelectContext(ctx, &media, query, sessionID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list media: %w", err)
+	}
+
+	return media, nil
+}
+
+// CountBySession conta as mídias da sessão
+func (r *MediaRepository) CountBySession(ctx context.Context, sessionID string) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM "zpMedia" WHERE "sessionId" = $1`
+
+	if err := r.db.GetContext(ctx, &count, query, sessionID); err != nil {
+		return 0, fmt.Errorf("failed to count media: %w", err)
+	}
+
+	return count, nil
+}
+
+// TotalSizeBySession soma o tamanho das mídias da sessão em bytes
+func (r *MediaRepository) TotalSizeBySession(ctx context.Context, sessionID string) (int64, error) {
+	var total int64
+	query := `SELECT COALESCE(SUM(size), 0) FROM "zpMedia" WHERE "sessionId" = $1`
+
+	if err := r.db.GetContext(ctx, &total, query, sessionID); err != nil {
+		return 0, fmt.Errorf("failed to sum media size: %w", err)
+	}
+
+	return total, nil
+}
+
+// Delete remove a mídia da sessão e, quando nenhuma linha (de qualquer sessão) referencia mais o objeto,
+// chama releaseObject para tirá-lo do storage. A contagem e a remoção do objeto acontecem com o lock do
+// objeto, então um upload concorrente do mesmo conteúdo não fica apontando para um objeto removido.
+// Retorna false se a mídia não existia.
+func (r *MediaRepository) Delete(ctx context.Context, sessionID, mediaID string, releaseObject func(storageDriver, storageKey string) error) (bool, error) {
+	media, err := r.GetByMediaID(ctx, sessionID, mediaID)
+	if err != nil || media == nil {
+		return false, err
+	}
+
+	deleted := false
+	err = r.withStorageKeyLock(ctx, media.StorageDriver, media.StorageKey, func(tx *sqlx.Tx) error {
+		result, err := tx.ExecContext(ctx, `DELETE FROM "zpMedia" WHERE "sessionId" = $1 AND "mediaId" = $2`, sessionID, mediaID)
+		if err != nil {
+			return fmt.Errorf("failed to delete media: %w", err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get affected rows: %w", err)
+		}
+		if rows == 0 {
+			return nil
+		}
+		deleted = true
+
+		var refs int
+		query := `SELECT COUNT(*) FROM "zpMedia" WHERE "storageDriver" = $1 AND "storageKey" = $2`
+		if err := tx.GetContext(ctx, &refs, query, media.StorageDriver, media.StorageKey); err != nil {
+			return fmt.Errorf("failed to count media references: %w", err)
+		}
+		if refs > 0 {
+			return nil
+		}
+
+		// Se o storage falhar, o rollback devolve a linha e a remoção pode ser repetida
+		return releaseObject(media.StorageDriver, media.StorageKey)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return deleted, nil
+}
+
+// withStorageKeyLock executa fn em uma transação com o advisory lock do objeto, liberado no commit ou rollback
+func (r *MediaRepository) withStorageKeyLock(ctx context.Context, storageDriver, storageKey string, fn func(tx *sqlx.Tx) error) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin media transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1), hashtext($2))`, storageDriver, storageKey); err != nil {
+		return fmt.Errorf("failed to lock media object: %w", err)
+	}
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit media transaction: %w", err)
+	}
+
+	return nil
+}
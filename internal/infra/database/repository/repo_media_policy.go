@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"zpmeow/internal/infra/database/models"
+)
+
+type MediaPolicyRepository struct {
+	db *sqlx.DB
+}
+
+func NewMediaPolicyRepository(db *sqlx.DB) *MediaPolicyRepository {
+	return &MediaPolicyRepository{db: db}
+}
+
+// GetBySessionID busca a política de mídia da sessão; retorna nil quando a sessão nunca configurou uma
+func (r *MediaPolicyRepository) GetBySessionID(ctx context.Context, sessionID string) (*models.MediaPolicyModel, error) {
+	var policy models.MediaPolicyModel
+	query := `
+		SELECT id, "sessionId", "autoDownload", "mediaTypes", "maxSizes", "createdAt", "updatedAt"
+		FROM "zpMediaPolicies"
+		WHERE "sessionId" = $1`
+
+	err := r.db.GetContext(ctx, &policy, query, sessionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get media policy: %w", err)
+	}
+
+	return &policy, nil
+}
+
+// Upsert cria ou substitui a política de mídia da sessão
+func (r *MediaPolicyRepository) Upsert(ctx context.Context, policy *models.MediaPolicyModel) error {
+	if policy.MediaTypes == nil {
+		policy.MediaTypes = models.StringArray{}
+	}
+	if policy.MaxSizes == nil {
+		policy.MaxSizes = models.JSONB{}
+	}
+
+	query := `
+		INSERT INTO "zpMediaPolicies" ("sessionId", "autoDownload", "mediaTypes", "maxSizes")
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT ("sessionId") DO UPDATE SET
+			"autoDownload" = EXCLUDED."autoDownload",
+			"mediaTypes" = EXCLUDED."mediaTypes",
+			"maxSizes" = EXCLUDED."maxSizes"
+		RETURNING id, "createdAt", "updatedAt"`
+
+	err := r.db.QueryRowContext(ctx, query,
+		policy.SessionId, policy.AutoDownload, policy.MediaTypes, policy.MaxSizes,
+	).Scan(&policy.ID, &policy.CreatedAt, &policy.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert media policy: %w", err)
+	}
+
+	return nil
+}
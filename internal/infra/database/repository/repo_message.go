@@ -4,9 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"zpmeow/internal/infra/database/models"
 )
 
@@ -69,6 +72,81 @@ func (r *MessageRepository) CreateMessage(ctx context.Context, message *models.M
 	return nil
 }
 
+// insertMessageIfNotExistsQuery grava a mensagem ignorando duplicatas de ("sessionId", "msgId")
+const insertMessageIfNotExistsQuery = `
+	INSERT INTO "zpMessages" (
+		"chatId", "sessionId", "msgId", "msgType", content,
+		"mediaInfo", "senderJid", "senderName", "isFromMe", "isForwarded", "isBroadcast",
+		"quotedMsgId", "quotedContent", status, timestamp, "editTimestamp", "isDeleted", "deletedAt", reaction, metadata
+	) VALUES (
+		$1, $2, $3, $4, $5,
+		$6, $7, $8, $9, $10, $11,
+		$12, $13, $14, $15, $16, $17, $18, $19, $20
+	)
+	ON CONFLICT ("sessionId", "msgId") DO NOTHING
+	RETURNING id, "createdAt", "updatedAt"`
+
+// CreateMessageIfNotExists cria a mensagem ignorando duplicatas de ("sessionId", "msgId").
+// Retorna false quando a mensagem já existia (ex.: eco do próprio envio)
+func (r *MessageRepository) CreateMessageIfNotExists(ctx context.Context, message *models.MessageModel) (bool, error) {
+	created, err := insertMessageIfNotExists(ctx, r.db, message)
+	if err != nil {
+		return false, fmt.Errorf("failed to create message: %w", err)
+	}
+
+	return created, nil
+}
+
+// CreateMessagesIfNotExist grava as mensagens em uma única transação, ignorando as que já existem,
+// e retorna quantas foram gravadas. Usado na importação do histórico, uma conversa por vez.
+func (r *MessageRepository) CreateMessagesIfNotExist(ctx context.Context, messages []*models.MessageModel) (int, error) {
+	if len(messages) == 0 {
+		return 0, nil
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin messages transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	created := 0
+	for _, message := range messages {
+		ok, err := insertMessageIfNotExists(ctx, tx, message)
+		if err != nil {
+			return 0, fmt.Errorf("failed to create message %s: %w", message.MsgId, err)
+		}
+		if ok {
+			created++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit messages: %w", err)
+	}
+
+	return created, nil
+}
+
+func insertMessageIfNotExists(ctx context.Context, db sqlx.QueryerContext, message *models.MessageModel) (bool, error) {
+	err := db.QueryRowxContext(ctx, insertMessageIfNotExistsQuery,
+		message.ChatId, message.SessionId, message.MsgId, message.MsgType, message.Content,
+		message.MediaInfo, message.SenderJid, message.SenderName, message.IsFromMe, message.IsForwarded, message.IsBroadcast,
+		message.QuotedMsgId, message.QuotedContent, message.Status, message.Timestamp, message.EditTimestamp, message.IsDeleted, message.DeletedAt, message.Reaction, message.Metadata,
+	).Scan(&message.ID, &message.CreatedAt, &message.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 // GetMessageByID busca uma mensagem por ID
 func (r *MessageRepository) GetMessageByID(ctx context.Context, id string) (*models.MessageModel, error) {
 	var message models.MessageModel
@@ -135,6 +213,74 @@ func (r *MessageRepository) GetMessagesByChatId(ctx context.Context, chatID stri
 	return messages, nil
 }
 
+// MessageHistoryFilter define a página do histórico de um chat; Before e After são exclusivos entre si
+type MessageHistoryFilter struct {
+	ChatID string
+	Types  []string // msgType
+	Sender string   // número (parte do JID antes de @ e do device)
+	Before *MessageCursor
+	After  *MessageCursor
+	Limit  int
+}
+
+// ListChatMessages busca uma página do histórico do chat, sempre ordenada da mais recente para a mais antiga.
+// Sem After, retorna as mensagens anteriores a Before (ou as mais recentes); com After, as posteriores ao cursor.
+// Mensagens apagadas também são retornadas, com isDeleted. O bool indica se há mais mensagens na direção pedida.
+func (r *MessageRepository) ListChatMessages(ctx context.Context, filter MessageHistoryFilter) ([]*models.MessageModel, bool, error) {
+	conditions := []string{`"chatId" = $1`}
+	args := []interface{}{filter.ChatID}
+
+	if len(filter.Types) > 0 {
+		args = append(args, pq.StringArray(filter.Types))
+		conditions = append(conditions, fmt.Sprintf(`"msgType" = ANY($%d)`, len(args)))
+	}
+	if filter.Sender != "" {
+		args = append(args, filter.Sender)
+		conditions = append(conditions, fmt.Sprintf(`split_part(split_part("senderJid", '@', 1), ':', 1) = $%d`, len(args)))
+	}
+
+	order := "DESC"
+	switch {
+	case filter.After != nil:
+		order = "ASC"
+		args = append(args, filter.After.Timestamp, filter.After.ID)
+		conditions = append(conditions, fmt.Sprintf(`(timestamp, id) > ($%d, $%d::uuid)`, len(args)-1, len(args)))
+	case filter.Before != nil:
+		args = append(args, filter.Before.Timestamp, filter.Before.ID)
+		conditions = append(conditions, fmt.Sprintf(`(timestamp, id) < ($%d, $%d::uuid)`, len(args)-1, len(args)))
+	}
+
+	// Busca um item a mais para saber se existe próxima página
+	args = append(args, filter.Limit+1)
+	query := `
+		SELECT id, "chatId", "sessionId", "msgId", "msgType", content,
+			   "mediaInfo", "senderJid", "senderName", "isFromMe", "isForwarded", "isBroadcast",
+			   "quotedMsgId", "quotedContent", status, timestamp, "editTimestamp",
+			   "isDeleted", "deletedAt", reaction, metadata, "createdAt", "updatedAt"
+		FROM "zpMessages"
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY timestamp ` + order + `, id ` + order + `
+		LIMIT $` + strconv.Itoa(len(args))
+
+	var messages []*models.MessageModel
+	if err := r.db.SelectContext(ctx, &messages, query, args...); err != nil {
+		return nil, false, fmt.Errorf("failed to list chat messages: %w", err)
+	}
+
+	hasMore := len(messages) > filter.Limit
+	if hasMore {
+		messages = messages[:filter.Limit]
+	}
+
+	if filter.After != nil {
+		for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+			messages[i], messages[j] = messages[j], messages[i]
+		}
+	}
+
+	return messages, hasMore, nil
+}
+
 // UpdateMessage atualiza uma mensagem
 func (r *MessageRepository) UpdateMessage(ctx context.Context, message *models.MessageModel) error {
 	query := `
@@ -174,6 +320,20 @@ func (r *MessageRepository) UpdateMessage(ctx context.Context, message *models.M
 	return nil
 }
 
+// MergeMediaInfo mescla as chaves informadas no "mediaInfo" da mensagem (sessionId + msgId do WhatsApp)
+func (r *MessageRepository) MergeMediaInfo(ctx context.Context, sessionID, whatsappMessageID string, mediaInfo models.JSONB) error {
+	query := `
+		UPDATE "zpMessages"
+		SET "mediaInfo" = COALESCE("mediaInfo", '{}'::jsonb) || $3::jsonb, "updatedAt" = CURRENT_TIMESTAMP
+		WHERE "sessionId" = $1 AND "msgId" = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, sessionID, whatsappMessageID, mediaInfo); err != nil {
+		return fmt.Errorf("failed to update message media info: %w", err)
+	}
+
+	return nil
+}
+
 // UpdateMessageStatus atualiza o status de uma mensagem
 func (r *MessageRepository) UpdateMessageStatus(ctx context.Context, id string, status string) error {
 	query := `UPDATE "zpMessages" SET status = $1, "updatedAt" = CURRENT_TIMESTAMP WHERE id = $2`
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"zpmeow/internal/infra/database/models"
+)
+
+type MessageReactionRepository struct {
+	db *sqlx.DB
+}
+
+func NewMessageReactionRepository(db *sqlx.DB) *MessageReactionRepository {
+	return &MessageReactionRepository{db: db}
+}
+
+// SetReaction grava a reação atual do remetente à mensagem; reação vazia remove a anterior.
+// Reações mais antigas que a gravada são ignoradas. Retorna false quando a mensagem não está
+// armazenada ou a reação chegou fora de ordem.
+func (r *MessageReactionRepository) SetReaction(ctx context.Context, sessionID, msgID, senderJid, reaction string, timestamp time.Time) (bool, error) {
+	var query string
+	if reaction == "" {
+		query = `
+			DELETE FROM "zpMessageReactions"
+			WHERE "sessionId" = $1 AND "msgId" = $2 AND "senderJid" = $3 AND timestamp <= $4`
+	} else {
+		query = `
+			INSERT INTO "zpMessageReactions" ("messageId", "sessionId", "msgId", "senderJid", reaction, timestamp)
+			SELECT id, "sessionId", "msgId", $3, $5, $4
+			FROM "zpMessages"
+			WHERE "sessionId" = $1 AND "msgId" = $2
+			ON CONFLICT ("messageId", "senderJid") DO UPDATE SET
+				reaction = EXCLUDED.reaction,
+				timestamp = EXCLUDED.timestamp
+			WHERE "zpMessageReactions".timestamp <= EXCLUDED.timestamp`
+	}
+
+	args := []interface{}{sessionID, msgID, senderJid, timestamp}
+	if reaction != "" {
+		args = append(args, reaction)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to set message reaction: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// ListByMessageIDs busca as reações das mensagens informadas, agrupadas pelo ID (UUID) da mensagem
+func (r *MessageReactionRepository) ListByMessageIDs(ctx context.Context, messageIDs []string) (map[string][]*models.MessageReactionModel, error) {
+	result := make(map[string][]*models.MessageReactionModel)
+	if len(messageIDs) == 0 {
+		return result, nil
+	}
+
+	var reactions []*models.MessageReactionModel
+	query := `
+		SELECT id, "messageId", "sessionId", "msgId", "senderJid", reaction, timestamp, "createdAt", "updatedAt"
+		FROM "zpMessageReactions"
+		WHERE "messageId" = ANY($1::uuid[])
+		ORDER BY timestamp ASC`
+
+	if err := r.db.SelectContext(ctx, &reactions, query, pq.StringArray(messageIDs)); err != nil {
+		return nil, fmt.Errorf("failed to list message reactions: %w", err)
+	}
+
+	for _, reaction := range reactions {
+		result[reaction.MessageId] = append(result[reaction.MessageId], reaction)
+	}
+
+	return result, nil
+}
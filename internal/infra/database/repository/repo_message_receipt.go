@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"zpmeow/internal/infra/database/models"
+)
+
+// messageStatusOrder é a progressão de zpMessages.status; o status nunca regride
+var messageStatusOrder = pq.StringArray{
+	models.MessageStatusPending,
+	models.MessageStatusSent,
+	models.MessageStatusDelivered,
+	models.MessageStatusRead,
+	models.MessageStatusPlayed,
+}
+
+type MessageReceiptRepository struct {
+	db *sqlx.DB
+}
+
+func NewMessageReceiptRepository(db *sqlx.DB) *MessageReceiptRepository {
+	return &MessageReceiptRepository{db: db}
+}
+
+// RecordReceipt grava um recibo por mensagem enviada e avança zpMessages.status.
+// Retorna quantas mensagens tiveram o status alterado.
+func (r *MessageReceiptRepository) RecordReceipt(ctx context.Context, sessionID string, msgIDs []string, recipientJid, status string, timestamp time.Time) (int64, error) {
+	if len(msgIDs) == 0 {
+		return 0, nil
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	insertQuery := `
+		INSERT INTO "zpMessageReceipts" ("messageId", "sessionId", "msgId", "recipientJid", status, timestamp)
+		SELECT id, "sessionId", "msgId", $3, $4, $5
+		FROM "zpMessages"
+		WHERE "sessionId" = $1 AND "msgId" = ANY($2) AND "isFromMe" = TRUE
+		ON CONFLICT ("messageId", "recipientJid", status) DO NOTHING`
+
+	if _, err := tx.ExecContext(ctx, insertQuery, sessionID, pq.StringArray(msgIDs), recipientJid, status, timestamp); err != nil {
+		return 0, fmt.Errorf("failed to insert message receipts: %w", err)
+	}
+
+	updated, err := advanceMessageStatus(ctx, tx, sessionID, msgIDs, status)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return updated, nil
+}
+
+// AdvanceStatus avança zpMessages.status sem gravar recibos (ex.: leitura feita em outro dispositivo)
+func (r *MessageReceiptRepository) AdvanceStatus(ctx context.Context, sessionID string, msgIDs []string, status string) (int64, error) {
+	if len(msgIDs) == 0 {
+		return 0, nil
+	}
+	return advanceMessageStatus(ctx, r.db, sessionID, msgIDs, status)
+}
+
+// ListByMessageID busca a linha do tempo de recibos de uma mensagem
+func (r *MessageReceiptRepository) ListByMessageID(ctx context.Context, messageID string) ([]*models.MessageReceiptModel, error) {
+	var receipts []*models.MessageReceiptModel
+	query := `
+		SELECT id, "messageId", "sessionId", "msgId", "recipientJid", status, timestamp, "createdAt"
+		FROM "zpMessageReceipts"
+		WHERE "messageId" = $1
+		ORDER BY timestamp ASC, array_position($2::text[], status) ASC`
+
+	err := r.db.SelectContext(ctx, &receipts, query, messageID, messageStatusOrder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list message receipts: %w", err)
+	}
+
+	return receipts, nil
+}
+
+func advanceMessageStatus(ctx context.Context, db sqlx.ExecerContext, sessionID string, msgIDs []string, status string) (int64, error) {
+	var query string
+	args := []interface{}{sessionID, pq.StringArray(msgIDs), status}
+
+	if status == models.MessageStatusFailed {
+		query = `
+			UPDATE "zpMessages" SET status = $3, "updatedAt" = CURRENT_TIMESTAMP
+			WHERE "sessionId" = $1 AND "msgId" = ANY($2) AND status IN ('pending', 'sent')`
+	} else {
+		query = `
+			UPDATE "zpMessages" SET status = $3, "updatedAt" = CURRENT_TIMESTAMP
+			WHERE "sessionId" = $1 AND "msgId" = ANY($2)
+			  AND COALESCE(array_position($4::text[], status), 0) < array_position($4::text[], $3::text)`
+		args = append(args, messageStatusOrder)
+	}
+
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update message status: %w", err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// MessageCursor aponta para a última mensagem de uma página ordenada por (timestamp, id) decrescente
+type MessageCursor struct {
+	Timestamp time.Time
+	ID        string
+}
+
+// EncodeMessageCursor gera o cursor opaco da mensagem informada
+func EncodeMessageCursor(timestamp time.Time, id string) string {
+	raw := strconv.FormatInt(timestamp.UnixNano(), 10) + ":" + id
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeMessageCursor interpreta um cursor gerado por EncodeMessageCursor
+func DecodeMessageCursor(cursor string) (*MessageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	unixNano, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	return &MessageCursor{Timestamp: time.Unix(0, unixNano), ID: id}, nil
+}
+
+// MessageSearchFilter define a busca textual e os filtros opcionais
+type MessageSearchFilter struct {
+	SessionID string
+	Query     string     // sintaxe de websearch_to_tsquery: "frase exata", OR, -excluir
+	ChatJID   string     // JID do chat
+	Sender    string     // número (parte do JID antes de @ e do device)
+	Types     []string   // msgType
+	From      *time.Time // inclusive
+	To        *time.Time // exclusivo
+	IsFromMe  *bool
+	Cursor    *MessageCursor
+	Limit     int
+}
+
+// MessageSearchHit é uma mensagem encontrada, com o trecho destacado e a relevância
+type MessageSearchHit struct {
+	ID         string    `db:"id"`
+	ChatJid    string    `db:"chatJid"`
+	MsgId      string    `db:"msgId"`
+	MsgType    string    `db:"msgType"`
+	Content    *string   `db:"content"`
+	SenderJid  string    `db:"senderJid"`
+	SenderName *string   `db:"senderName"`
+	IsFromMe   bool      `db:"isFromMe"`
+	Timestamp  time.Time `db:"timestamp"`
+	Snippet    string    `db:"snippet"`
+	Rank       float64   `db:"rank"`
+}
+
+// SearchMessages faz a busca textual nas mensagens da sessão, das mais recentes para as mais antigas.
+// Retorna o cursor da próxima página, vazio quando não há mais resultados.
+func (r *MessageRepository) SearchMessages(ctx context.Context, filter MessageSearchFilter) ([]*MessageSearchHit, string, error) {
+	conditions := []string{`m."sessionId" = $1`, `m."searchVector" @@ q.query`, `NOT coalesce(m."isDeleted", false)`}
+	args := []interface{}{filter.SessionID, filter.Query}
+
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, strings.ReplaceAll(condition, "?", fmt.Sprintf("$%d", len(args))))
+	}
+
+	if filter.ChatJID != "" {
+		addCondition(`c."chatJid" = ?`, filter.ChatJID)
+	}
+	if filter.Sender != "" {
+		addCondition(`split_part(split_part(m."senderJid", '@', 1), ':', 1) = ?`, filter.Sender)
+	}
+	if len(filter.Types) > 0 {
+		addCondition(`m."msgType" = ANY(?)`, pq.StringArray(filter.Types))
+	}
+	if filter.From != nil {
+		addCondition(`m.timestamp >= ?`, *filter.From)
+	}
+	if filter.To != nil {
+		addCondition(`m.timestamp < ?`, *filter.To)
+	}
+	if filter.IsFromMe != nil {
+		addCondition(`m."isFromMe" = ?`, *filter.IsFromMe)
+	}
+	if filter.Cursor != nil {
+		args = append(args, filter.Cursor.Timestamp, filter.Cursor.ID)
+		conditions = append(conditions, fmt.Sprintf(`(m.timestamp, m.id) < ($%d, $%d::uuid)`, len(args)-1, len(args)))
+	}
+
+	// Busca um item a mais para saber se existe próxima página
+	args = append(args, filter.Limit+1)
+	query := `
+		SELECT m.id, c."chatJid", m."msgId", m."msgType", m.content, m."senderJid", m."senderName", m."isFromMe", m.timestamp,
+			ts_headline('zpmeow_search', concat_ws(' ', m.content, m."mediaInfo"->>'caption', m."mediaInfo"->>'fileName'), q.query,
+				'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5, FragmentDelimiter=" … "') AS snippet,
+			ts_rank(m."searchVector", q.query) AS rank
+		FROM "zpMessages" m
+		JOIN "zpChats" c ON c.id = m."chatId"
+		CROSS JOIN websearch_to_tsquery('zpmeow_search', $2) AS q(query)
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY m.timestamp DESC, m.id DESC
+		LIMIT $` + strconv.Itoa(len(args))
+
+	var hits []*MessageSearchHit
+	if err := r.db.SelectContext(ctx, &hits, query, args...); err != nil {
+		return nil, "", fmt.Errorf("failed to search messages: %w", err)
+	}
+
+	nextCursor := ""
+	if len(hits) > filter.Limit {
+		hits = hits[:filter.Limit]
+		last := hits[len(hits)-1]
+		nextCursor = EncodeMessageCursor(last.Timestamp, last.ID)
+	}
+
+	return hits, nextCursor, nil
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+func TestMessageCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name      string
+		timestamp time.Time
+		id        string
+	}{
+		{name: "nanoseconds", timestamp: time.Date(2024, 5, 10, 12, 30, 45, 123456789, time.UTC), id: "3f2b8c1e-6d4a-4f5b-9c7e-2a1d0e9f8b7c"},
+		{name: "other timezone", timestamp: time.Date(2024, 5, 10, 9, 30, 0, 0, time.FixedZone("BRT", -3*3600)), id: "00000000-0000-0000-0000-000000000001"},
+		{name: "before epoch", timestamp: time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC), id: "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor := EncodeMessageCursor(tt.timestamp, tt.id)
+			got, err := DecodeMessageCursor(cursor)
+			if err != nil {
+				t.Fatalf("DecodeMessageCursor(%q) error = %v", cursor, err)
+			}
+			if !got.Timestamp.Equal(tt.timestamp) {
+				t.Errorf("Timestamp = %s, want %s", got.Timestamp, tt.timestamp)
+			}
+			if got.ID != tt.id {
+				t.Errorf("ID = %q, want %q", got.ID, tt.id)
+			}
+		})
+	}
+}
+
+func TestDecodeMessageCursorInvalid(t *testing.T) {
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "empty", cursor: ""},
+		{name: "not base64", cursor: "!!!"},
+		{name: "padded base64", cursor: base64.URLEncoding.EncodeToString([]byte("1:3f2b8c1e-6d4a-4f5b-9c7e-2a1d0e9f8b7c"))},
+		{name: "missing separator", cursor: encode("1715344245000000000")},
+		{name: "id is not a uuid", cursor: encode("1715344245000000000:abc")},
+		{name: "timestamp is not a number", cursor: encode("yesterday:3f2b8c1e-6d4a-4f5b-9c7e-2a1d0e9f8b7c")},
+		{name: "timestamp overflows", cursor: encode("99999999999999999999:3f2b8c1e-6d4a-4f5b-9c7e-2a1d0e9f8b7c")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := DecodeMessageCursor(tt.cursor); err == nil {
+				t.Errorf("DecodeMessageCursor(%q) = %+v, want error", tt.cursor, got)
+			}
+		})
+	}
+}
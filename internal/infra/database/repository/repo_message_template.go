@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"zpmeow/internal/infra/database/models"
+)
+
+const messageTemplateColumns = `id, "sessionId", name, "messageType", content, variables, "createdAt", "updatedAt"`
+
+type MessageTemplateRepository struct {
+	db *sqlx.DB
+}
+
+func NewMessageTemplateRepository(db *sqlx.DB) *MessageTemplateRepository {
+	return &MessageTemplateRepository{db: db}
+}
+
+// Create insere um novo template
+func (r *MessageTemplateRepository) Create(ctx context.Context, template *models.MessageTemplateModel) error {
+	query := `
+		INSERT INTO "zpMessageTemplates" ("sessionId", name, "messageType", content, variables)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, "createdAt", "updatedAt"`
+
+	err := r.db.QueryRowContext(ctx, query,
+		template.SessionId, template.Name, template.MessageType, string(template.Content), template.Variables,
+	).Scan(&template.ID, &template.CreatedAt, &template.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create message template: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID busca um template por ID dentro da sessão
+func (r *MessageTemplateRepository) GetByID(ctx context.Context, sessionID, id string) (*models.MessageTemplateModel, error) {
+	return r.get(ctx, `"sessionId" = $1 AND id = $2`, sessionID, id)
+}
+
+// GetByName busca um template pelo nome dentro da sessão
+func (r *MessageTemplateRepository) GetByName(ctx context.Context, sessionID, name string) (*models.MessageTemplateModel, error) {
+	return r.get(ctx, `"sessionId" = $1 AND name = $2`, sessionID, name)
+}
+
+func (r *MessageTemplateRepository) get(ctx context.Context, where string, args ...interface{}) (*models.MessageTemplateModel, error) {
+	var template models.MessageTemplateModel
+	query := `SELECT ` + messageTemplateColumns + ` FROM "zpMessageTemplates" WHERE ` + where
+
+	err := r.db.GetContext(ctx, &template, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Não encontrado
+		}
+		return nil, fmt.Errorf("failed to get message template: %w", err)
+	}
+
+	return &template, nil
+}
+
+// List lista os templates da sessão por nome, opcionalmente filtrados por tipo
+func (r *MessageTemplateRepository) List(ctx context.Context, sessionID, messageType string, limit, offset int) ([]*models.MessageTemplateModel, int, error) {
+	var templates []*models.MessageTemplateModel
+	query := `
+		SELECT ` + messageTemplateColumns + `
+		FROM "zpMessageTemplates"
+		WHERE "sessionId" = $1 AND ($2 = '' OR "messageType" = $2)
+		ORDER BY name
+		LIMIT $3 OFFSET $4`
+
+	if err := r.db.SelectContext(ctx, &templates, query, sessionID, messageType, limit, offset); err != nil {
+		return nil, 0, fmt.Errorf("failed to list message templates: %w", err)
+	}
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM "zpMessageTemplates" WHERE "sessionId" = $1 AND ($2 = '' OR "messageType" = $2)`
+	if err := r.db.GetContext(ctx, &total, countQuery, sessionID, messageType); err != nil {
+		return nil, 0, fmt.Errorf("failed to count message templates: %w", err)
+	}
+
+	return templates, total, nil
+}
+
+// Update substitui nome, tipo e conteúdo de um template; retorna nil se não existir
+func (r *MessageTemplateRepository) Update(ctx context.Context, template *models.MessageTemplateModel) (*models.MessageTemplateModel, error) {
+	var updated models.MessageTemplateModel
+	query := `
+		UPDATE "zpMessageTemplates"
+		SET name = $3, "messageType" = $4, content = $5, variables = $6
+		WHERE "sessionId" = $1 AND id = $2
+		RETURNING ` + messageTemplateColumns
+
+	err := r.db.GetContext(ctx, &updated, query,
+		template.SessionId, template.ID, template.Name, template.MessageType, string(template.Content), template.Variables)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to update message template: %w", err)
+	}
+
+	return &updated, nil
+}
+
+// Delete remove um template; retorna false se não existir
+func (r *MessageTemplateRepository) Delete(ctx context.Context, sessionID, id string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM "zpMessageTemplates" WHERE "sessionId" = $1 AND id = $2`, sessionID, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete message template: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete message template: %w", err)
+	}
+
+	return affected > 0, nil
+}
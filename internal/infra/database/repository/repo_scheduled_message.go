@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"zpmeow/internal/infra/database/models"
+)
+
+const scheduledMessageColumns = `id, "sessionId", phone, "messageType", payload, "sendAt", timezone, status, attempts,
+	"nextAttemptAt", "lockedAt", "lastError", "messageId", "sentAt", "createdAt", "updatedAt"`
+
+type ScheduledMessageRepository struct {
+	db *sqlx.DB
+}
+
+func NewScheduledMessageRepository(db *sqlx.DB) *ScheduledMessageRepository {
+	return &ScheduledMessageRepository{db: db}
+}
+
+// Create insere uma nova mensagem agendada
+func (r *ScheduledMessageRepository) Create(ctx context.Context, message *models.ScheduledMessageModel) error {
+	query := `
+		INSERT INTO "zpScheduledMessages" (
+			"sessionId", phone, "messageType", payload, "sendAt", timezone, status, attempts, "nextAttemptAt"
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, 'scheduled', 0, $5
+		) RETURNING id, status, attempts, "nextAttemptAt", "createdAt", "updatedAt"`
+
+	err := r.db.QueryRowContext(ctx, query,
+		message.SessionId, message.Phone, message.MessageType, string(message.Payload), message.SendAt, message.Timezone,
+	).Scan(&message.ID, &message.Status, &message.Attempts, &message.NextAttemptAt, &message.CreatedAt, &message.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create scheduled message: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID busca uma mensagem agendada por ID dentro da sessão
+func (r *ScheduledMessageRepository) GetByID(ctx context.Context, sessionID, id string) (*models.ScheduledMessageModel, error) {
+	var message models.ScheduledMessageModel
+	query := `
+		SELECT ` + scheduledMessageColumns + `
+		FROM "zpScheduledMessages"
+		WHERE "sessionId" = $1 AND id = $2`
+
+	err := r.db.GetContext(ctx, &message, query, sessionID, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Não encontrado
+		}
+		return nil, fmt.Errorf("failed to get scheduled message: %w", err)
+	}
+
+	return &message, nil
+}
+
+// List lista as mensagens agendadas de uma sessão, opcionalmente filtradas por status
+func (r *ScheduledMessageRepository) List(ctx context.Context, sessionID, status string, limit, offset int) ([]*models.ScheduledMessageModel, int, error) {
+	var messages []*models.ScheduledMessageModel
+	query := `
+		SELECT ` + scheduledMessageColumns + `
+		FROM "zpScheduledMessages"
+		WHERE "sessionId" = $1 AND ($2 = '' OR status = $2)
+		ORDER BY "sendAt"
+		LIMIT $3 OFFSET $4`
+
+	if err := r.db.SelectContext(ctx, &messages, query, sessionID, status, limit, offset); err != nil {
+		return nil, 0, fmt.Errorf("failed to list scheduled messages: %w", err)
+	}
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM "zpScheduledMessages" WHERE "sessionId" = $1 AND ($2 = '' OR status = $2)`
+	if err := r.db.GetContext(ctx, &total, countQuery, sessionID, status); err != nil {
+		return nil, 0, fmt.Errorf("failed to count scheduled messages: %w", err)
+	}
+
+	return messages, total, nil
+}
+
+// Cancel cancela uma mensagem que ainda não foi enviada; retorna nil se não existir ou não puder mais ser cancelada
+func (r *ScheduledMessageRepository) Cancel(ctx context.Context, sessionID, id string) (*models.ScheduledMessageModel, error) {
+	var message models.ScheduledMessageModel
+	query := `
+		UPDATE "zpScheduledMessages"
+		SET status = 'canceled', "lockedAt" = NULL
+		WHERE "sessionId" = $1 AND id = $2 AND status IN ('scheduled', 'waiting_session')
+		RETURNING ` + scheduledMessageColumns
+
+	err := r.db.GetContext(ctx, &message, query, sessionID, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to cancel scheduled message: %w", err)
+	}
+
+	return &message, nil
+}
+
+// Reschedule altera o horário de envio de uma mensagem que ainda não foi enviada, zerando as tentativas
+func (r *ScheduledMessageRepository) Reschedule(ctx context.Context, sessionID, id string, sendAt time.Time, timezone string) (*models.ScheduledMessageModel, error) {
+	var message models.ScheduledMessageModel
+	query := `
+		UPDATE "zpScheduledMessages"
+		SET "sendAt" = $3, "nextAttemptAt" = $3, timezone = $4, status = 'scheduled', attempts = 0,
+			"lockedAt" = NULL, "lastError" = NULL
+		WHERE "sessionId" = $1 AND id = $2 AND status IN ('scheduled', 'waiting_session')
+		RETURNING ` + scheduledMessageColumns
+
+	err := r.db.GetContext(ctx, &message, query, sessionID, id, sendAt, timezone)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to reschedule message: %w", err)
+	}
+
+	return &message, nil
+}
+
+// ClaimDue reserva até limit mensagens vencidas para envio.
+// FOR UPDATE SKIP LOCKED permite várias instâncias processando a mesma tabela.
+func (r *ScheduledMessageRepository) ClaimDue(ctx context.Context, limit int) ([]*models.ScheduledMessageModel, error) {
+	var messages []*models.ScheduledMessageModel
+	query := `
+		UPDATE "zpScheduledMessages"
+		SET status = 'processing', "lockedAt" = CURRENT_TIMESTAMP
+		WHERE id IN (
+			SELECT id FROM "zpScheduledMessages"
+			WHERE status = 'scheduled' AND "nextAttemptAt" <= CURRENT_TIMESTAMP
+			ORDER BY "nextAttemptAt"
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + scheduledMessageColumns
+
+	if err := r.db.SelectContext(ctx, &messages, query, limit); err != nil {
+		return nil, fmt.Errorf("failed to claim scheduled messages: %w", err)
+	}
+
+	return messages, nil
+}
+
+// ReleaseStale devolve para a fila mensagens presas em processing (ex.: processo reiniciado)
+func (r *ScheduledMessageRepository) ReleaseStale(ctx context.Context, olderThan time.Duration) (int64, error) {
+	query := `
+		UPDATE "zpScheduledMessages"
+		SET status = 'scheduled', "lockedAt" = NULL
+		WHERE status = 'processing' AND "lockedAt" < $1`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now().Add(-olderThan))
+	if err != nil {
+		return 0, fmt.Errorf("failed to release stale scheduled messages: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// MarkSent registra o envio com o ID da mensagem no WhatsApp
+func (r *ScheduledMessageRepository) MarkSent(ctx context.Context, id string, attempts int, messageID string) error {
+	query := `
+		UPDATE "zpScheduledMessages"
+		SET status = 'sent', attempts = $2, "messageId" = $3, "sentAt" = CURRENT_TIMESTAMP,
+			"lockedAt" = NULL, "lastError" = NULL
+		WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id, attempts, messageID); err != nil {
+		return fmt.Errorf("failed to mark scheduled message as sent: %w", err)
+	}
+
+	return nil
+}
+
+// MarkFailed registra a falha definitiva do envio
+func (r *ScheduledMessageRepository) MarkFailed(ctx context.Context, id string, attempts int, lastError string) error {
+	query := `
+		UPDATE "zpScheduledMessages"
+		SET status = 'failed', attempts = $2, "lastError" = $3, "lockedAt" = NULL
+		WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id, attempts, lastError); err != nil {
+		return fmt.Errorf("failed to mark scheduled message as failed: %w", err)
+	}
+
+	return nil
+}
+
+// ScheduleRetry registra a falha e agenda a próxima tentativa
+func (r *ScheduledMessageRepository) ScheduleRetry(ctx context.Context, id string, attempts int, nextAttemptAt time.Time, lastError string) error {
+	query := `
+		UPDATE "zpScheduledMessages"
+		SET status = 'scheduled', attempts = $2, "nextAttemptAt" = $3, "lastError" = $4, "lockedAt" = NULL
+		WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id, attempts, nextAttemptAt, lastError); err != nil {
+		return fmt.Errorf("failed to schedule retry for scheduled message: %w", err)
+	}
+
+	return nil
+}
+
+// MarkWaitingSession estaciona a mensagem até a sessão reconectar, sem consumir tentativas
+func (r *ScheduledMessageRepository) MarkWaitingSession(ctx context.Context, id, lastError string) error {
+	query := `
+		UPDATE "zpScheduledMessages"
+		SET status = 'waiting_session', "lastError" = $2, "lockedAt" = NULL
+		WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id, lastError); err != nil {
+		return fmt.Errorf("failed to mark scheduled message as waiting for session: %w", err)
+	}
+
+	return nil
+}
+
+// ListWaitingSessionIDs retorna as sessões com mensagens aguardando reconexão
+func (r *ScheduledMessageRepository) ListWaitingSessionIDs(ctx context.Context) ([]string, error) {
+	var sessionIDs []string
+	query := `SELECT DISTINCT "sessionId" FROM "zpScheduledMessages" WHERE status = 'waiting_session'`
+
+	if err := r.db.SelectContext(ctx, &sessionIDs, query); err != nil {
+		return nil, fmt.Errorf("failed to list sessions with waiting scheduled messages: %w", err)
+	}
+
+	return sessionIDs, nil
+}
+
+// RequeueWaiting devolve para a fila as mensagens de uma sessão que aguardavam reconexão
+func (r *ScheduledMessageRepository) RequeueWaiting(ctx context.Context, sessionID string) (int64, error) {
+	query := `
+		UPDATE "zpScheduledMessages"
+		SET status = 'scheduled', "nextAttemptAt" = CURRENT_TIMESTAMP
+		WHERE "sessionId" = $1 AND status = 'waiting_session'`
+
+	result, err := r.db.ExecContext(ctx, query, sessionID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to requeue waiting scheduled messages: %w", err)
+	}
+
+	return result.RowsAffected()
+}
@@ -2,10 +2,8 @@ package repository
 
 import (
 	"context"
-	"crypto/rand"
 	"database/sql"
 	"fmt"
-	"math/big"
 	"strings"
 	"time"
 
@@ -39,32 +37,39 @@ func (r *PostgresRepo) CreateWithGeneratedID(ctx context.Context, sessionEntity
 		updatedAt = sessionEntity.UpdatedAt().Value()
 	}
 
-	query := `
-		INSERT INTO "zpSessions" (name, "deviceJid", status, "qrCode", "proxyUrl", connected, "apiKey", "createdAt", "updatedAt")
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-		RETURNING id
-	`
-
 	isConnected := sessionEntity.Status() == session.StatusConnected && sessionEntity.DeviceJID().Value() != ""
 
 	apiKey := sessionEntity.ApiKey().Value()
 	if apiKey == "" || apiKey == "temp-key" {
 		var err error
-		apiKey, err = r.generateAPIKey()
+		apiKey, err = generateSessionAPIKey()
 		if err != nil {
-			return "", fmt.Errorf("failed to generate API key: %w", err)
+			return "", err
 		}
 	}
 
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to begin session transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	query := `
+		INSERT INTO "zpSessions" (name, "deviceJid", status, "qrCode", "proxyUrl", connected, "createdAt", "updatedAt")
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id
+	`
+
 	var generatedID string
-	err := r.db.QueryRowContext(ctx, query,
+	err = tx.QueryRowContext(ctx, query,
 		sessionEntity.Name().Value(),
 		sessionEntity.DeviceJID().Value(),
 		string(sessionEntity.Status()),
 		sessionEntity.QRCode().Value(),
 		sessionEntity.ProxyConfiguration().Value(),
 		isConnected,
-		apiKey,
 		createdAt,
 		updatedAt,
 	).Scan(&generatedID)
@@ -76,6 +81,20 @@ func (r *PostgresRepo) CreateWithGeneratedID(ctx context.Context, sessionEntity
 		return "", fmt.Errorf("failed to create session: %w", err)
 	}
 
+	// A chave default da sessão fica em zpApiKeys, só com o hash, e tem todos os escopos
+	if _, err := insertAPIKey(ctx, tx, apiKey, generatedID, DefaultAPIKeyName, []string{"*"}, nil, nil); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("failed to commit session creation: %w", err)
+	}
+
+	// A chave só pode ser devolvida agora, na criação
+	if err := sessionEntity.SetApiKey(apiKey); err != nil {
+		return "", fmt.Errorf("failed to set API key: %w", err)
+	}
+
 	return generatedID, nil
 }
 
@@ -97,7 +116,7 @@ func (r *PostgresRepo) Exists(ctx context.Context, name string) (bool, error) {
 func (r *PostgresRepo) GetByID(ctx context.Context, id string) (*session.Session, error) {
 	var model models.SessionModel
 	query := `
-		SELECT id, name, "deviceJid", status, "qrCode", "proxyUrl", connected, "apiKey", "createdAt", "updatedAt"
+		SELECT id, name, "deviceJid", status, "qrCode", "proxyUrl", connected, "createdAt", "updatedAt"
 		FROM "zpSessions" WHERE id = $1
 	`
 
@@ -115,7 +134,7 @@ func (r *PostgresRepo) GetByID(ctx context.Context, id string) (*session.Session
 func (r *PostgresRepo) GetByName(ctx context.Context, name string) (*session.Session, error) {
 	var model models.SessionModel
 	query := `
-		SELECT id, name, "deviceJid", status, "qrCode", "proxyUrl", connected, "apiKey", "createdAt", "updatedAt"
+		SELECT id, name, "deviceJid", status, "qrCode", "proxyUrl", connected, "createdAt", "updatedAt"
 		FROM "zpSessions" WHERE name = $1
 	`
 
@@ -133,7 +152,7 @@ func (r *PostgresRepo) GetByName(ctx context.Context, name string) (*session.Ses
 func (r *PostgresRepo) GetAll(ctx context.Context) ([]*session.Session, error) {
 	var sessionModels []models.SessionModel
 	query := `
-		SELECT id, name, "deviceJid", status, "qrCode", "proxyUrl", connected, "apiKey", "createdAt", "updatedAt"
+		SELECT id, name, "deviceJid", status, "qrCode", "proxyUrl", connected, "createdAt", "updatedAt"
 		FROM "zpSessions" ORDER BY "createdAt" DESC
 	`
 
@@ -161,7 +180,7 @@ func (r *PostgresRepo) Update(ctx context.Context, sessionEntity *session.Sessio
 	query := `
 		UPDATE "zpSessions"
 		SET name = $2, "deviceJid" = $3, status = $4, "qrCode" = $5, "proxyUrl" = $6,
-		    connected = $7, "apiKey" = $8, "updatedAt" = $9
+		    connected = $7, "updatedAt" = $8
 		WHERE id = $1
 	`
 
@@ -175,7 +194,6 @@ func (r *PostgresRepo) Update(ctx context.Context, sessionEntity *session.Sessio
 		sessionEntity.QRCode().Value(),
 		sessionEntity.ProxyConfiguration().Value(),
 		isConnected,
-		sessionEntity.ApiKey().Value(),
 		updatedAt,
 	)
 
@@ -238,7 +256,7 @@ func (r *PostgresRepo) List(ctx context.Context, limit, offset int, status strin
 	}
 
 	query := `
-		SELECT id, name, "deviceJid", status, "qrCode", "proxyUrl", connected, "apiKey", "createdAt", "updatedAt"
+		SELECT id, name, "deviceJid", status, "qrCode", "proxyUrl", connected, "createdAt", "updatedAt"
 		FROM "zpSessions"
 	`
 
@@ -271,7 +289,7 @@ func (r *PostgresRepo) List(ctx context.Context, limit, offset int, status strin
 func (r *PostgresRepo) GetActive(ctx context.Context) ([]*session.Session, error) {
 	var sessionModels []models.SessionModel
 	query := `
-		SELECT id, name, "deviceJid", status, "qrCode", "proxyUrl", connected, "apiKey", "createdAt", "updatedAt"
+		SELECT id, name, "deviceJid", status, "qrCode", "proxyUrl", connected, "createdAt", "updatedAt"
 		FROM "zpSessions" WHERE "deviceJid" IS NOT NULL AND "deviceJid" != '' ORDER BY "createdAt" DESC
 	`
 
@@ -295,7 +313,7 @@ func (r *PostgresRepo) GetActive(ctx context.Context) ([]*session.Session, error
 func (r *PostgresRepo) GetInactive(ctx context.Context) ([]*session.Session, error) {
 	var sessionModels []models.SessionModel
 	query := `
-		SELECT id, name, "deviceJid", status, "qrCode", "proxyUrl", connected, "apiKey", "createdAt", "updatedAt"
+		SELECT id, name, "deviceJid", status, "qrCode", "proxyUrl", connected, "createdAt", "updatedAt"
 		FROM "zpSessions" WHERE status != $1 ORDER BY "createdAt" DESC
 	`
 
@@ -316,14 +334,18 @@ func (r *PostgresRepo) GetInactive(ctx context.Context) ([]*session.Session, err
 	return sessions, nil
 }
 
+// GetByApiKey busca a sessão de uma chave ativa de zpApiKeys
 func (r *PostgresRepo) GetByApiKey(ctx context.Context, apiKey string) (*session.Session, error) {
 	var model models.SessionModel
 	query := `
-		SELECT id, name, "deviceJid", status, "qrCode", "proxyUrl", connected, "apiKey", "createdAt", "updatedAt"
-		FROM "zpSessions" WHERE "apiKey" = $1
+		SELECT s.id, s.name, s."deviceJid", s.status, s."qrCode", s."proxyUrl", s.connected, s."createdAt", s."updatedAt"
+		FROM "zpSessions" s
+		JOIN "zpApiKeys" k ON k."sessionId" = s.id
+		WHERE k."keyHash" = $1 AND k."revokedAt" IS NULL
+			AND (k."expiresAt" IS NULL OR k."expiresAt" > CURRENT_TIMESTAMP)
 	`
 
-	err := r.db.GetContext(ctx, &model, query, apiKey)
+	err := r.db.GetContext(ctx, &model, query, HashAPIKey(apiKey))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("session not found")
@@ -341,7 +363,7 @@ func (r *PostgresRepo) GetByDeviceJID(ctx context.Context, deviceJID string) (*s
 
 	var model models.SessionModel
 	query := `
-		SELECT id, name, "deviceJid", status, "qrCode", "proxyUrl", connected, "apiKey", "createdAt", "updatedAt"
+		SELECT id, name, "deviceJid", status, "qrCode", "proxyUrl", connected, "createdAt", "updatedAt"
 		FROM "zpSessions" WHERE "deviceJid" = $1
 	`
 
@@ -405,17 +427,16 @@ func (b *SessionBuilder) buildValueObjects() (session.SessionID, session.Session
 }
 
 // buildOptionalFields cria os campos opcionais
-func (b *SessionBuilder) buildOptionalFields() (session.ProxyConfiguration, session.DeviceJID, session.QRCode, session.ApiKey, error) {
+func (b *SessionBuilder) buildOptionalFields() (session.ProxyConfiguration, session.DeviceJID, session.QRCode, error) {
 	var proxyURL session.ProxyConfiguration
 	var deviceJID session.DeviceJID
 	var qrCode session.QRCode
-	var apiKey session.ApiKey
 
 	// Proxy URL
 	if b.model.ProxyUrl != "" {
 		proxy, err := session.NewProxyConfiguration(b.model.ProxyUrl)
 		if err != nil {
-			return proxyURL, deviceJID, qrCode, apiKey, fmt.Errorf("failed to create proxy configuration: %w", err)
+			return proxyURL, deviceJID, qrCode, fmt.Errorf("failed to create proxy configuration: %w", err)
 		}
 		proxyURL = proxy
 	}
@@ -424,7 +445,7 @@ func (b *SessionBuilder) buildOptionalFields() (session.ProxyConfiguration, sess
 	if b.model.DeviceJid != "" {
 		jid, err := session.NewDeviceJID(b.model.DeviceJid)
 		if err != nil {
-			return proxyURL, deviceJID, qrCode, apiKey, fmt.Errorf("failed to create device JID: %w", err)
+			return proxyURL, deviceJID, qrCode, fmt.Errorf("failed to create device JID: %w", err)
 		}
 		deviceJID = jid
 	}
@@ -433,21 +454,12 @@ func (b *SessionBuilder) buildOptionalFields() (session.ProxyConfiguration, sess
 	if b.model.QrCode != "" {
 		qr, err := session.NewQRCode(b.model.QrCode)
 		if err != nil {
-			return proxyURL, deviceJID, qrCode, apiKey, fmt.Errorf("failed to create QR code: %w", err)
+			return proxyURL, deviceJID, qrCode, fmt.Errorf("failed to create QR code: %w", err)
 		}
 		qrCode = qr
 	}
 
-	// API Key
-	if b.model.ApiKey != "" {
-		key, err := session.NewApiKey(b.model.ApiKey)
-		if err != nil {
-			return proxyURL, deviceJID, qrCode, apiKey, fmt.Errorf("failed to create API key: %w", err)
-		}
-		apiKey = key
-	}
-
-	return proxyURL, deviceJID, qrCode, apiKey, nil
+	return proxyURL, deviceJID, qrCode, nil
 }
 
 // configureStatus configura o status da sessão
@@ -473,7 +485,7 @@ func (b *SessionBuilder) configureStatus(sessionEntity *session.Session) error {
 }
 
 // configureOptionalProperties configura propriedades opcionais
-func (b *SessionBuilder) configureOptionalProperties(sessionEntity *session.Session, proxyURL session.ProxyConfiguration, deviceJID session.DeviceJID, qrCode session.QRCode, apiKey session.ApiKey) error {
+func (b *SessionBuilder) configureOptionalProperties(sessionEntity *session.Session, proxyURL session.ProxyConfiguration, deviceJID session.DeviceJID, qrCode session.QRCode) error {
 	if !proxyURL.IsEmpty() {
 		if err := sessionEntity.SetProxyConfiguration(proxyURL.Value()); err != nil {
 			return fmt.Errorf("failed to set proxy URL: %w", err)
@@ -492,12 +504,6 @@ func (b *SessionBuilder) configureOptionalProperties(sessionEntity *session.Sess
 		}
 	}
 
-	if !apiKey.IsEmpty() {
-		if err := sessionEntity.SetApiKey(apiKey.Value()); err != nil {
-			return fmt.Errorf("failed to set API key: %w", err)
-		}
-	}
-
 	return nil
 }
 
@@ -511,7 +517,7 @@ func (r *PostgresRepo) modelToDomain(model *models.SessionModel) (*session.Sessi
 	}
 
 	// Cria campos opcionais
-	proxyURL, deviceJID, qrCode, apiKey, err := builder.buildOptionalFields()
+	proxyURL, deviceJID, qrCode, err := builder.buildOptionalFields()
 	if err != nil {
 		return nil, err
 	}
@@ -528,24 +534,9 @@ func (r *PostgresRepo) modelToDomain(model *models.SessionModel) (*session.Sessi
 	}
 
 	// Configura propriedades opcionais
-	if err := builder.configureOptionalProperties(sessionEntity, proxyURL, deviceJID, qrCode, apiKey); err != nil {
+	if err := builder.configureOptionalProperties(sessionEntity, proxyURL, deviceJID, qrCode); err != nil {
 		return nil, err
 	}
 
 	return sessionEntity, nil
 }
-
-func (r *PostgresRepo) generateAPIKey() (string, error) {
-	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
-	const keyLength = 32
-
-	b := make([]byte, keyLength)
-	for i := range b {
-		randomIndex, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
-		if err != nil {
-			return "", fmt.Errorf("failed to generate random number: %w", err)
-		}
-		b[i] = charset[randomIndex.Int64()]
-	}
-	return string(b), nil
-}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"zpmeow/internal/infra/database/models"
+)
+
+const statusColumns = `id, "sessionId", "statusId", "senderJid", "senderName", type, content, "mediaInfo",
+	"backgroundColor", "textColor", font, "isFromMe", "postedAt", "expiresAt", "revokedAt", "createdAt"`
+
+type StatusRepository struct {
+	db *sqlx.DB
+}
+
+func NewStatusRepository(db *sqlx.DB) *StatusRepository {
+	return &StatusRepository{db: db}
+}
+
+// Create registra um status; retorna false se ele já estava registrado (reenvios do WhatsApp)
+func (r *StatusRepository) Create(ctx context.Context, status *models.StatusModel) (bool, error) {
+	if status.MediaInfo == nil {
+		status.MediaInfo = models.JSONB{}
+	}
+
+	query := `
+		INSERT INTO "zpStatuses" (
+			"sessionId", "statusId", "senderJid", "senderName", type, content, "mediaInfo",
+			"backgroundColor", "textColor", font, "isFromMe", "postedAt", "expiresAt"
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+		)
+		ON CONFLICT ("sessionId", "senderJid", "statusId") DO NOTHING
+		RETURNING id, "createdAt"`
+
+	err := r.db.QueryRowContext(ctx, query,
+		status.SessionId, status.StatusId, status.SenderJid, status.SenderName, status.Type, status.Content,
+		status.MediaInfo, status.BackgroundColor, status.TextColor, status.Font, status.IsFromMe,
+		status.PostedAt, status.ExpiresAt,
+	).Scan(&status.ID, &status.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to create status: %w", err)
+	}
+
+	return true, nil
+}
+
+// List retorna os status da sessão do mais recente para o mais antigo. Sem senderJid, de todos os contatos;
+// sem includeExpired, só os que ainda estão visíveis no WhatsApp. Status apagados pelo autor não são retornados.
+func (r *StatusRepository) List(ctx context.Context, sessionID, senderJid string, includeExpired bool, limit, offset int) ([]*models.StatusModel, int, error) {
+	var statuses []*models.StatusModel
+	where := `"sessionId" = $1 AND ($2 = '' OR "senderJid" = $2) AND ($3 OR "expiresAt" > $4) AND "revokedAt" IS NULL`
+	query := `
+		SELECT ` + statusColumns + `
+		FROM "zpStatuses"
+		WHERE ` + where + `
+		ORDER BY "postedAt" DESC
+		LIMIT $5 OFFSET $6`
+
+	now := time.Now()
+	if err := r.db.SelectContext(ctx, &statuses, query, sessionID, senderJid, includeExpired, now, limit, offset); err != nil {
+		return nil, 0, fmt.Errorf("failed to list statuses: %w", err)
+	}
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM "zpStatuses" WHERE ` + where
+	if err := r.db.GetContext(ctx, &total, countQuery, sessionID, senderJid, includeExpired, now); err != nil {
+		return nil, 0, fmt.Errorf("failed to count statuses: %w", err)
+	}
+
+	return statuses, total, nil
+}
+
+// Revoke marca o status como apagado pelo autor; retorna false se ele não estava registrado
+func (r *StatusRepository) Revoke(ctx context.Context, sessionID, senderJid, statusID string, revokedAt time.Time) (bool, error) {
+	query := `
+		UPDATE "zpStatuses"
+		SET "revokedAt" = $4
+		WHERE "sessionId" = $1 AND "senderJid" = $2 AND "statusId" = $3 AND "revokedAt" IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, sessionID, senderJid, statusID, revokedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke status: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get revoked status count: %w", err)
+	}
+
+	return rows > 0, nil
+}
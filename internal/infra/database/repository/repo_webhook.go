@@ -2,15 +2,21 @@ package repository
 
 import (
 	"context"
+	"crypto/rand"
 	"database/sql"
+	"encoding/hex"
 	"fmt"
+	"time"
 
 	"github.com/jmoiron/sqlx"
-	"github.com/lib/pq"
 
 	"zpmeow/internal/infra/database/models"
 )
 
+const webhookColumns = `id, "sessionId", name, url, events, headers, "isActive", "isDefault", "payloadFormat", "createdAt", "updatedAt",
+	secret, "previousSecret", "previousSecretExpiresAt", "secretRotatedAt",
+	"deliveredCount", "failedCount", "lastDeliveryAt", "lastSuccessAt", "lastFailureAt", "lastError", "lastStatusCode"`
+
 type WebhookRepository struct {
 	db *sqlx.DB
 }
@@ -19,43 +25,64 @@ func NewWebhookRepository(db *sqlx.DB) *WebhookRepository {
 	return &WebhookRepository{db: db}
 }
 
-// Create cria uma nova configuração de webhook
+// Create cria um novo endpoint de webhook para a sessão
 func (r *WebhookRepository) Create(ctx context.Context, webhook *models.WebhookModel) error {
+	if webhook.Secret == "" {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			return err
+		}
+		webhook.Secret = secret
+	}
+
+	if webhook.PayloadFormat == "" {
+		webhook.PayloadFormat = models.WebhookPayloadFormatV1
+	}
+
 	query := `
 		INSERT INTO "zpWebhooks" (
-			"sessionId", url, events, "isActive"
+			"sessionId", name, url, events, headers, "isActive", "isDefault", secret, "payloadFormat"
 		) VALUES (
-			$1, $2, $3, $4
+			$1, $2, $3, $4, $5, $6, $7, $8, $9
 		) RETURNING id, "createdAt", "updatedAt"`
 
-	rows, err := r.db.QueryContext(ctx, query,
-		webhook.SessionId, webhook.URL, pq.Array(webhook.Events), webhook.IsActive)
+	err := r.db.QueryRowContext(ctx, query,
+		webhook.SessionId, webhook.Name, webhook.URL, webhook.Events, webhook.Headers,
+		webhook.IsActive, webhook.IsDefault, webhook.Secret, webhook.PayloadFormat,
+	).Scan(&webhook.ID, &webhook.CreatedAt, &webhook.UpdatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to create webhook config: %w", err)
 	}
-	defer func() {
-		if closeErr := rows.Close(); closeErr != nil {
-			fmt.Printf("Warning: failed to close rows in CreateWebhook: %v\n", closeErr)
-		}
-	}()
 
-	if rows.Next() {
-		err = rows.Scan(&webhook.ID, &webhook.CreatedAt, &webhook.UpdatedAt)
-		if err != nil {
-			return fmt.Errorf("failed to scan created webhook config: %w", err)
+	return nil
+}
+
+// GetByID busca um endpoint de webhook por ID
+func (r *WebhookRepository) GetByID(ctx context.Context, id string) (*models.WebhookModel, error) {
+	var webhook models.WebhookModel
+	query := `
+		SELECT ` + webhookColumns + `
+		FROM "zpWebhooks"
+		WHERE id = $1`
+
+	err := r.db.GetContext(ctx, &webhook, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Não encontrado
 		}
+		return nil, fmt.Errorf("failed to get webhook by ID: %w", err)
 	}
 
-	return nil
+	return &webhook, nil
 }
 
-// GetBySessionID busca configuração de webhook por sessionID
+// GetBySessionID busca o endpoint padrão (API legada de webhook único) da sessão
 func (r *WebhookRepository) GetBySessionID(ctx context.Context, sessionID string) (*models.WebhookModel, error) {
 	var webhook models.WebhookModel
 	query := `
-		SELECT id, "sessionId", url, events, "isActive", "createdAt", "updatedAt"
+		SELECT ` + webhookColumns + `
 		FROM "zpWebhooks"
-		WHERE "sessionId" = $1`
+		WHERE "sessionId" = $1 AND "isDefault"`
 
 	err := r.db.GetContext(ctx, &webhook, query, sessionID)
 	if err != nil {
@@ -68,75 +95,128 @@ func (r *WebhookRepository) GetBySessionID(ctx context.Context, sessionID string
 	return &webhook, nil
 }
 
-// Update atualiza uma configuração de webhook existente
-func (r *WebhookRepository) Update(ctx context.Context, webhook *models.WebhookModel) error {
+// ListBySessionID lista todos os endpoints de webhook da sessão
+func (r *WebhookRepository) ListBySessionID(ctx context.Context, sessionID string) ([]*models.WebhookModel, error) {
+	var webhooks []*models.WebhookModel
 	query := `
-		UPDATE "zpWebhooks"
-		SET url = $2, events = $3, "isActive" = $4, "updatedAt" = CURRENT_TIMESTAMP
+		SELECT ` + webhookColumns + `
+		FROM "zpWebhooks"
 		WHERE "sessionId" = $1
-		RETURNING "updatedAt"`
+		ORDER BY "isDefault" DESC, "createdAt"`
+
+	if err := r.db.SelectContext(ctx, &webhooks, query, sessionID); err != nil {
+		return nil, fmt.Errorf("failed to list webhooks by sessionID: %w", err)
+	}
+
+	return webhooks, nil
+}
 
-	rows, err := r.db.QueryContext(ctx, query,
-		webhook.SessionId, webhook.URL, pq.Array(webhook.Events), webhook.IsActive)
+// ListActiveForEvent lista os endpoints ativos da sessão inscritos no evento
+func (r *WebhookRepository) ListActiveForEvent(ctx context.Context, sessionID, event string) ([]*models.WebhookModel, error) {
+	webhooks, err := r.ListBySessionID(ctx, sessionID)
 	if err != nil {
-		return fmt.Errorf("failed to update webhook config: %w", err)
+		return nil, err
 	}
-	defer func() {
-		if closeErr := rows.Close(); closeErr != nil {
-			fmt.Printf("Warning: failed to close rows in UpdateWebhook: %v\n", closeErr)
+
+	active := make([]*models.WebhookModel, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		if webhook.IsActive && webhook.URL != "" && webhook.SubscribesTo(event) {
+			active = append(active, webhook)
 		}
-	}()
+	}
 
-	if rows.Next() {
-		err = rows.Scan(&webhook.UpdatedAt)
-		if err != nil {
-			return fmt.Errorf("failed to scan updated webhook config: %w", err)
+	return active, nil
+}
+
+// SubscribedEvents retorna a união dos eventos dos endpoints ativos da sessão.
+// Sem endpoints, ou com algum endpoint inscrito em tudo, retorna ["All"].
+func (r *WebhookRepository) SubscribedEvents(ctx context.Context, sessionID string) ([]string, error) {
+	webhooks, err := r.ListBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	events := make([]string, 0)
+	for _, webhook := range webhooks {
+		if !webhook.IsActive {
+			continue
+		}
+		if webhook.SubscribesTo("All") {
+			return []string{"All"}, nil
+		}
+		for _, event := range webhook.Events {
+			if !seen[event] {
+				seen[event] = true
+				events = append(events, event)
+			}
+		}
+	}
+
+	if len(events) == 0 {
+		return []string{"All"}, nil
+	}
+
+	return events, nil
+}
+
+// Update atualiza um endpoint de webhook existente
+func (r *WebhookRepository) Update(ctx context.Context, webhook *models.WebhookModel) error {
+	query := `
+		UPDATE "zpWebhooks"
+		SET name = $2, url = $3, events = $4, headers = $5, "isActive" = $6, "payloadFormat" = $7, "updatedAt" = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING "updatedAt"`
+
+	err := r.db.QueryRowContext(ctx, query,
+		webhook.ID, webhook.Name, webhook.URL, webhook.Events, webhook.Headers, webhook.IsActive, webhook.PayloadFormat,
+	).Scan(&webhook.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("webhook config not found: %s", webhook.ID)
 		}
+		return fmt.Errorf("failed to update webhook config: %w", err)
 	}
 
 	return nil
 }
 
-// Upsert cria ou atualiza uma configuração de webhook
+// Upsert cria ou atualiza o endpoint padrão da sessão.
+// O segredo existente é preservado; um novo só é gerado na criação.
 func (r *WebhookRepository) Upsert(ctx context.Context, webhook *models.WebhookModel) error {
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return err
+	}
+
 	query := `
 		INSERT INTO "zpWebhooks" (
-			"sessionId", url, events, "isActive"
+			"sessionId", name, url, events, "isActive", "isDefault", secret
 		) VALUES (
-			$1, $2, $3, $4
-		) ON CONFLICT ("sessionId") DO UPDATE SET
+			$1, 'default', $2, $3, $4, TRUE, $5
+		) ON CONFLICT ("sessionId") WHERE "isDefault" DO UPDATE SET
 			url = EXCLUDED.url,
 			events = EXCLUDED.events,
 			"isActive" = EXCLUDED."isActive",
 			"updatedAt" = CURRENT_TIMESTAMP
-		RETURNING id, "createdAt", "updatedAt"`
+		RETURNING id, name, secret, "createdAt", "updatedAt"`
 
-	rows, err := r.db.QueryContext(ctx, query,
-		webhook.SessionId, webhook.URL, pq.Array(webhook.Events), webhook.IsActive)
+	err = r.db.QueryRowContext(ctx, query,
+		webhook.SessionId, webhook.URL, webhook.Events, webhook.IsActive, secret,
+	).Scan(&webhook.ID, &webhook.Name, &webhook.Secret, &webhook.CreatedAt, &webhook.UpdatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to upsert webhook config: %w", err)
 	}
-	defer func() {
-		if closeErr := rows.Close(); closeErr != nil {
-			fmt.Printf("Warning: failed to close rows in UpsertWebhook: %v\n", closeErr)
-		}
-	}()
-
-	if rows.Next() {
-		err = rows.Scan(&webhook.ID, &webhook.CreatedAt, &webhook.UpdatedAt)
-		if err != nil {
-			return fmt.Errorf("failed to scan upserted webhook config: %w", err)
-		}
-	}
+	webhook.IsDefault = true
 
 	return nil
 }
 
-// Delete remove uma configuração de webhook
-func (r *WebhookRepository) Delete(ctx context.Context, sessionID string) error {
-	query := `DELETE FROM "zpWebhooks" WHERE "sessionId" = $1`
+// Delete remove um endpoint de webhook da sessão
+func (r *WebhookRepository) Delete(ctx context.Context, sessionID, id string) error {
+	query := `DELETE FROM "zpWebhooks" WHERE "sessionId" = $1 AND id = $2`
 
-	result, err := r.db.ExecContext(ctx, query, sessionID)
+	result, err := r.db.ExecContext(ctx, query, sessionID, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete webhook config: %w", err)
 	}
@@ -147,7 +227,7 @@ func (r *WebhookRepository) Delete(ctx context.Context, sessionID string) error
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("webhook config not found for sessionID: %s", sessionID)
+		return fmt.Errorf("webhook config not found: %s", id)
 	}
 
 	return nil
@@ -158,7 +238,10 @@ func (r *WebhookRepository) List(ctx context.Context, isActive *bool) ([]*models
 	var webhooks []*models.WebhookModel
 
 	query := `
-		SELECT w.id, w."sessionId", w.url, w.events, w."isActive", w."createdAt", w."updatedAt"
+		SELECT w.id, w."sessionId", w.name, w.url, w.events, w.headers, w."isActive", w."isDefault", w."payloadFormat",
+			w."createdAt", w."updatedAt", w.secret, w."previousSecret", w."previousSecretExpiresAt", w."secretRotatedAt",
+			w."deliveredCount", w."failedCount", w."lastDeliveryAt", w."lastSuccessAt", w."lastFailureAt",
+			w."lastError", w."lastStatusCode"
 		FROM "zpWebhooks" w
 		INNER JOIN "zpSessions" s ON w."sessionId" = s.id`
 
@@ -185,7 +268,7 @@ func (r *WebhookRepository) GetActiveWebhooks(ctx context.Context) ([]*models.We
 	return r.List(ctx, &isActive)
 }
 
-// SetWebhookForSession define webhook para uma sessão (método de conveniência)
+// SetWebhookForSession define o webhook padrão de uma sessão (método de conveniência)
 func (r *WebhookRepository) SetWebhookForSession(ctx context.Context, sessionID, url string, events []string) error {
 	webhook := &models.WebhookModel{
 		SessionId: sessionID,
@@ -197,7 +280,7 @@ func (r *WebhookRepository) SetWebhookForSession(ctx context.Context, sessionID,
 	return r.Upsert(ctx, webhook)
 }
 
-// GetWebhookForSession busca webhook para uma sessão (método de conveniência)
+// GetWebhookForSession busca o webhook padrão de uma sessão (método de conveniência)
 func (r *WebhookRepository) GetWebhookForSession(ctx context.Context, sessionID string) (string, []string, error) {
 	webhook, err := r.GetBySessionID(ctx, sessionID)
 	if err != nil {
@@ -210,3 +293,73 @@ func (r *WebhookRepository) GetWebhookForSession(ctx context.Context, sessionID
 
 	return webhook.URL, webhook.Events, nil
 }
+
+// RotateSecret gera um novo segredo para o endpoint e mantém o anterior válido durante gracePeriod
+func (r *WebhookRepository) RotateSecret(ctx context.Context, sessionID, id string, gracePeriod time.Duration) (*models.WebhookModel, error) {
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	var webhook models.WebhookModel
+	query := `
+		UPDATE "zpWebhooks"
+		SET "previousSecret" = secret,
+			"previousSecretExpiresAt" = $4,
+			secret = $3,
+			"secretRotatedAt" = CURRENT_TIMESTAMP,
+			"updatedAt" = CURRENT_TIMESTAMP
+		WHERE "sessionId" = $1 AND id = $2
+		RETURNING ` + webhookColumns
+
+	err = r.db.GetContext(ctx, &webhook, query, sessionID, id, secret, time.Now().Add(gracePeriod))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Não encontrado
+		}
+		return nil, fmt.Errorf("failed to rotate webhook secret: %w", err)
+	}
+
+	return &webhook, nil
+}
+
+// RecordDelivery atualiza as estatísticas de entrega do endpoint
+func (r *WebhookRepository) RecordDelivery(ctx context.Context, id string, delivered bool, statusCode *int, lastError string) error {
+	var query string
+	var args []interface{}
+
+	if delivered {
+		query = `
+			UPDATE "zpWebhooks"
+			SET "deliveredCount" = "deliveredCount" + 1,
+				"lastDeliveryAt" = CURRENT_TIMESTAMP,
+				"lastSuccessAt" = CURRENT_TIMESTAMP,
+				"lastStatusCode" = $2
+			WHERE id = $1`
+		args = []interface{}{id, statusCode}
+	} else {
+		query = `
+			UPDATE "zpWebhooks"
+			SET "failedCount" = "failedCount" + 1,
+				"lastDeliveryAt" = CURRENT_TIMESTAMP,
+				"lastFailureAt" = CURRENT_TIMESTAMP,
+				"lastStatusCode" = $2,
+				"lastError" = $3
+			WHERE id = $1`
+		args = []interface{}{id, statusCode, lastError}
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to record webhook delivery stats: %w", err)
+	}
+
+	return nil
+}
+
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
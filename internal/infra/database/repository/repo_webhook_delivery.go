@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"zpmeow/internal/infra/database/models"
+)
+
+const webhookDeliveryColumns = `id, "sessionId", "webhookId", url, event, payload, status, attempts, "nextAttemptAt",
+	"lockedAt", "lastError", "lastStatusCode", "createdAt", "updatedAt"`
+
+const webhookDeadLetterColumns = `id, "sessionId", "deliveryId", "webhookId", url, event, payload, attempts, "lastError",
+	"lastStatusCode", "enqueuedAt", "failedAt", "replayedAt", "replayCount", "createdAt"`
+
+type WebhookDeliveryRepository struct {
+	db *sqlx.DB
+}
+
+func NewWebhookDeliveryRepository(db *sqlx.DB) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{db: db}
+}
+
+// Enqueue insere uma nova entrega pendente na fila
+func (r *WebhookDeliveryRepository) Enqueue(ctx context.Context, delivery *models.WebhookDeliveryModel) error {
+	query := `
+		INSERT INTO "zpWebhookDeliveries" (
+			"sessionId", "webhookId", url, event, payload, status, attempts, "nextAttemptAt"
+		) VALUES (
+			$1, $2, $3, $4, $5, 'pending', 0, CURRENT_TIMESTAMP
+		) RETURNING id, status, "nextAttemptAt", "createdAt", "updatedAt"`
+
+	err := r.db.QueryRowContext(ctx, query,
+		delivery.SessionId, delivery.WebhookId, delivery.URL, delivery.Event, string(delivery.Payload),
+	).Scan(&delivery.ID, &delivery.Status, &delivery.NextAttemptAt, &delivery.CreatedAt, &delivery.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimDue reserva até limit entregas vencidas para processamento.
+// FOR UPDATE SKIP LOCKED permite várias instâncias consumindo a mesma fila.
+func (r *WebhookDeliveryRepository) ClaimDue(ctx context.Context, limit int) ([]*models.WebhookDeliveryModel, error) {
+	var deliveries []*models.WebhookDeliveryModel
+	query := `
+		UPDATE "zpWebhookDeliveries"
+		SET status = 'processing', "lockedAt" = CURRENT_TIMESTAMP
+		WHERE id IN (
+			SELECT id FROM "zpWebhookDeliveries"
+			WHERE status = 'pending' AND "nextAttemptAt" <= CURRENT_TIMESTAMP
+			ORDER BY "nextAttemptAt"
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + webhookDeliveryColumns
+
+	err := r.db.SelectContext(ctx, &deliveries, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// ReleaseStale devolve para a fila entregas presas em processing (ex.: processo reiniciado)
+func (r *WebhookDeliveryRepository) ReleaseStale(ctx context.Context, olderThan time.Duration) (int64, error) {
+	query := `
+		UPDATE "zpWebhookDeliveries"
+		SET status = 'pending', "lockedAt" = NULL
+		WHERE status = 'processing' AND "lockedAt" < $1`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now().Add(-olderThan))
+	if err != nil {
+		return 0, fmt.Errorf("failed to release stale webhook deliveries: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// MarkDelivered remove uma entrega concluída da fila
+func (r *WebhookDeliveryRepository) MarkDelivered(ctx context.Context, id string) error {
+	query := `DELETE FROM "zpWebhookDeliveries" WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to mark webhook delivery as delivered: %w", err)
+	}
+
+	return nil
+}
+
+// ScheduleRetry registra a falha e agenda a próxima tentativa
+func (r *WebhookDeliveryRepository) ScheduleRetry(ctx context.Context, id string, attempts int, nextAttemptAt time.Time, lastError string, lastStatusCode *int) error {
+	query := `
+		UPDATE "zpWebhookDeliveries"
+		SET status = 'pending', attempts = $2, "nextAttemptAt" = $3, "lockedAt" = NULL,
+			"lastError" = $4, "lastStatusCode" = $5
+		WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id, attempts, nextAttemptAt, lastError, lastStatusCode); err != nil {
+		return fmt.Errorf("failed to schedule webhook delivery retry: %w", err)
+	}
+
+	return nil
+}
+
+// MoveToDeadLetter move uma entrega que esgotou as tentativas para a tabela de dead-letter
+func (r *WebhookDeliveryRepository) MoveToDeadLetter(ctx context.Context, delivery *models.WebhookDeliveryModel, attempts int, lastError string, lastStatusCode *int) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin dead-letter transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	insertQuery := `
+		INSERT INTO "zpWebhookDeadLetters" (
+			"sessionId", "deliveryId", "webhookId", url, event, payload, attempts, "lastError", "lastStatusCode", "enqueuedAt"
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+		)`
+
+	if _, err := tx.ExecContext(ctx, insertQuery,
+		delivery.SessionId, delivery.ID, delivery.WebhookId, delivery.URL, delivery.Event, string(delivery.Payload),
+		attempts, lastError, lastStatusCode, delivery.CreatedAt); err != nil {
+		return fmt.Errorf("failed to insert webhook dead letter: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM "zpWebhookDeliveries" WHERE id = $1`, delivery.ID); err != nil {
+		return fmt.Errorf("failed to remove dead-lettered webhook delivery: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit dead-letter transaction: %w", err)
+	}
+
+	return nil
+}
+
+// CountPending conta as entregas ainda na fila para uma sessão
+func (r *WebhookDeliveryRepository) CountPending(ctx context.Context, sessionID string) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM "zpWebhookDeliveries" WHERE "sessionId" = $1`
+
+	if err := r.db.GetContext(ctx, &count, query, sessionID); err != nil {
+		return 0, fmt.Errorf("failed to count pending webhook deliveries: %w", err)
+	}
+
+	return count, nil
+}
+
+// ListDeadLetters lista as entregas que falharam definitivamente para uma sessão
+func (r *WebhookDeliveryRepository) ListDeadLetters(ctx context.Context, sessionID string, limit, offset int) ([]*models.WebhookDeadLetterModel, int, error) {
+	var deadLetters []*models.WebhookDeadLetterModel
+	query := `
+		SELECT ` + webhookDeadLetterColumns + `
+		FROM "zpWebhookDeadLetters"
+		WHERE "sessionId" = $1
+		ORDER BY "failedAt" DESC
+		LIMIT $2 OFFSET $3`
+
+	if err := r.db.SelectContext(ctx, &deadLetters, query, sessionID, limit, offset); err != nil {
+		return nil, 0, fmt.Errorf("failed to list webhook dead letters: %w", err)
+	}
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM "zpWebhookDeadLetters" WHERE "sessionId" = $1`
+	if err := r.db.GetContext(ctx, &total, countQuery, sessionID); err != nil {
+		return nil, 0, fmt.Errorf("failed to count webhook dead letters: %w", err)
+	}
+
+	return deadLetters, total, nil
+}
+
+// GetDeadLetter busca uma entrega falha por ID dentro da sessão
+func (r *WebhookDeliveryRepository) GetDeadLetter(ctx context.Context, sessionID, id string) (*models.WebhookDeadLetterModel, error) {
+	var deadLetter models.WebhookDeadLetterModel
+	query := `
+		SELECT ` + webhookDeadLetterColumns + `
+		FROM "zpWebhookDeadLetters"
+		WHERE "sessionId" = $1 AND id = $2`
+
+	err := r.db.GetContext(ctx, &deadLetter, query, sessionID, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Não encontrado
+		}
+		return nil, fmt.Errorf("failed to get webhook dead letter: %w", err)
+	}
+
+	return &deadLetter, nil
+}
+
+// ReplayDeadLetter recoloca uma entrega falha na fila com as tentativas zeradas
+func (r *WebhookDeliveryRepository) ReplayDeadLetter(ctx context.Context, sessionID, id string) (*models.WebhookDeliveryModel, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin replay transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var deadLetter models.WebhookDeadLetterModel
+	selectQuery := `
+		SELECT ` + webhookDeadLetterColumns + `
+		FROM "zpWebhookDeadLetters"
+		WHERE "sessionId" = $1 AND id = $2
+		FOR UPDATE`
+
+	if err := tx.GetContext(ctx, &deadLetter, selectQuery, sessionID, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Não encontrado
+		}
+		return nil, fmt.Errorf("failed to get webhook dead letter for replay: %w", err)
+	}
+
+	delivery := &models.WebhookDeliveryModel{
+		SessionId: deadLetter.SessionId,
+		WebhookId: deadLetter.WebhookId,
+		URL:       deadLetter.URL,
+		Event:     deadLetter.Event,
+		Payload:   deadLetter.Payload,
+	}
+
+	insertQuery := `
+		INSERT INTO "zpWebhookDeliveries" (
+			"sessionId", "webhookId", url, event, payload, status, attempts, "nextAttemptAt"
+		) VALUES (
+			$1, $2, $3, $4, $5, 'pending', 0, CURRENT_TIMESTAMP
+		) RETURNING id, status, "nextAttemptAt", "createdAt", "updatedAt"`
+
+	if err := tx.QueryRowxContext(ctx, insertQuery,
+		delivery.SessionId, delivery.WebhookId, delivery.URL, delivery.Event, string(delivery.Payload),
+	).Scan(&delivery.ID, &delivery.Status, &delivery.NextAttemptAt, &delivery.CreatedAt, &delivery.UpdatedAt); err != nil {
+		return nil, fmt.Errorf("failed to re-enqueue webhook dead letter: %w", err)
+	}
+
+	updateQuery := `
+		UPDATE "zpWebhookDeadLetters"
+		SET "replayedAt" = CURRENT_TIMESTAMP, "replayCount" = "replayCount" + 1
+		WHERE id = $1`
+
+	if _, err := tx.ExecContext(ctx, updateQuery, deadLetter.ID); err != nil {
+		return nil, fmt.Errorf("failed to mark webhook dead letter as replayed: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit replay transaction: %w", err)
+	}
+
+	return delivery, nil
+}
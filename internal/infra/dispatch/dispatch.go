@@ -0,0 +1,304 @@
+// Package dispatch envia mensagens descritas pelo mesmo corpo JSON dos endpoints /message/send/*.
+// É usado por quem guarda envios para depois (agendamentos, templates) e precisa reconstruí-los.
+package dispatch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"zpmeow/internal/application/common"
+	"zpmeow/internal/application/payloads"
+	"zpmeow/internal/application/ports"
+	"zpmeow/internal/application/usecases/messaging"
+	"zpmeow/internal/domain/session"
+	"zpmeow/internal/infra/logging"
+)
+
+// Request é o corpo de um endpoint /message/send/*
+type Request interface {
+	Validate() error
+}
+
+// messageTypes mapeia o tipo de mensagem para o corpo do endpoint de envio correspondente
+var messageTypes = map[string]func() Request{
+	"text":     func() Request { return &payloads.SendTextRequest{} },
+	"media":    func() Request { return &payloads.SendMediaRequest{} },
+	"image":    func() Request { return &payloads.SendImageRequest{} },
+	"audio":    func() Request { return &payloads.SendAudioRequest{} },
+	"video":    func() Request { return &payloads.SendVideoRequest{} },
+	"document": func() Request { return &payloads.SendDocumentRequest{} },
+	"sticker":  func() Request { return &payloads.SendStickerRequest{} },
+	"location": func() Request { return &payloads.SendLocationRequest{} },
+	"contact":  func() Request { return &payloads.SendContactRequest{} },
+	"poll":     func() Request { return &payloads.SendPollMessageRequest{} },
+	"buttons":  func() Request { return &payloads.SendButtonMessageRequest{} },
+	"list":     func() Request { return &payloads.SendListMessageRequest{} },
+}
+
+// SupportedMessageTypes lista os tipos de mensagem que podem ser enviados a partir de um payload
+func SupportedMessageTypes() []string {
+	return []string{"text", "media", "image", "audio", "video", "document", "sticker", "location", "contact", "poll", "buttons", "list"}
+}
+
+// Decode decodifica e valida o payload, retornando também o destinatário
+func Decode(messageType string, payload []byte) (Request, string, error) {
+	newRequest, ok := messageTypes[messageType]
+	if !ok {
+		return nil, "", fmt.Errorf("%w: unsupported message type %q, must be one of: %s",
+			common.ErrInvalidInput, messageType, strings.Join(SupportedMessageTypes(), ", "))
+	}
+
+	request := newRequest()
+	if err := json.Unmarshal(payload, request); err != nil {
+		return nil, "", fmt.Errorf("%w: invalid %s message: %v", common.ErrInvalidInput, messageType, err)
+	}
+	if err := request.Validate(); err != nil {
+		return nil, "", fmt.Errorf("%w: %v", common.ErrInvalidInput, err)
+	}
+	if field, reference := mediaReference(request); field != "" && strings.TrimSpace(reference) == "" {
+		// Payloads guardados não têm upload multipart; a mídia precisa ser referenciada no payload
+		return nil, "", fmt.Errorf("%w: %s is required", common.ErrInvalidInput, field)
+	}
+
+	var recipient struct {
+		Phone string `json:"phone"`
+	}
+	_ = json.Unmarshal(payload, &recipient)
+
+	return request, recipient.Phone, nil
+}
+
+// mediaReference retorna o campo e a referência de mídia dos envios de mídia
+func mediaReference(request Request) (string, string) {
+	switch req := request.(type) {
+	case *payloads.SendMediaRequest:
+		return "media_url", req.MediaURL
+	case *payloads.SendImageRequest:
+		return "image", req.Image
+	case *payloads.SendAudioRequest:
+		return "audio", req.Audio
+	case *payloads.SendVideoRequest:
+		return "video", req.Video
+	case *payloads.SendDocumentRequest:
+		return "document", req.Document
+	case *payloads.SendStickerRequest:
+		return "sticker", req.Sticker
+	}
+	return "", ""
+}
+
+// Dispatcher envia payloads pelos use cases de messaging
+type Dispatcher struct {
+	text     *messaging.SendTextMessageUseCase
+	media    *messaging.SendMediaMessageUseCase
+	location *messaging.SendLocationMessageUseCase
+	contact  *messaging.SendContactMessageUseCase
+	poll     *messaging.SendPollMessageUseCase
+	buttons  *messaging.SendButtonMessageUseCase
+	list     *messaging.SendListMessageUseCase
+}
+
+func NewDispatcher(sessionRepo session.Repository, whatsappService ports.WhatsAppService, mediaResolver ports.MediaResolver, module string) *Dispatcher {
+	logger := logging.NewUseCaseLogger(module)
+
+	return &Dispatcher{
+		text:     messaging.NewSendTextMessageUseCase(sessionRepo, whatsappService, logger),
+		media:    messaging.NewSendMediaMessageUseCase(sessionRepo, whatsappService, mediaResolver, logger),
+		location: messaging.NewSendLocationMessageUseCase(sessionRepo, whatsappService, logger),
+		contact:  messaging.NewSendContactMessageUseCase(sessionRepo, whatsappService, logger),
+		poll:     messaging.NewSendPollMessageUseCase(sessionRepo, whatsappService, logger),
+		buttons:  messaging.NewSendButtonMessageUseCase(sessionRepo, whatsappService, logger),
+		list:     messaging.NewSendListMessageUseCase(sessionRepo, whatsappService, logger),
+	}
+}
+
+// Send envia a mensagem e retorna o ID gerado pelo WhatsApp
+func (d *Dispatcher) Send(ctx context.Context, sessionID, messageType string, payload []byte) (string, error) {
+	request, _, err := Decode(messageType, payload)
+	if err != nil {
+		return "", err
+	}
+
+	switch req := request.(type) {
+	case *payloads.SendTextRequest:
+		result, err := d.text.Handle(ctx, messaging.SendTextMessageCommand{
+			SessionID: sessionID,
+			ChatJID:   req.Phone,
+			Message:   req.Body,
+			Options:   sendOptions(req.SendContextFields),
+		})
+		if err != nil {
+			return "", err
+		}
+		return result.MessageID, nil
+
+	case *payloads.SendMediaRequest:
+		return d.sendMedia(ctx, messaging.SendMediaMessageCommand{
+			SessionID: sessionID,
+			ChatJID:   req.Phone,
+			MediaType: messaging.MediaType(req.MediaType),
+			Caption:   req.Caption,
+			Filename:  req.FileName,
+			PTT:       req.PTT,
+			Source:    ports.MediaSource{Reference: req.MediaURL, FileName: req.FileName, MimeType: req.MimeType},
+			Options:   sendOptions(req.SendContextFields),
+		})
+
+	case *payloads.SendImageRequest:
+		return d.sendMedia(ctx, messaging.SendMediaMessageCommand{
+			SessionID: sessionID,
+			ChatJID:   req.Phone,
+			MediaType: messaging.MediaTypeImage,
+			Caption:   req.Caption,
+			Source:    ports.MediaSource{Reference: req.Image},
+			Options:   sendOptions(req.SendContextFields),
+		})
+
+	case *payloads.SendAudioRequest:
+		return d.sendMedia(ctx, messaging.SendMediaMessageCommand{
+			SessionID: sessionID,
+			ChatJID:   req.Phone,
+			MediaType: messaging.MediaTypeAudio,
+			PTT:       req.PTT,
+			Source:    ports.MediaSource{Reference: req.Audio},
+			Options:   sendOptions(req.SendContextFields),
+		})
+
+	case *payloads.SendVideoRequest:
+		return d.sendMedia(ctx, messaging.SendMediaMessageCommand{
+			SessionID: sessionID,
+			ChatJID:   req.Phone,
+			MediaType: messaging.MediaTypeVideo,
+			Caption:   req.Caption,
+			Source:    ports.MediaSource{Reference: req.Video},
+			Options:   sendOptions(req.SendContextFields),
+		})
+
+	case *payloads.SendDocumentRequest:
+		return d.sendMedia(ctx, messaging.SendMediaMessageCommand{
+			SessionID: sessionID,
+			ChatJID:   req.Phone,
+			MediaType: messaging.MediaTypeDocument,
+			Filename:  req.FileName,
+			Source:    ports.MediaSource{Reference: req.Document, FileName: req.FileName, MimeType: req.MimeType},
+			Options:   sendOptions(req.SendContextFields),
+		})
+
+	case *payloads.SendStickerRequest:
+		return d.sendMedia(ctx, messaging.SendMediaMessageCommand{
+			SessionID: sessionID,
+			ChatJID:   req.Phone,
+			MediaType: messaging.MediaTypeSticker,
+			Source:    ports.MediaSource{Reference: req.Sticker},
+			Options:   sendOptions(req.SendContextFields),
+		})
+
+	case *payloads.SendLocationRequest:
+		result, err := d.location.Handle(ctx, messaging.SendLocationMessageCommand{
+			SessionID: sessionID,
+			ChatJID:   req.Phone,
+			Latitude:  req.Latitude,
+			Longitude: req.Longitude,
+			Name:      req.Name,
+			Address:   req.Address,
+			Options:   sendOptions(req.SendContextFields),
+		})
+		if err != nil {
+			return "", err
+		}
+		return result.MessageID, nil
+
+	case *payloads.SendContactRequest:
+		var contacts []messaging.ContactInfo
+		if req.IsSingleContact() {
+			contacts = append(contacts, messaging.ContactInfo{Name: req.ContactName, Phone: req.ContactPhone})
+		}
+		for _, contact := range req.Contacts {
+			contacts = append(contacts, messaging.ContactInfo{Name: contact.Name, Phone: contact.Phone})
+		}
+		result, err := d.contact.Handle(ctx, messaging.SendContactMessageCommand{
+			SessionID: sessionID,
+			ChatJID:   req.Phone,
+			Contacts:  contacts,
+			Options:   sendOptions(req.SendContextFields),
+		})
+		if err != nil {
+			return "", err
+		}
+		return result.MessageID, nil
+
+	case *payloads.SendPollMessageRequest:
+		result, err := d.poll.Handle(ctx, messaging.SendPollMessageCommand{
+			SessionID:       sessionID,
+			ChatJID:         req.Phone,
+			Name:            req.Name,
+			Options:         req.Options,
+			SelectableCount: req.SelectableCount,
+			SendOptions:     sendOptions(req.SendContextFields),
+		})
+		if err != nil {
+			return "", err
+		}
+		return result.MessageID, nil
+
+	case *payloads.SendButtonMessageRequest:
+		buttons := make([]messaging.ButtonData, 0, len(req.Buttons))
+		for _, button := range req.Buttons {
+			buttons = append(buttons, messaging.ButtonData{ID: button.ID, Text: button.Text, Type: button.Type})
+		}
+		result, err := d.buttons.Handle(ctx, messaging.SendButtonMessageCommand{
+			SessionID: sessionID,
+			ChatJID:   req.Phone,
+			Title:     req.Title,
+			Buttons:   buttons,
+		})
+		if err != nil {
+			return "", err
+		}
+		return result.MessageID, nil
+
+	case *payloads.SendListMessageRequest:
+		sections := make([]messaging.ListSection, 0, len(req.Sections))
+		for _, section := range req.Sections {
+			rows := make([]ports.ListItem, 0, len(section.Rows))
+			for _, row := range section.Rows {
+				rows = append(rows, ports.ListItem{ID: row.ID, Title: row.Title, Description: row.Description})
+			}
+			sections = append(sections, messaging.ListSection{Title: section.Title, Rows: rows})
+		}
+		result, err := d.list.Handle(ctx, messaging.SendListMessageCommand{
+			SessionID:   sessionID,
+			ChatJID:     req.Phone,
+			Title:       req.Title,
+			Description: req.Description,
+			ButtonText:  req.ButtonText,
+			FooterText:  req.FooterText,
+			Sections:    sections,
+		})
+		if err != nil {
+			return "", err
+		}
+		return result.MessageID, nil
+	}
+
+	return "", fmt.Errorf("%w: unsupported message type %q", common.ErrInvalidInput, messageType)
+}
+
+func (d *Dispatcher) sendMedia(ctx context.Context, cmd messaging.SendMediaMessageCommand) (string, error) {
+	result, err := d.media.Handle(ctx, cmd)
+	if err != nil {
+		return "", err
+	}
+	return result.MessageID, nil
+}
+
+func sendOptions(fields payloads.SendContextFields) ports.SendOptions {
+	return ports.SendOptions{
+		QuotedMessageID: fields.QuotedMessageID,
+		Mentions:        fields.Mentions,
+		MentionAll:      fields.MentionAll,
+		Forwarded:       fields.Forwarded,
+	}
+}
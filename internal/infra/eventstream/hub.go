@@ -0,0 +1,185 @@
+package eventstream
+
+import (
+	"sync"
+
+	"zpmeow/internal/infra/webhooks"
+)
+
+// Hub distribui os eventos de cada sessão aos clientes conectados por SSE ou WebSocket.
+// Os últimos eventos ficam em um buffer por sessão para que clientes reconectados
+// retomem a partir do Last-Event-ID sem perder eventos.
+type Hub struct {
+	mu           sync.Mutex
+	sessions     map[string]*sessionStream
+	bufferSize   int
+	clientBuffer int
+}
+
+type sessionStream struct {
+	buffer      []*webhooks.Envelope // em ordem de chegada, no máximo bufferSize
+	subscribers map[*Subscriber]struct{}
+}
+
+// Subscriber é um cliente conectado ao stream de uma sessão
+type Subscriber struct {
+	events chan *webhooks.Envelope
+	types  map[string]bool // vazio = todos os eventos da sessão
+	closed bool
+}
+
+// Events entrega os eventos publicados após a inscrição. O canal é fechado quando o cliente
+// é removido, inclusive por não consumir rápido o bastante; ele deve reconectar com o Last-Event-ID.
+func (s *Subscriber) Events() <-chan *webhooks.Envelope {
+	return s.events
+}
+
+func (s *Subscriber) accepts(eventType string) bool {
+	return len(s.types) == 0 || s.types[eventType]
+}
+
+func NewHub(bufferSize, clientBuffer int) *Hub {
+	if bufferSize < 0 {
+		bufferSize = 0
+	}
+	if clientBuffer <= 0 {
+		clientBuffer = 1
+	}
+	return &Hub{
+		sessions:     make(map[string]*sessionStream),
+		bufferSize:   bufferSize,
+		clientBuffer: clientBuffer,
+	}
+}
+
+// Publish guarda o evento no buffer da sessão e o envia aos clientes inscritos
+func (h *Hub) Publish(sessionID string, envelope *webhooks.Envelope) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	stream := h.stream(sessionID)
+	if h.bufferSize > 0 {
+		stream.buffer = append(stream.buffer, envelope)
+		if len(stream.buffer) > h.bufferSize {
+			stream.buffer = stream.buffer[len(stream.buffer)-h.bufferSize:]
+		}
+	}
+
+	for subscriber := range stream.subscribers {
+		if !subscriber.accepts(envelope.Type) {
+			continue
+		}
+		select {
+		case subscriber.events <- envelope:
+		default:
+			// Cliente lento: desconecta em vez de bloquear o processamento dos eventos
+			h.remove(stream, subscriber)
+		}
+	}
+}
+
+// Subscribe inscreve um cliente no stream da sessão, opcionalmente restrito aos tipos informados.
+// Com lastEventID, retorna os eventos guardados depois dele; se o ID já saiu do buffer,
+// retorna o buffer inteiro e o cliente deduplica pelo id do envelope.
+func (h *Hub) Subscribe(sessionID, lastEventID string, types []string) (*Subscriber, []*webhooks.Envelope) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subscriber := &Subscriber{
+		events: make(chan *webhooks.Envelope, h.clientBuffer),
+		types:  make(map[string]bool, len(types)),
+	}
+	for _, eventType := range types {
+		subscriber.types[eventType] = true
+	}
+
+	stream := h.stream(sessionID)
+	stream.subscribers[subscriber] = struct{}{}
+
+	if lastEventID == "" {
+		return subscriber, nil
+	}
+
+	start := 0
+	for i, envelope := range stream.buffer {
+		if envelope.ID == lastEventID {
+			start = i + 1
+			break
+		}
+	}
+
+	replay := make([]*webhooks.Envelope, 0, len(stream.buffer)-start)
+	for _, envelope := range stream.buffer[start:] {
+		if subscriber.accepts(envelope.Type) {
+			replay = append(replay, envelope)
+		}
+	}
+
+	return subscriber, replay
+}
+
+// Unsubscribe remove o cliente do stream da sessão
+func (h *Hub) Unsubscribe(sessionID string, subscriber *Subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if stream, ok := h.sessions[sessionID]; ok {
+		h.remove(stream, subscriber)
+	}
+}
+
+// Remove desconecta os clientes da sessão e descarta o buffer; usado quando a sessão é apagada
+func (h *Hub) Remove(sessionID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	stream, ok := h.sessions[sessionID]
+	if !ok {
+		return
+	}
+	for subscriber := range stream.subscribers {
+		h.remove(stream, subscriber)
+	}
+	delete(h.sessions, sessionID)
+}
+
+// Subscribers retorna o número de clientes conectados ao stream da sessão
+func (h *Hub) Subscribers(sessionID string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if stream, ok := h.sessions[sessionID]; ok {
+		return len(stream.subscribers)
+	}
+	return 0
+}
+
+// Close desconecta todos os clientes; usado no shutdown para que as conexões abertas terminem
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, stream := range h.sessions {
+		for subscriber := range stream.subscribers {
+			h.remove(stream, subscriber)
+		}
+	}
+}
+
+func (h *Hub) stream(sessionID string) *sessionStream {
+	stream, ok := h.sessions[sessionID]
+	if !ok {
+		stream = &sessionStream{subscribers: make(map[*Subscriber]struct{})}
+		h.sessions[sessionID] = stream
+	}
+	return stream
+}
+
+func (h *Hub) remove(stream *sessionStream, subscriber *Subscriber) {
+	if subscriber.closed {
+		return
+	}
+	subscriber.closed = true
+	delete(stream.subscribers, subscriber)
+	close(subscriber.events)
+}
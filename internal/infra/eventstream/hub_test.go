@@ -0,0 +1,137 @@
+package eventstream
+
+import (
+	"reflect"
+	"testing"
+
+	"zpmeow/internal/infra/webhooks"
+)
+
+func publishAll(hub *Hub, sessionID string, envelopes ...*webhooks.Envelope) {
+	for _, envelope := range envelopes {
+		hub.Publish(sessionID, envelope)
+	}
+}
+
+func envelopeIDs(envelopes []*webhooks.Envelope) []string {
+	ids := make([]string, 0, len(envelopes))
+	for _, envelope := range envelopes {
+		ids = append(ids, envelope.ID)
+	}
+	return ids
+}
+
+func TestHubReplay(t *testing.T) {
+	events := []*webhooks.Envelope{
+		{ID: "1", Type: "Message"},
+		{ID: "2", Type: "Receipt"},
+		{ID: "3", Type: "Message"},
+		{ID: "4", Type: "Presence"},
+		{ID: "5", Type: "Message"},
+	}
+
+	tests := []struct {
+		name        string
+		bufferSize  int
+		lastEventID string
+		types       []string
+		want        []string
+	}{
+		{name: "without last event id", bufferSize: 10, lastEventID: "", want: []string{}},
+		{name: "after a buffered id", bufferSize: 10, lastEventID: "2", want: []string{"3", "4", "5"}},
+		{name: "after the last id", bufferSize: 10, lastEventID: "5", want: []string{}},
+		{name: "unknown id replays the whole buffer", bufferSize: 10, lastEventID: "unknown", want: []string{"1", "2", "3", "4", "5"}},
+		{name: "filtered by type", bufferSize: 10, lastEventID: "1", types: []string{"Message"}, want: []string{"3", "5"}},
+		{name: "buffer keeps only the latest events", bufferSize: 3, lastEventID: "4", want: []string{"5"}},
+		{name: "id that left the buffer replays what is left", bufferSize: 3, lastEventID: "1", want: []string{"3", "4", "5"}},
+		{name: "no buffer", bufferSize: 0, lastEventID: "2", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := NewHub(tt.bufferSize, 10)
+			publishAll(hub, "session-a", events...)
+			hub.Publish("session-b", &webhooks.Envelope{ID: "other", Type: "Message"})
+
+			_, replay := hub.Subscribe("session-a", tt.lastEventID, tt.types)
+			if got := envelopeIDs(replay); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("replay = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHubDeliversLiveEvents(t *testing.T) {
+	hub := NewHub(10, 10)
+	publishAll(hub, "session-a", &webhooks.Envelope{ID: "1", Type: "Message"})
+
+	subscriber, _ := hub.Subscribe("session-a", "1", []string{"Message"})
+	publishAll(hub, "session-a",
+		&webhooks.Envelope{ID: "2", Type: "Receipt"},
+		&webhooks.Envelope{ID: "3", Type: "Message"},
+	)
+	hub.Publish("session-b", &webhooks.Envelope{ID: "4", Type: "Message"})
+
+	select {
+	case envelope := <-subscriber.Events():
+		if envelope.ID != "3" {
+			t.Errorf("event = %s, want 3", envelope.ID)
+		}
+	default:
+		t.Fatal("no event delivered")
+	}
+	select {
+	case envelope := <-subscriber.Events():
+		t.Errorf("unexpected event %s", envelope.ID)
+	default:
+	}
+}
+
+func TestHubDropsSlowSubscriber(t *testing.T) {
+	hub := NewHub(10, 1)
+	subscriber, _ := hub.Subscribe("session-a", "", nil)
+
+	publishAll(hub, "session-a",
+		&webhooks.Envelope{ID: "1", Type: "Message"},
+		&webhooks.Envelope{ID: "2", Type: "Message"},
+	)
+
+	if n := hub.Subscribers("session-a"); n != 0 {
+		t.Fatalf("Subscribers() = %d, want 0", n)
+	}
+	if envelope := <-subscriber.Events(); envelope == nil || envelope.ID != "1" {
+		t.Fatalf("first event = %v, want 1", envelope)
+	}
+	if _, ok := <-subscriber.Events(); ok {
+		t.Error("channel of a dropped subscriber is still open")
+	}
+
+	// Unsubscribe de um cliente já removido não pode fechar o canal de novo
+	hub.Unsubscribe("session-a", subscriber)
+}
+
+func TestHubRemove(t *testing.T) {
+	hub := NewHub(10, 10)
+	publishAll(hub, "session-a", &webhooks.Envelope{ID: "1", Type: "Message"})
+	publishAll(hub, "session-b", &webhooks.Envelope{ID: "2", Type: "Message"})
+	subscriber, _ := hub.Subscribe("session-a", "", nil)
+
+	hub.Remove("session-a")
+
+	if _, ok := <-subscriber.Events(); ok {
+		t.Error("channel of a removed session is still open")
+	}
+	if _, ok := hub.sessions["session-a"]; ok {
+		t.Error("stream of the removed session is still in the hub")
+	}
+	if _, replay := hub.Subscribe("session-a", "unknown", nil); len(replay) != 0 {
+		t.Errorf("replay after Remove = %v, want empty", envelopeIDs(replay))
+	}
+	if _, replay := hub.Subscribe("session-b", "unknown", nil); len(replay) != 1 {
+		t.Errorf("replay of another session = %v, want 1 event", envelopeIDs(replay))
+	}
+
+	// Unsubscribe depois do Remove não pode fechar o canal de novo
+	hub.Unsubscribe("session-a", subscriber)
+	hub.Remove("session-c")
+}
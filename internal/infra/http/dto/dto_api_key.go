@@ -0,0 +1,113 @@
+package dto
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CreateAPIKeyRequest cria uma chave com escopo para a sessão; sem expires_at/expires_in_seconds a chave não expira
+type CreateAPIKeyRequest struct {
+	Name             string     `json:"name" binding:"required" example:"CRM integration"`
+	Scopes           []string   `json:"scopes" binding:"required" example:"messages:send,chats:read"`
+	ExpiresAt        *time.Time `json:"expires_at,omitempty" example:"2026-01-01T00:00:00Z"`
+	ExpiresInSeconds *int       `json:"expires_in_seconds,omitempty" example:"2592000"`
+}
+
+func (r CreateAPIKeyRequest) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(r.Name) > 100 {
+		return fmt.Errorf("name must be at most 100 characters")
+	}
+	if len(r.Scopes) == 0 {
+		return fmt.Errorf("at least one scope is required")
+	}
+	if r.ExpiresAt != nil && r.ExpiresInSeconds != nil {
+		return fmt.Errorf("use either expires_at or expires_in_seconds")
+	}
+	if r.ExpiresAt != nil && !r.ExpiresAt.After(time.Now()) {
+		return fmt.Errorf("expires_at must be in the future")
+	}
+	if r.ExpiresInSeconds != nil && *r.ExpiresInSeconds <= 0 {
+		return fmt.Errorf("expires_in_seconds must be positive")
+	}
+	return nil
+}
+
+type RotateAPIKeyRequest struct {
+	// Por quanto tempo a chave anterior continua válida (padrão 24h, máximo 7 dias)
+	GracePeriodSeconds *int `json:"grace_period_seconds,omitempty" example:"86400"`
+}
+
+func (r RotateAPIKeyRequest) Validate() error {
+	if r.GracePeriodSeconds == nil {
+		return nil
+	}
+	if *r.GracePeriodSeconds < 0 {
+		return fmt.Errorf("grace_period_seconds must not be negative")
+	}
+	if *r.GracePeriodSeconds > 7*24*60*60 {
+		return fmt.Errorf("grace_period_seconds must not exceed 604800 (7 days)")
+	}
+	return nil
+}
+
+type APIKeyInfo struct {
+	ID          string     `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name        string     `json:"name" example:"CRM integration"`
+	Prefix      string     `json:"prefix" example:"zpk_3f7a9c1b"`
+	Scopes      []string   `json:"scopes" example:"messages:send,chats:read"`
+	Active      bool       `json:"active" example:"true"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	RotatedFrom string     `json:"rotated_from,omitempty" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	CreatedAt   time.Time  `json:"created_at" example:"2025-01-01T12:00:00Z"`
+}
+
+// APIKeySecretData é a chave criada ou rotacionada; key só é retornada nessa resposta
+type APIKeySecretData struct {
+	APIKeyInfo
+	Key string `json:"key" example:"zpk_3f7a9c1b..."`
+}
+
+type APIKeySecretResponse struct {
+	Success bool              `json:"success"`
+	Code    int               `json:"code"`
+	Data    *APIKeySecretData `json:"data,omitempty"`
+	Error   *ErrorInfo        `json:"error,omitempty"`
+}
+
+type APIKeyResponse struct {
+	Success bool        `json:"success"`
+	Code    int         `json:"code"`
+	Data    *APIKeyInfo `json:"data,omitempty"`
+	Error   *ErrorInfo  `json:"error,omitempty"`
+}
+
+type APIKeyListData struct {
+	SessionId string       `json:"sessionID"`
+	Keys      []APIKeyInfo `json:"keys"`
+	Count     int          `json:"count"`
+}
+
+type APIKeyListResponse struct {
+	Success bool            `json:"success"`
+	Code    int             `json:"code"`
+	Data    *APIKeyListData `json:"data,omitempty"`
+	Error   *ErrorInfo      `json:"error,omitempty"`
+}
+
+type APIKeyScope struct {
+	Name        string `json:"name" example:"messages:send"`
+	Description string `json:"description" example:"Send, edit, delete, react and mark messages as read"`
+}
+
+type APIKeyScopesResponse struct {
+	Success bool          `json:"success"`
+	Code    int           `json:"code"`
+	Data    []APIKeyScope `json:"data,omitempty"`
+	Error   *ErrorInfo    `json:"error,omitempty"`
+}
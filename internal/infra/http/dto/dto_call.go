@@ -0,0 +1,94 @@
+package dto
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CallPolicyRequest define o que a sessão faz com as chamadas individuais recebidas
+type CallPolicyRequest struct {
+	Action        string `json:"action" binding:"required" example:"reject_with_message"`
+	RejectMessage string `json:"reject_message,omitempty" example:"Este número não atende ligações. Envie uma mensagem!"`
+}
+
+func (r CallPolicyRequest) Validate() error {
+	switch r.Action {
+	case "ignore", "reject":
+	case "reject_with_message":
+		if strings.TrimSpace(r.RejectMessage) == "" {
+			return fmt.Errorf("reject_message is required when action is reject_with_message")
+		}
+	default:
+		return fmt.Errorf("action must be ignore, reject or reject_with_message")
+	}
+	if len(r.RejectMessage) > 4096 {
+		return fmt.Errorf("reject_message must be at most 4096 characters")
+	}
+	return nil
+}
+
+type CallPolicyData struct {
+	SessionId     string     `json:"sessionID" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Action        string     `json:"action" example:"reject_with_message"`
+	RejectMessage string     `json:"reject_message,omitempty" example:"Este número não atende ligações. Envie uma mensagem!"`
+	UpdatedAt     *time.Time `json:"updated_at,omitempty" example:"2025-01-01T12:00:00Z"`
+}
+
+type CallPolicyResponse struct {
+	Success bool            `json:"success"`
+	Code    int             `json:"code"`
+	Data    *CallPolicyData `json:"data,omitempty"`
+	Error   *ErrorInfo      `json:"error,omitempty"`
+}
+
+// RejectCallRequest rejeita uma chamada recebida; from é opcional quando a chamada está no registro
+type RejectCallRequest struct {
+	CallID string `json:"call_id" binding:"required" example:"4A9B8C7D6E5F4A3B2C1D0E9F8A7B6C5D"`
+	From   string `json:"from,omitempty" example:"5511999999999"`
+}
+
+func (r RejectCallRequest) Validate() error {
+	if strings.TrimSpace(r.CallID) == "" {
+		return fmt.Errorf("call_id is required")
+	}
+	return nil
+}
+
+type CallInfo struct {
+	ID             string     `json:"id" example:"6f1c2b1e-8a55-4c4e-9d6a-2f7c1e0b9a11"`
+	CallID         string     `json:"call_id" example:"4A9B8C7D6E5F4A3B2C1D0E9F8A7B6C5D"`
+	Caller         string     `json:"caller" example:"5511999999999@s.whatsapp.net"`
+	GroupJID       string     `json:"group_jid,omitempty" example:"120363000000000000@g.us"`
+	IsGroup        bool       `json:"is_group" example:"false"`
+	IsVideo        bool       `json:"is_video" example:"false"`
+	Outcome        string     `json:"outcome" example:"auto_rejected"`
+	Reason         string     `json:"reason,omitempty" example:"timeout"`
+	ReplyMessageID string     `json:"reply_message_id,omitempty" example:"3EB0123456789ABCDEF"`
+	RemotePlatform string     `json:"remote_platform,omitempty" example:"android"`
+	OfferedAt      time.Time  `json:"offered_at" example:"2025-01-01T12:00:00Z"`
+	EndedAt        *time.Time `json:"ended_at,omitempty" example:"2025-01-01T12:00:01Z"`
+}
+
+type CallResponse struct {
+	Success bool       `json:"success"`
+	Code    int        `json:"code"`
+	Data    *CallInfo  `json:"data,omitempty"`
+	Error   *ErrorInfo `json:"error,omitempty"`
+}
+
+type CallListData struct {
+	SessionId string     `json:"sessionID"`
+	Calls     []CallInfo `json:"calls"`
+	Count     int        `json:"count"`
+	Total     int        `json:"total"`
+	Limit     int        `json:"limit"`
+	Offset    int        `json:"offset"`
+}
+
+type CallListResponse struct {
+	Success bool          `json:"success"`
+	Code    int           `json:"code"`
+	Data    *CallListData `json:"data,omitempty"`
+	Error   *ErrorInfo    `json:"error,omitempty"`
+}
@@ -0,0 +1,118 @@
+package dto
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type CampaignRecipient struct {
+	Phone     string            `json:"phone" binding:"required" example:"5511999999999"`
+	Variables map[string]string `json:"variables,omitempty"`
+}
+
+// CreateCampaignRequest cria uma campanha; em multipart/form-data os destinatários vêm do CSV no campo "file"
+// e os demais campos são enviados como campos do formulário
+type CreateCampaignRequest struct {
+	Name          string              `json:"name" form:"name" binding:"required" example:"Black Friday"`
+	Template      string              `json:"template,omitempty" form:"template" example:"Hi {{name}}, your coupon is {{coupon}}"`
+	TemplateID    string              `json:"template_id,omitempty" form:"template_id" example:"9c2f4a1b-6d3e-4f5a-8b7c-1d2e3f4a5b6c"`
+	Recipients    []CampaignRecipient `json:"recipients" form:"-"`
+	RatePerMinute int                 `json:"rate_per_minute,omitempty" form:"rate_per_minute" example:"10"`
+	JitterSeconds *int                `json:"jitter_seconds,omitempty" form:"jitter_seconds" example:"5"`
+	WindowStart   string              `json:"window_start,omitempty" form:"window_start" example:"09:00"`
+	WindowEnd     string              `json:"window_end,omitempty" form:"window_end" example:"18:00"`
+	Timezone      string              `json:"timezone,omitempty" form:"timezone" example:"America/Sao_Paulo"`
+	CheckNumbers  *bool               `json:"check_numbers,omitempty" form:"check_numbers" example:"true"`
+}
+
+func (r CreateCampaignRequest) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if strings.TrimSpace(r.Template) == "" && strings.TrimSpace(r.TemplateID) == "" {
+		return fmt.Errorf("template or template_id is required")
+	}
+	return nil
+}
+
+type CampaignStats struct {
+	Total      int `json:"total" example:"100"`
+	Pending    int `json:"pending" example:"60"`
+	Processing int `json:"processing" example:"1"`
+	Sent       int `json:"sent" example:"35"`
+	Failed     int `json:"failed" example:"1"`
+	Invalid    int `json:"invalid" example:"3"`
+	Canceled   int `json:"canceled" example:"0"`
+}
+
+type CampaignInfo struct {
+	ID              string         `json:"id" example:"0b9d7c1e-3f2a-4c8e-9a41-5d2f6e7a8b90"`
+	SessionId       string         `json:"sessionID" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name            string         `json:"name" example:"Black Friday"`
+	Template        string         `json:"template" example:"Hi {{name}}, your coupon is {{coupon}}"`
+	Status          string         `json:"status" example:"running"`
+	RatePerMinute   int            `json:"rate_per_minute" example:"10"`
+	JitterSeconds   int            `json:"jitter_seconds" example:"5"`
+	WindowStart     string         `json:"window_start,omitempty" example:"09:00"`
+	WindowEnd       string         `json:"window_end,omitempty" example:"18:00"`
+	Timezone        string         `json:"timezone" example:"America/Sao_Paulo"`
+	CheckNumbers    bool           `json:"check_numbers" example:"true"`
+	TotalRecipients int            `json:"total_recipients" example:"100"`
+	Stats           *CampaignStats `json:"stats,omitempty"`
+	LastSentAt      *time.Time     `json:"last_sent_at,omitempty" example:"2025-01-01T12:00:00Z"`
+	CompletedAt     *time.Time     `json:"completed_at,omitempty" example:"2025-01-01T14:00:00Z"`
+	CreatedAt       time.Time      `json:"created_at" example:"2025-01-01T11:00:00Z"`
+	UpdatedAt       time.Time      `json:"updated_at" example:"2025-01-01T12:00:00Z"`
+}
+
+type CampaignResponse struct {
+	Success bool          `json:"success"`
+	Code    int           `json:"code"`
+	Data    *CampaignInfo `json:"data,omitempty"`
+	Error   *ErrorInfo    `json:"error,omitempty"`
+}
+
+type CampaignListData struct {
+	SessionId string         `json:"sessionID"`
+	Campaigns []CampaignInfo `json:"campaigns"`
+	Count     int            `json:"count"`
+	Total     int            `json:"total"`
+	Limit     int            `json:"limit"`
+	Offset    int            `json:"offset"`
+}
+
+type CampaignListResponse struct {
+	Success bool              `json:"success"`
+	Code    int               `json:"code"`
+	Data    *CampaignListData `json:"data,omitempty"`
+	Error   *ErrorInfo        `json:"error,omitempty"`
+}
+
+type CampaignRecipientInfo struct {
+	ID          string                 `json:"id" example:"7a1e2c3d-4b5f-4e6a-8c9d-0e1f2a3b4c5d"`
+	Position    int                    `json:"position" example:"1"`
+	Phone       string                 `json:"phone" example:"5511999999999"`
+	Variables   map[string]interface{} `json:"variables,omitempty"`
+	Status      string                 `json:"status" example:"sent"`
+	JID         string                 `json:"jid,omitempty" example:"5511999999999@s.whatsapp.net"`
+	MessageID   string                 `json:"message_id,omitempty" example:"3EB0123456789ABCDEF"`
+	Error       string                 `json:"error,omitempty" example:"number is not on WhatsApp"`
+	ProcessedAt *time.Time             `json:"processed_at,omitempty" example:"2025-01-01T12:00:00Z"`
+}
+
+type CampaignRecipientListData struct {
+	CampaignID string                  `json:"campaign_id"`
+	Recipients []CampaignRecipientInfo `json:"recipients"`
+	Count      int                     `json:"count"`
+	Total      int                     `json:"total"`
+	Limit      int                     `json:"limit"`
+	Offset     int                     `json:"offset"`
+}
+
+type CampaignRecipientListResponse struct {
+	Success bool                       `json:"success"`
+	Code    int                        `json:"code"`
+	Data    *CampaignRecipientListData `json:"data,omitempty"`
+	Error   *ErrorInfo                 `json:"error,omitempty"`
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 )
 
 type SetPresenceRequest struct {
@@ -50,7 +51,8 @@ func (r SetDisappearingTimerRequest) Validate() error {
 }
 
 type ListChatsRequest struct {
-	Type string `json:"type,omitempty" example:"all"`
+	Type  string `json:"type,omitempty" example:"all"`
+	Label string `json:"label,omitempty" example:"3"` // ID da etiqueta do WhatsApp Business
 }
 
 func (r ListChatsRequest) Validate() error {
@@ -123,6 +125,18 @@ func (r ArchiveChatRequest) Validate() error {
 	return nil
 }
 
+type MarkChatReadRequest struct {
+	JID  string `json:"jid" binding:"required" example:"5511999999999@s.whatsapp.net"`
+	Read bool   `json:"read" example:"true"`
+}
+
+func (r MarkChatReadRequest) Validate() error {
+	if strings.TrimSpace(r.JID) == "" {
+		return fmt.Errorf("jid is required")
+	}
+	return nil
+}
+
 type ChatErrorResponse struct {
 	Code    string `json:"code" example:"INVALID_JID"`
 	Message string `json:"message" example:"Invalid JID format"`
@@ -152,7 +166,9 @@ type ChatInfo struct {
 	UnreadCount int    `json:"unread_count" example:"3"`
 	Pinned      bool   `json:"pinned" example:"false"`
 	Muted       bool   `json:"muted" example:"false"`
+	MutedUntil  string `json:"muted_until,omitempty" example:"2023-01-01T08:00:00Z"`
 	Archived    bool   `json:"archived" example:"false"`
+	Unread      bool   `json:"unread" example:"false"`
 }
 
 type ListChatsResponse struct {
@@ -176,20 +192,52 @@ type GetChatInfoResponse struct {
 }
 
 type ChatHistoryData struct {
-	MessageID string `json:"message_id" example:"msg_123"`
-	From      string `json:"from" example:"5511999999999@s.whatsapp.net"`
-	To        string `json:"to" example:"5511888888888@s.whatsapp.net"`
-	Type      string `json:"type" example:"text"`
-	Content   string `json:"content" example:"Hello!"`
-	Timestamp int64  `json:"timestamp" example:"1640995200"`
-	FromMe    bool   `json:"from_me" example:"false"`
+	MessageID  string                `json:"message_id" example:"3EB0123456789ABCDEF"`
+	From       string                `json:"from" example:"5511999999999@s.whatsapp.net"`
+	To         string                `json:"to" example:"5511888888888@s.whatsapp.net"`
+	SenderName string                `json:"sender_name,omitempty" example:"João Silva"`
+	Type       string                `json:"type" example:"text"`
+	Content    string                `json:"content" example:"Hello!"`
+	Timestamp  int64                 `json:"timestamp" example:"1640995200"`
+	FromMe     bool                  `json:"from_me" example:"false"`
+	Forwarded  bool                  `json:"forwarded" example:"false"`
+	Status     string                `json:"status" example:"read"`
+	Media      *ChatHistoryMedia     `json:"media,omitempty"`
+	Quoted     *ChatHistoryQuote     `json:"quoted,omitempty"`
+	Mentions   []string              `json:"mentions,omitempty" example:"5511777777777@s.whatsapp.net"`
+	Reactions  []ChatHistoryReaction `json:"reactions,omitempty"`
+	Edited     bool                  `json:"edited" example:"false"`
+	EditedAt   *int64                `json:"edited_at,omitempty" example:"1640995260"`
+	Deleted    bool                  `json:"deleted" example:"false"`
+	DeletedAt  *int64                `json:"deleted_at,omitempty" example:"1640995320"`
+	Cursor     string                `json:"cursor" example:"MTY0MDk5NTIwMDAwMDAwMDAwMDo3YTFlMmMzZA"`
+}
+
+type ChatHistoryMedia struct {
+	MimeType   string `json:"mime_type,omitempty" example:"image/jpeg"`
+	FileName   string `json:"file_name,omitempty" example:"photo.jpg"`
+	FileLength int64  `json:"file_length,omitempty" example:"102400"`
+	MediaID    string `json:"media_id,omitempty" example:"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"`
+}
+
+type ChatHistoryQuote struct {
+	MessageID string `json:"message_id" example:"3EB0FEDCBA9876543210"`
+	Content   string `json:"content,omitempty" example:"Are you coming?"`
+}
+
+type ChatHistoryReaction struct {
+	Emoji  string `json:"emoji" example:"👍"`
+	Sender string `json:"sender,omitempty" example:"5511999999999@s.whatsapp.net"`
 }
 
 type ChatHistoryResponseData struct {
-	Phone    string            `json:"phone"`
-	Messages []ChatHistoryData `json:"messages"`
-	Count    int               `json:"count"`
-	Limit    int               `json:"limit"`
+	Phone        string            `json:"phone"`
+	Messages     []ChatHistoryData `json:"messages"`
+	Count        int               `json:"count"`
+	Limit        int               `json:"limit"`
+	HasMore      bool              `json:"has_more"`
+	BeforeCursor string            `json:"before_cursor,omitempty"`
+	AfterCursor  string            `json:"after_cursor,omitempty"`
 }
 
 type ChatHistoryResponse struct {
@@ -199,6 +247,56 @@ type ChatHistoryResponse struct {
 	Error   *ChatErrorResponse       `json:"error,omitempty"`
 }
 
+type MessageSearchResult struct {
+	ID         string    `json:"id" example:"7a1e2c3d-4b5f-4e6a-8c9d-0e1f2a3b4c5d"`
+	MessageID  string    `json:"message_id" example:"3EB0123456789ABCDEF"`
+	ChatJID    string    `json:"chat_jid" example:"5511999999999@s.whatsapp.net"`
+	Sender     string    `json:"sender" example:"5511999999999@s.whatsapp.net"`
+	SenderName string    `json:"sender_name,omitempty" example:"João Silva"`
+	Type       string    `json:"type" example:"text"`
+	Content    string    `json:"content,omitempty" example:"A promoção termina amanhã"`
+	Snippet    string    `json:"snippet" example:"A <mark>promoção</mark> termina amanhã"`
+	Rank       float64   `json:"rank" example:"0.0607927"`
+	FromMe     bool      `json:"from_me" example:"false"`
+	Timestamp  time.Time `json:"timestamp" example:"2025-01-01T12:00:00Z"`
+}
+
+type MessageSearchData struct {
+	Query      string                `json:"query"`
+	Results    []MessageSearchResult `json:"results"`
+	Count      int                   `json:"count"`
+	Limit      int                   `json:"limit"`
+	NextCursor string                `json:"next_cursor,omitempty"`
+}
+
+type MessageSearchResponse struct {
+	Success bool               `json:"success"`
+	Code    int                `json:"code"`
+	Data    *MessageSearchData `json:"data,omitempty"`
+	Error   *ChatErrorResponse `json:"error,omitempty"`
+}
+
+type HistorySyncStatusData struct {
+	Status          string     `json:"status" example:"running" enums:"idle,running,completed"`
+	SyncType        string     `json:"sync_type,omitempty" example:"INITIAL_BOOTSTRAP"`
+	Progress        int        `json:"progress" example:"42"`
+	Chunks          int        `json:"chunks" example:"3"`
+	Conversations   int        `json:"conversations" example:"120"`
+	MessagesStored  int        `json:"messages_stored" example:"5230"`
+	MessagesSkipped int        `json:"messages_skipped" example:"87"`
+	LastError       string     `json:"last_error,omitempty"`
+	StartedAt       *time.Time `json:"started_at,omitempty" example:"2025-01-01T12:00:00Z"`
+	LastChunkAt     *time.Time `json:"last_chunk_at,omitempty" example:"2025-01-01T12:03:00Z"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty"`
+}
+
+type HistorySyncStatusResponse struct {
+	Success bool                   `json:"success"`
+	Code    int                    `json:"code"`
+	Data    *HistorySyncStatusData `json:"data,omitempty"`
+	Error   *ChatErrorResponse     `json:"error,omitempty"`
+}
+
 func NewChatErrorResponse(code int, errorCode, message, details string) *ChatResponse {
 	return &ChatResponse{
 		Success: false,
@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LabelRequest cria ou edita uma etiqueta do WhatsApp Business; color é o índice na paleta do WhatsApp (0-19)
+type LabelRequest struct {
+	Name  string `json:"name" binding:"required" example:"Novo pedido"`
+	Color *int   `json:"color,omitempty" example:"2"`
+}
+
+func (r LabelRequest) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(r.Name) > 100 {
+		return fmt.Errorf("name must be at most 100 characters")
+	}
+	if r.Color != nil && (*r.Color < 0 || *r.Color > 19) {
+		return fmt.Errorf("color must be between 0 and 19")
+	}
+	return nil
+}
+
+// LabelChatRequest coloca ou retira a etiqueta de um chat
+type LabelChatRequest struct {
+	JID     string `json:"jid" binding:"required" example:"5511999999999@s.whatsapp.net"`
+	Labeled bool   `json:"labeled" example:"true"`
+}
+
+func (r LabelChatRequest) Validate() error {
+	if strings.TrimSpace(r.JID) == "" {
+		return fmt.Errorf("jid is required")
+	}
+	return nil
+}
+
+// LabelMessageRequest coloca ou retira a etiqueta de uma mensagem
+type LabelMessageRequest struct {
+	JID       string `json:"jid" binding:"required" example:"5511999999999@s.whatsapp.net"`
+	MessageID string `json:"message_id" binding:"required" example:"3EB0123456789ABCDEF"`
+	Labeled   bool   `json:"labeled" example:"true"`
+}
+
+func (r LabelMessageRequest) Validate() error {
+	if strings.TrimSpace(r.JID) == "" {
+		return fmt.Errorf("jid is required")
+	}
+	if strings.TrimSpace(r.MessageID) == "" {
+		return fmt.Errorf("message_id is required")
+	}
+	return nil
+}
+
+type LabelInfo struct {
+	ID           string    `json:"id" example:"3"`
+	Name         string    `json:"name" example:"Novo pedido"`
+	Color        int       `json:"color" example:"2"`
+	PredefinedID *int      `json:"predefined_id,omitempty" example:"2"`
+	ChatCount    int       `json:"chat_count" example:"12"`
+	UpdatedAt    time.Time `json:"updated_at" example:"2025-01-01T12:00:00Z"`
+}
+
+type LabelResponse struct {
+	Success bool       `json:"success"`
+	Code    int        `json:"code"`
+	Data    *LabelInfo `json:"data,omitempty"`
+	Error   *ErrorInfo `json:"error,omitempty"`
+}
+
+type LabelListData struct {
+	SessionId string      `json:"sessionID"`
+	Labels    []LabelInfo `json:"labels"`
+	Count     int         `json:"count"`
+}
+
+type LabelListResponse struct {
+	Success bool           `json:"success"`
+	Code    int            `json:"code"`
+	Data    *LabelListData `json:"data,omitempty"`
+	Error   *ErrorInfo     `json:"error,omitempty"`
+}
@@ -288,3 +288,36 @@ func (r GetMediaRequest) Validate() error {
 	}
 	return nil
 }
+
+// MediaPolicyRequest configura o download automático das mídias recebidas pela sessão
+type MediaPolicyRequest struct {
+	AutoDownload bool             `json:"auto_download" example:"true"`
+	MediaTypes   []string         `json:"media_types,omitempty" example:"image,audio,document"`
+	MaxSizes     map[string]int64 `json:"max_sizes,omitempty"`
+}
+
+func (r MediaPolicyRequest) Validate() error {
+	validTypes := map[string]bool{"image": true, "audio": true, "video": true, "document": true, "sticker": true}
+
+	for _, mediaType := range r.MediaTypes {
+		if !validTypes[mediaType] {
+			return fmt.Errorf("invalid media type in media_types: %s", mediaType)
+		}
+	}
+	for mediaType, size := range r.MaxSizes {
+		if !validTypes[mediaType] {
+			return fmt.Errorf("invalid media type in max_sizes: %s", mediaType)
+		}
+		if size < 0 {
+			return fmt.Errorf("max_sizes.%s must not be negative", mediaType)
+		}
+	}
+	return nil
+}
+
+type MediaPolicyData struct {
+	AutoDownload bool             `json:"auto_download"`
+	MediaTypes   []string         `json:"media_types"`
+	MaxSizes     map[string]int64 `json:"max_sizes"`
+	UpdatedAt    *time.Time       `json:"updated_at,omitempty"`
+}
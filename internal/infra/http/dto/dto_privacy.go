@@ -222,33 +222,70 @@ func NewBlockActionErrorResponse(code int, errorCode, message, details string) *
 	}
 }
 
-type SetAllPrivacySettingsRequest struct {
-	LastSeen          string `json:"last_seen" binding:"required" example:"contacts"`
-	ProfilePhoto      string `json:"profile_photo" binding:"required" example:"contacts"`
-	Status            string `json:"status" binding:"required" example:"contacts"`
-	ReadReceipts      bool   `json:"read_receipts" example:"true"`
-	GroupsAddMe       string `json:"groups_add_me" binding:"required" example:"contacts"`
-	CallsAddMe        string `json:"calls_add_me" binding:"required" example:"contacts"`
-	DisappearingChats bool   `json:"disappearing_chats" example:"false"`
+// privacyValues são os valores aceitos em cada configuração; everyone e nobody continuam aceitos como all e none
+var privacyValues = map[string][]string{
+	"last_seen":     {"all", "contacts", "contact_blacklist", "none", "everyone", "nobody"},
+	"profile_photo": {"all", "contacts", "contact_blacklist", "none", "everyone", "nobody"},
+	"status":        {"all", "contacts", "contact_blacklist", "none", "everyone", "nobody"},
+	"groups_add_me": {"all", "contacts", "contact_blacklist", "none", "everyone", "nobody"},
+	"calls_add_me":  {"all", "known", "everyone"},
+	"online":        {"all", "match_last_seen", "everyone"},
 }
 
-func (r SetAllPrivacySettingsRequest) Validate() error {
-	validValues := []string{"everyone", "contacts", "nobody"}
+// DisappearingTimers são os timers padrão de mensagens temporárias aceitos, em segundos
+var DisappearingTimers = map[string]int{
+	"off": 0,
+	"0":   0,
+	"24h": 24 * 60 * 60,
+	"7d":  7 * 24 * 60 * 60,
+	"90d": 90 * 24 * 60 * 60,
+}
 
-	if !contains(validValues, r.LastSeen) {
-		return fmt.Errorf("last_seen must be one of: everyone, contacts, nobody")
-	}
-	if !contains(validValues, r.ProfilePhoto) {
-		return fmt.Errorf("profile_photo must be one of: everyone, contacts, nobody")
+type SetAllPrivacySettingsRequest struct {
+	LastSeen          string `json:"last_seen,omitempty" example:"contacts"`
+	ProfilePhoto      string `json:"profile_photo,omitempty" example:"contacts"`
+	Status            string `json:"status,omitempty" example:"contacts"`
+	ReadReceipts      *bool  `json:"read_receipts,omitempty" example:"true"`
+	GroupsAddMe       string `json:"groups_add_me,omitempty" example:"contacts"`
+	CallsAddMe        string `json:"calls_add_me,omitempty" example:"known"`
+	Online            string `json:"online,omitempty" example:"match_last_seen"`
+	DisappearingTimer string `json:"disappearing_timer,omitempty" example:"7d"`
+}
+
+// Settings retorna as configurações informadas, pelo nome da API
+func (r SetAllPrivacySettingsRequest) Settings() map[string]string {
+	settings := map[string]string{}
+	for name, value := range map[string]string{
+		"last_seen":     r.LastSeen,
+		"profile_photo": r.ProfilePhoto,
+		"status":        r.Status,
+		"groups_add_me": r.GroupsAddMe,
+		"calls_add_me":  r.CallsAddMe,
+		"online":        r.Online,
+	} {
+		if value != "" {
+			settings[name] = strings.ToLower(value)
+		}
 	}
-	if !contains(validValues, r.Status) {
-		return fmt.Errorf("status must be one of: everyone, contacts, nobody")
+	return settings
+}
+
+func (r SetAllPrivacySettingsRequest) Validate() error {
+	settings := r.Settings()
+	if len(settings) == 0 && r.ReadReceipts == nil && r.DisappearingTimer == "" {
+		return fmt.Errorf("at least one privacy setting must be provided")
 	}
-	if !contains(validValues, r.GroupsAddMe) {
-		return fmt.Errorf("groups_add_me must be one of: everyone, contacts, nobody")
+
+	for name, value := range settings {
+		if !contains(privacyValues[name], value) {
+			return fmt.Errorf("%s must be one of: %s", name, strings.Join(privacyValues[name], ", "))
+		}
 	}
-	if !contains(validValues, r.CallsAddMe) {
-		return fmt.Errorf("calls_add_me must be one of: everyone, contacts, nobody")
+
+	if r.DisappearingTimer != "" {
+		if _, ok := DisappearingTimers[strings.ToLower(r.DisappearingTimer)]; !ok {
+			return fmt.Errorf("disappearing_timer must be one of: off, 24h, 7d, 90d")
+		}
 	}
 
 	return nil
@@ -263,13 +300,15 @@ type PrivacySettingsResponse struct {
 
 type PrivacySettingsData struct {
 	SessionId         string `json:"session_id"`
-	LastSeen          string `json:"last_seen"`
-	ProfilePhoto      string `json:"profile_photo"`
-	Status            string `json:"status"`
-	ReadReceipts      bool   `json:"read_receipts"`
-	GroupsAddMe       string `json:"groups_add_me"`
-	CallsAddMe        string `json:"calls_add_me"`
-	DisappearingChats bool   `json:"disappearing_chats"`
+	LastSeen          string `json:"last_seen,omitempty"`
+	ProfilePhoto      string `json:"profile_photo,omitempty"`
+	Status            string `json:"status,omitempty"`
+	ReadReceipts      *bool  `json:"read_receipts,omitempty"`
+	GroupsAddMe       string `json:"groups_add_me,omitempty"`
+	CallsAddMe        string `json:"calls_add_me,omitempty"`
+	Online            string `json:"online,omitempty"`
+	DisappearingChats *bool  `json:"disappearing_chats,omitempty"`
+	DisappearingTimer *int   `json:"disappearing_timer,omitempty" example:"604800"` // segundos; ausente se ainda não foi definido pela API
 	UpdatedAt         string `json:"updated_at"`
 }
 
@@ -300,7 +339,7 @@ type FindPrivacySettingsRequest struct {
 }
 
 func (r FindPrivacySettingsRequest) Validate() error {
-	validSettings := []string{"last_seen", "profile_photo", "status", "read_receipts", "groups_add_me", "calls_add_me"}
+	validSettings := []string{"last_seen", "profile_photo", "status", "read_receipts", "groups_add_me", "calls_add_me", "online", "disappearing_timer"}
 
 	for _, setting := range r.Settings {
 		found := false
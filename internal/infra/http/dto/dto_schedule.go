@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ScheduleMessageRequest agenda qualquer envio de /message/send/*; message tem o mesmo corpo do endpoint do tipo escolhido.
+// Com template_id o tipo vem do template e message só precisa do phone (e dos campos de contexto opcionais).
+type ScheduleMessageRequest struct {
+	Type       string            `json:"type,omitempty" example:"text"`
+	SendAt     string            `json:"send_at" binding:"required" example:"2025-01-01T09:00:00"`
+	Timezone   string            `json:"timezone,omitempty" example:"America/Sao_Paulo"`
+	Message    json.RawMessage   `json:"message" binding:"required" swaggertype:"object"`
+	TemplateID string            `json:"template_id,omitempty" example:"9c2f4a1b-6d3e-4f5a-8b7c-1d2e3f4a5b6c"`
+	Variables  map[string]string `json:"variables,omitempty"`
+}
+
+func (r ScheduleMessageRequest) Validate() error {
+	if strings.TrimSpace(r.Type) == "" && strings.TrimSpace(r.TemplateID) == "" {
+		return fmt.Errorf("type or template_id is required")
+	}
+	if strings.TrimSpace(r.SendAt) == "" {
+		return fmt.Errorf("send_at is required")
+	}
+	if len(r.Message) == 0 || string(r.Message) == "null" {
+		return fmt.Errorf("message is required")
+	}
+	return nil
+}
+
+type RescheduleMessageRequest struct {
+	SendAt   string `json:"send_at" binding:"required" example:"2025-01-01T18:30:00"`
+	Timezone string `json:"timezone,omitempty" example:"America/Sao_Paulo"`
+}
+
+func (r RescheduleMessageRequest) Validate() error {
+	if strings.TrimSpace(r.SendAt) == "" {
+		return fmt.Errorf("send_at is required")
+	}
+	return nil
+}
+
+type ScheduledMessageInfo struct {
+	ID            string          `json:"id" example:"6f1c2b1e-8a55-4c4e-9d6a-2f7c1e0b9a11"`
+	SessionId     string          `json:"sessionID" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Type          string          `json:"type" example:"text"`
+	Phone         string          `json:"phone" example:"5511999999999"`
+	Message       json.RawMessage `json:"message,omitempty" swaggertype:"object"`
+	SendAt        time.Time       `json:"send_at" example:"2025-01-01T12:00:00Z"`
+	Timezone      string          `json:"timezone" example:"America/Sao_Paulo"`
+	Status        string          `json:"status" example:"scheduled"`
+	Attempts      int             `json:"attempts" example:"0"`
+	NextAttemptAt time.Time       `json:"next_attempt_at" example:"2025-01-01T12:00:00Z"`
+	LastError     string          `json:"last_error,omitempty" example:"failed to send text message: context deadline exceeded"`
+	MessageID     string          `json:"message_id,omitempty" example:"3EB0123456789ABCDEF"`
+	SentAt        *time.Time      `json:"sent_at,omitempty" example:"2025-01-01T12:00:01Z"`
+	CreatedAt     time.Time       `json:"created_at" example:"2024-12-31T18:00:00Z"`
+	UpdatedAt     time.Time       `json:"updated_at" example:"2024-12-31T18:00:00Z"`
+}
+
+type ScheduledMessageResponse struct {
+	Success bool                  `json:"success"`
+	Code    int                   `json:"code"`
+	Data    *ScheduledMessageInfo `json:"data,omitempty"`
+	Error   *ErrorInfo            `json:"error,omitempty"`
+}
+
+type ScheduledMessageListData struct {
+	SessionId string                 `json:"sessionID"`
+	Messages  []ScheduledMessageInfo `json:"messages"`
+	Count     int                    `json:"count"`
+	Total     int                    `json:"total"`
+	Limit     int                    `json:"limit"`
+	Offset    int                    `json:"offset"`
+}
+
+type ScheduledMessageListResponse struct {
+	Success bool                      `json:"success"`
+	Code    int                       `json:"code"`
+	Data    *ScheduledMessageListData `json:"data,omitempty"`
+	Error   *ErrorInfo                `json:"error,omitempty"`
+}
@@ -0,0 +1,98 @@
+package dto
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TextStatusRequest publica um status de texto; as cores aceitam #RRGGBB ou #AARRGGBB
+type TextStatusRequest struct {
+	Text            string `json:"text" binding:"required" example:"Bom dia!"`
+	BackgroundColor string `json:"background_color,omitempty" example:"#075E54"`
+	TextColor       string `json:"text_color,omitempty" example:"#FFFFFF"`
+	Font            string `json:"font,omitempty" example:"system_bold"`
+}
+
+func (r TextStatusRequest) Validate() error {
+	if strings.TrimSpace(r.Text) == "" {
+		return fmt.Errorf("text is required")
+	}
+	if len(r.Text) > 700 {
+		return fmt.Errorf("text must be at most 700 characters")
+	}
+	return nil
+}
+
+// ImageStatusRequest publica um status de imagem; image aceita as mesmas referências do envio de mensagens
+type ImageStatusRequest struct {
+	Image   string `json:"image" form:"image" example:"data:image/jpeg;base64,/9j/4AAQ..."`
+	Caption string `json:"caption,omitempty" form:"caption" example:"Novidades da semana"`
+}
+
+func (r ImageStatusRequest) Validate() error {
+	return nil
+}
+
+// VideoStatusRequest publica um status de vídeo; video aceita as mesmas referências do envio de mensagens
+type VideoStatusRequest struct {
+	Video   string `json:"video" form:"video" example:"https://example.com/video.mp4"`
+	Caption string `json:"caption,omitempty" form:"caption" example:"Bastidores"`
+}
+
+func (r VideoStatusRequest) Validate() error {
+	return nil
+}
+
+type StatusInfo struct {
+	ID              string     `json:"id" example:"3EB0123456789ABCDEF"`
+	Sender          string     `json:"sender,omitempty" example:"5511999999999@s.whatsapp.net"`
+	SenderName      string     `json:"sender_name,omitempty" example:"Maria"`
+	Type            string     `json:"type" example:"text"`
+	Content         string     `json:"content,omitempty" example:"Bom dia!"`
+	MimeType        string     `json:"mime_type,omitempty" example:"image/jpeg"`
+	BackgroundColor string     `json:"background_color,omitempty" example:"#FF075E54"`
+	TextColor       string     `json:"text_color,omitempty" example:"#FFFFFFFF"`
+	Font            string     `json:"font,omitempty" example:"system_bold"`
+	IsFromMe        bool       `json:"is_from_me" example:"false"`
+	PostedAt        time.Time  `json:"posted_at" example:"2025-01-01T12:00:00Z"`
+	ExpiresAt       time.Time  `json:"expires_at" example:"2025-01-02T12:00:00Z"`
+	RevokedAt       *time.Time `json:"revoked_at,omitempty"`
+}
+
+type StatusResponse struct {
+	Success bool        `json:"success"`
+	Code    int         `json:"code"`
+	Data    *StatusInfo `json:"data,omitempty"`
+	Error   *ErrorInfo  `json:"error,omitempty"`
+}
+
+type StatusListData struct {
+	SessionId string       `json:"sessionID"`
+	Statuses  []StatusInfo `json:"statuses"`
+	Count     int          `json:"count"`
+	Total     int          `json:"total"`
+	Limit     int          `json:"limit"`
+	Offset    int          `json:"offset"`
+}
+
+type StatusListResponse struct {
+	Success bool            `json:"success"`
+	Code    int             `json:"code"`
+	Data    *StatusListData `json:"data,omitempty"`
+	Error   *ErrorInfo      `json:"error,omitempty"`
+}
+
+// StatusAudienceData é a privacidade de status da conta, que define quem recebe os status publicados
+type StatusAudienceData struct {
+	SessionId string   `json:"sessionID"`
+	Type      string   `json:"type" example:"contacts"` // contacts, blacklist ou whitelist
+	List      []string `json:"list"`
+}
+
+type StatusAudienceResponse struct {
+	Success bool                `json:"success"`
+	Code    int                 `json:"code"`
+	Data    *StatusAudienceData `json:"data,omitempty"`
+	Error   *ErrorInfo          `json:"error,omitempty"`
+}
@@ -0,0 +1,96 @@
+package dto
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"zpmeow/internal/application/payloads"
+)
+
+// MessageTemplateRequest cria ou substitui um template; content tem o corpo do endpoint /message/send/{type} sem o phone
+type MessageTemplateRequest struct {
+	Name    string          `json:"name" binding:"required" example:"order_shipped"`
+	Type    string          `json:"type" binding:"required" example:"text"`
+	Content json.RawMessage `json:"content" binding:"required" swaggertype:"object"`
+}
+
+func (r MessageTemplateRequest) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if strings.TrimSpace(r.Type) == "" {
+		return fmt.Errorf("type is required")
+	}
+	if len(r.Content) == 0 || string(r.Content) == "null" {
+		return fmt.Errorf("content is required")
+	}
+	return nil
+}
+
+type MessageTemplateInfo struct {
+	ID        string          `json:"id" example:"9c2f4a1b-6d3e-4f5a-8b7c-1d2e3f4a5b6c"`
+	SessionId string          `json:"sessionID" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name      string          `json:"name" example:"order_shipped"`
+	Type      string          `json:"type" example:"text"`
+	Content   json.RawMessage `json:"content" swaggertype:"object"`
+	Variables []string        `json:"variables" example:"name,order_id"`
+	CreatedAt time.Time       `json:"created_at" example:"2025-01-01T12:00:00Z"`
+	UpdatedAt time.Time       `json:"updated_at" example:"2025-01-01T12:00:00Z"`
+}
+
+type MessageTemplateResponse struct {
+	Success bool                 `json:"success"`
+	Code    int                  `json:"code"`
+	Data    *MessageTemplateInfo `json:"data,omitempty"`
+	Error   *ErrorInfo           `json:"error,omitempty"`
+}
+
+type MessageTemplateListData struct {
+	SessionId string                `json:"sessionID"`
+	Templates []MessageTemplateInfo `json:"templates"`
+	Count     int                   `json:"count"`
+	Total     int                   `json:"total"`
+	Limit     int                   `json:"limit"`
+	Offset    int                   `json:"offset"`
+}
+
+type MessageTemplateListResponse struct {
+	Success bool                     `json:"success"`
+	Code    int                      `json:"code"`
+	Data    *MessageTemplateListData `json:"data,omitempty"`
+	Error   *ErrorInfo               `json:"error,omitempty"`
+}
+
+// SendTemplateRequest envia um template salvo; variables preenche os placeholders ({{phone}} é automático)
+type SendTemplateRequest struct {
+	Phone      string            `json:"phone" binding:"required" example:"5511999999999"`
+	TemplateID string            `json:"template_id" binding:"required" example:"9c2f4a1b-6d3e-4f5a-8b7c-1d2e3f4a5b6c"`
+	Variables  map[string]string `json:"variables,omitempty"`
+	payloads.SendContextFields
+}
+
+func (r SendTemplateRequest) Validate() error {
+	if strings.TrimSpace(r.Phone) == "" {
+		return fmt.Errorf("phone is required")
+	}
+	if strings.TrimSpace(r.TemplateID) == "" {
+		return fmt.Errorf("template_id is required")
+	}
+	return nil
+}
+
+type SendTemplateData struct {
+	Phone      string `json:"phone" example:"5511999999999"`
+	TemplateID string `json:"template_id" example:"9c2f4a1b-6d3e-4f5a-8b7c-1d2e3f4a5b6c"`
+	Type       string `json:"type" example:"text"`
+	MessageID  string `json:"message_id" example:"3EB0123456789ABCDEF"`
+}
+
+type SendTemplateResponse struct {
+	Success bool              `json:"success"`
+	Code    int               `json:"code"`
+	Data    *SendTemplateData `json:"data,omitempty"`
+	Error   *ErrorInfo        `json:"error,omitempty"`
+}
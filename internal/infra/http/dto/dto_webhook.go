@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
@@ -45,6 +46,38 @@ func (r TestWebhookRequest) Validate() error {
 	return nil
 }
 
+type RotateWebhookSecretRequest struct {
+	// Por quanto tempo o segredo anterior continua assinando as entregas (padrão 24h, máximo 7 dias)
+	GracePeriodSeconds *int `json:"grace_period_seconds,omitempty" example:"86400"`
+}
+
+func (r RotateWebhookSecretRequest) Validate() error {
+	if r.GracePeriodSeconds == nil {
+		return nil
+	}
+	if *r.GracePeriodSeconds < 0 {
+		return fmt.Errorf("grace_period_seconds must not be negative")
+	}
+	if *r.GracePeriodSeconds > 7*24*60*60 {
+		return fmt.Errorf("grace_period_seconds must not exceed 604800 (7 days)")
+	}
+	return nil
+}
+
+type WebhookSecretData struct {
+	SessionId               string     `json:"sessionID"`
+	Secret                  string     `json:"secret" example:"whsec_3f7a9c..."`
+	PreviousSecretExpiresAt *time.Time `json:"previous_secret_expires_at,omitempty"`
+	RotatedAt               *time.Time `json:"rotated_at,omitempty"`
+}
+
+type WebhookSecretResponse struct {
+	Success bool               `json:"success"`
+	Code    int                `json:"code"`
+	Data    *WebhookSecretData `json:"data,omitempty"`
+	Error   *ErrorInfo         `json:"error,omitempty"`
+}
+
 type WebhookResponse struct {
 	Success bool        `json:"success"`
 	Code    int         `json:"code"`
@@ -92,6 +125,11 @@ type WebhookResponseData struct {
 	CreatedAt time.Time    `json:"created_at,omitempty"`
 	Events    []string     `json:"events,omitempty"`
 	URL       string       `json:"url,omitempty"`
+
+	// Segredo HMAC usado para validar X-Webhook-Signature
+	Secret                  string     `json:"secret,omitempty" example:"whsec_3f7a9c..."`
+	PreviousSecretExpiresAt *time.Time `json:"previous_secret_expires_at,omitempty"`
+	SecretRotatedAt         *time.Time `json:"secret_rotated_at,omitempty"`
 }
 
 type WebhookListResponse struct {
@@ -248,3 +286,197 @@ type SupportedEventsData struct {
 	Events []string `json:"events"`
 	Count  int      `json:"count"`
 }
+
+type WebhookDeadLetterInfo struct {
+	ID             string          `json:"id" example:"6f1c2b1e-8a55-4c4e-9d6a-2f7c1e0b9a11"`
+	DeliveryID     string          `json:"delivery_id" example:"0b2a9c44-3e1f-4b8e-a7c2-5d9e8f1a6b33"`
+	WebhookID      string          `json:"webhook_id,omitempty" example:"9d4e1f2a-6b7c-4d8e-9f0a-1b2c3d4e5f6a"`
+	SessionId      string          `json:"sessionID" example:"550e8400-e29b-41d4-a716-446655440000"`
+	URL            string          `json:"url" example:"https://example.com/webhook"`
+	Event          string          `json:"event" example:"Message"`
+	Attempts       int             `json:"attempts" example:"4"`
+	LastError      string          `json:"last_error,omitempty" example:"webhook request to https://example.com/webhook failed with status 503"`
+	LastStatusCode int             `json:"last_status_code,omitempty" example:"503"`
+	Payload        json.RawMessage `json:"payload,omitempty" swaggertype:"object"`
+	EnqueuedAt     time.Time       `json:"enqueued_at" example:"2023-01-01T12:00:00Z"`
+	FailedAt       time.Time       `json:"failed_at" example:"2023-01-01T12:01:00Z"`
+	ReplayedAt     *time.Time      `json:"replayed_at,omitempty" example:"2023-01-01T13:00:00Z"`
+	ReplayCount    int             `json:"replay_count" example:"0"`
+}
+
+type WebhookDeadLetterListData struct {
+	SessionId    string                  `json:"sessionID"`
+	DeadLetters  []WebhookDeadLetterInfo `json:"dead_letters"`
+	Count        int                     `json:"count"`
+	Total        int                     `json:"total"`
+	PendingCount int                     `json:"pending_count"`
+	Limit        int                     `json:"limit"`
+	Offset       int                     `json:"offset"`
+}
+
+type WebhookDeadLetterListResponse struct {
+	Success bool                       `json:"success"`
+	Code    int                        `json:"code"`
+	Data    *WebhookDeadLetterListData `json:"data,omitempty"`
+	Error   *ErrorInfo                 `json:"error,omitempty"`
+}
+
+type WebhookDeadLetterResponse struct {
+	Success bool                   `json:"success"`
+	Code    int                    `json:"code"`
+	Data    *WebhookDeadLetterInfo `json:"data,omitempty"`
+	Error   *ErrorInfo             `json:"error,omitempty"`
+}
+
+type WebhookReplayData struct {
+	DeadLetterID  string    `json:"dead_letter_id"`
+	DeliveryID    string    `json:"delivery_id"`
+	Event         string    `json:"event"`
+	URL           string    `json:"url"`
+	Status        string    `json:"status" example:"pending"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+}
+
+type WebhookReplayResponse struct {
+	Success bool               `json:"success"`
+	Code    int                `json:"code"`
+	Data    *WebhookReplayData `json:"data,omitempty"`
+	Error   *ErrorInfo         `json:"error,omitempty"`
+}
+
+// reservedWebhookHeaders não podem ser sobrescritos por headers customizados
+var reservedWebhookHeaders = map[string]bool{
+	"content-type":        true,
+	"user-agent":          true,
+	"x-webhook-signature": true,
+	"x-webhook-timestamp": true,
+	"x-webhook-event":     true,
+	"x-webhook-delivery":  true,
+	"x-webhook-attempt":   true,
+}
+
+func validateWebhookURL(url string) error {
+	if strings.TrimSpace(url) == "" {
+		return fmt.Errorf("url is required")
+	}
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return fmt.Errorf("url must be a valid HTTP or HTTPS URL")
+	}
+	return nil
+}
+
+func validateWebhookHeaders(headers map[string]string) error {
+	for name := range headers {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("header names must not be empty")
+		}
+		if reservedWebhookHeaders[strings.ToLower(name)] {
+			return fmt.Errorf("header %s is reserved and cannot be overridden", name)
+		}
+	}
+	return nil
+}
+
+// validateWebhookPayloadFormat aceita o envelope versionado (v1) ou a struct bruta do whatsmeow (raw)
+func validateWebhookPayloadFormat(format string) error {
+	if format != "v1" && format != "raw" {
+		return fmt.Errorf("payload_format must be v1 or raw")
+	}
+	return nil
+}
+
+type CreateWebhookEndpointRequest struct {
+	Name          string            `json:"name,omitempty" example:"crm"`
+	URL           string            `json:"url" binding:"required" example:"https://crm.example.com/webhook"`
+	Events        []string          `json:"events,omitempty" example:"Message,Receipt"`
+	Headers       map[string]string `json:"headers,omitempty"`
+	IsActive      *bool             `json:"is_active,omitempty" example:"true"`
+	PayloadFormat string            `json:"payload_format,omitempty" example:"v1" enums:"v1,raw"`
+}
+
+func (r CreateWebhookEndpointRequest) Validate() error {
+	if len(r.Name) > 100 {
+		return fmt.Errorf("name must be at most 100 characters")
+	}
+	if err := validateWebhookURL(r.URL); err != nil {
+		return err
+	}
+	if r.PayloadFormat != "" {
+		if err := validateWebhookPayloadFormat(r.PayloadFormat); err != nil {
+			return err
+		}
+	}
+	return validateWebhookHeaders(r.Headers)
+}
+
+type UpdateWebhookEndpointRequest struct {
+	Name          *string           `json:"name,omitempty" example:"crm"`
+	URL           *string           `json:"url,omitempty" example:"https://crm.example.com/webhook"`
+	Events        []string          `json:"events,omitempty" example:"Message,Receipt"`
+	Headers       map[string]string `json:"headers,omitempty"`
+	IsActive      *bool             `json:"is_active,omitempty" example:"false"`
+	PayloadFormat *string           `json:"payload_format,omitempty" example:"raw" enums:"v1,raw"`
+}
+
+func (r UpdateWebhookEndpointRequest) Validate() error {
+	if r.Name != nil && len(*r.Name) > 100 {
+		return fmt.Errorf("name must be at most 100 characters")
+	}
+	if r.URL != nil {
+		if err := validateWebhookURL(*r.URL); err != nil {
+			return err
+		}
+	}
+	if r.PayloadFormat != nil {
+		if err := validateWebhookPayloadFormat(*r.PayloadFormat); err != nil {
+			return err
+		}
+	}
+	return validateWebhookHeaders(r.Headers)
+}
+
+type WebhookEndpointStats struct {
+	DeliveredCount int64      `json:"delivered_count" example:"1280"`
+	FailedCount    int64      `json:"failed_count" example:"3"`
+	LastDeliveryAt *time.Time `json:"last_delivery_at,omitempty"`
+	LastSuccessAt  *time.Time `json:"last_success_at,omitempty"`
+	LastFailureAt  *time.Time `json:"last_failure_at,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	LastStatusCode int        `json:"last_status_code,omitempty" example:"200"`
+}
+
+type WebhookEndpointInfo struct {
+	ID            string               `json:"id" example:"9d4e1f2a-6b7c-4d8e-9f0a-1b2c3d4e5f6a"`
+	SessionId     string               `json:"sessionID" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name          string               `json:"name" example:"crm"`
+	URL           string               `json:"url" example:"https://crm.example.com/webhook"`
+	Events        []string             `json:"events" example:"Message,Receipt"`
+	Headers       map[string]string    `json:"headers,omitempty"`
+	IsActive      bool                 `json:"is_active" example:"true"`
+	IsDefault     bool                 `json:"is_default" example:"false"`
+	PayloadFormat string               `json:"payload_format" example:"v1"`
+	Secret        string               `json:"secret,omitempty" example:"whsec_3f7a9c..."`
+	Stats         WebhookEndpointStats `json:"stats"`
+	CreatedAt     time.Time            `json:"created_at" example:"2023-01-01T12:00:00Z"`
+	UpdatedAt     time.Time            `json:"updated_at" example:"2023-01-01T12:00:00Z"`
+}
+
+type WebhookEndpointResponse struct {
+	Success bool                 `json:"success"`
+	Code    int                  `json:"code"`
+	Data    *WebhookEndpointInfo `json:"data,omitempty"`
+	Error   *ErrorInfo           `json:"error,omitempty"`
+}
+
+type WebhookEndpointListData struct {
+	SessionId string                `json:"sessionID"`
+	Endpoints []WebhookEndpointInfo `json:"endpoints"`
+	Count     int                   `json:"count"`
+}
+
+type WebhookEndpointListResponse struct {
+	Success bool                     `json:"success"`
+	Code    int                      `json:"code"`
+	Data    *WebhookEndpointListData `json:"data,omitempty"`
+	Error   *ErrorInfo               `json:"error,omitempty"`
+}
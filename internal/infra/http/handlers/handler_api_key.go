@@ -0,0 +1,331 @@
+package handlers
+
+import (
+	"strings"
+	"time"
+
+	"zpmeow/internal/application"
+	"zpmeow/internal/infra/database/models"
+	"zpmeow/internal/infra/database/repository"
+	"zpmeow/internal/infra/http/dto"
+	"zpmeow/internal/infra/http/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// defaultKeyGracePeriod é por quanto tempo a chave anterior continua válida depois da rotação
+const defaultKeyGracePeriod = 24 * time.Hour
+
+type APIKeyHandler struct {
+	*BaseHandler
+	sessionService *application.SessionApp
+	apiKeyRepo     *repository.APIKeyRepository
+}
+
+func NewAPIKeyHandler(sessionService *application.SessionApp, apiKeyRepo *repository.APIKeyRepository) *APIKeyHandler {
+	return &APIKeyHandler{
+		BaseHandler:    NewBaseHandler("api-key-handler"),
+		sessionService: sessionService,
+		apiKeyRepo:     apiKeyRepo,
+	}
+}
+
+func (h *APIKeyHandler) resolveSessionID(c *fiber.Ctx, sessionIDOrName string) (string, error) {
+	if h.sessionService == nil {
+		return sessionIDOrName, nil
+	}
+
+	ctx := c.Context()
+	session, err := h.sessionService.GetSession(ctx, sessionIDOrName)
+	if err != nil {
+		return "", err
+	}
+
+	return session.SessionID().Value(), nil
+}
+
+func (h *APIKeyHandler) errorResponse(c *fiber.Ctx, status int, code, message, details string) error {
+	return c.Status(status).JSON(dto.APIKeyResponse{
+		Success: false,
+		Code:    status,
+		Error: &dto.ErrorInfo{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+	})
+}
+
+// ListAPIKeys godoc
+// @Summary List API keys
+// @Description Lists the scoped API keys of the session. The keys themselves are never returned, only their prefix.
+// @Tags API Keys
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param include_revoked query bool false "Include revoked keys" default(false)
+// @Success 200 {object} dto.APIKeyListResponse "API keys"
+// @Failure 403 {object} dto.APIKeyListResponse "API key without the keys:manage scope"
+// @Failure 404 {object} dto.APIKeyListResponse "Session not found"
+// @Failure 500 {object} dto.APIKeyListResponse "Failed to list API keys"
+// @Router /session/{sessionId}/keys [get]
+func (h *APIKeyHandler) ListAPIKeys(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	keys, err := h.apiKeyRepo.List(c.Context(), sessionID, c.QueryBool("include_revoked", false))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusInternalServerError, "LIST_FAILED", "Failed to list API keys", err.Error())
+	}
+
+	now := time.Now()
+	items := make([]dto.APIKeyInfo, 0, len(keys))
+	for _, key := range keys {
+		items = append(items, toAPIKeyInfo(key, now))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.APIKeyListResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data: &dto.APIKeyListData{
+			SessionId: sessionID,
+			Keys:      items,
+			Count:     len(items),
+		},
+	})
+}
+
+// CreateAPIKey godoc
+// @Summary Create an API key
+// @Description Creates a scoped API key for the session. The key is only returned in this response; only its SHA-256 is stored.
+// @Description A scoped key can only grant scopes it has itself. See GET /keys/scopes for the available scopes.
+// @Tags API Keys
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param request body dto.CreateAPIKeyRequest true "API key"
+// @Success 201 {object} dto.APIKeySecretResponse "API key created"
+// @Failure 400 {object} dto.APIKeySecretResponse "Invalid request data"
+// @Failure 403 {object} dto.APIKeySecretResponse "Scope not held by the calling key"
+// @Failure 404 {object} dto.APIKeySecretResponse "Session not found"
+// @Failure 500 {object} dto.APIKeySecretResponse "Failed to create API key"
+// @Router /session/{sessionId}/keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	var req dto.CreateAPIKeyRequest
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return h.errorResponse(c, fiber.StatusBadRequest, "VALIDATION_ERROR", "Invalid request data", err.Error())
+	}
+
+	scopes := make([]string, 0, len(req.Scopes))
+	seen := make(map[string]bool, len(req.Scopes))
+	for _, scope := range req.Scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if !middleware.IsValidScope(scope) {
+			return h.errorResponse(c, fiber.StatusBadRequest, "INVALID_SCOPE", "Invalid scope", "unknown scope: "+scope)
+		}
+		if !middleware.HasScope(c, scope) {
+			return h.errorResponse(c, fiber.StatusForbidden, "SCOPE_NOT_GRANTED", "A key can only grant scopes it has", "missing scope: "+scope)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+
+	expiresAt := req.ExpiresAt
+	if req.ExpiresInSeconds != nil {
+		expires := time.Now().Add(time.Duration(*req.ExpiresInSeconds) * time.Second)
+		expiresAt = &expires
+	}
+
+	key, secret, err := h.apiKeyRepo.Create(c.Context(), sessionID, strings.TrimSpace(req.Name), scopes, expiresAt)
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusInternalServerError, "CREATE_FAILED", "Failed to create API key", err.Error())
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.APIKeySecretResponse{
+		Success: true,
+		Code:    fiber.StatusCreated,
+		Data: &dto.APIKeySecretData{
+			APIKeyInfo: toAPIKeyInfo(key, time.Now()),
+			Key:        secret,
+		},
+	})
+}
+
+// RotateAPIKey godoc
+// @Summary Rotate an API key
+// @Description Creates a new key with the same name, scopes and expiry. The old key keeps working during the grace period
+// @Description (default 24h, 0 to revoke it now), so clients can switch keys without downtime.
+// @Tags API Keys
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param keyId path string true "API key ID"
+// @Param request body dto.RotateAPIKeyRequest false "Rotation options"
+// @Success 200 {object} dto.APIKeySecretResponse "API key rotated"
+// @Failure 400 {object} dto.APIKeySecretResponse "Invalid request data"
+// @Failure 403 {object} dto.APIKeySecretResponse "Key has scopes the calling key does not have"
+// @Failure 404 {object} dto.APIKeySecretResponse "Session or active key not found"
+// @Failure 500 {object} dto.APIKeySecretResponse "Failed to rotate API key"
+// @Router /session/{sessionId}/keys/{keyId}/rotate [post]
+func (h *APIKeyHandler) RotateAPIKey(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	var req dto.RotateAPIKeyRequest
+	if len(c.Body()) > 0 {
+		if err := h.BindAndValidate(c, &req); err != nil {
+			return h.errorResponse(c, fiber.StatusBadRequest, "VALIDATION_ERROR", "Invalid request data", err.Error())
+		}
+	}
+
+	gracePeriod := defaultKeyGracePeriod
+	if req.GracePeriodSeconds != nil {
+		gracePeriod = time.Duration(*req.GracePeriodSeconds) * time.Second
+	}
+
+	keyID := c.Params("keyId")
+	current, err := h.apiKeyRepo.GetByID(c.Context(), sessionID, keyID)
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusInternalServerError, "GET_FAILED", "Failed to get API key", err.Error())
+	}
+	if current == nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "KEY_NOT_FOUND", "API key not found", "")
+	}
+
+	// Rotacionar entrega a nova chave, então vale a mesma regra da criação
+	for _, scope := range current.Scopes {
+		if !middleware.HasScope(c, scope) {
+			return h.errorResponse(c, fiber.StatusForbidden, "SCOPE_NOT_GRANTED", "A key can only rotate keys with scopes it has", "missing scope: "+scope)
+		}
+	}
+
+	key, secret, err := h.apiKeyRepo.Rotate(c.Context(), sessionID, keyID, gracePeriod)
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusInternalServerError, "ROTATE_FAILED", "Failed to rotate API key", err.Error())
+	}
+	if key == nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "KEY_NOT_FOUND", "API key not found, revoked or expired", "")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.APIKeySecretResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data: &dto.APIKeySecretData{
+			APIKeyInfo: toAPIKeyInfo(key, time.Now()),
+			Key:        secret,
+		},
+	})
+}
+
+// RevokeAPIKey godoc
+// @Summary Revoke an API key
+// @Description Revokes the key immediately. Revoked keys are kept for auditing and listed with include_revoked.
+// @Tags API Keys
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param keyId path string true "API key ID"
+// @Success 200 {object} dto.APIKeyResponse "API key revoked"
+// @Failure 403 {object} dto.APIKeyResponse "The key being revoked has scopes the caller does not have"
+// @Failure 404 {object} dto.APIKeyResponse "Session or active key not found"
+// @Failure 500 {object} dto.APIKeyResponse "Failed to revoke API key"
+// @Router /session/{sessionId}/keys/{keyId} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	keyID := c.Params("keyId")
+	current, err := h.apiKeyRepo.GetByID(c.Context(), sessionID, keyID)
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusInternalServerError, "GET_FAILED", "Failed to get API key", err.Error())
+	}
+	if current == nil || current.RevokedAt != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "KEY_NOT_FOUND", "API key not found or already revoked", "")
+	}
+
+	// Revogar derruba o acesso de outro cliente, então a chave precisa ter todos os escopos da revogada
+	for _, scope := range current.Scopes {
+		if !middleware.HasScope(c, scope) {
+			return h.errorResponse(c, fiber.StatusForbidden, "SCOPE_NOT_GRANTED", "A key can only revoke keys with scopes it has", "missing scope: "+scope)
+		}
+	}
+
+	revoked, err := h.apiKeyRepo.Revoke(c.Context(), sessionID, keyID)
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusInternalServerError, "REVOKE_FAILED", "Failed to revoke API key", err.Error())
+	}
+	if !revoked {
+		return h.errorResponse(c, fiber.StatusNotFound, "KEY_NOT_FOUND", "API key not found or already revoked", "")
+	}
+
+	key, err := h.apiKeyRepo.GetByID(c.Context(), sessionID, keyID)
+	if err != nil || key == nil {
+		h.logger.Warnf("Failed to load revoked API key %s: %v", keyID, err)
+		return c.Status(fiber.StatusOK).JSON(dto.APIKeyResponse{Success: true, Code: fiber.StatusOK})
+	}
+
+	info := toAPIKeyInfo(key, time.Now())
+	return c.Status(fiber.StatusOK).JSON(dto.APIKeyResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data:    &info,
+	})
+}
+
+// ListScopes godoc
+// @Summary List API key scopes
+// @Description Lists the scopes that can be granted to API keys. The global key and keys with the * scope have every scope.
+// @Tags API Keys
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Success 200 {object} dto.APIKeyScopesResponse "Scopes"
+// @Router /session/{sessionId}/keys/scopes [get]
+func (h *APIKeyHandler) ListScopes(c *fiber.Ctx) error {
+	scopes := middleware.Scopes()
+	items := make([]dto.APIKeyScope, 0, len(scopes))
+	for _, scope := range scopes {
+		items = append(items, dto.APIKeyScope{Name: scope.Name, Description: scope.Description})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.APIKeyScopesResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data:    items,
+	})
+}
+
+func toAPIKeyInfo(key *models.APIKeyModel, now time.Time) dto.APIKeyInfo {
+	scopes := []string(key.Scopes)
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	return dto.APIKeyInfo{
+		ID:          key.ID,
+		Name:        key.Name,
+		Prefix:      key.KeyPrefix,
+		Scopes:      scopes,
+		Active:      key.IsActive(now),
+		ExpiresAt:   key.ExpiresAt,
+		LastUsedAt:  key.LastUsedAt,
+		RevokedAt:   key.RevokedAt,
+		RotatedFrom: stringValue(key.RotatedFromId),
+		CreatedAt:   key.CreatedAt,
+	}
+}
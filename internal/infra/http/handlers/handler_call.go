@@ -0,0 +1,301 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"zpmeow/internal/application"
+	"zpmeow/internal/application/common"
+	"zpmeow/internal/infra/database/models"
+	"zpmeow/internal/infra/database/repository"
+	"zpmeow/internal/infra/http/dto"
+	"zpmeow/internal/infra/wmeow"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var callOutcomes = map[string]bool{
+	models.CallOutcomeRinging:      true,
+	models.CallOutcomeAccepted:     true,
+	models.CallOutcomeRejected:     true,
+	models.CallOutcomeAutoRejected: true,
+	models.CallOutcomeMissed:       true,
+}
+
+type CallHandler struct {
+	*BaseHandler
+	sessionService *application.SessionApp
+	wmeowService   wmeow.WameowService
+	policyRepo     *repository.CallPolicyRepository
+	callRepo       *repository.CallRepository
+}
+
+func NewCallHandler(sessionService *application.SessionApp, wmeowService wmeow.WameowService, policyRepo *repository.CallPolicyRepository, callRepo *repository.CallRepository) *CallHandler {
+	return &CallHandler{
+		BaseHandler:    NewBaseHandler("call-handler"),
+		sessionService: sessionService,
+		wmeowService:   wmeowService,
+		policyRepo:     policyRepo,
+		callRepo:       callRepo,
+	}
+}
+
+func (h *CallHandler) resolveSessionID(c *fiber.Ctx, sessionIDOrName string) (string, error) {
+	if h.sessionService == nil {
+		return sessionIDOrName, nil
+	}
+
+	ctx := c.Context()
+	session, err := h.sessionService.GetSession(ctx, sessionIDOrName)
+	if err != nil {
+		return "", err
+	}
+
+	return session.SessionID().Value(), nil
+}
+
+func (h *CallHandler) errorResponse(c *fiber.Ctx, status int, code, message, details string) error {
+	return c.Status(status).JSON(dto.CallResponse{
+		Success: false,
+		Code:    status,
+		Error: &dto.ErrorInfo{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+	})
+}
+
+// GetCallPolicy godoc
+// @Summary Get incoming call policy
+// @Description Returns what the session does with incoming 1:1 calls: ignore (default), reject, or reject and send reject_message to the caller
+// @Tags Calls
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Success 200 {object} dto.CallPolicyResponse "Call policy"
+// @Failure 404 {object} dto.CallPolicyResponse "Session not found"
+// @Failure 500 {object} dto.CallPolicyResponse "Failed to get call policy"
+// @Router /session/{sessionId}/call/policy [get]
+func (h *CallHandler) GetCallPolicy(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	policy, err := h.policyRepo.GetBySessionID(c.Context(), sessionID)
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusInternalServerError, "GET_POLICY_FAILED", "Failed to get call policy", err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.CallPolicyResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data:    toCallPolicyData(sessionID, policy),
+	})
+}
+
+// UpdateCallPolicy godoc
+// @Summary Update incoming call policy
+// @Description Sets what the session does with incoming 1:1 calls. "reject" declines the call; "reject_with_message" also sends reject_message to the caller.
+// @Description Group calls are only logged. Every call is stored in the call log and delivered as call.* events.
+// @Tags Calls
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param request body dto.CallPolicyRequest true "Call policy"
+// @Success 200 {object} dto.CallPolicyResponse "Call policy updated"
+// @Failure 400 {object} dto.CallPolicyResponse "Invalid request data"
+// @Failure 404 {object} dto.CallPolicyResponse "Session not found"
+// @Failure 500 {object} dto.CallPolicyResponse "Failed to update call policy"
+// @Router /session/{sessionId}/call/policy [put]
+func (h *CallHandler) UpdateCallPolicy(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	var req dto.CallPolicyRequest
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return h.errorResponse(c, fiber.StatusBadRequest, "VALIDATION_ERROR", "Invalid request data", err.Error())
+	}
+
+	policy := &models.CallPolicyModel{
+		SessionId: sessionID,
+		Action:    req.Action,
+	}
+	if message := strings.TrimSpace(req.RejectMessage); message != "" {
+		policy.RejectMessage = &message
+	}
+
+	if err := h.policyRepo.Upsert(c.Context(), policy); err != nil {
+		return h.errorResponse(c, fiber.StatusInternalServerError, "UPDATE_POLICY_FAILED", "Failed to update call policy", err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.CallPolicyResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data:    toCallPolicyData(sessionID, policy),
+	})
+}
+
+// RejectCall godoc
+// @Summary Reject an incoming call
+// @Description Rejects a ringing call. "from" (phone or JID of the caller) can be omitted when the call is in the call log.
+// @Tags Calls
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param request body dto.RejectCallRequest true "Call to reject"
+// @Success 200 {object} dto.CallResponse "Call rejected"
+// @Failure 400 {object} dto.CallResponse "Invalid request data"
+// @Failure 404 {object} dto.CallResponse "Session or call not found"
+// @Failure 500 {object} dto.CallResponse "Failed to reject call"
+// @Router /session/{sessionId}/call/reject [post]
+func (h *CallHandler) RejectCall(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	var req dto.RejectCallRequest
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return h.errorResponse(c, fiber.StatusBadRequest, "VALIDATION_ERROR", "Invalid request data", err.Error())
+	}
+
+	if err := h.wmeowService.RejectCall(c.Context(), sessionID, req.CallID, req.From); err != nil {
+		switch {
+		case errors.Is(err, common.ErrInvalidInput):
+			return h.errorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request data", err.Error())
+		case errors.Is(err, common.ErrCallNotFound):
+			return h.errorResponse(c, fiber.StatusNotFound, "CALL_NOT_FOUND", "Call not found in the call log; provide from", err.Error())
+		default:
+			return h.errorResponse(c, fiber.StatusInternalServerError, "REJECT_FAILED", "Failed to reject call", err.Error())
+		}
+	}
+
+	info := dto.CallInfo{CallID: req.CallID, Caller: req.From, Outcome: models.CallOutcomeRejected}
+	if call, err := h.callRepo.GetByCallID(c.Context(), sessionID, req.CallID); err != nil {
+		h.logger.Warnf("Failed to load rejected call %s: %v", req.CallID, err)
+	} else if call != nil {
+		info = toCallInfo(call)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.CallResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data:    &info,
+	})
+}
+
+// ListCalls godoc
+// @Summary List the call log
+// @Description Lists the calls received by the session, most recent first, with caller, time and outcome
+// @Tags Calls
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param outcome query string false "Outcome filter" Enums(ringing, accepted, rejected, auto_rejected, missed)
+// @Param caller query string false "Caller phone number or JID"
+// @Param limit query int false "Maximum number of calls to return" default(50)
+// @Param offset query int false "Number of calls to skip" default(0)
+// @Success 200 {object} dto.CallListResponse "Call log"
+// @Failure 400 {object} dto.CallListResponse "Invalid query parameters"
+// @Failure 404 {object} dto.CallListResponse "Session not found"
+// @Failure 500 {object} dto.CallListResponse "Failed to list calls"
+// @Router /session/{sessionId}/call/log [get]
+func (h *CallHandler) ListCalls(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	outcome := c.Query("outcome")
+	if outcome != "" && !callOutcomes[outcome] {
+		return h.errorResponse(c, fiber.StatusBadRequest, "INVALID_OUTCOME", "Invalid outcome filter", "unknown outcome: "+outcome)
+	}
+
+	caller := strings.TrimSpace(c.Query("caller"))
+	if caller != "" && !strings.Contains(caller, "@") {
+		caller = strings.TrimPrefix(caller, "+") + "@s.whatsapp.net"
+	}
+
+	limit, err := strconv.Atoi(c.Query("limit", "50"))
+	if err != nil || limit <= 0 || limit > 500 {
+		return h.errorResponse(c, fiber.StatusBadRequest, "INVALID_LIMIT", "limit must be a number between 1 and 500", "")
+	}
+
+	offset, err := strconv.Atoi(c.Query("offset", "0"))
+	if err != nil || offset < 0 {
+		return h.errorResponse(c, fiber.StatusBadRequest, "INVALID_OFFSET", "offset must be a non-negative number", "")
+	}
+
+	calls, total, err := h.callRepo.List(c.Context(), sessionID, outcome, caller, limit, offset)
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusInternalServerError, "LIST_FAILED", "Failed to list calls", err.Error())
+	}
+
+	items := make([]dto.CallInfo, 0, len(calls))
+	for _, call := range calls {
+		items = append(items, toCallInfo(call))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.CallListResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data: &dto.CallListData{
+			SessionId: sessionID,
+			Calls:     items,
+			Count:     len(items),
+			Total:     total,
+			Limit:     limit,
+			Offset:    offset,
+		},
+	})
+}
+
+func toCallPolicyData(sessionID string, policy *models.CallPolicyModel) *dto.CallPolicyData {
+	if policy == nil {
+		return &dto.CallPolicyData{SessionId: sessionID, Action: models.CallActionIgnore}
+	}
+
+	var updatedAt *time.Time
+	if !policy.UpdatedAt.IsZero() {
+		updatedAt = &policy.UpdatedAt
+	}
+
+	return &dto.CallPolicyData{
+		SessionId:     sessionID,
+		Action:        policy.Action,
+		RejectMessage: stringValue(policy.RejectMessage),
+		UpdatedAt:     updatedAt,
+	}
+}
+
+func toCallInfo(call *models.CallModel) dto.CallInfo {
+	return dto.CallInfo{
+		ID:             call.ID,
+		CallID:         call.CallId,
+		Caller:         call.CallerJid,
+		GroupJID:       stringValue(call.GroupJid),
+		IsGroup:        call.IsGroup,
+		IsVideo:        call.IsVideo,
+		Outcome:        call.Outcome,
+		Reason:         stringValue(call.Reason),
+		ReplyMessageID: stringValue(call.ReplyMessageId),
+		RemotePlatform: stringValue(call.RemotePlatform),
+		OfferedAt:      call.OfferedAt,
+		EndedAt:        call.EndedAt,
+	}
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
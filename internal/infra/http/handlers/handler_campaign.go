@@ -0,0 +1,505 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"zpmeow/internal/application"
+	"zpmeow/internal/application/common"
+	"zpmeow/internal/application/payloads"
+	"zpmeow/internal/infra/campaigns"
+	"zpmeow/internal/infra/database/models"
+	"zpmeow/internal/infra/http/dto"
+	"zpmeow/internal/infra/templates"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var campaignStatuses = map[string]bool{
+	models.CampaignStatusRunning:   true,
+	models.CampaignStatusPaused:    true,
+	models.CampaignStatusCompleted: true,
+	models.CampaignStatusCanceled:  true,
+}
+
+var recipientStatuses = map[string]bool{
+	models.RecipientStatusPending:    true,
+	models.RecipientStatusProcessing: true,
+	models.RecipientStatusSent:       true,
+	models.RecipientStatusFailed:     true,
+	models.RecipientStatusInvalid:    true,
+	models.RecipientStatusCanceled:   true,
+}
+
+type CampaignHandler struct {
+	*BaseHandler
+	sessionService *application.SessionApp
+	runner         *campaigns.Runner
+	templates      *templates.Service
+}
+
+func NewCampaignHandler(sessionService *application.SessionApp, runner *campaigns.Runner, templateService *templates.Service) *CampaignHandler {
+	return &CampaignHandler{
+		BaseHandler:    NewBaseHandler("campaign-handler"),
+		sessionService: sessionService,
+		runner:         runner,
+		templates:      templateService,
+	}
+}
+
+func (h *CampaignHandler) resolveSessionID(c *fiber.Ctx, sessionIDOrName string) (string, error) {
+	if h.sessionService == nil {
+		return sessionIDOrName, nil
+	}
+
+	ctx := c.Context()
+	session, err := h.sessionService.GetSession(ctx, sessionIDOrName)
+	if err != nil {
+		return "", err
+	}
+
+	return session.SessionID().Value(), nil
+}
+
+func (h *CampaignHandler) errorResponse(c *fiber.Ctx, status int, code, message, details string) error {
+	return c.Status(status).JSON(dto.CampaignResponse{
+		Success: false,
+		Code:    status,
+		Error: &dto.ErrorInfo{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+	})
+}
+
+// CreateCampaign godoc
+// @Summary Create a broadcast campaign
+// @Description Sends a text template to a list of recipients, one at a time, paced by rate_per_minute plus a random delay of up to jitter_seconds.
+// @Description Placeholders like {{name}} are filled from each recipient's variables ({{phone}} is always available).
+// @Description Recipients come from the JSON "recipients" array or from a multipart/form-data CSV upload in the "file" field with a "phone" column; other columns become variables.
+// @Description When window_start/window_end (HH:MM in "timezone") are set, messages are only sent inside that daily window.
+// @Description With check_numbers (default true) numbers are checked first and those not on WhatsApp are marked as invalid.
+// @Description Progress is published to webhooks as CampaignProgress and CampaignCompleted events.
+// @Description Instead of "template", template_id may reference a stored text template.
+// @Tags Campaigns
+// @Accept json
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param request body dto.CreateCampaignRequest true "Campaign"
+// @Success 201 {object} dto.CampaignResponse "Campaign created"
+// @Failure 400 {object} dto.CampaignResponse "Invalid request data"
+// @Failure 404 {object} dto.CampaignResponse "Session or template not found"
+// @Failure 500 {object} dto.CampaignResponse "Failed to create campaign"
+// @Router /session/{sessionId}/campaigns [post]
+func (h *CampaignHandler) CreateCampaign(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	var req dto.CreateCampaignRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.errorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err.Error())
+	}
+
+	if err := req.Validate(); err != nil {
+		return h.errorResponse(c, fiber.StatusBadRequest, "VALIDATION_ERROR", "Request validation failed", err.Error())
+	}
+
+	if req.TemplateID != "" {
+		body, status, err := h.templateBody(c, sessionID, req.TemplateID)
+		if err != nil {
+			return h.errorResponse(c, status, "INVALID_TEMPLATE", "Invalid campaign template", err.Error())
+		}
+		req.Template = body
+	}
+
+	recipients := make([]campaigns.Recipient, 0, len(req.Recipients))
+	for _, recipient := range req.Recipients {
+		recipients = append(recipients, campaigns.Recipient{Phone: recipient.Phone, Variables: recipient.Variables})
+	}
+
+	if strings.HasPrefix(string(c.Request().Header.ContentType()), fiber.MIMEMultipartForm) {
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			return h.errorResponse(c, fiber.StatusBadRequest, "VALIDATION_ERROR", "CSV file is required in the \"file\" field", err.Error())
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			return h.errorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Failed to read CSV file", err.Error())
+		}
+		defer file.Close()
+
+		recipients, err = campaigns.ParseRecipientsCSV(file)
+		if err != nil {
+			return h.errorResponse(c, fiber.StatusBadRequest, "INVALID_CSV", "Invalid recipients CSV", err.Error())
+		}
+	}
+
+	campaign, err := h.runner.Create(c.Context(), sessionID, campaigns.Spec{
+		Name:          req.Name,
+		Template:      req.Template,
+		Recipients:    recipients,
+		RatePerMinute: req.RatePerMinute,
+		JitterSeconds: req.JitterSeconds,
+		WindowStart:   req.WindowStart,
+		WindowEnd:     req.WindowEnd,
+		Timezone:      req.Timezone,
+		CheckNumbers:  req.CheckNumbers,
+	})
+	if err != nil {
+		if errors.Is(err, common.ErrInvalidInput) {
+			return h.errorResponse(c, fiber.StatusBadRequest, "VALIDATION_ERROR", "Invalid campaign", err.Error())
+		}
+		return h.errorResponse(c, fiber.StatusInternalServerError, "CREATE_FAILED", "Failed to create campaign", err.Error())
+	}
+
+	return h.campaignResponse(c, fiber.StatusCreated, campaign)
+}
+
+// ListCampaigns godoc
+// @Summary List campaigns
+// @Description Lists the campaigns of a session, newest first, optionally filtered by status
+// @Tags Campaigns
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param status query string false "Status filter" Enums(running, paused, completed, canceled)
+// @Param limit query int false "Maximum number of campaigns to return" default(50)
+// @Param offset query int false "Number of campaigns to skip" default(0)
+// @Success 200 {object} dto.CampaignListResponse "Campaigns"
+// @Failure 400 {object} dto.CampaignListResponse "Invalid query parameters"
+// @Failure 404 {object} dto.CampaignListResponse "Session not found"
+// @Failure 500 {object} dto.CampaignListResponse "Failed to list campaigns"
+// @Router /session/{sessionId}/campaigns [get]
+func (h *CampaignHandler) ListCampaigns(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	status := c.Query("status")
+	if status != "" && !campaignStatuses[status] {
+		return h.errorResponse(c, fiber.StatusBadRequest, "INVALID_STATUS", "Invalid status filter", "unknown status: "+status)
+	}
+
+	limit, err := strconv.Atoi(c.Query("limit", "50"))
+	if err != nil || limit <= 0 || limit > 500 {
+		return h.errorResponse(c, fiber.StatusBadRequest, "INVALID_LIMIT", "limit must be a number between 1 and 500", "")
+	}
+
+	offset, err := strconv.Atoi(c.Query("offset", "0"))
+	if err != nil || offset < 0 {
+		return h.errorResponse(c, fiber.StatusBadRequest, "INVALID_OFFSET", "offset must be a non-negative number", "")
+	}
+
+	items, total, err := h.runner.List(c.Context(), sessionID, status, limit, offset)
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusInternalServerError, "LIST_FAILED", "Failed to list campaigns", err.Error())
+	}
+
+	infos := make([]dto.CampaignInfo, 0, len(items))
+	for _, campaign := range items {
+		infos = append(infos, toCampaignInfo(campaign, nil))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.CampaignListResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data: &dto.CampaignListData{
+			SessionId: sessionID,
+			Campaigns: infos,
+			Count:     len(infos),
+			Total:     total,
+			Limit:     limit,
+			Offset:    offset,
+		},
+	})
+}
+
+// GetCampaign godoc
+// @Summary Get a campaign
+// @Description Retrieves a campaign with its per-status recipient counters
+// @Tags Campaigns
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param campaignId path string true "Campaign ID"
+// @Success 200 {object} dto.CampaignResponse "Campaign"
+// @Failure 404 {object} dto.CampaignResponse "Session or campaign not found"
+// @Failure 500 {object} dto.CampaignResponse "Failed to get campaign"
+// @Router /session/{sessionId}/campaigns/{campaignId} [get]
+func (h *CampaignHandler) GetCampaign(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	campaignID := c.Params("campaignId")
+	campaign, err := h.runner.Get(c.Context(), sessionID, campaignID)
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusInternalServerError, "GET_FAILED", "Failed to get campaign", err.Error())
+	}
+
+	if campaign == nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "CAMPAIGN_NOT_FOUND", "Campaign not found: "+campaignID, "")
+	}
+
+	return h.campaignResponse(c, fiber.StatusOK, campaign)
+}
+
+// ListCampaignRecipients godoc
+// @Summary List campaign recipients
+// @Description Lists the per-recipient results of a campaign in send order, optionally filtered by status
+// @Tags Campaigns
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param campaignId path string true "Campaign ID"
+// @Param status query string false "Status filter" Enums(pending, processing, sent, failed, invalid, canceled)
+// @Param limit query int false "Maximum number of recipients to return" default(50)
+// @Param offset query int false "Number of recipients to skip" default(0)
+// @Success 200 {object} dto.CampaignRecipientListResponse "Campaign recipients"
+// @Failure 400 {object} dto.CampaignRecipientListResponse "Invalid query parameters"
+// @Failure 404 {object} dto.CampaignRecipientListResponse "Session or campaign not found"
+// @Failure 500 {object} dto.CampaignRecipientListResponse "Failed to list recipients"
+// @Router /session/{sessionId}/campaigns/{campaignId}/recipients [get]
+func (h *CampaignHandler) ListCampaignRecipients(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	status := c.Query("status")
+	if status != "" && !recipientStatuses[status] {
+		return h.errorResponse(c, fiber.StatusBadRequest, "INVALID_STATUS", "Invalid status filter", "unknown status: "+status)
+	}
+
+	limit, err := strconv.Atoi(c.Query("limit", "50"))
+	if err != nil || limit <= 0 || limit > 500 {
+		return h.errorResponse(c, fiber.StatusBadRequest, "INVALID_LIMIT", "limit must be a number between 1 and 500", "")
+	}
+
+	offset, err := strconv.Atoi(c.Query("offset", "0"))
+	if err != nil || offset < 0 {
+		return h.errorResponse(c, fiber.StatusBadRequest, "INVALID_OFFSET", "offset must be a non-negative number", "")
+	}
+
+	campaignID := c.Params("campaignId")
+	campaign, err := h.runner.Get(c.Context(), sessionID, campaignID)
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusInternalServerError, "GET_FAILED", "Failed to get campaign", err.Error())
+	}
+	if campaign == nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "CAMPAIGN_NOT_FOUND", "Campaign not found: "+campaignID, "")
+	}
+
+	recipients, total, err := h.runner.ListRecipients(c.Context(), campaign.ID, status, limit, offset)
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusInternalServerError, "LIST_FAILED", "Failed to list recipients", err.Error())
+	}
+
+	items := make([]dto.CampaignRecipientInfo, 0, len(recipients))
+	for _, recipient := range recipients {
+		items = append(items, toCampaignRecipientInfo(recipient))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.CampaignRecipientListResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data: &dto.CampaignRecipientListData{
+			CampaignID: campaign.ID,
+			Recipients: items,
+			Count:      len(items),
+			Total:      total,
+			Limit:      limit,
+			Offset:     offset,
+		},
+	})
+}
+
+// PauseCampaign godoc
+// @Summary Pause a campaign
+// @Description Stops sending a running campaign until it is resumed
+// @Tags Campaigns
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param campaignId path string true "Campaign ID"
+// @Success 200 {object} dto.CampaignResponse "Campaign paused"
+// @Failure 404 {object} dto.CampaignResponse "Session or campaign not found"
+// @Failure 409 {object} dto.CampaignResponse "Campaign is not running"
+// @Failure 500 {object} dto.CampaignResponse "Failed to pause campaign"
+// @Router /session/{sessionId}/campaigns/{campaignId}/pause [post]
+func (h *CampaignHandler) PauseCampaign(c *fiber.Ctx) error {
+	return h.changeStatus(c, "PAUSE_FAILED", "Failed to pause campaign", h.runner.Pause)
+}
+
+// ResumeCampaign godoc
+// @Summary Resume a campaign
+// @Description Continues a paused campaign from the next pending recipient
+// @Tags Campaigns
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param campaignId path string true "Campaign ID"
+// @Success 200 {object} dto.CampaignResponse "Campaign resumed"
+// @Failure 404 {object} dto.CampaignResponse "Session or campaign not found"
+// @Failure 409 {object} dto.CampaignResponse "Campaign is not paused"
+// @Failure 500 {object} dto.CampaignResponse "Failed to resume campaign"
+// @Router /session/{sessionId}/campaigns/{campaignId}/resume [post]
+func (h *CampaignHandler) ResumeCampaign(c *fiber.Ctx) error {
+	return h.changeStatus(c, "RESUME_FAILED", "Failed to resume campaign", h.runner.Resume)
+}
+
+// CancelCampaign godoc
+// @Summary Cancel a campaign
+// @Description Cancels a running or paused campaign; recipients not sent yet are marked as canceled
+// @Tags Campaigns
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param campaignId path string true "Campaign ID"
+// @Success 200 {object} dto.CampaignResponse "Campaign canceled"
+// @Failure 404 {object} dto.CampaignResponse "Session or campaign not found"
+// @Failure 409 {object} dto.CampaignResponse "Campaign already completed or canceled"
+// @Failure 500 {object} dto.CampaignResponse "Failed to cancel campaign"
+// @Router /session/{sessionId}/campaigns/{campaignId}/cancel [post]
+func (h *CampaignHandler) CancelCampaign(c *fiber.Ctx) error {
+	return h.changeStatus(c, "CANCEL_FAILED", "Failed to cancel campaign", h.runner.Cancel)
+}
+
+func (h *CampaignHandler) changeStatus(c *fiber.Ctx, failureCode, failureMessage string, change func(ctx context.Context, sessionID, id string) (*models.CampaignModel, error)) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	campaignID := c.Params("campaignId")
+	campaign, err := change(c.Context(), sessionID, campaignID)
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusInternalServerError, failureCode, failureMessage, err.Error())
+	}
+
+	if campaign != nil {
+		return h.campaignResponse(c, fiber.StatusOK, campaign)
+	}
+
+	// Nada mudou: campanha inexistente (404) ou em um status que não permite a transição (409)
+	existing, err := h.runner.Get(c.Context(), sessionID, campaignID)
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusInternalServerError, "GET_FAILED", "Failed to get campaign", err.Error())
+	}
+	if existing == nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "CAMPAIGN_NOT_FOUND", "Campaign not found: "+campaignID, "")
+	}
+
+	return h.errorResponse(c, fiber.StatusConflict, "INVALID_CAMPAIGN_STATUS",
+		"Campaign status does not allow this action", "current status: "+existing.Status)
+}
+
+// templateBody carrega o texto de um template salvo; campanhas só enviam texto
+func (h *CampaignHandler) templateBody(c *fiber.Ctx, sessionID, templateID string) (string, int, error) {
+	template, err := h.templates.Get(c.Context(), sessionID, templateID)
+	if err != nil {
+		return "", fiber.StatusInternalServerError, err
+	}
+	if template == nil {
+		return "", fiber.StatusNotFound, fmt.Errorf("message template not found: %s", templateID)
+	}
+	if template.MessageType != "text" {
+		return "", fiber.StatusBadRequest, fmt.Errorf("campaigns only support text templates, got %q", template.MessageType)
+	}
+
+	var content payloads.SendTextRequest
+	if err := json.Unmarshal(template.Content, &content); err != nil {
+		return "", fiber.StatusInternalServerError, fmt.Errorf("invalid template content: %w", err)
+	}
+	return content.Body, 0, nil
+}
+
+func (h *CampaignHandler) campaignResponse(c *fiber.Ctx, status int, campaign *models.CampaignModel) error {
+	stats, err := h.runner.Stats(c.Context(), campaign.ID)
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusInternalServerError, "STATS_FAILED", "Failed to get campaign stats", err.Error())
+	}
+
+	info := toCampaignInfo(campaign, stats)
+	return c.Status(status).JSON(dto.CampaignResponse{
+		Success: true,
+		Code:    status,
+		Data:    &info,
+	})
+}
+
+func toCampaignInfo(campaign *models.CampaignModel, stats *models.CampaignStats) dto.CampaignInfo {
+	info := dto.CampaignInfo{
+		ID:              campaign.ID,
+		SessionId:       campaign.SessionId,
+		Name:            campaign.Name,
+		Template:        campaign.Template,
+		Status:          campaign.Status,
+		RatePerMinute:   campaign.RatePerMinute,
+		JitterSeconds:   campaign.JitterSeconds,
+		Timezone:        campaign.Timezone,
+		CheckNumbers:    campaign.CheckNumbers,
+		TotalRecipients: campaign.TotalRecipients,
+		LastSentAt:      campaign.LastSentAt,
+		CompletedAt:     campaign.CompletedAt,
+		CreatedAt:       campaign.CreatedAt,
+		UpdatedAt:       campaign.UpdatedAt,
+	}
+	if campaign.WindowStart != nil {
+		info.WindowStart = *campaign.WindowStart
+	}
+	if campaign.WindowEnd != nil {
+		info.WindowEnd = *campaign.WindowEnd
+	}
+	if stats != nil {
+		info.Stats = &dto.CampaignStats{
+			Total:      stats.Total,
+			Pending:    stats.Pending,
+			Processing: stats.Processing,
+			Sent:       stats.Sent,
+			Failed:     stats.Failed,
+			Invalid:    stats.Invalid,
+			Canceled:   stats.Canceled,
+		}
+	}
+	return info
+}
+
+func toCampaignRecipientInfo(recipient *models.CampaignRecipientModel) dto.CampaignRecipientInfo {
+	info := dto.CampaignRecipientInfo{
+		ID:          recipient.ID,
+		Position:    recipient.Position,
+		Phone:       recipient.Phone,
+		Variables:   recipient.Variables,
+		Status:      recipient.Status,
+		ProcessedAt: recipient.ProcessedAt,
+	}
+	if recipient.Jid != nil {
+		info.JID = *recipient.Jid
+	}
+	if recipient.MessageId != nil {
+		info.MessageID = *recipient.MessageId
+	}
+	if recipient.Error != nil {
+		info.Error = *recipient.Error
+	}
+	return info
+}
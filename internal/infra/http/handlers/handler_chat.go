@@ -1,11 +1,14 @@
 package handlers
 
 import (
+	"errors"
 	"strconv"
 	"strings"
 	"time"
 
 	"zpmeow/internal/application"
+	"zpmeow/internal/application/common"
+	"zpmeow/internal/infra/database/repository"
 	"zpmeow/internal/infra/http/dto"
 	"zpmeow/internal/infra/wmeow"
 
@@ -13,17 +16,37 @@ import (
 )
 
 type ChatHandler struct {
-	chatService  *application.ChatApp
-	wmeowService wmeow.WameowService
+	chatService     *application.ChatApp
+	wmeowService    wmeow.WameowService
+	sessionService  *application.SessionApp
+	messageRepo     *repository.MessageRepository
+	historySyncRepo *repository.HistorySyncRepository
 }
 
-func NewChatHandler(chatService *application.ChatApp, wmeowService wmeow.WameowService) *ChatHandler {
+func NewChatHandler(chatService *application.ChatApp, wmeowService wmeow.WameowService, sessionService *application.SessionApp, messageRepo *repository.MessageRepository, historySyncRepo *repository.HistorySyncRepository) *ChatHandler {
 	return &ChatHandler{
-		chatService:  chatService,
-		wmeowService: wmeowService,
+		chatService:     chatService,
+		wmeowService:    wmeowService,
+		sessionService:  sessionService,
+		messageRepo:     messageRepo,
+		historySyncRepo: historySyncRepo,
 	}
 }
 
+func (h *ChatHandler) resolveSessionID(c *fiber.Ctx, sessionIDOrName string) (string, error) {
+	if h.sessionService == nil {
+		return sessionIDOrName, nil
+	}
+
+	ctx := c.Context()
+	session, err := h.sessionService.GetSession(ctx, sessionIDOrName)
+	if err != nil {
+		return "", err
+	}
+
+	return session.SessionID().Value(), nil
+}
+
 // SetPresence godoc
 // @Summary Set chat presence
 // @Description Sets presence status (typing, recording, etc.) for a chat
@@ -178,6 +201,14 @@ func (h *ChatHandler) downloadMedia(c *fiber.Ctx, mediaType string) error {
 
 	ctx := c.Context()
 	data, mimeType, err := h.wmeowService.DownloadMedia(ctx, sessionID, req.MessageID)
+	if errors.Is(err, common.ErrMediaNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(dto.NewChatErrorResponse(
+			fiber.StatusNotFound,
+			"MEDIA_NOT_FOUND",
+			"Media not found",
+			err.Error(),
+		))
+	}
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(dto.NewChatErrorResponse(
 			fiber.StatusInternalServerError,
@@ -202,27 +233,275 @@ func (h *ChatHandler) downloadMedia(c *fiber.Ctx, mediaType string) error {
 	return c.Status(fiber.StatusOK).JSON(response)
 }
 
+// GetHistorySyncStatus godoc
+// @Summary Get history sync status
+// @Description Returns the progress of the history import sent by the phone after pairing.
+// @Description Status is idle while no history chunk has been received for the session.
+// @Tags Chat
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Success 200 {object} dto.HistorySyncStatusResponse "History sync status"
+// @Failure 401 {object} dto.HistorySyncStatusResponse "Unauthorized - Invalid API key"
+// @Failure 404 {object} dto.HistorySyncStatusResponse "Session not found"
+// @Failure 500 {object} dto.HistorySyncStatusResponse "Failed to get history sync status"
+// @Router /session/{sessionId}/chat/history/sync [get]
+func (h *ChatHandler) GetHistorySyncStatus(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.historySyncErrorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	sync, err := h.historySyncRepo.GetBySessionID(c.Context(), sessionID)
+	if err != nil {
+		return h.historySyncErrorResponse(c, fiber.StatusInternalServerError, "GET_HISTORY_SYNC_FAILED", "Failed to get history sync status", err.Error())
+	}
+
+	data := &dto.HistorySyncStatusData{Status: "idle"}
+	if sync != nil {
+		data = &dto.HistorySyncStatusData{
+			Status:          sync.Status,
+			SyncType:        sync.SyncType,
+			Progress:        sync.Progress,
+			Chunks:          sync.Chunks,
+			Conversations:   sync.Conversations,
+			MessagesStored:  sync.MessagesStored,
+			MessagesSkipped: sync.MessagesSkipped,
+			StartedAt:       &sync.StartedAt,
+			LastChunkAt:     &sync.LastChunkAt,
+			CompletedAt:     sync.CompletedAt,
+		}
+		if sync.LastError != nil {
+			data.LastError = *sync.LastError
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(&dto.HistorySyncStatusResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data:    data,
+	})
+}
+
+func (h *ChatHandler) historySyncErrorResponse(c *fiber.Ctx, status int, code, message, details string) error {
+	return c.Status(status).JSON(&dto.HistorySyncStatusResponse{
+		Success: false,
+		Code:    status,
+		Error: &dto.ChatErrorResponse{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+	})
+}
+
+// SearchMessages godoc
+// @Summary Search stored messages
+// @Description Full-text search over stored message text, captions and file names (Portuguese stemming, accent-insensitive).
+// @Description Supports "exact phrases", OR and -exclusions. Results are ordered from newest to oldest; pass next_cursor as cursor to get the next page.
+// @Tags Chat
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param q query string true "Search terms"
+// @Param chat query string false "Chat phone number or JID"
+// @Param sender query string false "Sender phone number or JID"
+// @Param type query string false "Message types, comma separated (e.g. text,image)"
+// @Param from query string false "Start of the period, inclusive (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "End of the period, exclusive (RFC3339 or YYYY-MM-DD)"
+// @Param is_from_me query bool false "Only messages sent (true) or received (false) by the session"
+// @Param limit query int false "Number of results (1-100)" default(20)
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Success 200 {object} dto.MessageSearchResponse "Search results"
+// @Failure 400 {object} dto.MessageSearchResponse "Invalid search parameters"
+// @Failure 401 {object} dto.MessageSearchResponse "Unauthorized - Invalid API key"
+// @Failure 404 {object} dto.MessageSearchResponse "Session not found"
+// @Failure 500 {object} dto.MessageSearchResponse "Failed to search messages"
+// @Router /session/{sessionId}/chat/search [get]
+func (h *ChatHandler) SearchMessages(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.searchErrorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		return h.searchErrorResponse(c, fiber.StatusBadRequest, "MISSING_QUERY", "Search query is required", "")
+	}
+	if len(query) > 500 {
+		return h.searchErrorResponse(c, fiber.StatusBadRequest, "INVALID_QUERY", "Search query is too long", "Query must not exceed 500 characters")
+	}
+
+	limit, err := strconv.Atoi(c.Query("limit", "20"))
+	if err != nil || limit < 1 || limit > 100 {
+		return h.searchErrorResponse(c, fiber.StatusBadRequest, "INVALID_LIMIT", "Invalid limit parameter", "Limit must be between 1 and 100")
+	}
+
+	filter := repository.MessageSearchFilter{
+		SessionID: sessionID,
+		Query:     query,
+		ChatJID:   searchChatJID(c.Query("chat")),
+		Sender:    searchSender(c.Query("sender")),
+		Limit:     limit,
+	}
+
+	for _, messageType := range strings.Split(c.Query("type"), ",") {
+		if messageType = strings.TrimSpace(messageType); messageType != "" {
+			filter.Types = append(filter.Types, messageType)
+		}
+	}
+
+	if from := c.Query("from"); from != "" {
+		parsed, err := parseSearchTime(from)
+		if err != nil {
+			return h.searchErrorResponse(c, fiber.StatusBadRequest, "INVALID_FROM", "Invalid from parameter", err.Error())
+		}
+		filter.From = &parsed
+	}
+
+	if to := c.Query("to"); to != "" {
+		parsed, err := parseSearchTime(to)
+		if err != nil {
+			return h.searchErrorResponse(c, fiber.StatusBadRequest, "INVALID_TO", "Invalid to parameter", err.Error())
+		}
+		filter.To = &parsed
+	}
+
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return h.searchErrorResponse(c, fiber.StatusBadRequest, "INVALID_PERIOD", "Invalid period", "from must be before to")
+	}
+
+	if isFromMe := c.Query("is_from_me"); isFromMe != "" {
+		parsed, err := strconv.ParseBool(isFromMe)
+		if err != nil {
+			return h.searchErrorResponse(c, fiber.StatusBadRequest, "INVALID_IS_FROM_ME", "Invalid is_from_me parameter", "is_from_me must be true or false")
+		}
+		filter.IsFromMe = &parsed
+	}
+
+	if cursor := c.Query("cursor"); cursor != "" {
+		decoded, err := repository.DecodeMessageCursor(cursor)
+		if err != nil {
+			return h.searchErrorResponse(c, fiber.StatusBadRequest, "INVALID_CURSOR", "Invalid cursor parameter", err.Error())
+		}
+		filter.Cursor = decoded
+	}
+
+	hits, nextCursor, err := h.messageRepo.SearchMessages(c.Context(), filter)
+	if err != nil {
+		return h.searchErrorResponse(c, fiber.StatusInternalServerError, "SEARCH_MESSAGES_FAILED", "Failed to search messages", err.Error())
+	}
+
+	results := make([]dto.MessageSearchResult, 0, len(hits))
+	for _, hit := range hits {
+		result := dto.MessageSearchResult{
+			ID:        hit.ID,
+			MessageID: hit.MsgId,
+			ChatJID:   hit.ChatJid,
+			Sender:    hit.SenderJid,
+			Type:      hit.MsgType,
+			Snippet:   hit.Snippet,
+			Rank:      hit.Rank,
+			FromMe:    hit.IsFromMe,
+			Timestamp: hit.Timestamp,
+		}
+		if hit.SenderName != nil {
+			result.SenderName = *hit.SenderName
+		}
+		if hit.Content != nil {
+			result.Content = *hit.Content
+		}
+		results = append(results, result)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(&dto.MessageSearchResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data: &dto.MessageSearchData{
+			Query:      query,
+			Results:    results,
+			Count:      len(results),
+			Limit:      limit,
+			NextCursor: nextCursor,
+		},
+	})
+}
+
+func (h *ChatHandler) searchErrorResponse(c *fiber.Ctx, status int, code, message, details string) error {
+	return c.Status(status).JSON(&dto.MessageSearchResponse{
+		Success: false,
+		Code:    status,
+		Error: &dto.ChatErrorResponse{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+	})
+}
+
+// searchChatJID aceita número ou JID; número sem domínio vira contato individual
+func searchChatJID(chat string) string {
+	chat = strings.TrimSpace(chat)
+	if chat == "" || strings.Contains(chat, "@") {
+		return chat
+	}
+	return strings.TrimPrefix(chat, "+") + "@s.whatsapp.net"
+}
+
+// searchSender reduz número ou JID à parte do usuário, sem domínio e sem device
+func searchSender(sender string) string {
+	sender = strings.TrimSpace(sender)
+	sender, _, _ = strings.Cut(sender, "@")
+	sender, _, _ = strings.Cut(sender, ":")
+	return strings.TrimPrefix(sender, "+")
+}
+
+// parseSearchTime aceita RFC3339 ou apenas a data (YYYY-MM-DD, em UTC)
+func parseSearchTime(value string) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	parsed, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, errors.New("must be RFC3339 or YYYY-MM-DD")
+	}
+	return parsed, nil
+}
+
 // GetChatHistory godoc
 // @Summary Get chat history
-// @Description Retrieves message history for a specific chat
+// @Description Retrieves stored message history for a specific chat, newest first, with cursor pagination.
+// @Description Pass before_cursor as before to page back to older messages, or after_cursor as after to fetch newer ones.
+// @Description before/after also accept the WhatsApp ID of a message of the chat.
 // @Tags Chat
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
 // @Param sessionId path string true "Session ID"
 // @Param phone query string true "Phone number or JID"
-// @Param limit query int false "Number of messages to retrieve" default(50)
-// @Success 200 {object} dto.ChatResponse "Chat history"
-// @Failure 400 {object} dto.ChatResponse "Invalid request data"
-// @Failure 401 {object} dto.ChatResponse "Unauthorized - Invalid API key" "Invalid request data"
-// @Failure 404 {object} dto.ChatResponse "Session not found"
-// @Failure 500 {object} dto.ChatResponse "Failed to get chat history"
+// @Param limit query int false "Number of messages to retrieve (1-1000)" default(50)
+// @Param before query string false "Cursor or message ID; returns messages older than it"
+// @Param after query string false "Cursor or message ID; returns messages newer than it"
+// @Param type query string false "Message types, comma separated (e.g. text,image)"
+// @Param sender query string false "Sender phone number or JID"
+// @Success 200 {object} dto.ChatHistoryResponse "Chat history"
+// @Failure 400 {object} dto.ChatHistoryResponse "Invalid request data"
+// @Failure 401 {object} dto.ChatHistoryResponse "Unauthorized - Invalid API key"
+// @Failure 404 {object} dto.ChatHistoryResponse "Session or cursor message not found"
+// @Failure 500 {object} dto.ChatHistoryResponse "Failed to get chat history"
 // @Router /session/{sessionId}/chat/history [get]
 func (h *ChatHandler) GetChatHistory(c *fiber.Ctx) error {
-	sessionID := c.Params("sessionId")
-	phone := c.Query("phone")
-	limitStr := c.Query("limit", "50")
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(dto.NewChatErrorResponse(
+			fiber.StatusNotFound,
+			"SESSION_NOT_FOUND",
+			"Session not found",
+			err.Error(),
+		))
+	}
 
+	phone := c.Query("phone")
 	if phone == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.NewChatErrorResponse(
 			fiber.StatusBadRequest,
@@ -232,66 +511,129 @@ func (h *ChatHandler) GetChatHistory(c *fiber.Ctx) error {
 		))
 	}
 
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil {
+	limit, err := strconv.Atoi(c.Query("limit", "50"))
+	if err != nil || limit < 1 || limit > 1000 {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.NewChatErrorResponse(
 			fiber.StatusBadRequest,
 			"INVALID_LIMIT",
 			"Invalid limit parameter",
-			err.Error(),
+			"Limit must be between 1 and 1000",
 		))
 	}
 
-	ctx := c.Context()
 	req := application.GetChatHistoryRequest{
 		SessionID: sessionID,
 		Phone:     phone,
 		Limit:     limit,
-		Offset:    0,
+		Before:    c.Query("before"),
+		After:     c.Query("after"),
+		Sender:    searchSender(c.Query("sender")),
+	}
+
+	if req.Before != "" && req.After != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.NewChatErrorResponse(
+			fiber.StatusBadRequest,
+			"INVALID_CURSOR",
+			"Invalid pagination parameters",
+			"before and after cannot be used together",
+		))
+	}
+
+	for _, messageType := range strings.Split(c.Query("type"), ",") {
+		if messageType = strings.TrimSpace(messageType); messageType != "" {
+			req.Types = append(req.Types, messageType)
+		}
 	}
 
-	result, err := h.chatService.GetChatHistory(ctx, req)
+	result, err := h.chatService.GetChatHistory(c.Context(), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.NewChatErrorResponse(
-			fiber.StatusInternalServerError,
-			"GET_CHAT_HISTORY_FAILED",
+		status, code := fiber.StatusInternalServerError, "GET_CHAT_HISTORY_FAILED"
+		switch {
+		case errors.Is(err, common.ErrInvalidInput):
+			status, code = fiber.StatusBadRequest, "INVALID_CURSOR"
+		case errors.Is(err, common.ErrMessageNotFound):
+			status, code = fiber.StatusNotFound, "MESSAGE_NOT_FOUND"
+		}
+		return c.Status(status).JSON(dto.NewChatErrorResponse(
+			status,
+			code,
 			"Failed to get chat history",
 			err.Error(),
 		))
 	}
 
-	var messages []dto.ChatHistoryData
+	messages := make([]dto.ChatHistoryData, 0, len(result.Messages))
 	for _, message := range result.Messages {
-		timestamp := int64(0)
-		if message.Timestamp != "" {
-			timestamp = time.Now().Unix()
+		data := dto.ChatHistoryData{
+			MessageID:  message.ID,
+			From:       message.FromJID,
+			To:         message.ChatJID,
+			SenderName: message.SenderName,
+			Type:       message.Type,
+			Content:    message.Content,
+			Timestamp:  message.Timestamp.Unix(),
+			FromMe:     message.IsFromMe,
+			Forwarded:  message.IsForwarded,
+			Status:     message.Status,
+			Mentions:   message.Mentions,
+			Edited:     message.EditedAt != nil,
+			EditedAt:   unixPointer(message.EditedAt),
+			Deleted:    message.IsDeleted,
+			DeletedAt:  unixPointer(message.DeletedAt),
+			Cursor:     message.Cursor,
+		}
+
+		if message.Media != nil {
+			data.Media = &dto.ChatHistoryMedia{
+				MimeType:   message.Media.MimeType,
+				FileName:   message.Media.FileName,
+				FileLength: message.Media.FileLength,
+				MediaID:    message.Media.MediaID,
+			}
 		}
 
-		messages = append(messages, dto.ChatHistoryData{
-			MessageID: message.ID,
-			From:      message.FromJID,
-			To:        message.ChatJID,
-			Type:      message.Type,
-			Content:   message.Content,
-			Timestamp: timestamp,
-			FromMe:    message.IsFromMe,
-		})
+		if message.Quoted != nil {
+			data.Quoted = &dto.ChatHistoryQuote{
+				MessageID: message.Quoted.MessageID,
+				Content:   message.Quoted.Content,
+			}
+		}
+
+		for _, reaction := range message.Reactions {
+			data.Reactions = append(data.Reactions, dto.ChatHistoryReaction{
+				Emoji:  reaction.Emoji,
+				Sender: reaction.SenderJID,
+			})
+		}
+
+		messages = append(messages, data)
 	}
 
 	response := &dto.ChatHistoryResponse{
 		Success: true,
 		Code:    fiber.StatusOK,
 		Data: &dto.ChatHistoryResponseData{
-			Phone:    phone,
-			Messages: messages,
-			Count:    result.Count,
-			Limit:    limit,
+			Phone:        phone,
+			Messages:     messages,
+			Count:        len(messages),
+			Limit:        limit,
+			HasMore:      result.HasMore,
+			BeforeCursor: result.BeforeCursor,
+			AfterCursor:  result.AfterCursor,
 		},
 	}
 
 	return c.Status(fiber.StatusOK).JSON(response)
 }
 
+func unixPointer(t *time.Time) *int64 {
+	if t == nil {
+		return nil
+	}
+	unix := t.Unix()
+	return &unix
+}
+
 // SetDisappearingTimer godoc
 // @Summary Set disappearing message timer
 // @Description Sets the disappearing message timer for a WhatsApp chat
@@ -374,7 +716,7 @@ func (h *ChatHandler) SetDisappearingTimer(c *fiber.Ctx) error {
 
 // ListChats godoc
 // @Summary List chats
-// @Description Retrieves a list of chats for a session
+// @Description Retrieves a list of chats for a session, optionally only the chats with a WhatsApp Business label
 // @Tags Chat
 // @Accept json
 // @Produce json
@@ -384,7 +726,7 @@ func (h *ChatHandler) SetDisappearingTimer(c *fiber.Ctx) error {
 // @Success 200 {object} dto.ChatResponse "Chats list"
 // @Failure 400 {object} dto.ChatResponse "Invalid request data"
 // @Failure 401 {object} dto.ChatResponse "Unauthorized - Invalid API key" "Invalid request data"
-// @Failure 404 {object} dto.ChatResponse "Session not found"
+// @Failure 404 {object} dto.ChatResponse "Session or label not found"
 // @Failure 500 {object} dto.ChatResponse "Failed to list chats"
 // @Router /session/{sessionId}/chat/list [post]
 func (h *ChatHandler) ListChats(c *fiber.Ctx) error {
@@ -415,8 +757,16 @@ func (h *ChatHandler) ListChats(c *fiber.Ctx) error {
 	}
 
 	ctx := c.Context()
-	chats, err := h.wmeowService.ListChats(ctx, sessionID, req.Type)
+	chats, err := h.wmeowService.ListChats(ctx, sessionID, req.Type, strings.TrimSpace(req.Label))
 	if err != nil {
+		if errors.Is(err, common.ErrLabelNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(dto.NewListChatsErrorResponse(
+				fiber.StatusNotFound,
+				"LABEL_NOT_FOUND",
+				"Label not found",
+				err.Error(),
+			))
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(dto.NewListChatsErrorResponse(
 			fiber.StatusInternalServerError,
 			"LIST_CHATS_FAILED",
@@ -440,7 +790,9 @@ func (h *ChatHandler) ListChats(c *fiber.Ctx) error {
 			UnreadCount: chat.UnreadCount,
 			Pinned:      chat.IsPinned,
 			Muted:       chat.IsMuted,
+			MutedUntil:  chat.MutedUntil,
 			Archived:    chat.IsArchived,
+			Unread:      chat.MarkedUnread,
 		}
 	}
 
@@ -509,7 +861,9 @@ func (h *ChatHandler) GetChatInfo(c *fiber.Ctx) error {
 		UnreadCount: chatInfo.UnreadCount,
 		Pinned:      chatInfo.IsPinned,
 		Muted:       chatInfo.IsMuted,
+		MutedUntil:  chatInfo.MutedUntil,
 		Archived:    chatInfo.IsArchived,
+		Unread:      chatInfo.MarkedUnread,
 	}
 
 	response := dto.NewGetChatInfoSuccessResponse(dtoChatInfo)
@@ -707,3 +1061,60 @@ func (h *ChatHandler) ArchiveChat(c *fiber.Ctx) error {
 	response := dto.NewChatSuccessResponse(req.JID, "", action)
 	return c.Status(fiber.StatusOK).JSON(response)
 }
+
+// MarkChatRead godoc
+// @Summary Mark chat as read or unread
+// @Description Marks a whole WhatsApp chat as read or unread on every linked device. Marking as read also clears the unread counter.
+// @Tags Chat
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param request body dto.MarkChatReadRequest true "Mark chat read request"
+// @Success 200 {object} dto.ChatResponse "Chat marked as read/unread successfully"
+// @Failure 400 {object} dto.ChatResponse "Invalid request data"
+// @Failure 401 {object} dto.ChatResponse "Unauthorized - Invalid API key"
+// @Failure 404 {object} dto.ChatResponse "Session not found"
+// @Failure 500 {object} dto.ChatResponse "Failed to mark chat as read/unread"
+// @Router /session/{sessionId}/chat/read [post]
+func (h *ChatHandler) MarkChatRead(c *fiber.Ctx) error {
+	sessionID := c.Params("sessionId")
+
+	var req dto.MarkChatReadRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.NewChatErrorResponse(
+			fiber.StatusBadRequest,
+			"INVALID_REQUEST",
+			"Invalid request format",
+			err.Error(),
+		))
+	}
+
+	if req.JID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.NewChatErrorResponse(
+			fiber.StatusBadRequest,
+			"MISSING_JID",
+			"JID is required",
+			"",
+		))
+	}
+
+	ctx := c.Context()
+	err := h.wmeowService.MarkChatRead(ctx, sessionID, req.JID, req.Read)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.NewChatErrorResponse(
+			fiber.StatusInternalServerError,
+			"MARK_CHAT_READ_FAILED",
+			"Failed to mark chat as read/unread",
+			err.Error(),
+		))
+	}
+
+	action := "mark_chat_unread"
+	if req.Read {
+		action = "mark_chat_read"
+	}
+
+	response := dto.NewChatSuccessResponse(req.JID, "", action)
+	return c.Status(fiber.StatusOK).JSON(response)
+}
@@ -0,0 +1,306 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"zpmeow/internal/application"
+	"zpmeow/internal/application/common"
+	"zpmeow/internal/application/ports"
+	"zpmeow/internal/infra/http/dto"
+	"zpmeow/internal/infra/wmeow"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type LabelHandler struct {
+	*BaseHandler
+	sessionService *application.SessionApp
+	wmeowService   wmeow.WameowService
+}
+
+func NewLabelHandler(sessionService *application.SessionApp, wmeowService wmeow.WameowService) *LabelHandler {
+	return &LabelHandler{
+		BaseHandler:    NewBaseHandler("label-handler"),
+		sessionService: sessionService,
+		wmeowService:   wmeowService,
+	}
+}
+
+func (h *LabelHandler) resolveSessionID(c *fiber.Ctx, sessionIDOrName string) (string, error) {
+	if h.sessionService == nil {
+		return sessionIDOrName, nil
+	}
+
+	ctx := c.Context()
+	session, err := h.sessionService.GetSession(ctx, sessionIDOrName)
+	if err != nil {
+		return "", err
+	}
+
+	return session.SessionID().Value(), nil
+}
+
+func (h *LabelHandler) errorResponse(c *fiber.Ctx, status int, code, message, details string) error {
+	return c.Status(status).JSON(dto.LabelResponse{
+		Success: false,
+		Code:    status,
+		Error: &dto.ErrorInfo{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+	})
+}
+
+func (h *LabelHandler) labelError(c *fiber.Ctx, err error, code, message string) error {
+	switch {
+	case errors.Is(err, common.ErrInvalidInput):
+		return h.errorResponse(c, fiber.StatusBadRequest, "VALIDATION_ERROR", message, err.Error())
+	case errors.Is(err, common.ErrLabelNotFound):
+		return h.errorResponse(c, fiber.StatusNotFound, "LABEL_NOT_FOUND", "Label not found", err.Error())
+	case strings.Contains(err.Error(), "client not found"):
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	case strings.Contains(err.Error(), "not connected"):
+		return h.errorResponse(c, fiber.StatusServiceUnavailable, "SESSION_NOT_CONNECTED", "Session not connected", err.Error())
+	default:
+		return h.errorResponse(c, fiber.StatusInternalServerError, code, message, err.Error())
+	}
+}
+
+// ListLabels godoc
+// @Summary List labels
+// @Description Lists the WhatsApp Business labels of the session with the number of labeled chats.
+// @Description Labels are synced from the app state, including the ones created or edited on the phone.
+// @Tags Labels
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Success 200 {object} dto.LabelListResponse "Labels"
+// @Failure 404 {object} dto.LabelListResponse "Session not found"
+// @Failure 500 {object} dto.LabelListResponse "Failed to list labels"
+// @Router /session/{sessionId}/labels [get]
+func (h *LabelHandler) ListLabels(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	labels, err := h.wmeowService.ListLabels(c.Context(), sessionID)
+	if err != nil {
+		return h.labelError(c, err, "LIST_FAILED", "Failed to list labels")
+	}
+
+	items := make([]dto.LabelInfo, 0, len(labels))
+	for _, label := range labels {
+		items = append(items, toLabelInfo(label))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.LabelListResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data: &dto.LabelListData{
+			SessionId: sessionID,
+			Labels:    items,
+			Count:     len(items),
+		},
+	})
+}
+
+// CreateLabel godoc
+// @Summary Create a label
+// @Description Creates a WhatsApp Business label on every linked device. color is the index in the WhatsApp palette (0-19, default 0).
+// @Tags Labels
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param request body dto.LabelRequest true "Label"
+// @Success 201 {object} dto.LabelResponse "Label created"
+// @Failure 400 {object} dto.LabelResponse "Invalid request data"
+// @Failure 404 {object} dto.LabelResponse "Session not found"
+// @Failure 503 {object} dto.LabelResponse "Session not connected"
+// @Failure 500 {object} dto.LabelResponse "Failed to create label"
+// @Router /session/{sessionId}/labels [post]
+func (h *LabelHandler) CreateLabel(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	var req dto.LabelRequest
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return h.errorResponse(c, fiber.StatusBadRequest, "VALIDATION_ERROR", "Invalid request data", err.Error())
+	}
+
+	color := 0
+	if req.Color != nil {
+		color = *req.Color
+	}
+
+	label, err := h.wmeowService.CreateLabel(c.Context(), sessionID, req.Name, color)
+	if err != nil {
+		return h.labelError(c, err, "CREATE_FAILED", "Failed to create label")
+	}
+
+	info := toLabelInfo(*label)
+	return c.Status(fiber.StatusCreated).JSON(dto.LabelResponse{
+		Success: true,
+		Code:    fiber.StatusCreated,
+		Data:    &info,
+	})
+}
+
+// EditLabel godoc
+// @Summary Edit a label
+// @Description Renames a WhatsApp Business label and, when color is given, changes its color
+// @Tags Labels
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param labelId path string true "Label ID"
+// @Param request body dto.LabelRequest true "Label"
+// @Success 200 {object} dto.LabelResponse "Label updated"
+// @Failure 400 {object} dto.LabelResponse "Invalid request data"
+// @Failure 404 {object} dto.LabelResponse "Session or label not found"
+// @Failure 503 {object} dto.LabelResponse "Session not connected"
+// @Failure 500 {object} dto.LabelResponse "Failed to edit label"
+// @Router /session/{sessionId}/labels/{labelId} [put]
+func (h *LabelHandler) EditLabel(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	var req dto.LabelRequest
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return h.errorResponse(c, fiber.StatusBadRequest, "VALIDATION_ERROR", "Invalid request data", err.Error())
+	}
+
+	label, err := h.wmeowService.EditLabel(c.Context(), sessionID, c.Params("labelId"), req.Name, req.Color)
+	if err != nil {
+		return h.labelError(c, err, "EDIT_FAILED", "Failed to edit label")
+	}
+
+	info := toLabelInfo(*label)
+	return c.Status(fiber.StatusOK).JSON(dto.LabelResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data:    &info,
+	})
+}
+
+// DeleteLabel godoc
+// @Summary Delete a label
+// @Description Deletes a WhatsApp Business label, removing it from every chat and message
+// @Tags Labels
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param labelId path string true "Label ID"
+// @Success 200 {object} dto.LabelResponse "Label deleted"
+// @Failure 404 {object} dto.LabelResponse "Session or label not found"
+// @Failure 503 {object} dto.LabelResponse "Session not connected"
+// @Failure 500 {object} dto.LabelResponse "Failed to delete label"
+// @Router /session/{sessionId}/labels/{labelId} [delete]
+func (h *LabelHandler) DeleteLabel(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	if err := h.wmeowService.DeleteLabel(c.Context(), sessionID, c.Params("labelId")); err != nil {
+		return h.labelError(c, err, "DELETE_FAILED", "Failed to delete label")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.LabelResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+	})
+}
+
+// LabelChat godoc
+// @Summary Label or unlabel a chat
+// @Description Attaches the label to a chat ("labeled": true) or detaches it ("labeled": false) on every linked device
+// @Tags Labels
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param labelId path string true "Label ID"
+// @Param request body dto.LabelChatRequest true "Chat"
+// @Success 200 {object} dto.LabelResponse "Chat label updated"
+// @Failure 400 {object} dto.LabelResponse "Invalid request data"
+// @Failure 404 {object} dto.LabelResponse "Session or label not found"
+// @Failure 503 {object} dto.LabelResponse "Session not connected"
+// @Failure 500 {object} dto.LabelResponse "Failed to label chat"
+// @Router /session/{sessionId}/labels/{labelId}/chats [post]
+func (h *LabelHandler) LabelChat(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	var req dto.LabelChatRequest
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return h.errorResponse(c, fiber.StatusBadRequest, "VALIDATION_ERROR", "Invalid request data", err.Error())
+	}
+
+	if err := h.wmeowService.LabelChat(c.Context(), sessionID, c.Params("labelId"), req.JID, req.Labeled); err != nil {
+		return h.labelError(c, err, "LABEL_CHAT_FAILED", "Failed to label chat")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.LabelResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+	})
+}
+
+// LabelMessage godoc
+// @Summary Label or unlabel a message
+// @Description Attaches the label to a message ("labeled": true) or detaches it ("labeled": false) on every linked device
+// @Tags Labels
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param labelId path string true "Label ID"
+// @Param request body dto.LabelMessageRequest true "Message"
+// @Success 200 {object} dto.LabelResponse "Message label updated"
+// @Failure 400 {object} dto.LabelResponse "Invalid request data"
+// @Failure 404 {object} dto.LabelResponse "Session or label not found"
+// @Failure 503 {object} dto.LabelResponse "Session not connected"
+// @Failure 500 {object} dto.LabelResponse "Failed to label message"
+// @Router /session/{sessionId}/labels/{labelId}/messages [post]
+func (h *LabelHandler) LabelMessage(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	var req dto.LabelMessageRequest
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return h.errorResponse(c, fiber.StatusBadRequest, "VALIDATION_ERROR", "Invalid request data", err.Error())
+	}
+
+	if err := h.wmeowService.LabelMessage(c.Context(), sessionID, c.Params("labelId"), req.JID, req.MessageID, req.Labeled); err != nil {
+		return h.labelError(c, err, "LABEL_MESSAGE_FAILED", "Failed to label message")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.LabelResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+	})
+}
+
+func toLabelInfo(label ports.Label) dto.LabelInfo {
+	return dto.LabelInfo{
+		ID:           label.ID,
+		Name:         label.Name,
+		Color:        label.Color,
+		PredefinedID: label.PredefinedID,
+		ChatCount:    label.ChatCount,
+		UpdatedAt:    label.UpdatedAt,
+	}
+}
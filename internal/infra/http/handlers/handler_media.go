@@ -2,10 +2,14 @@ package handlers
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"strconv"
 
 	"zpmeow/internal/application"
+	"zpmeow/internal/application/common"
+	"zpmeow/internal/infra/database/models"
+	"zpmeow/internal/infra/database/repository"
 	"zpmeow/internal/infra/http/dto"
 	"zpmeow/internal/infra/wmeow"
 
@@ -15,17 +19,83 @@ import (
 type MediaHandler struct {
 	sessionService *application.SessionApp
 	wmeowService   wmeow.WameowService
+	policyRepo     *repository.MediaPolicyRepository
 }
 
-func NewMediaHandler(sessionService *application.SessionApp, wmeowService wmeow.WameowService) *MediaHandler {
+func NewMediaHandler(sessionService *application.SessionApp, wmeowService wmeow.WameowService, policyRepo *repository.MediaPolicyRepository) *MediaHandler {
 	return &MediaHandler{
 		sessionService: sessionService,
 		wmeowService:   wmeowService,
+		policyRepo:     policyRepo,
 	}
 }
 
+// defaultMediaPolicyTypes são os tipos arquivados quando a política não informa media_types
+var defaultMediaPolicyTypes = []string{"image", "video", "audio", "document", "sticker"}
+
+func (h *MediaHandler) resolveSessionID(c *fiber.Ctx) (string, error) {
+	sessionIDOrName := c.Params("sessionId")
+	if sessionIDOrName == "" {
+		return "", fmt.Errorf("session ID is required")
+	}
+
+	if h.sessionService == nil {
+		return sessionIDOrName, nil
+	}
+
+	session, err := h.sessionService.GetSession(c.Context(), sessionIDOrName)
+	if err != nil {
+		return "", err
+	}
+
+	return session.ID().String(), nil
+}
+
+func (h *MediaHandler) sendSessionError(c *fiber.Ctx, err error) error {
+	return c.Status(fiber.StatusNotFound).JSON(dto.NewMediaErrorResponse(
+		fiber.StatusNotFound,
+		"SESSION_NOT_FOUND",
+		"Session not found",
+		err.Error(),
+	))
+}
+
+// sendMediaError converte os erros do storage de mídia no status HTTP correspondente
+func (h *MediaHandler) sendMediaError(c *fiber.Ctx, err error, errorCode, message string) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, common.ErrMediaNotFound):
+		status, errorCode, message = fiber.StatusNotFound, "MEDIA_NOT_FOUND", "Media not found"
+	case errors.Is(err, common.ErrMediaTooLarge):
+		status, errorCode, message = fiber.StatusRequestEntityTooLarge, "MEDIA_TOO_LARGE", "Media exceeds the maximum allowed size"
+	case errors.Is(err, common.ErrUnsupportedMediaOp):
+		status, errorCode, message = fiber.StatusNotImplemented, "UNSUPPORTED_OPERATION", "Operation not supported by the media storage"
+	}
+
+	return c.Status(status).JSON(dto.NewMediaErrorResponse(status, errorCode, message, err.Error()))
+}
+
+// UploadMedia godoc
+// @Summary Upload media
+// @Description Stores base64 media in the configured storage backend (local or S3). The returned media_id is the SHA-256 of the content,
+// @Description so uploading the same file twice returns the same ID.
+// @Tags Media
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param request body dto.UploadMediaRequest true "Media upload request"
+// @Success 200 {object} dto.MediaResponse "Media uploaded successfully"
+// @Failure 400 {object} dto.MediaResponse "Invalid request data"
+// @Failure 404 {object} dto.MediaResponse "Session not found"
+// @Failure 413 {object} dto.MediaResponse "Media too large"
+// @Failure 500 {object} dto.MediaResponse "Failed to upload media"
+// @Router /session/{sessionId}/media/upload [post]
 func (h *MediaHandler) UploadMedia(c *fiber.Ctx) error {
-	sessionID := c.Params("sessionId")
+	sessionID, err := h.resolveSessionID(c)
+	if err != nil {
+		return h.sendSessionError(c, err)
+	}
 
 	var req dto.UploadMediaRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -57,114 +127,106 @@ func (h *MediaHandler) UploadMedia(c *fiber.Ctx) error {
 	}
 
 	ctx := c.Context()
-	mediaURL, err := h.wmeowService.UploadMedia(ctx, sessionID, data, req.MediaType)
+	mediaID, err := h.wmeowService.UploadMedia(ctx, sessionID, data, req.MediaType, req.FileName)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.NewMediaErrorResponse(
-			fiber.StatusInternalServerError,
-			"UPLOAD_FAILED",
-			"Failed to upload media",
-			err.Error(),
-		))
+		return h.sendMediaError(c, err, "UPLOAD_FAILED", "Failed to upload media")
 	}
 
-	responseData := map[string]interface{}{
-		"media_url":  mediaURL,
-		"media_type": req.MediaType,
-		"size":       len(data),
+	mediaInfo, err := h.wmeowService.GetMediaInfo(ctx, sessionID, mediaID)
+	if err != nil {
+		return h.sendMediaError(c, err, "UPLOAD_FAILED", "Failed to upload media")
 	}
 
-	return c.Status(fiber.StatusOK).JSON(dto.NewMediaSuccessResponse(sessionID, "upload", "Media uploaded successfully", responseData))
+	return c.Status(fiber.StatusOK).JSON(dto.NewMediaSuccessResponse(sessionID, "upload", "Media uploaded successfully", mediaInfo))
 }
 
+// GetMedia godoc
+// @Summary Get media info
+// @Description Returns the stored metadata of a media item
+// @Tags Media
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param mediaId path string true "Media ID"
+// @Success 200 {object} dto.MediaResponse "Media retrieved successfully"
+// @Failure 404 {object} dto.MediaResponse "Session or media not found"
+// @Failure 500 {object} dto.MediaResponse "Failed to get media info"
+// @Router /session/{sessionId}/media/{mediaId} [get]
 func (h *MediaHandler) GetMedia(c *fiber.Ctx) error {
-	sessionID := c.Params("sessionId")
-
-	var req dto.GetMediaRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.NewMediaErrorResponse(
-			fiber.StatusBadRequest,
-			"INVALID_REQUEST",
-			"Invalid request format",
-			err.Error(),
-		))
-	}
-
-	if err := req.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.NewMediaErrorResponse(
-			fiber.StatusBadRequest,
-			"VALIDATION_ERROR",
-			"Request validation failed",
-			err.Error(),
-		))
+	sessionID, err := h.resolveSessionID(c)
+	if err != nil {
+		return h.sendSessionError(c, err)
 	}
 
-	ctx := c.Context()
-	mediaInfo, err := h.wmeowService.GetMediaInfo(ctx, sessionID, req.MediaID)
+	mediaInfo, err := h.wmeowService.GetMediaInfo(c.Context(), sessionID, c.Params("mediaId"))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.NewMediaErrorResponse(
-			fiber.StatusInternalServerError,
-			"GET_MEDIA_FAILED",
-			"Failed to get media info",
-			err.Error(),
-		))
+		return h.sendMediaError(c, err, "GET_MEDIA_FAILED", "Failed to get media info")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(dto.NewMediaSuccessResponse(sessionID, "get", "Media retrieved successfully", mediaInfo))
 }
 
+// DownloadMedia godoc
+// @Summary Download media
+// @Description Streams the raw media content with its detected Content-Type
+// @Tags Media
+// @Produce octet-stream
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param mediaId path string true "Media ID"
+// @Success 200 {file} binary "Media content"
+// @Failure 404 {object} dto.MediaResponse "Session or media not found"
+// @Failure 500 {object} dto.MediaResponse "Failed to download media"
+// @Router /session/{sessionId}/media/{mediaId}/download [get]
 func (h *MediaHandler) DownloadMedia(c *fiber.Ctx) error {
-	sessionID := c.Params("sessionId")
-	mediaID := c.Params("mediaId")
-
-	if mediaID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.NewMediaErrorResponse(
-			fiber.StatusBadRequest,
-			"MISSING_MEDIA_ID",
-			"Media ID is required",
-			"",
-		))
+	sessionID, err := h.resolveSessionID(c)
+	if err != nil {
+		return h.sendSessionError(c, err)
 	}
 
-	ctx := c.Context()
-	data, mimeType, err := h.wmeowService.DownloadMedia(ctx, sessionID, mediaID)
+	mediaID := c.Params("mediaId")
+	data, mediaInfo, err := h.wmeowService.GetMediaContent(c.Context(), sessionID, mediaID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.NewMediaErrorResponse(
-			fiber.StatusInternalServerError,
-			"DOWNLOAD_FAILED",
-			"Failed to download media",
-			err.Error(),
-		))
+		return h.sendMediaError(c, err, "DOWNLOAD_FAILED", "Failed to download media")
+	}
+
+	fileName := mediaID
+	if name, ok := mediaInfo["filename"].(string); ok && name != "" {
+		fileName = name
+	}
+	mimeType, _ := mediaInfo["mime_type"].(string)
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
 	}
 
 	c.Set("Content-Type", mimeType)
-	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", mediaID))
-	c.Set("Content-Length", fmt.Sprintf("%d", len(data)))
+	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	c.Set("Content-Length", strconv.Itoa(len(data)))
 
 	return c.Status(fiber.StatusOK).Send(data)
 }
 
+// DeleteMedia godoc
+// @Summary Delete media
+// @Description Removes a media item from the session. The stored object is deleted once no session references it anymore.
+// @Tags Media
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param mediaId path string true "Media ID"
+// @Success 200 {object} dto.MediaResponse "Media deleted successfully"
+// @Failure 404 {object} dto.MediaResponse "Session or media not found"
+// @Failure 500 {object} dto.MediaResponse "Failed to delete media"
+// @Router /session/{sessionId}/media/{mediaId} [delete]
 func (h *MediaHandler) DeleteMedia(c *fiber.Ctx) error {
-	sessionID := c.Params("sessionId")
-	mediaID := c.Params("mediaId")
-
-	if mediaID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.NewMediaErrorResponse(
-			fiber.StatusBadRequest,
-			"MISSING_MEDIA_ID",
-			"Media ID is required",
-			"",
-		))
+	sessionID, err := h.resolveSessionID(c)
+	if err != nil {
+		return h.sendSessionError(c, err)
 	}
 
-	ctx := c.Context()
-	err := h.wmeowService.DeleteMedia(ctx, sessionID, mediaID)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.NewMediaErrorResponse(
-			fiber.StatusInternalServerError,
-			"DELETE_FAILED",
-			"Failed to delete media",
-			err.Error(),
-		))
+	mediaID := c.Params("mediaId")
+	if err := h.wmeowService.DeleteMedia(c.Context(), sessionID, mediaID); err != nil {
+		return h.sendMediaError(c, err, "DELETE_FAILED", "Failed to delete media")
 	}
 
 	responseData := map[string]interface{}{
@@ -175,82 +237,117 @@ func (h *MediaHandler) DeleteMedia(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(dto.NewMediaSuccessResponse(sessionID, "delete", "Media deleted successfully", responseData))
 }
 
+// ListMedia godoc
+// @Summary List media
+// @Description Lists the media stored for the session, newest first
+// @Tags Media
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param limit query int false "Maximum number of items" default(50)
+// @Param offset query int false "Number of items to skip" default(0)
+// @Success 200 {object} dto.MediaResponse "Media listed successfully"
+// @Failure 400 {object} dto.MediaResponse "Invalid pagination parameters"
+// @Failure 404 {object} dto.MediaResponse "Session not found"
+// @Failure 500 {object} dto.MediaResponse "Failed to list media"
+// @Router /session/{sessionId}/media/list [get]
 func (h *MediaHandler) ListMedia(c *fiber.Ctx) error {
-	sessionID := c.Params("sessionId")
+	sessionID, err := h.resolveSessionID(c)
+	if err != nil {
+		return h.sendSessionError(c, err)
+	}
 
 	limitStr := c.Query("limit", "50")
 	offsetStr := c.Query("offset", "0")
 
 	limit, err := strconv.Atoi(limitStr)
-	if err != nil {
+	if err != nil || limit < 1 || limit > 500 {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.NewMediaErrorResponse(
 			fiber.StatusBadRequest,
 			"INVALID_LIMIT",
 			"Invalid limit parameter",
-			err.Error(),
+			"limit must be a number between 1 and 500",
 		))
 	}
 
 	offset, err := strconv.Atoi(offsetStr)
-	if err != nil {
+	if err != nil || offset < 0 {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.NewMediaErrorResponse(
 			fiber.StatusBadRequest,
 			"INVALID_OFFSET",
 			"Invalid offset parameter",
-			err.Error(),
+			"offset must be a non-negative number",
 		))
 	}
 
 	ctx := c.Context()
 	mediaList, err := h.wmeowService.ListMedia(ctx, sessionID, limit, offset)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.NewMediaErrorResponse(
-			fiber.StatusInternalServerError,
-			"LIST_FAILED",
-			"Failed to list media",
-			err.Error(),
-		))
+		return h.sendMediaError(c, err, "LIST_FAILED", "Failed to list media")
+	}
+
+	totalSize, err := h.wmeowService.GetMediaStorageUsage(ctx, sessionID)
+	if err != nil {
+		return h.sendMediaError(c, err, "LIST_FAILED", "Failed to list media")
 	}
 
 	responseData := map[string]interface{}{
-		"media":  mediaList,
-		"count":  len(mediaList),
-		"limit":  limit,
-		"offset": offset,
+		"media":      mediaList,
+		"count":      len(mediaList),
+		"limit":      limit,
+		"offset":     offset,
+		"total_size": totalSize,
 	}
 
 	return c.Status(fiber.StatusOK).JSON(dto.NewMediaSuccessResponse(sessionID, "list", "Media listed successfully", responseData))
 }
 
+// GetMediaProgress godoc
+// @Summary Get media upload progress
+// @Description Returns the upload status of a media item. Uploads are synchronous, so stored media is always completed.
+// @Tags Media
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param mediaId path string true "Media ID"
+// @Success 200 {object} dto.MediaResponse "Media progress retrieved successfully"
+// @Failure 404 {object} dto.MediaResponse "Session or media not found"
+// @Failure 500 {object} dto.MediaResponse "Failed to get media progress"
+// @Router /session/{sessionId}/media/{mediaId}/progress [get]
 func (h *MediaHandler) GetMediaProgress(c *fiber.Ctx) error {
-	sessionID := c.Params("sessionId")
-	mediaID := c.Params("mediaId")
-
-	if mediaID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.NewMediaErrorResponse(
-			fiber.StatusBadRequest,
-			"MISSING_MEDIA_ID",
-			"Media ID is required",
-			"",
-		))
+	sessionID, err := h.resolveSessionID(c)
+	if err != nil {
+		return h.sendSessionError(c, err)
 	}
 
-	ctx := c.Context()
-	progress, err := h.wmeowService.GetMediaProgress(ctx, sessionID, mediaID)
+	progress, err := h.wmeowService.GetMediaProgress(c.Context(), sessionID, c.Params("mediaId"))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.NewMediaErrorResponse(
-			fiber.StatusInternalServerError,
-			"GET_PROGRESS_FAILED",
-			"Failed to get media progress",
-			err.Error(),
-		))
+		return h.sendMediaError(c, err, "GET_PROGRESS_FAILED", "Failed to get media progress")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(dto.NewMediaSuccessResponse(sessionID, "progress", "Media progress retrieved successfully", progress))
 }
 
+// ConvertMedia godoc
+// @Summary Convert media
+// @Description Converts a media item to another format. Not supported by the current storage backends (returns 501).
+// @Tags Media
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param mediaId path string true "Media ID"
+// @Param request body dto.ConvertMediaRequest true "Conversion request"
+// @Success 200 {object} dto.MediaResponse "Media converted successfully"
+// @Failure 400 {object} dto.MediaResponse "Invalid request data"
+// @Failure 404 {object} dto.MediaResponse "Session or media not found"
+// @Failure 501 {object} dto.MediaResponse "Operation not supported"
+// @Router /session/{sessionId}/media/{mediaId}/convert [post]
 func (h *MediaHandler) ConvertMedia(c *fiber.Ctx) error {
-	sessionID := c.Params("sessionId")
+	sessionID, err := h.resolveSessionID(c)
+	if err != nil {
+		return h.sendSessionError(c, err)
+	}
 	mediaID := c.Params("mediaId")
 
 	var req dto.ConvertMediaRequest
@@ -272,15 +369,9 @@ func (h *MediaHandler) ConvertMedia(c *fiber.Ctx) error {
 		))
 	}
 
-	ctx := c.Context()
-	convertedMediaID, err := h.wmeowService.ConvertMedia(ctx, sessionID, mediaID, req.TargetFormat)
+	convertedMediaID, err := h.wmeowService.ConvertMedia(c.Context(), sessionID, mediaID, req.TargetFormat)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.NewMediaErrorResponse(
-			fiber.StatusInternalServerError,
-			"CONVERT_FAILED",
-			"Failed to convert media",
-			err.Error(),
-		))
+		return h.sendMediaError(c, err, "CONVERT_FAILED", "Failed to convert media")
 	}
 
 	responseData := map[string]interface{}{
@@ -292,8 +383,26 @@ func (h *MediaHandler) ConvertMedia(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(dto.NewMediaSuccessResponse(sessionID, "convert", "Media converted successfully", responseData))
 }
 
+// CompressMedia godoc
+// @Summary Compress media
+// @Description Compresses a media item. Not supported by the current storage backends (returns 501).
+// @Tags Media
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param mediaId path string true "Media ID"
+// @Param request body dto.CompressMediaRequest true "Compression request"
+// @Success 200 {object} dto.MediaResponse "Media compressed successfully"
+// @Failure 400 {object} dto.MediaResponse "Invalid request data"
+// @Failure 404 {object} dto.MediaResponse "Session or media not found"
+// @Failure 501 {object} dto.MediaResponse "Operation not supported"
+// @Router /session/{sessionId}/media/{mediaId}/compress [post]
 func (h *MediaHandler) CompressMedia(c *fiber.Ctx) error {
-	sessionID := c.Params("sessionId")
+	sessionID, err := h.resolveSessionID(c)
+	if err != nil {
+		return h.sendSessionError(c, err)
+	}
 	mediaID := c.Params("mediaId")
 
 	var req dto.CompressMediaRequest
@@ -315,15 +424,9 @@ func (h *MediaHandler) CompressMedia(c *fiber.Ctx) error {
 		))
 	}
 
-	ctx := c.Context()
-	compressedMediaID, err := h.wmeowService.CompressMedia(ctx, sessionID, mediaID, req.Quality)
+	compressedMediaID, err := h.wmeowService.CompressMedia(c.Context(), sessionID, mediaID, req.Quality)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.NewMediaErrorResponse(
-			fiber.StatusInternalServerError,
-			"COMPRESS_FAILED",
-			"Failed to compress media",
-			err.Error(),
-		))
+		return h.sendMediaError(c, err, "COMPRESS_FAILED", "Failed to compress media")
 	}
 
 	responseData := map[string]interface{}{
@@ -335,29 +438,145 @@ func (h *MediaHandler) CompressMedia(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(dto.NewMediaSuccessResponse(sessionID, "compress", "Media compressed successfully", responseData))
 }
 
+// GetMediaMetadata godoc
+// @Summary Get media metadata
+// @Description Returns the full stored record of a media item, including storage driver and key
+// @Tags Media
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param mediaId path string true "Media ID"
+// @Success 200 {object} dto.MediaResponse "Media metadata retrieved successfully"
+// @Failure 404 {object} dto.MediaResponse "Session or media not found"
+// @Failure 500 {object} dto.MediaResponse "Failed to get media metadata"
+// @Router /session/{sessionId}/media/{mediaId}/metadata [get]
 func (h *MediaHandler) GetMediaMetadata(c *fiber.Ctx) error {
-	sessionID := c.Params("sessionId")
-	mediaID := c.Params("mediaId")
+	sessionID, err := h.resolveSessionID(c)
+	if err != nil {
+		return h.sendSessionError(c, err)
+	}
 
-	if mediaID == "" {
+	metadata, err := h.wmeowService.GetMediaMetadata(c.Context(), sessionID, c.Params("mediaId"))
+	if err != nil {
+		return h.sendMediaError(c, err, "GET_METADATA_FAILED", "Failed to get media metadata")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.NewMediaSuccessResponse(sessionID, "metadata", "Media metadata retrieved successfully", metadata))
+}
+
+// GetMediaPolicy godoc
+// @Summary Get inbound media policy
+// @Description Returns the session policy for automatically downloading inbound media into the storage backend. Disabled by default.
+// @Tags Media
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Success 200 {object} dto.MediaResponse "Media policy retrieved successfully"
+// @Failure 404 {object} dto.MediaResponse "Session not found"
+// @Failure 500 {object} dto.MediaResponse "Failed to get media policy"
+// @Router /session/{sessionId}/media/policy [get]
+func (h *MediaHandler) GetMediaPolicy(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c)
+	if err != nil {
+		return h.sendSessionError(c, err)
+	}
+
+	policy, err := h.policyRepo.GetBySessionID(c.Context(), sessionID)
+	if err != nil {
+		return h.sendMediaError(c, err, "GET_POLICY_FAILED", "Failed to get media policy")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.NewMediaSuccessResponse(sessionID, "policy", "Media policy retrieved successfully", mediaPolicyToDTO(policy)))
+}
+
+// UpdateMediaPolicy godoc
+// @Summary Update inbound media policy
+// @Description Enables or disables automatic download of inbound image, video, audio, document and sticker media.
+// @Description max_sizes (bytes per media type) can only lower the server-wide STORAGE_MAX_* limits.
+// @Tags Media
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param request body dto.MediaPolicyRequest true "Media policy"
+// @Success 200 {object} dto.MediaResponse "Media policy updated successfully"
+// @Failure 400 {object} dto.MediaResponse "Invalid request data"
+// @Failure 404 {object} dto.MediaResponse "Session not found"
+// @Failure 500 {object} dto.MediaResponse "Failed to update media policy"
+// @Router /session/{sessionId}/media/policy [put]
+func (h *MediaHandler) UpdateMediaPolicy(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c)
+	if err != nil {
+		return h.sendSessionError(c, err)
+	}
+
+	var req dto.MediaPolicyRequest
+	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.NewMediaErrorResponse(
 			fiber.StatusBadRequest,
-			"MISSING_MEDIA_ID",
-			"Media ID is required",
-			"",
+			"INVALID_REQUEST",
+			"Invalid request format",
+			err.Error(),
 		))
 	}
 
-	ctx := c.Context()
-	metadata, err := h.wmeowService.GetMediaMetadata(ctx, sessionID, mediaID)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.NewMediaErrorResponse(
-			fiber.StatusInternalServerError,
-			"GET_METADATA_FAILED",
-			"Failed to get media metadata",
+	if err := req.Validate(); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.NewMediaErrorResponse(
+			fiber.StatusBadRequest,
+			"VALIDATION_ERROR",
+			"Request validation failed",
 			err.Error(),
 		))
 	}
 
-	return c.Status(fiber.StatusOK).JSON(dto.NewMediaSuccessResponse(sessionID, "metadata", "Media metadata retrieved successfully", metadata))
+	mediaTypes := req.MediaTypes
+	if len(mediaTypes) == 0 {
+		mediaTypes = defaultMediaPolicyTypes
+	}
+
+	maxSizes := models.JSONB{}
+	for mediaType, size := range req.MaxSizes {
+		if size > 0 {
+			maxSizes[mediaType] = size
+		}
+	}
+
+	policy := &models.MediaPolicyModel{
+		SessionId:    sessionID,
+		AutoDownload: req.AutoDownload,
+		MediaTypes:   models.StringArray(mediaTypes),
+		MaxSizes:     maxSizes,
+	}
+	if err := h.policyRepo.Upsert(c.Context(), policy); err != nil {
+		return h.sendMediaError(c, err, "UPDATE_POLICY_FAILED", "Failed to update media policy")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.NewMediaSuccessResponse(sessionID, "policy", "Media policy updated successfully", mediaPolicyToDTO(policy)))
+}
+
+func mediaPolicyToDTO(policy *models.MediaPolicyModel) dto.MediaPolicyData {
+	if policy == nil {
+		return dto.MediaPolicyData{
+			AutoDownload: false,
+			MediaTypes:   defaultMediaPolicyTypes,
+			MaxSizes:     map[string]int64{},
+		}
+	}
+
+	maxSizes := make(map[string]int64, len(policy.MaxSizes))
+	for mediaType, value := range policy.MaxSizes {
+		switch v := value.(type) {
+		case float64:
+			maxSizes[mediaType] = int64(v)
+		case int64:
+			maxSizes[mediaType] = v
+		}
+	}
+
+	return dto.MediaPolicyData{
+		AutoDownload: policy.AutoDownload,
+		MediaTypes:   policy.MediaTypes,
+		MaxSizes:     maxSizes,
+		UpdatedAt:    &policy.UpdatedAt,
+	}
 }
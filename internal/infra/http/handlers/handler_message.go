@@ -1,15 +1,17 @@
 package handlers
 
 import (
-	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
-	"net/http"
 	"strings"
-	"time"
 
 	"zpmeow/internal/application"
+	"zpmeow/internal/application/common"
+	"zpmeow/internal/application/payloads"
 	"zpmeow/internal/application/ports"
+	"zpmeow/internal/infra/database/models"
+	"zpmeow/internal/infra/database/repository"
 	"zpmeow/internal/infra/http/dto"
 	"zpmeow/internal/infra/wmeow"
 
@@ -21,14 +23,20 @@ type MessageHandler struct {
 	*BaseHandler
 	sessionService  *application.SessionApp
 	wmeowService    wmeow.WameowService
+	messageRepo     *repository.MessageRepository
+	receiptRepo     *repository.MessageReceiptRepository
+	mediaResolver   ports.MediaResolver
 	operationHelper *GroupOperationHelper
 }
 
-func NewMessageHandler(sessionService *application.SessionApp, wmeowService wmeow.WameowService) *MessageHandler {
+func NewMessageHandler(sessionService *application.SessionApp, wmeowService wmeow.WameowService, messageRepo *repository.MessageRepository, receiptRepo *repository.MessageReceiptRepository, mediaResolver ports.MediaResolver) *MessageHandler {
 	return &MessageHandler{
 		BaseHandler:     NewBaseHandler("message-handler"),
 		sessionService:  sessionService,
 		wmeowService:    wmeowService,
+		messageRepo:     messageRepo,
+		receiptRepo:     receiptRepo,
+		mediaResolver:   mediaResolver,
 		operationHelper: NewGroupOperationHelper(),
 	}
 }
@@ -67,67 +75,82 @@ func (h *MessageHandler) sendSessionErrorResponse(c *fiber.Ctx, err error) error
 	))
 }
 
-func (h *MessageHandler) decodeMediaData(dataURL string) ([]byte, error) {
-	if strings.HasPrefix(dataURL, "http://") || strings.HasPrefix(dataURL, "https://") {
-		// Cria requisição com contexto
-		req, err := http.NewRequest("GET", dataURL, nil)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create request: %w", err)
-		}
-
-		// Adiciona headers necessários para Chatwoot
-		req.Header.Set("User-Agent", "zpmeow/1.0")
-		req.Header.Set("Accept", "*/*")
-
-		// Se for URL do Chatwoot local, adiciona headers específicos
-		if strings.Contains(dataURL, "localhost:3001") || strings.Contains(dataURL, "127.0.0.1:3001") {
-			req.Header.Set("X-Forwarded-For", "127.0.0.1")
-			req.Header.Set("X-Real-IP", "127.0.0.1")
-		}
+func (h *MessageHandler) resolveMedia(c *fiber.Ctx, sessionID, mediaType, fileField, reference, fileName, mimeType string) (*ports.ResolvedMedia, error) {
+	return resolveRequestMedia(c, h.mediaResolver, sessionID, mediaType, fileField, reference, fileName, mimeType)
+}
 
-		client := &http.Client{
-			Timeout: 30 * time.Second,
-		}
+// resolveRequestMedia carrega a mídia do envio a partir do arquivo multipart (fileField) ou da referência do JSON,
+// delegando URL, data URL, base64 e media ID ao MediaResolver
+func resolveRequestMedia(c *fiber.Ctx, resolver ports.MediaResolver, sessionID, mediaType, fileField, reference, fileName, mimeType string) (*ports.ResolvedMedia, error) {
+	source := ports.MediaSource{
+		Reference: strings.TrimSpace(reference),
+		FileName:  fileName,
+		MimeType:  mimeType,
+	}
 
-		resp, err := client.Do(req)
-		if err != nil {
-			return nil, fmt.Errorf("failed to download from URL: %w", err)
-		}
-		defer func() {
-			if err := resp.Body.Close(); err != nil {
-				fmt.Printf("Warning: failed to close response body: %v\n", err)
+	if strings.HasPrefix(string(c.Request().Header.ContentType()), fiber.MIMEMultipartForm) {
+		if fileHeader, err := c.FormFile(fileField); err == nil {
+			file, err := fileHeader.Open()
+			if err != nil {
+				return nil, fmt.Errorf("%w: failed to open uploaded file: %v", common.ErrInvalidInput, err)
 			}
-		}()
+			defer file.Close()
 
-		if resp.StatusCode != fiber.StatusOK {
-			return nil, fmt.Errorf("failed to download from URL: status %d", resp.StatusCode)
+			source.Data, err = io.ReadAll(file)
+			if err != nil {
+				return nil, fmt.Errorf("%w: failed to read uploaded file: %v", common.ErrInvalidInput, err)
+			}
+			if source.FileName == "" {
+				source.FileName = fileHeader.Filename
+			}
+			if source.MimeType == "" {
+				source.MimeType = fileHeader.Header.Get("Content-Type")
+			}
 		}
+	}
 
-		return io.ReadAll(resp.Body)
+	if source.IsEmpty() {
+		return nil, fmt.Errorf("%w: %s is required", common.ErrInvalidInput, mediaType)
 	}
 
-	if strings.HasPrefix(dataURL, "data:") {
-		commaIndex := strings.Index(dataURL, ",")
-		if commaIndex == -1 {
-			return nil, fmt.Errorf("invalid data URL format")
-		}
+	return resolver.Resolve(c.Context(), sessionID, mediaType, source)
+}
 
-		base64Data := dataURL[commaIndex+1:]
+// sendMediaResolveError converte os erros de resolução de mídia no status HTTP correspondente
+func (h *MessageHandler) sendMediaResolveError(c *fiber.Ctx, err error, errorCode, message string) error {
+	status := fiber.StatusBadRequest
+	switch {
+	case errors.Is(err, common.ErrMediaNotFound):
+		status, errorCode, message = fiber.StatusNotFound, "MEDIA_NOT_FOUND", "Media not found"
+	case errors.Is(err, common.ErrMediaTooLarge):
+		status, errorCode, message = fiber.StatusRequestEntityTooLarge, "MEDIA_TOO_LARGE", "Media exceeds the maximum allowed size"
+	}
 
-		data, err := base64.StdEncoding.DecodeString(base64Data)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode base64 data: %w", err)
-		}
+	return c.Status(status).JSON(dto.NewMessageErrorResponse(status, errorCode, message, err.Error()))
+}
 
-		return data, nil
+// sendOptions converte os campos de contexto do request nas opções de envio
+func sendOptions(fields payloads.SendContextFields) ports.SendOptions {
+	return ports.SendOptions{
+		QuotedMessageID: strings.TrimSpace(fields.QuotedMessageID),
+		Mentions:        fields.Mentions,
+		MentionAll:      fields.MentionAll,
+		Forwarded:       fields.Forwarded,
 	}
+}
 
-	data, err := base64.StdEncoding.DecodeString(dataURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode base64 data: %w", err)
+// sendMessageError converte os erros de envio no status HTTP correspondente
+func (h *MessageHandler) sendMessageError(c *fiber.Ctx, err error, errorCode, message string) error {
+	status := fiber.StatusInternalServerError
+	var validationErr *wmeow.ValidationError
+	switch {
+	case errors.Is(err, common.ErrMessageNotFound):
+		status, errorCode, message = fiber.StatusNotFound, "QUOTED_MESSAGE_NOT_FOUND", "Quoted message not found"
+	case errors.As(err, &validationErr):
+		status, errorCode = fiber.StatusBadRequest, "VALIDATION_ERROR"
 	}
 
-	return data, nil
+	return c.Status(status).JSON(dto.NewMessageErrorResponse(status, errorCode, message, err.Error()))
 }
 
 // SendText godoc
@@ -139,12 +162,12 @@ func (h *MessageHandler) decodeMediaData(dataURL string) ([]byte, error) {
 // @Security ApiKeyAuth
 // @Security ApiKeyAuth
 // @Param sessionId path string true "Session ID"
-// @Param request body dto.SendTextRequest true "Text message request"
+// @Param request body payloads.SendTextRequest true "Text message request"
 // @Success 200 {object} dto.MessageResponse "Message sent successfully"
 // @Failure 400 {object} dto.MessageResponse "Invalid request data"
 // @Failure 401 {object} dto.MessageResponse "Unauthorized - Invalid API key" "Invalid request data"
 // @Failure 401 {object} dto.MessageResponse "Unauthorized - Invalid API key"
-// @Failure 404 {object} dto.MessageResponse "Session not found"
+// @Failure 404 {object} dto.MessageResponse "Session or quoted message not found"
 // @Failure 500 {object} dto.MessageResponse "Failed to send message"
 // @Router /session/{sessionId}/message/send/text [post]
 func (h *MessageHandler) SendText(c *fiber.Ctx) error {
@@ -153,7 +176,7 @@ func (h *MessageHandler) SendText(c *fiber.Ctx) error {
 		return h.sendSessionErrorResponse(c, err)
 	}
 
-	var req dto.SendTextRequest
+	var req payloads.SendTextRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.NewMessageErrorResponse(
 			fiber.StatusBadRequest,
@@ -173,14 +196,9 @@ func (h *MessageHandler) SendText(c *fiber.Ctx) error {
 	}
 
 	ctx := c.Context()
-	sendResp, err := h.wmeowService.SendTextMessage(ctx, sessionID, req.Phone, req.Body)
+	sendResp, err := h.wmeowService.SendTextMessage(ctx, sessionID, req.Phone, req.Body, sendOptions(req.SendContextFields))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.NewMessageErrorResponse(
-			fiber.StatusInternalServerError,
-			"SEND_TEXT_FAILED",
-			"Failed to send text message",
-			err.Error(),
-		))
+		return h.sendMessageError(c, err, "SEND_TEXT_FAILED", "Failed to send text message")
 	}
 
 	messageID := string(sendResp.ID)
@@ -191,18 +209,20 @@ func (h *MessageHandler) SendText(c *fiber.Ctx) error {
 // SendMedia godoc
 // @Summary Send media message
 // @Description Sends a media message (image, video, audio, document) to a WhatsApp contact or group
+// @Description The media can be a data URL, base64, an http(s) URL, a media_id from /media/upload or a multipart/form-data file upload.
 // @Tags Messages
-// @Accept json
+// @Accept json,mpfd
 // @Produce json
 // @Security ApiKeyAuth
 // @Security ApiKeyAuth
 // @Param sessionId path string true "Session ID"
-// @Param request body dto.SendMediaRequest true "Media message request"
+// @Param request body payloads.SendMediaRequest true "Media message request"
 // @Success 200 {object} dto.MessageResponse "Media sent successfully"
 // @Failure 400 {object} dto.MessageResponse "Invalid request data"
 // @Failure 401 {object} dto.MessageResponse "Unauthorized - Invalid API key" "Invalid request data"
 // @Failure 401 {object} dto.MessageResponse "Unauthorized - Invalid API key"
-// @Failure 404 {object} dto.MessageResponse "Session not found"
+// @Failure 404 {object} dto.MessageResponse "Session or quoted message not found"
+// @Failure 413 {object} dto.MessageResponse "Media too large"
 // @Failure 500 {object} dto.MessageResponse "Failed to send media"
 // @Router /session/{sessionId}/message/send/media [post]
 func (h *MessageHandler) SendMedia(c *fiber.Ctx) error {
@@ -226,7 +246,7 @@ func (h *MessageHandler) SendMedia(c *fiber.Ctx) error {
 		))
 	}
 
-	var req dto.SendMediaRequest
+	var req payloads.SendMediaRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.NewMessageErrorResponse(
 			fiber.StatusBadRequest,
@@ -245,31 +265,30 @@ func (h *MessageHandler) SendMedia(c *fiber.Ctx) error {
 		))
 	}
 
-	mediaData, err := h.decodeMediaData(req.MediaURL)
+	media, err := h.resolveMedia(c, sessionID, req.MediaType, "file", req.MediaURL, req.FileName, req.MimeType)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.NewMessageErrorResponse(
-			fiber.StatusBadRequest,
-			"INVALID_MEDIA_DATA",
-			"Failed to decode media data",
-			err.Error(),
-		))
+		return h.sendMediaResolveError(c, err, "INVALID_MEDIA_DATA", "Failed to decode media data")
 	}
 
 	ctx := c.Context()
+	opts := sendOptions(req.SendContextFields)
 	var sendResp *whatsmeow.SendResponse
 
 	switch req.MediaType {
 	case "image":
-		sendResp, err = h.wmeowService.SendImageMessage(ctx, sessionID, req.Phone, mediaData, req.Caption, "image/jpeg")
+		sendResp, err = h.wmeowService.SendImageMessage(ctx, sessionID, req.Phone, media.Data, req.Caption, media.MimeType, opts)
 	case "audio":
-		sendResp, err = h.wmeowService.SendAudioMessageWithPTT(ctx, sessionID, req.Phone, mediaData, "audio/mpeg", req.PTT)
+		sendResp, err = h.wmeowService.SendAudioMessageWithPTT(ctx, sessionID, req.Phone, media.Data, media.MimeType, req.PTT, opts)
 	case "video":
-		sendResp, err = h.wmeowService.SendVideoMessage(ctx, sessionID, req.Phone, mediaData, req.Caption, "video/mp4")
+		sendResp, err = h.wmeowService.SendVideoMessage(ctx, sessionID, req.Phone, media.Data, req.Caption, media.MimeType, opts)
 	case "document":
-		filename := "document"
-		sendResp, err = h.wmeowService.SendDocumentMessage(ctx, sessionID, req.Phone, mediaData, filename, req.Caption, "application/octet-stream")
+		filename := media.FileName
+		if filename == "" {
+			filename = "document"
+		}
+		sendResp, err = h.wmeowService.SendDocumentMessage(ctx, sessionID, req.Phone, media.Data, filename, req.Caption, media.MimeType, opts)
 	case "sticker":
-		sendResp, err = h.wmeowService.SendStickerMessage(ctx, sessionID, req.Phone, mediaData, "image/webp")
+		sendResp, err = h.wmeowService.SendStickerMessage(ctx, sessionID, req.Phone, media.Data, media.MimeType, opts)
 	default:
 		return c.Status(fiber.StatusBadRequest).JSON(dto.NewMessageErrorResponse(
 			fiber.StatusBadRequest,
@@ -280,12 +299,7 @@ func (h *MessageHandler) SendMedia(c *fiber.Ctx) error {
 	}
 
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.NewMessageErrorResponse(
-			fiber.StatusInternalServerError,
-			"SEND_MEDIA_FAILED",
-			"Failed to send media message",
-			err.Error(),
-		))
+		return h.sendMessageError(c, err, "SEND_MEDIA_FAILED", "Failed to send media message")
 	}
 
 	messageID := string(sendResp.ID)
@@ -579,11 +593,11 @@ func (h *MessageHandler) EditMessage(c *fiber.Ctx) error {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param sessionId path string true "Session ID"
-// @Param request body dto.SendLocationRequest true "Location message request"
+// @Param request body payloads.SendLocationRequest true "Location message request"
 // @Success 200 {object} dto.MessageResponse "Location sent successfully"
 // @Failure 400 {object} dto.MessageResponse "Invalid request data"
 // @Failure 401 {object} dto.MessageResponse "Unauthorized - Invalid API key" "Invalid request data"
-// @Failure 404 {object} dto.MessageResponse "Session not found"
+// @Failure 404 {object} dto.MessageResponse "Session or quoted message not found"
 // @Failure 500 {object} dto.MessageResponse "Failed to send location"
 // @Router /session/{sessionId}/message/send/location [post]
 func (h *MessageHandler) SendLocation(c *fiber.Ctx) error {
@@ -607,7 +621,7 @@ func (h *MessageHandler) SendLocation(c *fiber.Ctx) error {
 		))
 	}
 
-	var req dto.SendLocationRequest
+	var req payloads.SendLocationRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.NewMessageErrorResponse(
 			fiber.StatusBadRequest,
@@ -627,14 +641,9 @@ func (h *MessageHandler) SendLocation(c *fiber.Ctx) error {
 	}
 
 	ctx := c.Context()
-	sendResp, err := h.wmeowService.SendLocationMessage(ctx, sessionID, req.Phone, req.Latitude, req.Longitude, req.Name, req.Address)
+	sendResp, err := h.wmeowService.SendLocationMessage(ctx, sessionID, req.Phone, req.Latitude, req.Longitude, req.Name, req.Address, sendOptions(req.SendContextFields))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.NewMessageErrorResponse(
-			fiber.StatusInternalServerError,
-			"SEND_LOCATION_FAILED",
-			"Failed to send location message",
-			err.Error(),
-		))
+		return h.sendMessageError(c, err, "SEND_LOCATION_FAILED", "Failed to send location message")
 	}
 
 	messageID := string(sendResp.ID)
@@ -650,11 +659,11 @@ func (h *MessageHandler) SendLocation(c *fiber.Ctx) error {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param sessionId path string true "Session ID"
-// @Param request body dto.SendContactRequest true "Contact message request"
+// @Param request body payloads.SendContactRequest true "Contact message request"
 // @Success 200 {object} dto.MessageResponse "Contact sent successfully"
 // @Failure 400 {object} dto.MessageResponse "Invalid request data"
 // @Failure 401 {object} dto.MessageResponse "Unauthorized - Invalid API key" "Invalid request data"
-// @Failure 404 {object} dto.MessageResponse "Session not found"
+// @Failure 404 {object} dto.MessageResponse "Session or quoted message not found"
 // @Failure 500 {object} dto.MessageResponse "Failed to send contact"
 // @Router /session/{sessionId}/message/send/contact [post]
 func (h *MessageHandler) SendContact(c *fiber.Ctx) error {
@@ -678,7 +687,7 @@ func (h *MessageHandler) SendContact(c *fiber.Ctx) error {
 		))
 	}
 
-	var req dto.SendContactRequest
+	var req payloads.SendContactRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.NewMessageErrorResponse(
 			fiber.StatusBadRequest,
@@ -705,14 +714,9 @@ func (h *MessageHandler) SendContact(c *fiber.Ctx) error {
 			Phone: req.ContactPhone,
 		}}
 
-		sendResp, err := h.wmeowService.SendContactsMessage(ctx, sessionID, req.Phone, contacts)
+		sendResp, err := h.wmeowService.SendContactsMessage(ctx, sessionID, req.Phone, contacts, sendOptions(req.SendContextFields))
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(dto.NewMessageErrorResponse(
-				fiber.StatusInternalServerError,
-				"SEND_CONTACT_FAILED",
-				"Failed to send contact message",
-				err.Error(),
-			))
+			return h.sendMessageError(c, err, "SEND_CONTACT_FAILED", "Failed to send contact message")
 		}
 
 		vcard := "BEGIN:VCARD\nVERSION:3.0\nFN:" + req.ContactName + "\nTEL:" + req.ContactPhone + "\nEND:VCARD"
@@ -730,14 +734,9 @@ func (h *MessageHandler) SendContact(c *fiber.Ctx) error {
 			})
 		}
 
-		sendResp, err := h.wmeowService.SendContactsMessage(ctx, sessionID, req.Phone, contacts)
+		sendResp, err := h.wmeowService.SendContactsMessage(ctx, sessionID, req.Phone, contacts, sendOptions(req.SendContextFields))
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(dto.NewMessageErrorResponse(
-				fiber.StatusInternalServerError,
-				"SEND_CONTACTS_FAILED",
-				"Failed to send contacts message",
-				err.Error(),
-			))
+			return h.sendMessageError(c, err, "SEND_CONTACTS_FAILED", "Failed to send contacts message")
 		}
 
 		var vcards []string
@@ -762,16 +761,18 @@ func (h *MessageHandler) SendContact(c *fiber.Ctx) error {
 // SendImage godoc
 // @Summary Send image message
 // @Description Sends an image message to a WhatsApp contact or group
+// @Description The media can be a data URL, base64, an http(s) URL, a media_id from /media/upload or a multipart/form-data file upload.
 // @Tags Messages
-// @Accept json
+// @Accept json,mpfd
 // @Produce json
 // @Security ApiKeyAuth
 // @Param sessionId path string true "Session ID"
-// @Param request body dto.SendImageRequest true "Image message request"
+// @Param request body payloads.SendImageRequest true "Image message request"
 // @Success 200 {object} dto.MessageResponse "Image sent successfully"
 // @Failure 400 {object} dto.MessageResponse "Invalid request data"
 // @Failure 401 {object} dto.MessageResponse "Unauthorized - Invalid API key" "Invalid request data"
-// @Failure 404 {object} dto.MessageResponse "Session not found"
+// @Failure 404 {object} dto.MessageResponse "Session or quoted message not found"
+// @Failure 413 {object} dto.MessageResponse "Media too large"
 // @Failure 500 {object} dto.MessageResponse "Failed to send image"
 // @Router /session/{sessionId}/message/send/image [post]
 func (h *MessageHandler) SendImage(c *fiber.Ctx) error {
@@ -795,7 +796,7 @@ func (h *MessageHandler) SendImage(c *fiber.Ctx) error {
 		))
 	}
 
-	var req dto.SendImageRequest
+	var req payloads.SendImageRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.NewMessageErrorResponse(
 			fiber.StatusBadRequest,
@@ -814,25 +815,15 @@ func (h *MessageHandler) SendImage(c *fiber.Ctx) error {
 		))
 	}
 
-	imageData, err := h.decodeMediaData(req.Image)
+	image, err := h.resolveMedia(c, sessionID, "image", "image", req.Image, "", "")
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.NewMessageErrorResponse(
-			fiber.StatusBadRequest,
-			"INVALID_IMAGE_DATA",
-			"Failed to decode image data",
-			err.Error(),
-		))
+		return h.sendMediaResolveError(c, err, "INVALID_IMAGE_DATA", "Failed to decode image data")
 	}
 
 	ctx := c.Context()
-	sendResp, err := h.wmeowService.SendImageMessage(ctx, sessionID, req.Phone, imageData, req.Caption, "image/jpeg")
+	sendResp, err := h.wmeowService.SendImageMessage(ctx, sessionID, req.Phone, image.Data, req.Caption, image.MimeType, sendOptions(req.SendContextFields))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.NewMessageErrorResponse(
-			fiber.StatusInternalServerError,
-			"SEND_IMAGE_FAILED",
-			"Failed to send image message",
-			err.Error(),
-		))
+		return h.sendMessageError(c, err, "SEND_IMAGE_FAILED", "Failed to send image message")
 	}
 
 	messageID := string(sendResp.ID)
@@ -843,16 +834,18 @@ func (h *MessageHandler) SendImage(c *fiber.Ctx) error {
 // SendAudio godoc
 // @Summary Send audio message
 // @Description Sends an audio message to a WhatsApp contact or group
+// @Description The media can be a data URL, base64, an http(s) URL, a media_id from /media/upload or a multipart/form-data file upload.
 // @Tags Messages
-// @Accept json
+// @Accept json,mpfd
 // @Produce json
 // @Security ApiKeyAuth
 // @Param sessionId path string true "Session ID"
-// @Param request body dto.SendAudioRequest true "Audio message request"
+// @Param request body payloads.SendAudioRequest true "Audio message request"
 // @Success 200 {object} dto.MessageResponse "Audio sent successfully"
 // @Failure 400 {object} dto.MessageResponse "Invalid request data"
 // @Failure 401 {object} dto.MessageResponse "Unauthorized - Invalid API key" "Invalid request data"
-// @Failure 404 {object} dto.MessageResponse "Session not found"
+// @Failure 404 {object} dto.MessageResponse "Session or quoted message not found"
+// @Failure 413 {object} dto.MessageResponse "Media too large"
 // @Failure 500 {object} dto.MessageResponse "Failed to send audio"
 // @Router /session/{sessionId}/message/send/audio [post]
 func (h *MessageHandler) SendAudio(c *fiber.Ctx) error {
@@ -876,7 +869,7 @@ func (h *MessageHandler) SendAudio(c *fiber.Ctx) error {
 		))
 	}
 
-	var req dto.SendAudioRequest
+	var req payloads.SendAudioRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.NewMessageErrorResponse(
 			fiber.StatusBadRequest,
@@ -895,25 +888,15 @@ func (h *MessageHandler) SendAudio(c *fiber.Ctx) error {
 		))
 	}
 
-	audioData, err := h.decodeMediaData(req.Audio)
+	audio, err := h.resolveMedia(c, sessionID, "audio", "audio", req.Audio, "", "")
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.NewMessageErrorResponse(
-			fiber.StatusBadRequest,
-			"INVALID_AUDIO_DATA",
-			"Failed to decode audio data",
-			err.Error(),
-		))
+		return h.sendMediaResolveError(c, err, "INVALID_AUDIO_DATA", "Failed to decode audio data")
 	}
 
 	ctx := c.Context()
-	sendResp, err := h.wmeowService.SendAudioMessageWithPTT(ctx, sessionID, req.Phone, audioData, "audio/mpeg", req.PTT)
+	sendResp, err := h.wmeowService.SendAudioMessageWithPTT(ctx, sessionID, req.Phone, audio.Data, audio.MimeType, req.PTT, sendOptions(req.SendContextFields))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.NewMessageErrorResponse(
-			fiber.StatusInternalServerError,
-			"SEND_AUDIO_FAILED",
-			"Failed to send audio message",
-			err.Error(),
-		))
+		return h.sendMessageError(c, err, "SEND_AUDIO_FAILED", "Failed to send audio message")
 	}
 
 	messageID := string(sendResp.ID)
@@ -924,16 +907,18 @@ func (h *MessageHandler) SendAudio(c *fiber.Ctx) error {
 // SendDocument godoc
 // @Summary Send document message
 // @Description Sends a document message to a WhatsApp contact or group
+// @Description The media can be a data URL, base64, an http(s) URL, a media_id from /media/upload or a multipart/form-data file upload.
 // @Tags Messages
-// @Accept json
+// @Accept json,mpfd
 // @Produce json
 // @Security ApiKeyAuth
 // @Param sessionId path string true "Session ID"
-// @Param request body dto.SendDocumentRequest true "Document message request"
+// @Param request body payloads.SendDocumentRequest true "Document message request"
 // @Success 200 {object} dto.MessageResponse "Document sent successfully"
 // @Failure 400 {object} dto.MessageResponse "Invalid request data"
 // @Failure 401 {object} dto.MessageResponse "Unauthorized - Invalid API key" "Invalid request data"
-// @Failure 404 {object} dto.MessageResponse "Session not found"
+// @Failure 404 {object} dto.MessageResponse "Session or quoted message not found"
+// @Failure 413 {object} dto.MessageResponse "Media too large"
 // @Failure 500 {object} dto.MessageResponse "Failed to send document"
 // @Router /session/{sessionId}/message/send/document [post]
 func (h *MessageHandler) SendDocument(c *fiber.Ctx) error {
@@ -957,7 +942,7 @@ func (h *MessageHandler) SendDocument(c *fiber.Ctx) error {
 		))
 	}
 
-	var req dto.SendDocumentRequest
+	var req payloads.SendDocumentRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.NewMessageErrorResponse(
 			fiber.StatusBadRequest,
@@ -976,35 +961,22 @@ func (h *MessageHandler) SendDocument(c *fiber.Ctx) error {
 		))
 	}
 
-	documentData, err := h.decodeMediaData(req.Document)
+	document, err := h.resolveMedia(c, sessionID, "document", "document", req.Document, req.FileName, req.MimeType)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.NewMessageErrorResponse(
-			fiber.StatusBadRequest,
-			"INVALID_DOCUMENT_DATA",
-			"Failed to decode document data",
-			err.Error(),
-		))
+		return h.sendMediaResolveError(c, err, "INVALID_DOCUMENT_DATA", "Failed to decode document data")
 	}
 
-	filename := req.FileName
+	filename := document.FileName
 	if filename == "" {
 		filename = "document"
 	}
-	mimeType := req.MimeType
-	if mimeType == "" {
-		mimeType = "application/octet-stream"
-	}
+	mimeType := document.MimeType
 
 	ctx := c.Context()
 	var sendResp *whatsmeow.SendResponse
-	sendResp, err = h.wmeowService.SendDocumentMessage(ctx, sessionID, req.Phone, documentData, filename, "", mimeType)
+	sendResp, err = h.wmeowService.SendDocumentMessage(ctx, sessionID, req.Phone, document.Data, filename, "", mimeType, sendOptions(req.SendContextFields))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.NewMessageErrorResponse(
-			fiber.StatusInternalServerError,
-			"SEND_DOCUMENT_FAILED",
-			"Failed to send document message",
-			err.Error(),
-		))
+		return h.sendMessageError(c, err, "SEND_DOCUMENT_FAILED", "Failed to send document message")
 	}
 
 	messageID := string(sendResp.ID)
@@ -1015,16 +987,18 @@ func (h *MessageHandler) SendDocument(c *fiber.Ctx) error {
 // SendVideo godoc
 // @Summary Send video message
 // @Description Sends a video message to a WhatsApp contact or group
+// @Description The media can be a data URL, base64, an http(s) URL, a media_id from /media/upload or a multipart/form-data file upload.
 // @Tags Messages
-// @Accept json
+// @Accept json,mpfd
 // @Produce json
 // @Security ApiKeyAuth
 // @Param sessionId path string true "Session ID"
-// @Param request body dto.SendVideoRequest true "Video message request"
+// @Param request body payloads.SendVideoRequest true "Video message request"
 // @Success 200 {object} dto.MessageResponse "Video sent successfully"
 // @Failure 400 {object} dto.MessageResponse "Invalid request data"
 // @Failure 401 {object} dto.MessageResponse "Unauthorized - Invalid API key" "Invalid request data"
-// @Failure 404 {object} dto.MessageResponse "Session not found"
+// @Failure 404 {object} dto.MessageResponse "Session or quoted message not found"
+// @Failure 413 {object} dto.MessageResponse "Media too large"
 // @Failure 500 {object} dto.MessageResponse "Failed to send video"
 // @Router /session/{sessionId}/message/send/video [post]
 func (h *MessageHandler) SendVideo(c *fiber.Ctx) error {
@@ -1048,7 +1022,7 @@ func (h *MessageHandler) SendVideo(c *fiber.Ctx) error {
 		))
 	}
 
-	var req dto.SendVideoRequest
+	var req payloads.SendVideoRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.NewMessageErrorResponse(
 			fiber.StatusBadRequest,
@@ -1067,25 +1041,15 @@ func (h *MessageHandler) SendVideo(c *fiber.Ctx) error {
 		))
 	}
 
-	videoData, err := h.decodeMediaData(req.Video)
+	video, err := h.resolveMedia(c, sessionID, "video", "video", req.Video, "", "")
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.NewMessageErrorResponse(
-			fiber.StatusBadRequest,
-			"INVALID_VIDEO_DATA",
-			"Failed to decode video data",
-			err.Error(),
-		))
+		return h.sendMediaResolveError(c, err, "INVALID_VIDEO_DATA", "Failed to decode video data")
 	}
 
 	ctx := c.Context()
-	sendResp, err := h.wmeowService.SendVideoMessage(ctx, sessionID, req.Phone, videoData, req.Caption, "video/mp4")
+	sendResp, err := h.wmeowService.SendVideoMessage(ctx, sessionID, req.Phone, video.Data, req.Caption, video.MimeType, sendOptions(req.SendContextFields))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.NewMessageErrorResponse(
-			fiber.StatusInternalServerError,
-			"SEND_VIDEO_FAILED",
-			"Failed to send video message",
-			err.Error(),
-		))
+		return h.sendMessageError(c, err, "SEND_VIDEO_FAILED", "Failed to send video message")
 	}
 
 	messageID := string(sendResp.ID)
@@ -1096,16 +1060,18 @@ func (h *MessageHandler) SendVideo(c *fiber.Ctx) error {
 // SendSticker godoc
 // @Summary Send sticker message
 // @Description Sends a sticker message to a WhatsApp contact or group
+// @Description The media can be a data URL, base64, an http(s) URL, a media_id from /media/upload or a multipart/form-data file upload.
 // @Tags Messages
-// @Accept json
+// @Accept json,mpfd
 // @Produce json
 // @Security ApiKeyAuth
 // @Param sessionId path string true "Session ID"
-// @Param request body dto.SendStickerRequest true "Sticker message request"
+// @Param request body payloads.SendStickerRequest true "Sticker message request"
 // @Success 200 {object} dto.MessageResponse "Sticker sent successfully"
 // @Failure 400 {object} dto.MessageResponse "Invalid request data"
 // @Failure 401 {object} dto.MessageResponse "Unauthorized - Invalid API key" "Invalid request data"
-// @Failure 404 {object} dto.MessageResponse "Session not found"
+// @Failure 404 {object} dto.MessageResponse "Session or quoted message not found"
+// @Failure 413 {object} dto.MessageResponse "Media too large"
 // @Failure 500 {object} dto.MessageResponse "Failed to send sticker"
 // @Router /session/{sessionId}/message/send/sticker [post]
 func (h *MessageHandler) SendSticker(c *fiber.Ctx) error {
@@ -1129,7 +1095,7 @@ func (h *MessageHandler) SendSticker(c *fiber.Ctx) error {
 		))
 	}
 
-	var req dto.SendStickerRequest
+	var req payloads.SendStickerRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.NewMessageErrorResponse(
 			fiber.StatusBadRequest,
@@ -1148,25 +1114,15 @@ func (h *MessageHandler) SendSticker(c *fiber.Ctx) error {
 		))
 	}
 
-	stickerData, err := h.decodeMediaData(req.Sticker)
+	sticker, err := h.resolveMedia(c, sessionID, "sticker", "sticker", req.Sticker, "", "")
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.NewMessageErrorResponse(
-			fiber.StatusBadRequest,
-			"INVALID_STICKER_DATA",
-			"Failed to decode sticker data",
-			err.Error(),
-		))
+		return h.sendMediaResolveError(c, err, "INVALID_STICKER_DATA", "Failed to decode sticker data")
 	}
 
 	ctx := c.Context()
-	sendResp, err := h.wmeowService.SendStickerMessage(ctx, sessionID, req.Phone, stickerData, "image/webp")
+	sendResp, err := h.wmeowService.SendStickerMessage(ctx, sessionID, req.Phone, sticker.Data, sticker.MimeType, sendOptions(req.SendContextFields))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.NewMessageErrorResponse(
-			fiber.StatusInternalServerError,
-			"SEND_STICKER_FAILED",
-			"Failed to send sticker message",
-			err.Error(),
-		))
+		return h.sendMessageError(c, err, "SEND_STICKER_FAILED", "Failed to send sticker message")
 	}
 
 	messageID := string(sendResp.ID)
@@ -1182,7 +1138,7 @@ func (h *MessageHandler) SendSticker(c *fiber.Ctx) error {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param sessionId path string true "Session ID"
-// @Param request body dto.SendButtonMessageRequest true "Button message request"
+// @Param request body payloads.SendButtonMessageRequest true "Button message request"
 // @Success 200 {object} dto.MessageResponse "Button message sent successfully"
 // @Failure 400 {object} dto.MessageResponse "Invalid request data"
 // @Failure 401 {object} dto.MessageResponse "Unauthorized - Invalid API key" "Invalid request data"
@@ -1192,7 +1148,7 @@ func (h *MessageHandler) SendSticker(c *fiber.Ctx) error {
 func (h *MessageHandler) SendButton(c *fiber.Ctx) error {
 	sessionID := c.Params("sessionId")
 
-	var req dto.SendButtonMessageRequest
+	var req payloads.SendButtonMessageRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.NewMessageErrorResponse(
 			fiber.StatusBadRequest,
@@ -1243,7 +1199,7 @@ func (h *MessageHandler) SendButton(c *fiber.Ctx) error {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param sessionId path string true "Session ID"
-// @Param request body dto.SendListMessageRequest true "List message request"
+// @Param request body payloads.SendListMessageRequest true "List message request"
 // @Success 200 {object} dto.MessageResponse "List message sent successfully"
 // @Failure 400 {object} dto.MessageResponse "Invalid request data"
 // @Failure 401 {object} dto.MessageResponse "Unauthorized - Invalid API key" "Invalid request data"
@@ -1253,7 +1209,7 @@ func (h *MessageHandler) SendButton(c *fiber.Ctx) error {
 func (h *MessageHandler) SendList(c *fiber.Ctx) error {
 	sessionID := c.Params("sessionId")
 
-	var req dto.SendListMessageRequest
+	var req payloads.SendListMessageRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.NewMessageErrorResponse(
 			fiber.StatusBadRequest,
@@ -1311,17 +1267,20 @@ func (h *MessageHandler) SendList(c *fiber.Ctx) error {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param sessionId path string true "Session ID"
-// @Param request body dto.SendPollMessageRequest true "Poll message request"
+// @Param request body payloads.SendPollMessageRequest true "Poll message request"
 // @Success 200 {object} dto.MessageResponse "Poll message sent successfully"
 // @Failure 400 {object} dto.MessageResponse "Invalid request data"
 // @Failure 401 {object} dto.MessageResponse "Unauthorized - Invalid API key" "Invalid request data"
-// @Failure 404 {object} dto.MessageResponse "Session not found"
+// @Failure 404 {object} dto.MessageResponse "Session or quoted message not found"
 // @Failure 500 {object} dto.MessageResponse "Failed to send poll message"
 // @Router /session/{sessionId}/message/send/poll [post]
 func (h *MessageHandler) SendPoll(c *fiber.Ctx) error {
-	sessionID := c.Params("sessionId")
+	sessionID, err := h.validateAndResolveSessionID(c)
+	if err != nil {
+		return h.sendSessionErrorResponse(c, err)
+	}
 
-	var req dto.SendPollMessageRequest
+	var req payloads.SendPollMessageRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.NewMessageErrorResponse(
 			fiber.StatusBadRequest,
@@ -1341,16 +1300,126 @@ func (h *MessageHandler) SendPoll(c *fiber.Ctx) error {
 	}
 
 	ctx := c.Context()
-	resp, err := h.wmeowService.SendPollMessage(ctx, sessionID, req.Phone, req.Name, req.Options, req.SelectableCount)
+	resp, err := h.wmeowService.SendPollMessage(ctx, sessionID, req.Phone, req.Name, req.Options, req.SelectableCount, sendOptions(req.SendContextFields))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.NewMessageErrorResponse(
-			fiber.StatusInternalServerError,
-			"SEND_POLL_MESSAGE_FAILED",
-			"Failed to send poll message",
-			err.Error(),
-		))
+		return h.sendMessageError(c, err, "SEND_POLL_MESSAGE_FAILED", "Failed to send poll message")
 	}
 
 	response := dto.NewMessageSuccessResponse(sessionID, req.Phone, "poll_message_sent", resp.ID, resp.Timestamp.Unix())
 	return c.Status(fiber.StatusOK).JSON(response)
 }
+
+// GetMessageStatus godoc
+// @Summary Get message status timeline
+// @Description Retrieves the delivery status of a message and the per-recipient receipt timeline (who received, read or played it and when)
+// @Tags Messages
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param messageId path string true "WhatsApp message ID"
+// @Success 200 {object} dto.MessageStatusResponse "Message status"
+// @Failure 400 {object} dto.MessageStatusResponse "Invalid request data"
+// @Failure 404 {object} dto.MessageStatusResponse "Message not found"
+// @Failure 500 {object} dto.MessageStatusResponse "Failed to get message status"
+// @Router /session/{sessionId}/message/{messageId}/status [get]
+func (h *MessageHandler) GetMessageStatus(c *fiber.Ctx) error {
+	sessionID, err := h.validateAndResolveSessionID(c)
+	if err != nil {
+		return h.sendSessionErrorResponse(c, err)
+	}
+
+	messageID := c.Params("messageId")
+	ctx := c.Context()
+
+	message, err := h.messageRepo.GetMessageByWhatsAppID(ctx, sessionID, messageID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.MessageStatusResponse{
+			Success: false,
+			Code:    fiber.StatusInternalServerError,
+			Error: &dto.MessageErrorResponse{
+				Code:    "GET_MESSAGE_FAILED",
+				Message: "Failed to get message",
+				Details: err.Error(),
+			},
+		})
+	}
+
+	if message == nil {
+		return c.Status(fiber.StatusNotFound).JSON(dto.MessageStatusResponse{
+			Success: false,
+			Code:    fiber.StatusNotFound,
+			Error: &dto.MessageErrorResponse{
+				Code:    "MESSAGE_NOT_FOUND",
+				Message: "Message not found",
+				Details: "No stored message with ID " + messageID,
+			},
+		})
+	}
+
+	receipts, err := h.receiptRepo.ListByMessageID(ctx, message.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.MessageStatusResponse{
+			Success: false,
+			Code:    fiber.StatusInternalServerError,
+			Error: &dto.MessageErrorResponse{
+				Code:    "GET_RECEIPTS_FAILED",
+				Message: "Failed to get message receipts",
+				Details: err.Error(),
+			},
+		})
+	}
+
+	timeline := make([]dto.MessageReceiptInfo, 0, len(receipts))
+	for _, receipt := range receipts {
+		timeline = append(timeline, dto.MessageReceiptInfo{
+			Recipient: receipt.RecipientJid,
+			Status:    receipt.Status,
+			Timestamp: receipt.Timestamp,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.MessageStatusResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data: &dto.MessageStatusData{
+			MessageID:  message.MsgId,
+			Status:     message.Status,
+			FromMe:     message.IsFromMe,
+			Timestamp:  message.Timestamp,
+			Recipients: summarizeRecipients(receipts),
+			Timeline:   timeline,
+		},
+	})
+}
+
+// summarizeRecipients agrupa os recibos por destinatário, na ordem em que apareceram na linha do tempo
+func summarizeRecipients(receipts []*models.MessageReceiptModel) []dto.MessageRecipientStatus {
+	recipients := make([]dto.MessageRecipientStatus, 0)
+	index := make(map[string]int)
+
+	for _, receipt := range receipts {
+		i, exists := index[receipt.RecipientJid]
+		if !exists {
+			i = len(recipients)
+			index[receipt.RecipientJid] = i
+			recipients = append(recipients, dto.MessageRecipientStatus{Recipient: receipt.RecipientJid})
+		}
+
+		timestamp := receipt.Timestamp
+		recipient := &recipients[i]
+		switch receipt.Status {
+		case models.MessageStatusDelivered:
+			recipient.DeliveredAt = &timestamp
+		case models.MessageStatusRead:
+			recipient.ReadAt = &timestamp
+		case models.MessageStatusPlayed:
+			recipient.PlayedAt = &timestamp
+		case models.MessageStatusFailed:
+			recipient.FailedAt = &timestamp
+		}
+		recipient.Status = receipt.Status
+	}
+
+	return recipients
+}
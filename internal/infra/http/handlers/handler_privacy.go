@@ -2,13 +2,17 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 
 	"zpmeow/internal/application"
+	"zpmeow/internal/application/common"
+	"zpmeow/internal/application/ports"
 	"zpmeow/internal/infra/http/dto"
 	"zpmeow/internal/infra/wmeow"
 )
@@ -26,8 +30,10 @@ func NewPrivacyHandler(sessionService *application.SessionApp, wmeowService wmeo
 }
 
 // SetAllPrivacySettings godoc
-// @Summary Set all privacy settings
-// @Description Sets all privacy settings for a WhatsApp session
+// @Summary Set privacy settings
+// @Description Updates the WhatsApp privacy settings of the session; only the fields sent are changed.
+// @Description last_seen, profile_photo, status and groups_add_me accept all, contacts, contact_blacklist or none; calls_add_me accepts all or known;
+// @Description online accepts all or match_last_seen. disappearing_timer sets the default timer of new chats (off, 24h, 7d, 90d).
 // @Tags Privacy
 // @Accept json
 // @Produce json
@@ -39,6 +45,7 @@ func NewPrivacyHandler(sessionService *application.SessionApp, wmeowService wmeo
 // @Failure 401 {object} dto.PrivacySettingsResponse "Unauthorized - Invalid API key"
 // @Failure 404 {object} dto.PrivacySettingsResponse "Session not found"
 // @Failure 500 {object} dto.PrivacySettingsResponse "Failed to set privacy settings"
+// @Failure 503 {object} dto.PrivacySettingsResponse "Session not connected"
 // @Router /session/{sessionId}/privacy/set [put]
 func (h *PrivacyHandler) SetAllPrivacySettings(c *fiber.Ctx) error {
 	sessionID := c.Params("sessionId")
@@ -47,6 +54,7 @@ func (h *PrivacyHandler) SetAllPrivacySettings(c *fiber.Ctx) error {
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.PrivacySettingsResponse{
 			Success: false,
+			Code:    fiber.StatusBadRequest,
 			Error: &dto.ErrorInfo{
 				Code:    "INVALID_REQUEST",
 				Message: "Invalid request format",
@@ -55,9 +63,11 @@ func (h *PrivacyHandler) SetAllPrivacySettings(c *fiber.Ctx) error {
 		})
 	}
 
+	// Valida todos os valores antes de alterar qualquer configuração
 	if err := req.Validate(); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.PrivacySettingsResponse{
 			Success: false,
+			Code:    fiber.StatusBadRequest,
 			Error: &dto.ErrorInfo{
 				Code:    "VALIDATION_ERROR",
 				Message: "Invalid privacy settings",
@@ -69,125 +79,38 @@ func (h *PrivacyHandler) SetAllPrivacySettings(c *fiber.Ctx) error {
 	ctx, cancel := context.WithTimeout(c.Context(), 60*time.Second)
 	defer cancel()
 
-	currentSettings, err := h.wmeowService.GetPrivacySettings(ctx, sessionID)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.PrivacySettingsResponse{
-			Success: false,
-			Error: &dto.ErrorInfo{
-				Code:    "PRIVACY_FETCH_ERROR",
-				Message: "Failed to fetch current privacy settings",
-				Details: err.Error(),
-			},
-		})
-	}
-
-	if req.GroupsAddMe != "" {
-		err := h.wmeowService.SetPrivacySetting(ctx, sessionID, "groupadd", req.GroupsAddMe)
-		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(dto.PrivacySettingsResponse{
-				Success: false,
-				Error: &dto.ErrorInfo{
-					Code:    "PRIVACY_UPDATE_ERROR",
-					Message: "Failed to update group add privacy",
-					Details: err.Error(),
-				},
-			})
+	for setting, value := range req.Settings() {
+		if err := h.wmeowService.SetPrivacySetting(ctx, sessionID, setting, value); err != nil {
+			return privacyErrorResponse(c, err, "PRIVACY_UPDATE_ERROR", fmt.Sprintf("Failed to update %s privacy", setting))
 		}
-		currentSettings.GroupsAddMe = req.GroupsAddMe
 	}
 
-	if req.LastSeen != "" {
-		err := h.wmeowService.SetPrivacySetting(ctx, sessionID, "last", req.LastSeen)
-		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(dto.PrivacySettingsResponse{
-				Success: false,
-				Error: &dto.ErrorInfo{
-					Code:    "PRIVACY_UPDATE_ERROR",
-					Message: "Failed to update last seen privacy",
-					Details: err.Error(),
-				},
-			})
+	if req.ReadReceipts != nil {
+		value := "none"
+		if *req.ReadReceipts {
+			value = "all"
 		}
-		currentSettings.LastSeen = req.LastSeen
-	}
-
-	if req.Status != "" {
-		err := h.wmeowService.SetPrivacySetting(ctx, sessionID, "status", req.Status)
-		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(dto.PrivacySettingsResponse{
-				Success: false,
-				Error: &dto.ErrorInfo{
-					Code:    "PRIVACY_UPDATE_ERROR",
-					Message: "Failed to update status privacy",
-					Details: err.Error(),
-				},
-			})
+		if err := h.wmeowService.SetPrivacySetting(ctx, sessionID, "read_receipts", value); err != nil {
+			return privacyErrorResponse(c, err, "PRIVACY_UPDATE_ERROR", "Failed to update read receipts privacy")
 		}
-		currentSettings.Status = req.Status
 	}
 
-	if req.ProfilePhoto != "" {
-		err := h.wmeowService.SetPrivacySetting(ctx, sessionID, "profile", req.ProfilePhoto)
-		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(dto.PrivacySettingsResponse{
-				Success: false,
-				Error: &dto.ErrorInfo{
-					Code:    "PRIVACY_UPDATE_ERROR",
-					Message: "Failed to update profile privacy",
-					Details: err.Error(),
-				},
-			})
+	if req.DisappearingTimer != "" {
+		seconds := dto.DisappearingTimers[strings.ToLower(req.DisappearingTimer)]
+		if err := h.wmeowService.SetDisappearingMessagesDefault(ctx, sessionID, seconds); err != nil {
+			return privacyErrorResponse(c, err, "PRIVACY_UPDATE_ERROR", "Failed to update default disappearing timer")
 		}
-		currentSettings.ProfilePhoto = req.ProfilePhoto
 	}
 
-	value := "none"
-	if req.ReadReceipts {
-		value = "all"
-	}
-	err = h.wmeowService.SetPrivacySetting(ctx, sessionID, "readreceipts", value)
+	settings, err := h.wmeowService.GetPrivacySettings(ctx, sessionID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.PrivacySettingsResponse{
-			Success: false,
-			Error: &dto.ErrorInfo{
-				Code:    "PRIVACY_UPDATE_ERROR",
-				Message: "Failed to update read receipts privacy",
-				Details: err.Error(),
-			},
-		})
-	}
-	currentSettings.ReadReceipts = value == "all"
-
-	if req.CallsAddMe != "" {
-		err := h.wmeowService.SetPrivacySetting(ctx, sessionID, "calladd", req.CallsAddMe)
-		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(dto.PrivacySettingsResponse{
-				Success: false,
-				Error: &dto.ErrorInfo{
-					Code:    "PRIVACY_UPDATE_ERROR",
-					Message: "Failed to update call privacy",
-					Details: err.Error(),
-				},
-			})
-		}
-		currentSettings.CallsAddMe = req.CallsAddMe
-	}
-
-	data := &dto.PrivacySettingsData{
-		SessionId:         sessionID,
-		LastSeen:          currentSettings.LastSeen,
-		ProfilePhoto:      currentSettings.ProfilePhoto,
-		Status:            currentSettings.Status,
-		ReadReceipts:      currentSettings.ReadReceipts,
-		GroupsAddMe:       currentSettings.GroupsAddMe,
-		CallsAddMe:        currentSettings.CallsAddMe,
-		DisappearingChats: currentSettings.DisappearingMessages == "on",
-		UpdatedAt:         time.Now().Format(time.RFC3339),
+		return privacyErrorResponse(c, err, "PRIVACY_FETCH_ERROR", "Failed to fetch privacy settings")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(dto.PrivacySettingsResponse{
 		Success: true,
-		Data:    data,
+		Code:    fiber.StatusOK,
+		Data:    toPrivacySettingsData(sessionID, settings, nil),
 	})
 }
 
@@ -390,7 +313,9 @@ func createNotFoundError(resource string) *dto.ErrorInfo {
 
 // FindPrivacySettings godoc
 // @Summary Find privacy settings
-// @Description Retrieves current privacy settings for a WhatsApp session
+// @Description Returns the current WhatsApp privacy settings of the session, optionally only the ones listed in settings.
+// @Description The settings are fetched from WhatsApp once per connection and kept up to date by the PrivacySettings events.
+// @Description disappearing_timer is only known after being set through this API, since WhatsApp does not allow querying it.
 // @Tags Privacy
 // @Accept json
 // @Produce json
@@ -402,6 +327,7 @@ func createNotFoundError(resource string) *dto.ErrorInfo {
 // @Failure 401 {object} dto.PrivacySettingsResponse "Unauthorized - Invalid API key"
 // @Failure 404 {object} dto.PrivacySettingsResponse "Session not found"
 // @Failure 500 {object} dto.PrivacySettingsResponse "Failed to get privacy settings"
+// @Failure 503 {object} dto.PrivacySettingsResponse "Session not connected"
 // @Router /session/{sessionId}/privacy/find [post]
 func (h *PrivacyHandler) FindPrivacySettings(c *fiber.Ctx) error {
 	sessionID := c.Params("sessionId")
@@ -411,64 +337,91 @@ func (h *PrivacyHandler) FindPrivacySettings(c *fiber.Ctx) error {
 		req.Settings = []string{}
 	}
 
-	ctx, cancel := context.WithTimeout(c.Context(), 30*time.Second)
-	defer cancel()
-
-	allSettings, err := h.wmeowService.GetPrivacySettings(ctx, sessionID)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.PrivacySettingsResponse{
+	if err := req.Validate(); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.PrivacySettingsResponse{
 			Success: false,
+			Code:    fiber.StatusBadRequest,
 			Error: &dto.ErrorInfo{
-				Code:    "PRIVACY_FETCH_ERROR",
-				Message: "Failed to fetch privacy settings",
+				Code:    "VALIDATION_ERROR",
+				Message: "Invalid privacy settings query",
 				Details: err.Error(),
 			},
 		})
 	}
 
-	if len(req.Settings) == 0 {
-		data := &dto.PrivacySettingsData{
-			SessionId:         sessionID,
-			LastSeen:          allSettings.LastSeen,
-			ProfilePhoto:      allSettings.ProfilePhoto,
-			Status:            allSettings.Status,
-			ReadReceipts:      allSettings.ReadReceipts,
-			GroupsAddMe:       allSettings.GroupsAddMe,
-			CallsAddMe:        allSettings.CallsAddMe,
-			DisappearingChats: allSettings.DisappearingMessages == "on",
-			UpdatedAt:         time.Now().Format(time.RFC3339),
-		}
-
-		return c.Status(fiber.StatusOK).JSON(dto.PrivacySettingsResponse{
-			Success: true,
-			Data:    data,
-		})
-	}
+	ctx, cancel := context.WithTimeout(c.Context(), 30*time.Second)
+	defer cancel()
 
-	filteredData := &dto.PrivacySettingsData{
-		SessionId: sessionID,
-		UpdatedAt: time.Now().Format(time.RFC3339),
-	}
-
-	for _, setting := range req.Settings {
-		switch setting {
-		case "groups_add_me":
-			filteredData.GroupsAddMe = allSettings.GroupsAddMe
-		case "last_seen":
-			filteredData.LastSeen = allSettings.LastSeen
-		case "status":
-			filteredData.Status = allSettings.Status
-		case "profile_photo":
-			filteredData.ProfilePhoto = allSettings.ProfilePhoto
-		case "read_receipts":
-			filteredData.ReadReceipts = allSettings.ReadReceipts
-		case "calls_add_me":
-			filteredData.CallsAddMe = allSettings.CallsAddMe
-		}
+	settings, err := h.wmeowService.GetPrivacySettings(ctx, sessionID)
+	if err != nil {
+		return privacyErrorResponse(c, err, "PRIVACY_FETCH_ERROR", "Failed to fetch privacy settings")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(dto.PrivacySettingsResponse{
 		Success: true,
-		Data:    filteredData,
+		Code:    fiber.StatusOK,
+		Data:    toPrivacySettingsData(sessionID, settings, req.Settings),
+	})
+}
+
+// toPrivacySettingsData converte as configurações para a resposta; com only, inclui apenas as configurações pedidas
+func toPrivacySettingsData(sessionID string, settings *ports.PrivacySettings, only []string) *dto.PrivacySettingsData {
+	include := func(name string) bool {
+		return len(only) == 0 || slices.Contains(only, name)
+	}
+
+	data := &dto.PrivacySettingsData{SessionId: sessionID}
+	if !settings.UpdatedAt.IsZero() {
+		data.UpdatedAt = settings.UpdatedAt.Format(time.RFC3339)
+	}
+	if include("last_seen") {
+		data.LastSeen = settings.LastSeen
+	}
+	if include("profile_photo") {
+		data.ProfilePhoto = settings.ProfilePhoto
+	}
+	if include("status") {
+		data.Status = settings.Status
+	}
+	if include("read_receipts") {
+		data.ReadReceipts = &settings.ReadReceipts
+	}
+	if include("groups_add_me") {
+		data.GroupsAddMe = settings.GroupsAddMe
+	}
+	if include("calls_add_me") {
+		data.CallsAddMe = settings.CallsAddMe
+	}
+	if include("online") {
+		data.Online = settings.Online
+	}
+	if include("disappearing_timer") && settings.DisappearingTimer != nil {
+		enabled := *settings.DisappearingTimer > 0
+		data.DisappearingTimer = settings.DisappearingTimer
+		data.DisappearingChats = &enabled
+	}
+	return data
+}
+
+// privacyErrorResponse traduz os erros do serviço de privacidade para o status HTTP
+func privacyErrorResponse(c *fiber.Ctx, err error, code, message string) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, common.ErrInvalidInput):
+		status, code = fiber.StatusBadRequest, "VALIDATION_ERROR"
+	case strings.Contains(err.Error(), "client not found"):
+		status, code = fiber.StatusNotFound, "SESSION_NOT_FOUND"
+	case strings.Contains(err.Error(), "not connected"):
+		status, code = fiber.StatusServiceUnavailable, "SESSION_NOT_CONNECTED"
+	}
+
+	return c.Status(status).JSON(dto.PrivacySettingsResponse{
+		Success: false,
+		Code:    status,
+		Error: &dto.ErrorInfo{
+			Code:    code,
+			Message: message,
+			Details: err.Error(),
+		},
 	})
 }
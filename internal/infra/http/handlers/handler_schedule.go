@@ -0,0 +1,376 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"zpmeow/internal/application"
+	"zpmeow/internal/application/common"
+	"zpmeow/internal/application/payloads"
+	"zpmeow/internal/infra/database/models"
+	"zpmeow/internal/infra/http/dto"
+	"zpmeow/internal/infra/scheduler"
+	"zpmeow/internal/infra/templates"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var scheduledStatuses = map[string]bool{
+	models.ScheduledStatusScheduled:      true,
+	models.ScheduledStatusProcessing:     true,
+	models.ScheduledStatusWaitingSession: true,
+	models.ScheduledStatusSent:           true,
+	models.ScheduledStatusFailed:         true,
+	models.ScheduledStatusCanceled:       true,
+}
+
+type ScheduleHandler struct {
+	*BaseHandler
+	sessionService *application.SessionApp
+	scheduler      *scheduler.Scheduler
+	templates      *templates.Service
+}
+
+func NewScheduleHandler(sessionService *application.SessionApp, messageScheduler *scheduler.Scheduler, templateService *templates.Service) *ScheduleHandler {
+	return &ScheduleHandler{
+		BaseHandler:    NewBaseHandler("schedule-handler"),
+		sessionService: sessionService,
+		scheduler:      messageScheduler,
+		templates:      templateService,
+	}
+}
+
+func (h *ScheduleHandler) resolveSessionID(c *fiber.Ctx, sessionIDOrName string) (string, error) {
+	if h.sessionService == nil {
+		return sessionIDOrName, nil
+	}
+
+	ctx := c.Context()
+	session, err := h.sessionService.GetSession(ctx, sessionIDOrName)
+	if err != nil {
+		return "", err
+	}
+
+	return session.SessionID().Value(), nil
+}
+
+func (h *ScheduleHandler) errorResponse(c *fiber.Ctx, status int, code, message, details string) error {
+	return c.Status(status).JSON(dto.ScheduledMessageResponse{
+		Success: false,
+		Code:    status,
+		Error: &dto.ErrorInfo{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+	})
+}
+
+// ScheduleMessage godoc
+// @Summary Schedule a message
+// @Description Schedules any message type for a future time. "message" has the same body as the matching /message/send/{type} endpoint
+// @Description (text, media, image, audio, video, document, sticker, location, contact, poll, buttons or list); media must be referenced by URL, data URL, base64 or media_id.
+// @Description send_at is RFC3339 or a local "YYYY-MM-DDTHH:MM[:SS]" interpreted in "timezone" (IANA name, default UTC).
+// @Description If the session is not connected at the due time, the message is sent as soon as it reconnects.
+// @Description With template_id the stored template is rendered now with "variables"; "message" then only needs "phone" (and optional reply/mention fields) and "type" is taken from the template.
+// @Tags Scheduled Messages
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param request body dto.ScheduleMessageRequest true "Scheduled message"
+// @Success 201 {object} dto.ScheduledMessageResponse "Message scheduled"
+// @Failure 400 {object} dto.ScheduledMessageResponse "Invalid request data"
+// @Failure 404 {object} dto.ScheduledMessageResponse "Session or template not found"
+// @Failure 500 {object} dto.ScheduledMessageResponse "Failed to schedule message"
+// @Router /session/{sessionId}/message/schedule [post]
+func (h *ScheduleHandler) ScheduleMessage(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	var req dto.ScheduleMessageRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.errorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err.Error())
+	}
+
+	if err := req.Validate(); err != nil {
+		return h.errorResponse(c, fiber.StatusBadRequest, "VALIDATION_ERROR", "Request validation failed", err.Error())
+	}
+
+	sendAt, timezone, err := scheduler.ParseSendAt(req.SendAt, req.Timezone)
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusBadRequest, "INVALID_SEND_AT", "Invalid send time", err.Error())
+	}
+
+	messageType, payload := req.Type, []byte(req.Message)
+	if req.TemplateID != "" {
+		var target struct {
+			Phone string `json:"phone"`
+			payloads.SendContextFields
+		}
+		if err := json.Unmarshal(req.Message, &target); err != nil {
+			return h.errorResponse(c, fiber.StatusBadRequest, "VALIDATION_ERROR", "Invalid scheduled message", err.Error())
+		}
+
+		messageType, payload, err = h.templates.Render(c.Context(), sessionID, req.TemplateID, target.Phone, req.Variables, target.SendContextFields)
+		if err != nil {
+			switch {
+			case errors.Is(err, templates.ErrNotFound):
+				return h.errorResponse(c, fiber.StatusNotFound, "TEMPLATE_NOT_FOUND", "Message template not found: "+req.TemplateID, "")
+			case errors.Is(err, common.ErrInvalidInput):
+				return h.errorResponse(c, fiber.StatusBadRequest, "VALIDATION_ERROR", "Invalid scheduled message", err.Error())
+			}
+			return h.errorResponse(c, fiber.StatusInternalServerError, "SCHEDULE_FAILED", "Failed to render message template", err.Error())
+		}
+	}
+
+	message, err := h.scheduler.Schedule(c.Context(), sessionID, messageType, payload, sendAt, timezone)
+	if err != nil {
+		if errors.Is(err, common.ErrInvalidInput) {
+			return h.errorResponse(c, fiber.StatusBadRequest, "VALIDATION_ERROR", "Invalid scheduled message", err.Error())
+		}
+		return h.errorResponse(c, fiber.StatusInternalServerError, "SCHEDULE_FAILED", "Failed to schedule message", err.Error())
+	}
+
+	info := toScheduledMessageInfo(message, true)
+	return c.Status(fiber.StatusCreated).JSON(dto.ScheduledMessageResponse{
+		Success: true,
+		Code:    fiber.StatusCreated,
+		Data:    &info,
+	})
+}
+
+// ListScheduledMessages godoc
+// @Summary List scheduled messages
+// @Description Lists the scheduled messages of a session ordered by send time, optionally filtered by status
+// @Tags Scheduled Messages
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param status query string false "Status filter" Enums(scheduled, processing, waiting_session, sent, failed, canceled)
+// @Param limit query int false "Maximum number of messages to return" default(50)
+// @Param offset query int false "Number of messages to skip" default(0)
+// @Success 200 {object} dto.ScheduledMessageListResponse "Scheduled messages"
+// @Failure 400 {object} dto.ScheduledMessageListResponse "Invalid query parameters"
+// @Failure 404 {object} dto.ScheduledMessageListResponse "Session not found"
+// @Failure 500 {object} dto.ScheduledMessageListResponse "Failed to list scheduled messages"
+// @Router /session/{sessionId}/message/schedule [get]
+func (h *ScheduleHandler) ListScheduledMessages(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	status := c.Query("status")
+	if status != "" && !scheduledStatuses[status] {
+		return h.errorResponse(c, fiber.StatusBadRequest, "INVALID_STATUS", "Invalid status filter", "unknown status: "+status)
+	}
+
+	limit, err := strconv.Atoi(c.Query("limit", "50"))
+	if err != nil || limit <= 0 || limit > 500 {
+		return h.errorResponse(c, fiber.StatusBadRequest, "INVALID_LIMIT", "limit must be a number between 1 and 500", "")
+	}
+
+	offset, err := strconv.Atoi(c.Query("offset", "0"))
+	if err != nil || offset < 0 {
+		return h.errorResponse(c, fiber.StatusBadRequest, "INVALID_OFFSET", "offset must be a non-negative number", "")
+	}
+
+	messages, total, err := h.scheduler.List(c.Context(), sessionID, status, limit, offset)
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusInternalServerError, "LIST_FAILED", "Failed to list scheduled messages", err.Error())
+	}
+
+	items := make([]dto.ScheduledMessageInfo, 0, len(messages))
+	for _, message := range messages {
+		items = append(items, toScheduledMessageInfo(message, false))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.ScheduledMessageListResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data: &dto.ScheduledMessageListData{
+			SessionId: sessionID,
+			Messages:  items,
+			Count:     len(items),
+			Total:     total,
+			Limit:     limit,
+			Offset:    offset,
+		},
+	})
+}
+
+// GetScheduledMessage godoc
+// @Summary Get a scheduled message
+// @Description Retrieves a scheduled message including its payload, status and last error
+// @Tags Scheduled Messages
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param scheduleId path string true "Scheduled message ID"
+// @Success 200 {object} dto.ScheduledMessageResponse "Scheduled message"
+// @Failure 404 {object} dto.ScheduledMessageResponse "Session or scheduled message not found"
+// @Failure 500 {object} dto.ScheduledMessageResponse "Failed to get scheduled message"
+// @Router /session/{sessionId}/message/schedule/{scheduleId} [get]
+func (h *ScheduleHandler) GetScheduledMessage(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	scheduleID := c.Params("scheduleId")
+	message, err := h.scheduler.Get(c.Context(), sessionID, scheduleID)
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusInternalServerError, "GET_FAILED", "Failed to get scheduled message", err.Error())
+	}
+
+	if message == nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SCHEDULED_MESSAGE_NOT_FOUND", "Scheduled message not found: "+scheduleID, "")
+	}
+
+	info := toScheduledMessageInfo(message, true)
+	return c.Status(fiber.StatusOK).JSON(dto.ScheduledMessageResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data:    &info,
+	})
+}
+
+// CancelScheduledMessage godoc
+// @Summary Cancel a scheduled message
+// @Description Cancels a scheduled message that has not been sent yet
+// @Tags Scheduled Messages
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param scheduleId path string true "Scheduled message ID"
+// @Success 200 {object} dto.ScheduledMessageResponse "Scheduled message canceled"
+// @Failure 404 {object} dto.ScheduledMessageResponse "Session or scheduled message not found"
+// @Failure 409 {object} dto.ScheduledMessageResponse "Message already sent, failed or canceled"
+// @Failure 500 {object} dto.ScheduledMessageResponse "Failed to cancel scheduled message"
+// @Router /session/{sessionId}/message/schedule/{scheduleId} [delete]
+func (h *ScheduleHandler) CancelScheduledMessage(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	scheduleID := c.Params("scheduleId")
+	message, err := h.scheduler.Cancel(c.Context(), sessionID, scheduleID)
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusInternalServerError, "CANCEL_FAILED", "Failed to cancel scheduled message", err.Error())
+	}
+
+	if message == nil {
+		return h.notPendingResponse(c, sessionID, scheduleID)
+	}
+
+	info := toScheduledMessageInfo(message, false)
+	return c.Status(fiber.StatusOK).JSON(dto.ScheduledMessageResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data:    &info,
+	})
+}
+
+// RescheduleMessage godoc
+// @Summary Reschedule a message
+// @Description Moves a scheduled message that has not been sent yet to a new send time, resetting its attempts
+// @Tags Scheduled Messages
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param scheduleId path string true "Scheduled message ID"
+// @Param request body dto.RescheduleMessageRequest true "New send time"
+// @Success 200 {object} dto.ScheduledMessageResponse "Message rescheduled"
+// @Failure 400 {object} dto.ScheduledMessageResponse "Invalid request data"
+// @Failure 404 {object} dto.ScheduledMessageResponse "Session or scheduled message not found"
+// @Failure 409 {object} dto.ScheduledMessageResponse "Message already sent, failed or canceled"
+// @Failure 500 {object} dto.ScheduledMessageResponse "Failed to reschedule message"
+// @Router /session/{sessionId}/message/schedule/{scheduleId} [put]
+func (h *ScheduleHandler) RescheduleMessage(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	var req dto.RescheduleMessageRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.errorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err.Error())
+	}
+
+	if err := req.Validate(); err != nil {
+		return h.errorResponse(c, fiber.StatusBadRequest, "VALIDATION_ERROR", "Request validation failed", err.Error())
+	}
+
+	sendAt, timezone, err := scheduler.ParseSendAt(req.SendAt, req.Timezone)
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusBadRequest, "INVALID_SEND_AT", "Invalid send time", err.Error())
+	}
+
+	scheduleID := c.Params("scheduleId")
+	message, err := h.scheduler.Reschedule(c.Context(), sessionID, scheduleID, sendAt, timezone)
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusInternalServerError, "RESCHEDULE_FAILED", "Failed to reschedule message", err.Error())
+	}
+
+	if message == nil {
+		return h.notPendingResponse(c, sessionID, scheduleID)
+	}
+
+	info := toScheduledMessageInfo(message, false)
+	return c.Status(fiber.StatusOK).JSON(dto.ScheduledMessageResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data:    &info,
+	})
+}
+
+// notPendingResponse diferencia agendamento inexistente (404) de agendamento que já saiu da fila (409)
+func (h *ScheduleHandler) notPendingResponse(c *fiber.Ctx, sessionID, scheduleID string) error {
+	existing, err := h.scheduler.Get(c.Context(), sessionID, scheduleID)
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusInternalServerError, "GET_FAILED", "Failed to get scheduled message", err.Error())
+	}
+
+	if existing == nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SCHEDULED_MESSAGE_NOT_FOUND", "Scheduled message not found: "+scheduleID, "")
+	}
+
+	return h.errorResponse(c, fiber.StatusConflict, "SCHEDULED_MESSAGE_NOT_PENDING",
+		"Scheduled message can no longer be changed", "current status: "+existing.Status)
+}
+
+func toScheduledMessageInfo(message *models.ScheduledMessageModel, withPayload bool) dto.ScheduledMessageInfo {
+	info := dto.ScheduledMessageInfo{
+		ID:            message.ID,
+		SessionId:     message.SessionId,
+		Type:          message.MessageType,
+		Phone:         message.Phone,
+		SendAt:        message.SendAt,
+		Timezone:      message.Timezone,
+		Status:        message.Status,
+		Attempts:      message.Attempts,
+		NextAttemptAt: message.NextAttemptAt,
+		SentAt:        message.SentAt,
+		CreatedAt:     message.CreatedAt,
+		UpdatedAt:     message.UpdatedAt,
+	}
+	if message.LastError != nil {
+		info.LastError = *message.LastError
+	}
+	if message.MessageId != nil {
+		info.MessageID = *message.MessageId
+	}
+	if withPayload {
+		info.Message = message.Payload
+	}
+	return info
+}
@@ -9,6 +9,7 @@ import (
 
 	"zpmeow/internal/application"
 	"zpmeow/internal/domain/session"
+	"zpmeow/internal/infra/eventstream"
 	"zpmeow/internal/infra/http/dto"
 	"zpmeow/internal/infra/wmeow"
 )
@@ -17,13 +18,15 @@ type SessionHandler struct {
 	*BaseHandler
 	sessionService *application.SessionApp
 	wmeowService   wmeow.WameowService
+	eventHub       *eventstream.Hub
 }
 
-func NewSessionHandler(sessionService *application.SessionApp, wmeowService wmeow.WameowService) *SessionHandler {
+func NewSessionHandler(sessionService *application.SessionApp, wmeowService wmeow.WameowService, eventHub *eventstream.Hub) *SessionHandler {
 	return &SessionHandler{
 		BaseHandler:    NewBaseHandler("session-handler"),
 		sessionService: sessionService,
 		wmeowService:   wmeowService,
+		eventHub:       eventHub,
 	}
 }
 
@@ -111,6 +114,7 @@ func (h *SessionHandler) convertToSessionInfo(session *session.Session) *dto.Ses
 		Status:    string(session.Status()),
 		DeviceJID: session.DeviceJID().Value(),
 		ApiKey:    session.ApiKey().Value(),
+		ProxyURL:  session.ProxyConfiguration().Redacted(),
 		CreatedAt: session.CreatedAt().Value(),
 		UpdatedAt: session.UpdatedAt().Value(),
 	}
@@ -276,6 +280,11 @@ func (h *SessionHandler) DeleteSession(c *fiber.Ctx) error {
 		return h.sendErrorResponse(c, fiber.StatusInternalServerError, "DELETE_SESSION_FAILED", "Failed to delete session", err.Error())
 	}
 
+	// Desconecta os clientes de SSE/WebSocket e libera o buffer de eventos da sessão apagada
+	if h.eventHub != nil {
+		h.eventHub.Remove(session.SessionID().Value())
+	}
+
 	h.logSuccess("Delete session", sessionID)
 	return h.sendSuccessResponse(c, sessionID, "delete", nil)
 }
@@ -562,3 +571,56 @@ func (h *SessionHandler) UpdateSessionWebhook(c *fiber.Ctx) error {
 	h.logSuccess("Update session webhook", sessionID)
 	return h.sendSuccessResponse(c, sessionID, "webhook_update", nil)
 }
+
+// UpdateSessionProxy godoc
+// @Summary Update session proxy
+// @Description Sets the HTTP, HTTPS or SOCKS5 proxy (credentials allowed in the URL) used by the session websocket and media transfers, reconnecting the session if it is connected. An empty proxy_url removes the proxy
+// @Tags Sessions
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param request body dto.UpdateProxyRequest true "Proxy update request"
+// @Success 200 {object} dto.SessionResponse "Proxy updated successfully"
+// @Failure 400 {object} dto.SessionResponse "Invalid proxy configuration"
+// @Failure 401 {object} dto.SessionResponse "Unauthorized - Invalid API key"
+// @Failure 404 {object} dto.SessionResponse "Session not found"
+// @Failure 500 {object} dto.SessionResponse "Failed to update proxy"
+// @Router /sessions/{sessionId}/proxy [put]
+func (h *SessionHandler) UpdateSessionProxy(c *fiber.Ctx) error {
+	sessionID, ok := h.validateSessionID(c)
+	if !ok {
+		return nil // validateSessionId já enviou a resposta de erro
+	}
+
+	var req dto.UpdateProxyRequest
+	if !h.bindAndValidateRequest(c, &req) {
+		return nil // bindAndValidateRequest já enviou a resposta de erro
+	}
+
+	proxy, err := session.NewProxyConfiguration(req.ProxyURL)
+	if err != nil {
+		return h.sendErrorResponse(c, fiber.StatusBadRequest, "INVALID_PROXY", err.Error(), "")
+	}
+
+	h.logOperation("Updating proxy for session", fmt.Sprintf("session: %s, proxy: %s", sessionID, proxy.Redacted()))
+
+	sess, err := h.sessionService.GetSession(c.Context(), sessionID)
+	if err != nil {
+		h.logError("get session "+sessionID+" for proxy update", err)
+		return h.sendErrorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	if err := h.sessionService.UpdateSessionProxy(c.Context(), sess, proxy.Value()); err != nil {
+		h.logError("update proxy for session "+sess.SessionID().Value(), err)
+		return h.sendErrorResponse(c, fiber.StatusInternalServerError, "PROXY_UPDATE_FAILED", "Failed to update proxy", err.Error())
+	}
+
+	if err := h.wmeowService.UpdateSessionProxy(c.Context(), sess.SessionID().Value(), proxy); err != nil {
+		h.logError("reconnect session "+sess.SessionID().Value()+" with new proxy", err)
+		return h.sendErrorResponse(c, fiber.StatusInternalServerError, "PROXY_RECONNECT_FAILED", "Proxy saved but reconnection failed", err.Error())
+	}
+
+	h.logSuccess("Update session proxy", sessionID)
+	return h.sendSuccessResponse(c, sessionID, "proxy_update", h.convertToSessionInfo(sess))
+}
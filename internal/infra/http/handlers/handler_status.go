@@ -0,0 +1,354 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+
+	"zpmeow/internal/application"
+	"zpmeow/internal/application/common"
+	"zpmeow/internal/application/ports"
+	"zpmeow/internal/infra/database/models"
+	"zpmeow/internal/infra/database/repository"
+	"zpmeow/internal/infra/http/dto"
+	"zpmeow/internal/infra/wmeow"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type StatusHandler struct {
+	*BaseHandler
+	sessionService *application.SessionApp
+	wmeowService   wmeow.WameowService
+	statusRepo     *repository.StatusRepository
+	mediaResolver  ports.MediaResolver
+}
+
+func NewStatusHandler(sessionService *application.SessionApp, wmeowService wmeow.WameowService, statusRepo *repository.StatusRepository, mediaResolver ports.MediaResolver) *StatusHandler {
+	return &StatusHandler{
+		BaseHandler:    NewBaseHandler("status-handler"),
+		sessionService: sessionService,
+		wmeowService:   wmeowService,
+		statusRepo:     statusRepo,
+		mediaResolver:  mediaResolver,
+	}
+}
+
+func (h *StatusHandler) resolveSessionID(c *fiber.Ctx, sessionIDOrName string) (string, error) {
+	if h.sessionService == nil {
+		return sessionIDOrName, nil
+	}
+
+	ctx := c.Context()
+	session, err := h.sessionService.GetSession(ctx, sessionIDOrName)
+	if err != nil {
+		return "", err
+	}
+
+	return session.SessionID().Value(), nil
+}
+
+func (h *StatusHandler) errorResponse(c *fiber.Ctx, status int, code, message, details string) error {
+	return c.Status(status).JSON(dto.StatusResponse{
+		Success: false,
+		Code:    status,
+		Error: &dto.ErrorInfo{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+	})
+}
+
+func (h *StatusHandler) statusError(c *fiber.Ctx, err error, code, message string) error {
+	switch {
+	case errors.Is(err, common.ErrInvalidInput):
+		return h.errorResponse(c, fiber.StatusBadRequest, "VALIDATION_ERROR", message, err.Error())
+	case errors.Is(err, common.ErrMediaNotFound):
+		return h.errorResponse(c, fiber.StatusNotFound, "MEDIA_NOT_FOUND", "Media not found", err.Error())
+	case errors.Is(err, common.ErrMediaTooLarge):
+		return h.errorResponse(c, fiber.StatusRequestEntityTooLarge, "MEDIA_TOO_LARGE", "Media exceeds the maximum allowed size", err.Error())
+	case strings.Contains(err.Error(), "client not found"):
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	case strings.Contains(err.Error(), "not connected"):
+		return h.errorResponse(c, fiber.StatusServiceUnavailable, "SESSION_NOT_CONNECTED", "Session not connected", err.Error())
+	default:
+		return h.errorResponse(c, fiber.StatusInternalServerError, code, message, err.Error())
+	}
+}
+
+// PostTextStatus godoc
+// @Summary Post a text status
+// @Description Posts a text status (story) with optional background color, text color (#RRGGBB or #AARRGGBB) and font.
+// @Description Fonts: system, system_text, fb_script, system_bold, morningbreeze_regular, calistoga_regular, exo2_extrabold, courierprime_bold.
+// @Description The status is delivered to the audience of the account status privacy, see GET /status/privacy.
+// @Tags Status
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param request body dto.TextStatusRequest true "Text status"
+// @Success 200 {object} dto.StatusResponse "Status posted"
+// @Failure 400 {object} dto.StatusResponse "Invalid request data"
+// @Failure 404 {object} dto.StatusResponse "Session not found"
+// @Failure 503 {object} dto.StatusResponse "Session not connected"
+// @Failure 500 {object} dto.StatusResponse "Failed to post status"
+// @Router /session/{sessionId}/status/text [post]
+func (h *StatusHandler) PostTextStatus(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	var req dto.TextStatusRequest
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return h.errorResponse(c, fiber.StatusBadRequest, "VALIDATION_ERROR", "Invalid request data", err.Error())
+	}
+
+	resp, err := h.wmeowService.PostTextStatus(c.Context(), sessionID, ports.TextStatus{
+		Text:            req.Text,
+		BackgroundColor: req.BackgroundColor,
+		TextColor:       req.TextColor,
+		Font:            req.Font,
+	})
+	if err != nil {
+		return h.statusError(c, err, "POST_STATUS_FAILED", "Failed to post status")
+	}
+
+	return h.postedResponse(c, resp, "text", strings.TrimSpace(req.Text), "")
+}
+
+// PostImageStatus godoc
+// @Summary Post an image status
+// @Description Posts an image status (story) with an optional caption.
+// @Description The media can be a data URL, base64, an http(s) URL, a media_id from /media/upload or a multipart/form-data file upload.
+// @Tags Status
+// @Accept json,mpfd
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param request body dto.ImageStatusRequest true "Image status"
+// @Success 200 {object} dto.StatusResponse "Status posted"
+// @Failure 400 {object} dto.StatusResponse "Invalid request data"
+// @Failure 404 {object} dto.StatusResponse "Session or media not found"
+// @Failure 413 {object} dto.StatusResponse "Media too large"
+// @Failure 503 {object} dto.StatusResponse "Session not connected"
+// @Failure 500 {object} dto.StatusResponse "Failed to post status"
+// @Router /session/{sessionId}/status/image [post]
+func (h *StatusHandler) PostImageStatus(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	var req dto.ImageStatusRequest
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return h.errorResponse(c, fiber.StatusBadRequest, "VALIDATION_ERROR", "Invalid request data", err.Error())
+	}
+
+	image, err := resolveRequestMedia(c, h.mediaResolver, sessionID, "image", "image", req.Image, "", "")
+	if err != nil {
+		return h.statusError(c, err, "INVALID_IMAGE_DATA", "Failed to decode image data")
+	}
+
+	resp, err := h.wmeowService.PostImageStatus(c.Context(), sessionID, image.Data, req.Caption, image.MimeType)
+	if err != nil {
+		return h.statusError(c, err, "POST_STATUS_FAILED", "Failed to post status")
+	}
+
+	return h.postedResponse(c, resp, "image", req.Caption, image.MimeType)
+}
+
+// PostVideoStatus godoc
+// @Summary Post a video status
+// @Description Posts a video status (story) with an optional caption.
+// @Description The media can be a data URL, base64, an http(s) URL, a media_id from /media/upload or a multipart/form-data file upload.
+// @Tags Status
+// @Accept json,mpfd
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param request body dto.VideoStatusRequest true "Video status"
+// @Success 200 {object} dto.StatusResponse "Status posted"
+// @Failure 400 {object} dto.StatusResponse "Invalid request data"
+// @Failure 404 {object} dto.StatusResponse "Session or media not found"
+// @Failure 413 {object} dto.StatusResponse "Media too large"
+// @Failure 503 {object} dto.StatusResponse "Session not connected"
+// @Failure 500 {object} dto.StatusResponse "Failed to post status"
+// @Router /session/{sessionId}/status/video [post]
+func (h *StatusHandler) PostVideoStatus(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	var req dto.VideoStatusRequest
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return h.errorResponse(c, fiber.StatusBadRequest, "VALIDATION_ERROR", "Invalid request data", err.Error())
+	}
+
+	video, err := resolveRequestMedia(c, h.mediaResolver, sessionID, "video", "video", req.Video, "", "")
+	if err != nil {
+		return h.statusError(c, err, "INVALID_VIDEO_DATA", "Failed to decode video data")
+	}
+
+	resp, err := h.wmeowService.PostVideoStatus(c.Context(), sessionID, video.Data, req.Caption, video.MimeType)
+	if err != nil {
+		return h.statusError(c, err, "POST_STATUS_FAILED", "Failed to post status")
+	}
+
+	return h.postedResponse(c, resp, "video", req.Caption, video.MimeType)
+}
+
+// GetStatusAudience godoc
+// @Summary Get the status audience
+// @Description Returns who receives the statuses posted by the session, from the account status privacy:
+// @Description contacts (all contacts), blacklist (all contacts except list) or whitelist (only list).
+// @Description WhatsApp does not allow choosing recipients per status; change the audience on the phone.
+// @Tags Status
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Success 200 {object} dto.StatusAudienceResponse "Status audience"
+// @Failure 404 {object} dto.StatusAudienceResponse "Session not found"
+// @Failure 503 {object} dto.StatusAudienceResponse "Session not connected"
+// @Failure 500 {object} dto.StatusAudienceResponse "Failed to get status audience"
+// @Router /session/{sessionId}/status/privacy [get]
+func (h *StatusHandler) GetStatusAudience(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	audience, err := h.wmeowService.GetStatusAudience(c.Context(), sessionID)
+	if err != nil {
+		return h.statusError(c, err, "GET_AUDIENCE_FAILED", "Failed to get status audience")
+	}
+
+	list := audience.List
+	if list == nil {
+		list = []string{}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.StatusAudienceResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data: &dto.StatusAudienceData{
+			SessionId: sessionID,
+			Type:      audience.Type,
+			List:      list,
+		},
+	})
+}
+
+// ListStatuses godoc
+// @Summary List statuses
+// @Description Lists the stored statuses, most recent first: the ones received from contacts and the ones posted by the session.
+// @Description Expired statuses (older than 24 hours) are only returned with include_expired; statuses deleted by the sender are never returned.
+// @Tags Status
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param sender query string false "Sender phone number or JID"
+// @Param include_expired query bool false "Include expired statuses" default(false)
+// @Param limit query int false "Maximum number of statuses to return" default(50)
+// @Param offset query int false "Number of statuses to skip" default(0)
+// @Success 200 {object} dto.StatusListResponse "Statuses"
+// @Failure 400 {object} dto.StatusListResponse "Invalid query parameters"
+// @Failure 404 {object} dto.StatusListResponse "Session not found"
+// @Failure 500 {object} dto.StatusListResponse "Failed to list statuses"
+// @Router /session/{sessionId}/status/list [get]
+func (h *StatusHandler) ListStatuses(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	sender := strings.TrimSpace(c.Query("sender"))
+	if sender != "" && !strings.Contains(sender, "@") {
+		sender = strings.TrimPrefix(sender, "+") + "@s.whatsapp.net"
+	}
+
+	includeExpired, err := strconv.ParseBool(c.Query("include_expired", "false"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusBadRequest, "INVALID_INCLUDE_EXPIRED", "include_expired must be true or false", "")
+	}
+
+	limit, err := strconv.Atoi(c.Query("limit", "50"))
+	if err != nil || limit <= 0 || limit > 500 {
+		return h.errorResponse(c, fiber.StatusBadRequest, "INVALID_LIMIT", "limit must be a number between 1 and 500", "")
+	}
+
+	offset, err := strconv.Atoi(c.Query("offset", "0"))
+	if err != nil || offset < 0 {
+		return h.errorResponse(c, fiber.StatusBadRequest, "INVALID_OFFSET", "offset must be a non-negative number", "")
+	}
+
+	statuses, total, err := h.statusRepo.List(c.Context(), sessionID, sender, includeExpired, limit, offset)
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusInternalServerError, "LIST_FAILED", "Failed to list statuses", err.Error())
+	}
+
+	items := make([]dto.StatusInfo, 0, len(statuses))
+	for _, status := range statuses {
+		items = append(items, toStatusInfo(status))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.StatusListResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data: &dto.StatusListData{
+			SessionId: sessionID,
+			Statuses:  items,
+			Count:     len(items),
+			Total:     total,
+			Limit:     limit,
+			Offset:    offset,
+		},
+	})
+}
+
+func (h *StatusHandler) postedResponse(c *fiber.Ctx, resp *whatsmeow.SendResponse, statusType, content, mimeType string) error {
+	postedAt := resp.Timestamp
+	if postedAt.IsZero() {
+		postedAt = time.Now()
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.StatusResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data: &dto.StatusInfo{
+			ID:        string(resp.ID),
+			Type:      statusType,
+			Content:   content,
+			MimeType:  mimeType,
+			IsFromMe:  true,
+			PostedAt:  postedAt,
+			ExpiresAt: postedAt.Add(wmeow.StatusLifetime),
+		},
+	})
+}
+
+func toStatusInfo(status *models.StatusModel) dto.StatusInfo {
+	info := dto.StatusInfo{
+		ID:              status.StatusId,
+		Sender:          status.SenderJid,
+		SenderName:      stringValue(status.SenderName),
+		Type:            status.Type,
+		Content:         stringValue(status.Content),
+		BackgroundColor: stringValue(status.BackgroundColor),
+		TextColor:       stringValue(status.TextColor),
+		Font:            stringValue(status.Font),
+		IsFromMe:        status.IsFromMe,
+		PostedAt:        status.PostedAt,
+		ExpiresAt:       status.ExpiresAt,
+		RevokedAt:       status.RevokedAt,
+	}
+	if mimeType, ok := status.MediaInfo["mimeType"].(string); ok {
+		info.MimeType = mimeType
+	}
+	return info
+}
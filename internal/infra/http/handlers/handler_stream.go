@@ -0,0 +1,262 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"zpmeow/internal/application"
+	"zpmeow/internal/infra/eventstream"
+	"zpmeow/internal/infra/http/dto"
+	"zpmeow/internal/infra/http/middleware"
+	"zpmeow/internal/infra/logging"
+	"zpmeow/internal/infra/webhooks"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// streamRetry é o intervalo de reconexão sugerido aos clientes SSE
+	streamRetry = 3 * time.Second
+
+	streamWriteTimeout = 10 * time.Second
+	streamSessionLocal = "stream_session_id"
+)
+
+// EventStreamHandler entrega os eventos da sessão por SSE e WebSocket, para consumidores
+// que não podem expor uma URL pública de webhook
+type EventStreamHandler struct {
+	sessionService *application.SessionApp
+	hub            *eventstream.Hub
+	heartbeat      time.Duration
+	logger         logging.Logger
+}
+
+func NewEventStreamHandler(sessionService *application.SessionApp, hub *eventstream.Hub, heartbeat time.Duration) *EventStreamHandler {
+	if heartbeat <= 0 {
+		heartbeat = 25 * time.Second
+	}
+	return &EventStreamHandler{
+		sessionService: sessionService,
+		hub:            hub,
+		heartbeat:      heartbeat,
+		logger:         logging.GetLogger().Sub("event-stream"),
+	}
+}
+
+func (h *EventStreamHandler) resolveSessionID(c *fiber.Ctx, sessionIDOrName string) (string, error) {
+	if h.sessionService == nil {
+		return sessionIDOrName, nil
+	}
+
+	session, err := h.sessionService.GetSession(c.Context(), sessionIDOrName)
+	if err != nil {
+		return "", err
+	}
+
+	return session.SessionID().Value(), nil
+}
+
+// authorize resolve a sessão do path e garante que uma chave de sessão só acesse o próprio stream
+func (h *EventStreamHandler) authorize(c *fiber.Ctx) (string, error) {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return "", c.Status(fiber.StatusNotFound).JSON(dto.NewErrorResponse(fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error()))
+	}
+
+	if !middleware.IsGlobalAuth(c) {
+		session, ok := middleware.GetAuthenticatedSession(c)
+		if !ok || session.SessionID().Value() != sessionID {
+			return "", c.Status(fiber.StatusForbidden).JSON(dto.NewErrorResponse(fiber.StatusForbidden, "FORBIDDEN", "API key does not belong to this session", ""))
+		}
+	}
+
+	return sessionID, nil
+}
+
+// StreamEvents godoc
+// @Summary Stream session events (SSE)
+// @Description Streams the session events as Server-Sent Events, filtered by the session's subscribed events. Each event is a v1 webhook envelope:
+// @Description the SSE "id" is the envelope id and "event" its type. Reconnect with the Last-Event-ID header (or last_event_id) to replay the events missed
+// @Description from a short buffer. Browsers may pass the API key in api_key, since EventSource cannot send headers.
+// @Tags Events
+// @Produce text/event-stream
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param events query string false "Comma-separated event types to receive (default: all subscribed events)"
+// @Param last_event_id query string false "Resume after this event id (same as the Last-Event-ID header)"
+// @Param api_key query string false "API key, for clients that cannot send headers"
+// @Success 200 {string} string "Event stream"
+// @Failure 401 {object} dto.BaseResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} dto.BaseResponse "API key belongs to another session"
+// @Failure 404 {object} dto.BaseResponse "Session not found"
+// @Router /session/{sessionId}/events/stream [get]
+func (h *EventStreamHandler) StreamEvents(c *fiber.Ctx) error {
+	sessionID, err := h.authorize(c)
+	if sessionID == "" {
+		return err
+	}
+
+	lastEventID := c.Get("Last-Event-ID", c.Query("last_event_id"))
+	subscriber, replay := h.hub.Subscribe(sessionID, lastEventID, parseEventTypes(c.Query("events")))
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no") // desativa o buffer de proxies como o nginx
+
+	h.logger.Infof("SSE client connected to session %s (replaying %d events)", sessionID, len(replay))
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer h.hub.Unsubscribe(sessionID, subscriber)
+		defer h.logger.Infof("SSE client disconnected from session %s", sessionID)
+
+		heartbeat := time.NewTicker(h.heartbeat)
+		defer heartbeat.Stop()
+
+		fmt.Fprintf(w, "retry: %d\n\n", streamRetry.Milliseconds())
+		for _, envelope := range replay {
+			if err := writeServerSentEvent(w, envelope); err != nil {
+				return
+			}
+		}
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case envelope, ok := <-subscriber.Events():
+				if !ok {
+					// Removido por não acompanhar o ritmo dos eventos; o cliente retoma pelo Last-Event-ID
+					return
+				}
+				if err := writeServerSentEvent(w, envelope); err != nil {
+					return
+				}
+			case <-heartbeat.C:
+				if _, err := w.WriteString(": heartbeat\n\n"); err != nil {
+					return
+				}
+			}
+
+			// Flush falha quando o cliente desconecta
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+
+	return nil
+}
+
+// UpgradeEventSocket valida a sessão antes do upgrade para WebSocket
+func (h *EventStreamHandler) UpgradeEventSocket(c *fiber.Ctx) error {
+	if !websocket.IsWebSocketUpgrade(c) {
+		return c.Status(fiber.StatusUpgradeRequired).JSON(dto.NewErrorResponse(fiber.StatusUpgradeRequired, "UPGRADE_REQUIRED", "WebSocket upgrade required", ""))
+	}
+
+	sessionID, err := h.authorize(c)
+	if sessionID == "" {
+		return err
+	}
+
+	c.Locals(streamSessionLocal, sessionID)
+	return c.Next()
+}
+
+// EventSocket godoc
+// @Summary Stream session events (WebSocket)
+// @Description Streams the session events over a WebSocket as JSON text messages, each one a v1 webhook envelope, filtered by the session's subscribed events.
+// @Description Pass last_event_id to replay the events missed from a short buffer. Browsers may pass the API key in api_key.
+// @Tags Events
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param events query string false "Comma-separated event types to receive (default: all subscribed events)"
+// @Param last_event_id query string false "Resume after this event id"
+// @Param api_key query string false "API key, for clients that cannot send headers"
+// @Success 101 {string} string "Switching protocols"
+// @Failure 401 {object} dto.BaseResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} dto.BaseResponse "API key belongs to another session"
+// @Failure 404 {object} dto.BaseResponse "Session not found"
+// @Failure 426 {object} dto.BaseResponse "WebSocket upgrade required"
+// @Router /session/{sessionId}/events/ws [get]
+func (h *EventStreamHandler) EventSocket() fiber.Handler {
+	return websocket.New(func(conn *websocket.Conn) {
+		sessionID, _ := conn.Locals(streamSessionLocal).(string)
+		subscriber, replay := h.hub.Subscribe(sessionID, conn.Query("last_event_id"), parseEventTypes(conn.Query("events")))
+		defer h.hub.Unsubscribe(sessionID, subscriber)
+
+		h.logger.Infof("WebSocket client connected to session %s (replaying %d events)", sessionID, len(replay))
+		defer h.logger.Infof("WebSocket client disconnected from session %s", sessionID)
+
+		// O cliente não envia mensagens; a leitura só detecta o fechamento da conexão
+		closed := make(chan struct{})
+		go func() {
+			defer close(closed)
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}()
+
+		for _, envelope := range replay {
+			if err := writeSocketEvent(conn, envelope); err != nil {
+				return
+			}
+		}
+
+		heartbeat := time.NewTicker(h.heartbeat)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case <-closed:
+				return
+			case envelope, ok := <-subscriber.Events():
+				if !ok {
+					_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "client too slow"), time.Now().Add(streamWriteTimeout))
+					return
+				}
+				if err := writeSocketEvent(conn, envelope); err != nil {
+					return
+				}
+			case <-heartbeat.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamWriteTimeout)); err != nil {
+					return
+				}
+			}
+		}
+	})
+}
+
+func writeServerSentEvent(w *bufio.Writer, envelope *webhooks.Envelope) error {
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", envelope.ID, envelope.Type, data)
+	return err
+}
+
+func writeSocketEvent(conn *websocket.Conn, envelope *webhooks.Envelope) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout)); err != nil {
+		return err
+	}
+	return conn.WriteJSON(envelope)
+}
+
+// parseEventTypes separa a lista de eventos do parâmetro events
+func parseEventTypes(value string) []string {
+	var types []string
+	for _, eventType := range strings.Split(value, ",") {
+		if eventType = strings.TrimSpace(eventType); eventType != "" {
+			types = append(types, eventType)
+		}
+	}
+	return types
+}
@@ -0,0 +1,360 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"zpmeow/internal/application"
+	"zpmeow/internal/application/common"
+	"zpmeow/internal/infra/database/models"
+	"zpmeow/internal/infra/http/dto"
+	"zpmeow/internal/infra/templates"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type TemplateHandler struct {
+	*BaseHandler
+	sessionService *application.SessionApp
+	templates      *templates.Service
+}
+
+func NewTemplateHandler(sessionService *application.SessionApp, templateService *templates.Service) *TemplateHandler {
+	return &TemplateHandler{
+		BaseHandler:    NewBaseHandler("template-handler"),
+		sessionService: sessionService,
+		templates:      templateService,
+	}
+}
+
+func (h *TemplateHandler) resolveSessionID(c *fiber.Ctx, sessionIDOrName string) (string, error) {
+	if h.sessionService == nil {
+		return sessionIDOrName, nil
+	}
+
+	ctx := c.Context()
+	session, err := h.sessionService.GetSession(ctx, sessionIDOrName)
+	if err != nil {
+		return "", err
+	}
+
+	return session.SessionID().Value(), nil
+}
+
+func (h *TemplateHandler) errorResponse(c *fiber.Ctx, status int, code, message, details string) error {
+	return c.Status(status).JSON(dto.MessageTemplateResponse{
+		Success: false,
+		Code:    status,
+		Error: &dto.ErrorInfo{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+	})
+}
+
+// templateError converte os erros do serviço de templates no status HTTP correspondente
+func (h *TemplateHandler) templateError(c *fiber.Ctx, err error, code, message string) error {
+	var validationErr *common.ValidationError
+	switch {
+	case errors.Is(err, common.ErrInvalidInput), errors.As(err, &validationErr):
+		return h.errorResponse(c, fiber.StatusBadRequest, "VALIDATION_ERROR", "Invalid message template", err.Error())
+	case errors.Is(err, templates.ErrNameTaken):
+		return h.errorResponse(c, fiber.StatusConflict, "TEMPLATE_NAME_TAKEN", "A template with this name already exists", err.Error())
+	case errors.Is(err, templates.ErrNotFound):
+		return h.errorResponse(c, fiber.StatusNotFound, "TEMPLATE_NOT_FOUND", "Message template not found", err.Error())
+	}
+	return h.errorResponse(c, fiber.StatusInternalServerError, code, message, err.Error())
+}
+
+// CreateTemplate godoc
+// @Summary Create a message template
+// @Description Stores a reusable message for the session. "content" has the same body as /message/send/{type} without "phone",
+// @Description and any string in it may contain {{variable}} placeholders. Supported types: text, media, buttons, list and poll.
+// @Tags Message Templates
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param request body dto.MessageTemplateRequest true "Message template"
+// @Success 201 {object} dto.MessageTemplateResponse "Template created"
+// @Failure 400 {object} dto.MessageTemplateResponse "Invalid request data"
+// @Failure 404 {object} dto.MessageTemplateResponse "Session not found"
+// @Failure 409 {object} dto.MessageTemplateResponse "Template name already in use"
+// @Failure 500 {object} dto.MessageTemplateResponse "Failed to create template"
+// @Router /session/{sessionId}/templates [post]
+func (h *TemplateHandler) CreateTemplate(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	var req dto.MessageTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.errorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err.Error())
+	}
+
+	if err := req.Validate(); err != nil {
+		return h.errorResponse(c, fiber.StatusBadRequest, "VALIDATION_ERROR", "Request validation failed", err.Error())
+	}
+
+	template, err := h.templates.Create(c.Context(), sessionID, templates.Input{Name: req.Name, Type: req.Type, Content: req.Content})
+	if err != nil {
+		return h.templateError(c, err, "CREATE_FAILED", "Failed to create message template")
+	}
+
+	info := toMessageTemplateInfo(template)
+	return c.Status(fiber.StatusCreated).JSON(dto.MessageTemplateResponse{
+		Success: true,
+		Code:    fiber.StatusCreated,
+		Data:    &info,
+	})
+}
+
+// ListTemplates godoc
+// @Summary List message templates
+// @Description Lists the message templates of a session ordered by name, optionally filtered by type
+// @Tags Message Templates
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param type query string false "Type filter" Enums(text, media, buttons, list, poll)
+// @Param limit query int false "Maximum number of templates to return" default(50)
+// @Param offset query int false "Number of templates to skip" default(0)
+// @Success 200 {object} dto.MessageTemplateListResponse "Message templates"
+// @Failure 400 {object} dto.MessageTemplateListResponse "Invalid query parameters"
+// @Failure 404 {object} dto.MessageTemplateListResponse "Session not found"
+// @Failure 500 {object} dto.MessageTemplateListResponse "Failed to list templates"
+// @Router /session/{sessionId}/templates [get]
+func (h *TemplateHandler) ListTemplates(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	messageType := c.Query("type")
+	limit, err := strconv.Atoi(c.Query("limit", "50"))
+	if err != nil || limit <= 0 || limit > 500 {
+		return h.errorResponse(c, fiber.StatusBadRequest, "INVALID_LIMIT", "limit must be a number between 1 and 500", "")
+	}
+
+	offset, err := strconv.Atoi(c.Query("offset", "0"))
+	if err != nil || offset < 0 {
+		return h.errorResponse(c, fiber.StatusBadRequest, "INVALID_OFFSET", "offset must be a non-negative number", "")
+	}
+
+	items, total, err := h.templates.List(c.Context(), sessionID, messageType, limit, offset)
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusInternalServerError, "LIST_FAILED", "Failed to list message templates", err.Error())
+	}
+
+	infos := make([]dto.MessageTemplateInfo, 0, len(items))
+	for _, template := range items {
+		infos = append(infos, toMessageTemplateInfo(template))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.MessageTemplateListResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data: &dto.MessageTemplateListData{
+			SessionId: sessionID,
+			Templates: infos,
+			Count:     len(infos),
+			Total:     total,
+			Limit:     limit,
+			Offset:    offset,
+		},
+	})
+}
+
+// GetTemplate godoc
+// @Summary Get a message template
+// @Description Retrieves a message template with its content and required variables
+// @Tags Message Templates
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param templateId path string true "Template ID"
+// @Success 200 {object} dto.MessageTemplateResponse "Message template"
+// @Failure 404 {object} dto.MessageTemplateResponse "Session or template not found"
+// @Failure 500 {object} dto.MessageTemplateResponse "Failed to get template"
+// @Router /session/{sessionId}/templates/{templateId} [get]
+func (h *TemplateHandler) GetTemplate(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	templateID := c.Params("templateId")
+	template, err := h.templates.Get(c.Context(), sessionID, templateID)
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusInternalServerError, "GET_FAILED", "Failed to get message template", err.Error())
+	}
+
+	if template == nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "TEMPLATE_NOT_FOUND", "Message template not found: "+templateID, "")
+	}
+
+	info := toMessageTemplateInfo(template)
+	return c.Status(fiber.StatusOK).JSON(dto.MessageTemplateResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data:    &info,
+	})
+}
+
+// UpdateTemplate godoc
+// @Summary Update a message template
+// @Description Replaces the name, type and content of a message template
+// @Tags Message Templates
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param templateId path string true "Template ID"
+// @Param request body dto.MessageTemplateRequest true "Message template"
+// @Success 200 {object} dto.MessageTemplateResponse "Template updated"
+// @Failure 400 {object} dto.MessageTemplateResponse "Invalid request data"
+// @Failure 404 {object} dto.MessageTemplateResponse "Session or template not found"
+// @Failure 409 {object} dto.MessageTemplateResponse "Template name already in use"
+// @Failure 500 {object} dto.MessageTemplateResponse "Failed to update template"
+// @Router /session/{sessionId}/templates/{templateId} [put]
+func (h *TemplateHandler) UpdateTemplate(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	var req dto.MessageTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.errorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err.Error())
+	}
+
+	if err := req.Validate(); err != nil {
+		return h.errorResponse(c, fiber.StatusBadRequest, "VALIDATION_ERROR", "Request validation failed", err.Error())
+	}
+
+	template, err := h.templates.Update(c.Context(), sessionID, c.Params("templateId"),
+		templates.Input{Name: req.Name, Type: req.Type, Content: req.Content})
+	if err != nil {
+		return h.templateError(c, err, "UPDATE_FAILED", "Failed to update message template")
+	}
+
+	info := toMessageTemplateInfo(template)
+	return c.Status(fiber.StatusOK).JSON(dto.MessageTemplateResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data:    &info,
+	})
+}
+
+// DeleteTemplate godoc
+// @Summary Delete a message template
+// @Description Deletes a message template. Messages already scheduled from it are not affected.
+// @Tags Message Templates
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param templateId path string true "Template ID"
+// @Success 200 {object} dto.MessageTemplateResponse "Template deleted"
+// @Failure 404 {object} dto.MessageTemplateResponse "Session or template not found"
+// @Failure 500 {object} dto.MessageTemplateResponse "Failed to delete template"
+// @Router /session/{sessionId}/templates/{templateId} [delete]
+func (h *TemplateHandler) DeleteTemplate(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	templateID := c.Params("templateId")
+	deleted, err := h.templates.Delete(c.Context(), sessionID, templateID)
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusInternalServerError, "DELETE_FAILED", "Failed to delete message template", err.Error())
+	}
+
+	if !deleted {
+		return h.errorResponse(c, fiber.StatusNotFound, "TEMPLATE_NOT_FOUND", "Message template not found: "+templateID, "")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.MessageTemplateResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+	})
+}
+
+// SendTemplate godoc
+// @Summary Send a message template
+// @Description Renders a stored template with the given variables and sends it as the template's message type.
+// @Description {{phone}} is filled with the recipient automatically; every other placeholder must be given in "variables".
+// @Tags Messages
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param request body dto.SendTemplateRequest true "Template send request"
+// @Success 200 {object} dto.SendTemplateResponse "Message sent successfully"
+// @Failure 400 {object} dto.SendTemplateResponse "Invalid request data or missing variables"
+// @Failure 404 {object} dto.SendTemplateResponse "Session, template or quoted message not found"
+// @Failure 409 {object} dto.SendTemplateResponse "Session is not connected"
+// @Failure 500 {object} dto.SendTemplateResponse "Failed to send message"
+// @Router /session/{sessionId}/message/send/template [post]
+func (h *TemplateHandler) SendTemplate(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return h.errorResponse(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "Session not found", err.Error())
+	}
+
+	var req dto.SendTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.errorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err.Error())
+	}
+
+	if err := req.Validate(); err != nil {
+		return h.errorResponse(c, fiber.StatusBadRequest, "VALIDATION_ERROR", "Request validation failed", err.Error())
+	}
+
+	messageType, messageID, err := h.templates.Send(c.Context(), sessionID, req.TemplateID, req.Phone, req.Variables, req.SendContextFields)
+	if err != nil {
+		var businessErr *common.BusinessRuleError
+		switch {
+		case errors.As(err, &businessErr):
+			return h.errorResponse(c, fiber.StatusConflict, "SESSION_NOT_READY", "Session cannot send messages", err.Error())
+		case errors.Is(err, common.ErrMessageNotFound):
+			return h.errorResponse(c, fiber.StatusNotFound, "QUOTED_MESSAGE_NOT_FOUND", "Quoted message not found", err.Error())
+		case errors.Is(err, common.ErrMediaNotFound):
+			return h.errorResponse(c, fiber.StatusNotFound, "MEDIA_NOT_FOUND", "Media not found", err.Error())
+		}
+		return h.templateError(c, err, "SEND_TEMPLATE_FAILED", "Failed to send message template")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SendTemplateResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data: &dto.SendTemplateData{
+			Phone:      req.Phone,
+			TemplateID: req.TemplateID,
+			Type:       messageType,
+			MessageID:  messageID,
+		},
+	})
+}
+
+func toMessageTemplateInfo(template *models.MessageTemplateModel) dto.MessageTemplateInfo {
+	variables := []string(template.Variables)
+	if variables == nil {
+		variables = []string{}
+	}
+	return dto.MessageTemplateInfo{
+		ID:        template.ID,
+		SessionId: template.SessionId,
+		Name:      template.Name,
+		Type:      template.MessageType,
+		Content:   template.Content,
+		Variables: variables,
+		CreatedAt: template.CreatedAt,
+		UpdatedAt: template.UpdatedAt,
+	}
+}
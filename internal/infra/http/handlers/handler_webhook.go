@@ -2,11 +2,15 @@ package handlers
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"zpmeow/internal/application"
+	"zpmeow/internal/infra/database/models"
+	"zpmeow/internal/infra/database/repository"
 	"zpmeow/internal/infra/http/dto"
+	"zpmeow/internal/infra/webhooks"
 	"zpmeow/internal/infra/wmeow"
 
 	"github.com/gofiber/fiber/v2"
@@ -17,14 +21,18 @@ type WebhookHandler struct {
 	sessionService *application.SessionApp
 	webhookApp     *application.WebhookApp
 	wmeowService   wmeow.WameowService
+	webhookRepo    *repository.WebhookRepository
+	deliveryQueue  *webhooks.DeliveryQueue
 }
 
-func NewWebhookHandler(sessionService *application.SessionApp, webhookApp *application.WebhookApp, wmeowService wmeow.WameowService) *WebhookHandler {
+func NewWebhookHandler(sessionService *application.SessionApp, webhookApp *application.WebhookApp, wmeowService wmeow.WameowService, webhookRepo *repository.WebhookRepository, deliveryQueue *webhooks.DeliveryQueue) *WebhookHandler {
 	return &WebhookHandler{
 		BaseHandler:    NewBaseHandler("webhook-handler"),
 		sessionService: sessionService,
 		webhookApp:     webhookApp,
 		wmeowService:   wmeowService,
+		webhookRepo:    webhookRepo,
+		deliveryQueue:  deliveryQueue,
 	}
 }
 
@@ -44,7 +52,8 @@ func (h *WebhookHandler) resolveSessionID(c *fiber.Ctx, sessionIDOrName string)
 
 // SetWebhook godoc
 // @Summary Set webhook URL
-// @Description Sets or updates the webhook URL and events for a session
+// @Description Sets or updates the webhook URL and events for a session. The response includes the HMAC secret used to sign deliveries:
+// @Description every request carries X-Webhook-Timestamp and X-Webhook-Signature ("sha256=" + hex HMAC-SHA256 of "<timestamp>.<body>").
 // @Tags Webhooks
 // @Accept json
 // @Produce json
@@ -138,11 +147,38 @@ func (h *WebhookHandler) SetWebhook(c *fiber.Ctx) error {
 		})
 	}
 
-	err = h.wmeowService.UpdateSessionSubscriptions(sessionID, validEvents)
-	if err != nil {
-		h.logger.Warnf("Failed to update session subscriptions for %s: %v", sessionID, err)
+	data := &dto.StandardWebhookData{
+		CreatedAt: time.Now(),
+		Events:    validEvents,
+		SessionId: sessionIDOrName,
+		Status:    "active",
+		URL:       req.URL,
+	}
+
+	if h.webhookRepo != nil {
+		webhook := &models.WebhookModel{
+			SessionId: sessionID,
+			URL:       req.URL,
+			Events:    validEvents,
+			IsActive:  true,
+		}
+		if err := h.webhookRepo.Upsert(c.Context(), webhook); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(dto.WebhookResponse{
+				Success: false,
+				Code:    fiber.StatusInternalServerError,
+				Data:    &dto.WebhookResponseData{},
+				Error: &dto.ErrorInfo{
+					Code:    "SAVE_FAILED",
+					Message: "Failed to save webhook: " + err.Error(),
+				},
+			})
+		}
+		data.CreatedAt = webhook.CreatedAt
+		data.Secret = webhook.Secret
 	}
 
+	h.refreshSubscriptions(c, sessionID, validEvents)
+
 	err = h.wmeowService.UpdateSessionWebhook(sessionID, req.URL)
 	if err != nil {
 		h.logger.Warnf("Failed to update session webhook URL for %s: %v", sessionID, err)
@@ -151,19 +187,13 @@ func (h *WebhookHandler) SetWebhook(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusCreated).JSON(dto.StandardWebhookCreateResponse{
 		Success: true,
 		Code:    fiber.StatusCreated,
-		Data: &dto.StandardWebhookData{
-			CreatedAt: time.Now(),
-			Events:    validEvents,
-			SessionId: sessionIDOrName,
-			Status:    "active",
-			URL:       req.URL,
-		},
+		Data:    data,
 	})
 }
 
 // GetWebhook godoc
 // @Summary Get webhook configuration
-// @Description Retrieves the current webhook URL and events for a session
+// @Description Retrieves the current webhook URL, events and signing secret for a session
 // @Tags Webhooks
 // @Accept json
 // @Produce json
@@ -189,6 +219,10 @@ func (h *WebhookHandler) GetWebhook(c *fiber.Ctx) error {
 		})
 	}
 
+	if h.webhookRepo != nil {
+		return h.getStoredWebhook(c, sessionID, sessionIDOrName)
+	}
+
 	webhookURL, events, err := h.webhookApp.GetWebhook(c.Context(), sessionID)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(dto.WebhookResponse{
@@ -227,6 +261,182 @@ func (h *WebhookHandler) GetWebhook(c *fiber.Ctx) error {
 	})
 }
 
+func (h *WebhookHandler) getStoredWebhook(c *fiber.Ctx, sessionID, sessionIDOrName string) error {
+	webhook, err := h.webhookRepo.GetBySessionID(c.Context(), sessionID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.WebhookResponse{
+			Success: false,
+			Code:    fiber.StatusInternalServerError,
+			Data:    &dto.WebhookResponseData{},
+			Error: &dto.ErrorInfo{
+				Code:    "GET_FAILED",
+				Message: "Failed to get webhook: " + err.Error(),
+			},
+		})
+	}
+
+	if webhook == nil || webhook.URL == "" {
+		return c.Status(fiber.StatusNotFound).JSON(dto.WebhookResponse{
+			Success: false,
+			Code:    fiber.StatusNotFound,
+			Data:    &dto.WebhookResponseData{},
+			Error: &dto.ErrorInfo{
+				Code:    "NOT_FOUND",
+				Message: "No webhook configured for this session",
+			},
+		})
+	}
+
+	status := "active"
+	if !webhook.IsActive {
+		status = "inactive"
+	}
+
+	data := &dto.StandardWebhookData{
+		CreatedAt:       webhook.CreatedAt,
+		Events:          webhook.Events,
+		SessionId:       sessionIDOrName,
+		Status:          status,
+		URL:             webhook.URL,
+		Secret:          webhook.Secret,
+		SecretRotatedAt: webhook.SecretRotatedAt,
+	}
+	if webhook.PreviousSecretExpiresAt != nil && time.Now().Before(*webhook.PreviousSecretExpiresAt) {
+		data.PreviousSecretExpiresAt = webhook.PreviousSecretExpiresAt
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.StandardWebhookResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data:    data,
+	})
+}
+
+// RotateWebhookSecret godoc
+// @Summary Rotate webhook signing secret
+// @Description Generates a new HMAC secret for the session webhook. During the grace window deliveries are signed with both secrets
+// @Description (comma-separated entries in X-Webhook-Signature), so receivers can switch without dropping events.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param request body dto.RotateWebhookSecretRequest false "Rotation options"
+// @Success 200 {object} dto.WebhookSecretResponse "Secret rotated"
+// @Failure 400 {object} dto.WebhookSecretResponse "Invalid request data"
+// @Failure 404 {object} dto.WebhookSecretResponse "Session or webhook not found"
+// @Failure 500 {object} dto.WebhookSecretResponse "Failed to rotate secret"
+// @Router /session/{sessionId}/webhook/secret/rotate [post]
+func (h *WebhookHandler) RotateWebhookSecret(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(dto.WebhookSecretResponse{
+			Success: false,
+			Code:    fiber.StatusNotFound,
+			Error: &dto.ErrorInfo{
+				Code:    "SESSION_NOT_FOUND",
+				Message: "Session not found: " + err.Error(),
+			},
+		})
+	}
+
+	webhook, err := h.webhookRepo.GetBySessionID(c.Context(), sessionID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.WebhookSecretResponse{
+			Success: false,
+			Code:    fiber.StatusInternalServerError,
+			Error: &dto.ErrorInfo{
+				Code:    "GET_FAILED",
+				Message: "Failed to get webhook",
+				Details: err.Error(),
+			},
+		})
+	}
+
+	if webhook == nil {
+		return c.Status(fiber.StatusNotFound).JSON(dto.WebhookSecretResponse{
+			Success: false,
+			Code:    fiber.StatusNotFound,
+			Error: &dto.ErrorInfo{
+				Code:    "NOT_FOUND",
+				Message: "No webhook configured for this session",
+			},
+		})
+	}
+
+	return h.rotateSecret(c, sessionID, webhook.ID)
+}
+
+// rotateSecret rotaciona o segredo do endpoint usando a janela de transição do corpo da requisição
+func (h *WebhookHandler) rotateSecret(c *fiber.Ctx, sessionID, webhookID string) error {
+	var req dto.RotateWebhookSecretRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.WebhookSecretResponse{
+				Success: false,
+				Code:    fiber.StatusBadRequest,
+				Error: &dto.ErrorInfo{
+					Code:    "INVALID_REQUEST",
+					Message: "Invalid request body: " + err.Error(),
+				},
+			})
+		}
+	}
+
+	if err := req.Validate(); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.WebhookSecretResponse{
+			Success: false,
+			Code:    fiber.StatusBadRequest,
+			Error: &dto.ErrorInfo{
+				Code:    "VALIDATION_FAILED",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	gracePeriod := webhooks.DefaultSecretGracePeriod
+	if req.GracePeriodSeconds != nil {
+		gracePeriod = time.Duration(*req.GracePeriodSeconds) * time.Second
+	}
+
+	webhook, err := h.webhookRepo.RotateSecret(c.Context(), sessionID, webhookID, gracePeriod)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.WebhookSecretResponse{
+			Success: false,
+			Code:    fiber.StatusInternalServerError,
+			Error: &dto.ErrorInfo{
+				Code:    "ROTATE_FAILED",
+				Message: "Failed to rotate webhook secret",
+				Details: err.Error(),
+			},
+		})
+	}
+
+	if webhook == nil {
+		return c.Status(fiber.StatusNotFound).JSON(dto.WebhookSecretResponse{
+			Success: false,
+			Code:    fiber.StatusNotFound,
+			Error: &dto.ErrorInfo{
+				Code:    "NOT_FOUND",
+				Message: "Webhook endpoint not found: " + webhookID,
+			},
+		})
+	}
+
+	h.logger.Infof("Rotated secret of webhook %s for session %s (grace period: %v)", webhookID, sessionID, gracePeriod)
+
+	return c.Status(fiber.StatusOK).JSON(dto.WebhookSecretResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data: &dto.WebhookSecretData{
+			SessionId:               sessionID,
+			Secret:                  webhook.Secret,
+			PreviousSecretExpiresAt: webhook.PreviousSecretExpiresAt,
+			RotatedAt:               webhook.SecretRotatedAt,
+		},
+	})
+}
+
 // ListEvents godoc
 // @Summary List supported webhook events
 // @Description Retrieves a list of all supported webhook events
@@ -259,11 +469,761 @@ func (h *WebhookHandler) ListEvents(c *fiber.Ctx) error {
 	})
 }
 
-func (h *WebhookHandler) isValidEvent(event string, validEvents []string) bool {
-	for _, validEvent := range validEvents {
-		if event == validEvent {
-			return true
-		}
+// ListFailedDeliveries godoc
+// @Summary List failed webhook deliveries
+// @Description Lists webhook deliveries that exhausted all retries and were moved to the dead-letter store
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param limit query int false "Maximum number of deliveries to return" default(50)
+// @Param offset query int false "Number of deliveries to skip" default(0)
+// @Success 200 {object} dto.WebhookDeadLetterListResponse "Failed deliveries"
+// @Failure 400 {object} dto.WebhookDeadLetterListResponse "Invalid pagination parameters"
+// @Failure 404 {object} dto.WebhookDeadLetterListResponse "Session not found"
+// @Failure 500 {object} dto.WebhookDeadLetterListResponse "Failed to list deliveries"
+// @Failure 503 {object} dto.WebhookDeadLetterListResponse "Webhook delivery queue not available"
+// @Router /session/{sessionId}/webhook/deliveries/failed [get]
+func (h *WebhookHandler) ListFailedDeliveries(c *fiber.Ctx) error {
+	if h.deliveryQueue == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(dto.WebhookDeadLetterListResponse{
+			Success: false,
+			Code:    fiber.StatusServiceUnavailable,
+			Error: &dto.ErrorInfo{
+				Code:    "QUEUE_UNAVAILABLE",
+				Message: "Webhook delivery queue is not enabled",
+			},
+		})
 	}
-	return false
+
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(dto.WebhookDeadLetterListResponse{
+			Success: false,
+			Code:    fiber.StatusNotFound,
+			Error: &dto.ErrorInfo{
+				Code:    "SESSION_NOT_FOUND",
+				Message: "Session not found: " + err.Error(),
+			},
+		})
+	}
+
+	limit, err := strconv.Atoi(c.Query("limit", "50"))
+	if err != nil || limit <= 0 || limit > 500 {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.WebhookDeadLetterListResponse{
+			Success: false,
+			Code:    fiber.StatusBadRequest,
+			Error: &dto.ErrorInfo{
+				Code:    "INVALID_LIMIT",
+				Message: "limit must be a number between 1 and 500",
+			},
+		})
+	}
+
+	offset, err := strconv.Atoi(c.Query("offset", "0"))
+	if err != nil || offset < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.WebhookDeadLetterListResponse{
+			Success: false,
+			Code:    fiber.StatusBadRequest,
+			Error: &dto.ErrorInfo{
+				Code:    "INVALID_OFFSET",
+				Message: "offset must be a non-negative number",
+			},
+		})
+	}
+
+	deadLetters, total, err := h.deliveryQueue.ListDeadLetters(c.Context(), sessionID, limit, offset)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.WebhookDeadLetterListResponse{
+			Success: false,
+			Code:    fiber.StatusInternalServerError,
+			Error: &dto.ErrorInfo{
+				Code:    "LIST_FAILED",
+				Message: "Failed to list failed webhook deliveries",
+				Details: err.Error(),
+			},
+		})
+	}
+
+	pending, err := h.deliveryQueue.PendingCount(c.Context(), sessionID)
+	if err != nil {
+		h.logger.Warnf("Failed to count pending webhook deliveries for %s: %v", sessionID, err)
+	}
+
+	items := make([]dto.WebhookDeadLetterInfo, 0, len(deadLetters))
+	for _, deadLetter := range deadLetters {
+		items = append(items, toWebhookDeadLetterInfo(deadLetter, false))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.WebhookDeadLetterListResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data: &dto.WebhookDeadLetterListData{
+			SessionId:    sessionID,
+			DeadLetters:  items,
+			Count:        len(items),
+			Total:        total,
+			PendingCount: pending,
+			Limit:        limit,
+			Offset:       offset,
+		},
+	})
+}
+
+// GetFailedDelivery godoc
+// @Summary Get a failed webhook delivery
+// @Description Retrieves a dead-lettered webhook delivery including its original payload
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param deliveryId path string true "Dead letter ID"
+// @Success 200 {object} dto.WebhookDeadLetterResponse "Failed delivery"
+// @Failure 404 {object} dto.WebhookDeadLetterResponse "Session or delivery not found"
+// @Failure 500 {object} dto.WebhookDeadLetterResponse "Failed to get delivery"
+// @Failure 503 {object} dto.WebhookDeadLetterResponse "Webhook delivery queue not available"
+// @Router /session/{sessionId}/webhook/deliveries/failed/{deliveryId} [get]
+func (h *WebhookHandler) GetFailedDelivery(c *fiber.Ctx) error {
+	if h.deliveryQueue == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(dto.WebhookDeadLetterResponse{
+			Success: false,
+			Code:    fiber.StatusServiceUnavailable,
+			Error: &dto.ErrorInfo{
+				Code:    "QUEUE_UNAVAILABLE",
+				Message: "Webhook delivery queue is not enabled",
+			},
+		})
+	}
+
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(dto.WebhookDeadLetterResponse{
+			Success: false,
+			Code:    fiber.StatusNotFound,
+			Error: &dto.ErrorInfo{
+				Code:    "SESSION_NOT_FOUND",
+				Message: "Session not found: " + err.Error(),
+			},
+		})
+	}
+
+	deliveryID := c.Params("deliveryId")
+	deadLetter, err := h.deliveryQueue.GetDeadLetter(c.Context(), sessionID, deliveryID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.WebhookDeadLetterResponse{
+			Success: false,
+			Code:    fiber.StatusInternalServerError,
+			Error: &dto.ErrorInfo{
+				Code:    "GET_FAILED",
+				Message: "Failed to get failed webhook delivery",
+				Details: err.Error(),
+			},
+		})
+	}
+
+	if deadLetter == nil {
+		return c.Status(fiber.StatusNotFound).JSON(dto.WebhookDeadLetterResponse{
+			Success: false,
+			Code:    fiber.StatusNotFound,
+			Error: &dto.ErrorInfo{
+				Code:    "DELIVERY_NOT_FOUND",
+				Message: "Failed webhook delivery not found: " + deliveryID,
+			},
+		})
+	}
+
+	info := toWebhookDeadLetterInfo(deadLetter, true)
+	return c.Status(fiber.StatusOK).JSON(dto.WebhookDeadLetterResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data:    &info,
+	})
+}
+
+// ReplayFailedDelivery godoc
+// @Summary Replay a failed webhook delivery
+// @Description Re-enqueues a dead-lettered webhook delivery with a fresh retry budget
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param deliveryId path string true "Dead letter ID"
+// @Success 202 {object} dto.WebhookReplayResponse "Delivery re-enqueued"
+// @Failure 404 {object} dto.WebhookReplayResponse "Session or delivery not found"
+// @Failure 500 {object} dto.WebhookReplayResponse "Failed to replay delivery"
+// @Failure 503 {object} dto.WebhookReplayResponse "Webhook delivery queue not available"
+// @Router /session/{sessionId}/webhook/deliveries/failed/{deliveryId}/replay [post]
+func (h *WebhookHandler) ReplayFailedDelivery(c *fiber.Ctx) error {
+	if h.deliveryQueue == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(dto.WebhookReplayResponse{
+			Success: false,
+			Code:    fiber.StatusServiceUnavailable,
+			Error: &dto.ErrorInfo{
+				Code:    "QUEUE_UNAVAILABLE",
+				Message: "Webhook delivery queue is not enabled",
+			},
+		})
+	}
+
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(dto.WebhookReplayResponse{
+			Success: false,
+			Code:    fiber.StatusNotFound,
+			Error: &dto.ErrorInfo{
+				Code:    "SESSION_NOT_FOUND",
+				Message: "Session not found: " + err.Error(),
+			},
+		})
+	}
+
+	deliveryID := c.Params("deliveryId")
+	delivery, err := h.deliveryQueue.ReplayDeadLetter(c.Context(), sessionID, deliveryID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.WebhookReplayResponse{
+			Success: false,
+			Code:    fiber.StatusInternalServerError,
+			Error: &dto.ErrorInfo{
+				Code:    "REPLAY_FAILED",
+				Message: "Failed to replay webhook delivery",
+				Details: err.Error(),
+			},
+		})
+	}
+
+	if delivery == nil {
+		return c.Status(fiber.StatusNotFound).JSON(dto.WebhookReplayResponse{
+			Success: false,
+			Code:    fiber.StatusNotFound,
+			Error: &dto.ErrorInfo{
+				Code:    "DELIVERY_NOT_FOUND",
+				Message: "Failed webhook delivery not found: " + deliveryID,
+			},
+		})
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(dto.WebhookReplayResponse{
+		Success: true,
+		Code:    fiber.StatusAccepted,
+		Data: &dto.WebhookReplayData{
+			DeadLetterID:  deliveryID,
+			DeliveryID:    delivery.ID,
+			Event:         delivery.Event,
+			URL:           delivery.URL,
+			Status:        delivery.Status,
+			NextAttemptAt: delivery.NextAttemptAt,
+		},
+	})
+}
+
+func toWebhookDeadLetterInfo(deadLetter *models.WebhookDeadLetterModel, withPayload bool) dto.WebhookDeadLetterInfo {
+	info := dto.WebhookDeadLetterInfo{
+		ID:          deadLetter.ID,
+		DeliveryID:  deadLetter.DeliveryId,
+		SessionId:   deadLetter.SessionId,
+		URL:         deadLetter.URL,
+		Event:       deadLetter.Event,
+		Attempts:    deadLetter.Attempts,
+		EnqueuedAt:  deadLetter.EnqueuedAt,
+		FailedAt:    deadLetter.FailedAt,
+		ReplayedAt:  deadLetter.ReplayedAt,
+		ReplayCount: deadLetter.ReplayCount,
+	}
+	if deadLetter.WebhookId != nil {
+		info.WebhookID = *deadLetter.WebhookId
+	}
+	if deadLetter.LastError != nil {
+		info.LastError = *deadLetter.LastError
+	}
+	if deadLetter.LastStatusCode != nil {
+		info.LastStatusCode = *deadLetter.LastStatusCode
+	}
+	if withPayload {
+		info.Payload = deadLetter.Payload
+	}
+	return info
+}
+
+func (h *WebhookHandler) isValidEvent(event string, validEvents []string) bool {
+	for _, validEvent := range validEvents {
+		if event == validEvent {
+			return true
+		}
+	}
+	return false
+}
+
+// refreshSubscriptions recalcula a união dos eventos dos endpoints e atualiza o cliente em execução
+func (h *WebhookHandler) refreshSubscriptions(c *fiber.Ctx, sessionID string, fallback []string) {
+	events := fallback
+	if h.webhookRepo != nil {
+		subscribed, err := h.webhookRepo.SubscribedEvents(c.Context(), sessionID)
+		if err != nil {
+			h.logger.Warnf("Failed to load subscribed events for %s: %v", sessionID, err)
+		} else {
+			events = subscribed
+		}
+	}
+
+	if err := h.wmeowService.UpdateSessionSubscriptions(sessionID, events); err != nil {
+		h.logger.Warnf("Failed to update session subscriptions for %s: %v", sessionID, err)
+	}
+}
+
+// validateEvents retorna o primeiro evento desconhecido, se houver
+func (h *WebhookHandler) validateEvents(c *fiber.Ctx, events []string) (string, error) {
+	allValidEvents, err := h.webhookApp.ListEvents(c.Context())
+	if err != nil {
+		return "", err
+	}
+
+	for _, event := range events {
+		if !h.isValidEvent(event, allValidEvents) {
+			return event, nil
+		}
+	}
+
+	return "", nil
+}
+
+// ListWebhookEndpoints godoc
+// @Summary List webhook endpoints
+// @Description Lists all webhook endpoints of a session with their event filters and delivery stats
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Success 200 {object} dto.WebhookEndpointListResponse "Webhook endpoints"
+// @Failure 404 {object} dto.WebhookEndpointListResponse "Session not found"
+// @Failure 500 {object} dto.WebhookEndpointListResponse "Failed to list endpoints"
+// @Router /session/{sessionId}/webhook/endpoints [get]
+func (h *WebhookHandler) ListWebhookEndpoints(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(dto.WebhookEndpointListResponse{
+			Success: false,
+			Code:    fiber.StatusNotFound,
+			Error: &dto.ErrorInfo{
+				Code:    "SESSION_NOT_FOUND",
+				Message: "Session not found: " + err.Error(),
+			},
+		})
+	}
+
+	webhooksList, err := h.webhookRepo.ListBySessionID(c.Context(), sessionID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.WebhookEndpointListResponse{
+			Success: false,
+			Code:    fiber.StatusInternalServerError,
+			Error: &dto.ErrorInfo{
+				Code:    "LIST_FAILED",
+				Message: "Failed to list webhook endpoints",
+				Details: err.Error(),
+			},
+		})
+	}
+
+	endpoints := make([]dto.WebhookEndpointInfo, 0, len(webhooksList))
+	for _, webhook := range webhooksList {
+		endpoints = append(endpoints, toWebhookEndpointInfo(webhook, false))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.WebhookEndpointListResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data: &dto.WebhookEndpointListData{
+			SessionId: sessionID,
+			Endpoints: endpoints,
+			Count:     len(endpoints),
+		},
+	})
+}
+
+// CreateWebhookEndpoint godoc
+// @Summary Create webhook endpoint
+// @Description Adds a webhook endpoint to the session. Each endpoint has its own URL, event filter, custom headers and signing secret
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param request body dto.CreateWebhookEndpointRequest true "Endpoint configuration"
+// @Success 201 {object} dto.WebhookEndpointResponse "Endpoint created"
+// @Failure 400 {object} dto.WebhookEndpointResponse "Invalid request data"
+// @Failure 404 {object} dto.WebhookEndpointResponse "Session not found"
+// @Failure 500 {object} dto.WebhookEndpointResponse "Failed to create endpoint"
+// @Router /session/{sessionId}/webhook/endpoints [post]
+func (h *WebhookHandler) CreateWebhookEndpoint(c *fiber.Ctx) error {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(dto.WebhookEndpointResponse{
+			Success: false,
+			Code:    fiber.StatusNotFound,
+			Error: &dto.ErrorInfo{
+				Code:    "SESSION_NOT_FOUND",
+				Message: "Session not found: " + err.Error(),
+			},
+		})
+	}
+
+	var req dto.CreateWebhookEndpointRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.WebhookEndpointResponse{
+			Success: false,
+			Code:    fiber.StatusBadRequest,
+			Error: &dto.ErrorInfo{
+				Code:    "INVALID_REQUEST",
+				Message: "Invalid request body: " + err.Error(),
+			},
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.WebhookEndpointResponse{
+			Success: false,
+			Code:    fiber.StatusBadRequest,
+			Error: &dto.ErrorInfo{
+				Code:    "VALIDATION_FAILED",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	if invalid, err := h.validateEvents(c, req.Events); err != nil || invalid != "" {
+		return h.invalidEventResponse(c, invalid, err)
+	}
+
+	webhook := &models.WebhookModel{
+		SessionId:     sessionID,
+		Name:          req.Name,
+		URL:           req.URL,
+		Events:        req.Events,
+		Headers:       toHeadersJSONB(req.Headers),
+		IsActive:      req.IsActive == nil || *req.IsActive,
+		PayloadFormat: req.PayloadFormat,
+	}
+	if webhook.Events == nil {
+		webhook.Events = []string{}
+	}
+
+	if err := h.webhookRepo.Create(c.Context(), webhook); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.WebhookEndpointResponse{
+			Success: false,
+			Code:    fiber.StatusInternalServerError,
+			Error: &dto.ErrorInfo{
+				Code:    "CREATE_FAILED",
+				Message: "Failed to create webhook endpoint",
+				Details: err.Error(),
+			},
+		})
+	}
+
+	h.refreshSubscriptions(c, sessionID, nil)
+
+	info := toWebhookEndpointInfo(webhook, true)
+	return c.Status(fiber.StatusCreated).JSON(dto.WebhookEndpointResponse{
+		Success: true,
+		Code:    fiber.StatusCreated,
+		Data:    &info,
+	})
+}
+
+// GetWebhookEndpoint godoc
+// @Summary Get webhook endpoint
+// @Description Retrieves a webhook endpoint including its signing secret and delivery stats
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param webhookId path string true "Webhook endpoint ID"
+// @Success 200 {object} dto.WebhookEndpointResponse "Webhook endpoint"
+// @Failure 404 {object} dto.WebhookEndpointResponse "Session or endpoint not found"
+// @Failure 500 {object} dto.WebhookEndpointResponse "Failed to get endpoint"
+// @Router /session/{sessionId}/webhook/endpoints/{webhookId} [get]
+func (h *WebhookHandler) GetWebhookEndpoint(c *fiber.Ctx) error {
+	webhook, _, done := h.loadEndpoint(c)
+	if done != nil {
+		return done()
+	}
+
+	info := toWebhookEndpointInfo(webhook, true)
+	return c.Status(fiber.StatusOK).JSON(dto.WebhookEndpointResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data:    &info,
+	})
+}
+
+// UpdateWebhookEndpoint godoc
+// @Summary Update webhook endpoint
+// @Description Updates the name, URL, event filter, headers, active flag or payload format of a webhook endpoint. Omitted fields are kept
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param webhookId path string true "Webhook endpoint ID"
+// @Param request body dto.UpdateWebhookEndpointRequest true "Fields to update"
+// @Success 200 {object} dto.WebhookEndpointResponse "Endpoint updated"
+// @Failure 400 {object} dto.WebhookEndpointResponse "Invalid request data"
+// @Failure 404 {object} dto.WebhookEndpointResponse "Session or endpoint not found"
+// @Failure 500 {object} dto.WebhookEndpointResponse "Failed to update endpoint"
+// @Router /session/{sessionId}/webhook/endpoints/{webhookId} [put]
+func (h *WebhookHandler) UpdateWebhookEndpoint(c *fiber.Ctx) error {
+	webhook, sessionID, done := h.loadEndpoint(c)
+	if done != nil {
+		return done()
+	}
+
+	var req dto.UpdateWebhookEndpointRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.WebhookEndpointResponse{
+			Success: false,
+			Code:    fiber.StatusBadRequest,
+			Error: &dto.ErrorInfo{
+				Code:    "INVALID_REQUEST",
+				Message: "Invalid request body: " + err.Error(),
+			},
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.WebhookEndpointResponse{
+			Success: false,
+			Code:    fiber.StatusBadRequest,
+			Error: &dto.ErrorInfo{
+				Code:    "VALIDATION_FAILED",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	if invalid, err := h.validateEvents(c, req.Events); err != nil || invalid != "" {
+		return h.invalidEventResponse(c, invalid, err)
+	}
+
+	if req.Name != nil {
+		webhook.Name = *req.Name
+	}
+	if req.URL != nil {
+		webhook.URL = *req.URL
+	}
+	if req.Events != nil {
+		webhook.Events = req.Events
+	}
+	if req.Headers != nil {
+		webhook.Headers = toHeadersJSONB(req.Headers)
+	}
+	if req.IsActive != nil {
+		webhook.IsActive = *req.IsActive
+	}
+	if req.PayloadFormat != nil {
+		webhook.PayloadFormat = *req.PayloadFormat
+	}
+
+	if err := h.webhookRepo.Update(c.Context(), webhook); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.WebhookEndpointResponse{
+			Success: false,
+			Code:    fiber.StatusInternalServerError,
+			Error: &dto.ErrorInfo{
+				Code:    "UPDATE_FAILED",
+				Message: "Failed to update webhook endpoint",
+				Details: err.Error(),
+			},
+		})
+	}
+
+	h.refreshSubscriptions(c, sessionID, nil)
+
+	info := toWebhookEndpointInfo(webhook, true)
+	return c.Status(fiber.StatusOK).JSON(dto.WebhookEndpointResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data:    &info,
+	})
+}
+
+// DeleteWebhookEndpoint godoc
+// @Summary Delete webhook endpoint
+// @Description Removes a webhook endpoint. Its queued deliveries are discarded; existing dead letters are kept
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param webhookId path string true "Webhook endpoint ID"
+// @Success 200 {object} dto.WebhookEndpointResponse "Endpoint deleted"
+// @Failure 404 {object} dto.WebhookEndpointResponse "Session or endpoint not found"
+// @Failure 500 {object} dto.WebhookEndpointResponse "Failed to delete endpoint"
+// @Router /session/{sessionId}/webhook/endpoints/{webhookId} [delete]
+func (h *WebhookHandler) DeleteWebhookEndpoint(c *fiber.Ctx) error {
+	webhook, sessionID, done := h.loadEndpoint(c)
+	if done != nil {
+		return done()
+	}
+
+	if err := h.webhookRepo.Delete(c.Context(), sessionID, webhook.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.WebhookEndpointResponse{
+			Success: false,
+			Code:    fiber.StatusInternalServerError,
+			Error: &dto.ErrorInfo{
+				Code:    "DELETE_FAILED",
+				Message: "Failed to delete webhook endpoint",
+				Details: err.Error(),
+			},
+		})
+	}
+
+	h.refreshSubscriptions(c, sessionID, nil)
+
+	info := toWebhookEndpointInfo(webhook, false)
+	return c.Status(fiber.StatusOK).JSON(dto.WebhookEndpointResponse{
+		Success: true,
+		Code:    fiber.StatusOK,
+		Data:    &info,
+	})
+}
+
+// RotateWebhookEndpointSecret godoc
+// @Summary Rotate webhook endpoint signing secret
+// @Description Generates a new HMAC secret for the endpoint, keeping the previous one signing deliveries during the grace window
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Session ID"
+// @Param webhookId path string true "Webhook endpoint ID"
+// @Param request body dto.RotateWebhookSecretRequest false "Rotation options"
+// @Success 200 {object} dto.WebhookSecretResponse "Secret rotated"
+// @Failure 400 {object} dto.WebhookSecretResponse "Invalid request data"
+// @Failure 404 {object} dto.WebhookSecretResponse "Session or endpoint not found"
+// @Failure 500 {object} dto.WebhookSecretResponse "Failed to rotate secret"
+// @Router /session/{sessionId}/webhook/endpoints/{webhookId}/secret/rotate [post]
+func (h *WebhookHandler) RotateWebhookEndpointSecret(c *fiber.Ctx) error {
+	webhook, sessionID, done := h.loadEndpoint(c)
+	if done != nil {
+		return done()
+	}
+
+	return h.rotateSecret(c, sessionID, webhook.ID)
+}
+
+// loadEndpoint resolve a sessão e o endpoint da URL; em caso de erro retorna a resposta a ser enviada
+func (h *WebhookHandler) loadEndpoint(c *fiber.Ctx) (*models.WebhookModel, string, func() error) {
+	sessionID, err := h.resolveSessionID(c, c.Params("sessionId"))
+	if err != nil {
+		return nil, "", func() error {
+			return c.Status(fiber.StatusNotFound).JSON(dto.WebhookEndpointResponse{
+				Success: false,
+				Code:    fiber.StatusNotFound,
+				Error: &dto.ErrorInfo{
+					Code:    "SESSION_NOT_FOUND",
+					Message: "Session not found: " + err.Error(),
+				},
+			})
+		}
+	}
+
+	webhookID := c.Params("webhookId")
+	webhook, err := h.webhookRepo.GetByID(c.Context(), webhookID)
+	if err != nil {
+		return nil, "", func() error {
+			return c.Status(fiber.StatusInternalServerError).JSON(dto.WebhookEndpointResponse{
+				Success: false,
+				Code:    fiber.StatusInternalServerError,
+				Error: &dto.ErrorInfo{
+					Code:    "GET_FAILED",
+					Message: "Failed to get webhook endpoint",
+					Details: err.Error(),
+				},
+			})
+		}
+	}
+
+	if webhook == nil || webhook.SessionId != sessionID {
+		return nil, "", func() error {
+			return c.Status(fiber.StatusNotFound).JSON(dto.WebhookEndpointResponse{
+				Success: false,
+				Code:    fiber.StatusNotFound,
+				Error: &dto.ErrorInfo{
+					Code:    "NOT_FOUND",
+					Message: "Webhook endpoint not found: " + webhookID,
+				},
+			})
+		}
+	}
+
+	return webhook, sessionID, nil
+}
+
+func (h *WebhookHandler) invalidEventResponse(c *fiber.Ctx, event string, err error) error {
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.WebhookEndpointResponse{
+			Success: false,
+			Code:    fiber.StatusInternalServerError,
+			Error: &dto.ErrorInfo{
+				Code:    "VALIDATION_ERROR",
+				Message: "Failed to get valid events",
+				Details: err.Error(),
+			},
+		})
+	}
+
+	return c.Status(fiber.StatusBadRequest).JSON(dto.WebhookEndpointResponse{
+		Success: false,
+		Code:    fiber.StatusBadRequest,
+		Error: &dto.ErrorInfo{
+			Code:    "INVALID_EVENT",
+			Message: fmt.Sprintf("Invalid event type: %s", event),
+		},
+	})
+}
+
+func toHeadersJSONB(headers map[string]string) models.JSONB {
+	result := models.JSONB{}
+	for key, value := range headers {
+		result[key] = value
+	}
+	return result
+}
+
+func toWebhookEndpointInfo(webhook *models.WebhookModel, withSecret bool) dto.WebhookEndpointInfo {
+	info := dto.WebhookEndpointInfo{
+		ID:            webhook.ID,
+		SessionId:     webhook.SessionId,
+		Name:          webhook.Name,
+		URL:           webhook.URL,
+		Events:        webhook.Events,
+		Headers:       webhooks.EndpointHeaders(webhook),
+		IsActive:      webhook.IsActive,
+		IsDefault:     webhook.IsDefault,
+		PayloadFormat: webhook.PayloadFormat,
+		Stats: dto.WebhookEndpointStats{
+			DeliveredCount: webhook.DeliveredCount,
+			FailedCount:    webhook.FailedCount,
+			LastDeliveryAt: webhook.LastDeliveryAt,
+			LastSuccessAt:  webhook.LastSuccessAt,
+			LastFailureAt:  webhook.LastFailureAt,
+		},
+		CreatedAt: webhook.CreatedAt,
+		UpdatedAt: webhook.UpdatedAt,
+	}
+	if info.Events == nil {
+		info.Events = []string{}
+	}
+	if withSecret {
+		info.Secret = webhook.Secret
+	}
+	if webhook.LastError != nil {
+		info.Stats.LastError = *webhook.LastError
+	}
+	if webhook.LastStatusCode != nil {
+		info.Stats.LastStatusCode = *webhook.LastStatusCode
+	}
+	return info
 }
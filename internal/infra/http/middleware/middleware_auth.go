@@ -2,10 +2,11 @@ package middleware
 
 import (
 	"context"
-	"fmt"
+	"time"
 
 	"zpmeow/internal/config"
 	"zpmeow/internal/domain/session"
+	"zpmeow/internal/infra/database/repository"
 	"zpmeow/internal/infra/logging"
 
 	"github.com/gofiber/fiber/v2"
@@ -14,13 +15,15 @@ import (
 type AuthMiddleware struct {
 	config      *config.Config
 	sessionRepo session.Repository
+	apiKeyRepo  *repository.APIKeyRepository
 	logger      logging.Logger
 }
 
-func NewAuthMiddleware(config *config.Config, sessionRepo session.Repository, logger logging.Logger) *AuthMiddleware {
+func NewAuthMiddleware(config *config.Config, sessionRepo session.Repository, apiKeyRepo *repository.APIKeyRepository, logger logging.Logger) *AuthMiddleware {
 	return &AuthMiddleware{
 		config:      config,
 		sessionRepo: sessionRepo,
+		apiKeyRepo:  apiKeyRepo,
 		logger:      logger,
 	}
 }
@@ -45,6 +48,9 @@ func (a *AuthMiddleware) AuthenticateGlobal() fiber.Handler {
 	}
 }
 
+// AuthenticateSession aceita a chave global e as chaves da sessão, todas guardadas em zpApiKeys (inclusive a
+// chave default criada com a sessão, com escopo *). Chaves de sessão só acessam a própria sessão; os escopos
+// são conferidos por RequireScope.
 func (a *AuthMiddleware) AuthenticateSession() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		apiKey := a.extractAPIKey(c)
@@ -60,22 +66,17 @@ func (a *AuthMiddleware) AuthenticateSession() fiber.Handler {
 			return c.Next()
 		}
 
-		session, err := a.sessionRepo.GetByApiKey(context.Background(), apiKey)
-		if err != nil {
-			if err == fmt.Errorf("session not found") {
-				a.logger.Warn("Invalid session API key provided")
-				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid API key"})
-			} else {
-				a.logger.Error("Error validating session API key: " + err.Error())
-				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Authentication error"})
-			}
+		if ok, err := a.authenticateSessionKey(c, apiKey); !ok {
+			return err
+		}
+
+		session, _ := GetAuthenticatedSession(c)
+		if sessionIDOrName := c.Params("sessionId"); sessionIDOrName != "" &&
+			sessionIDOrName != session.SessionID().Value() && sessionIDOrName != session.Name().Value() {
+			a.logger.Warn("Session API key used for another session: " + session.SessionID().Value())
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "API key does not belong to this session"})
 		}
 
-		a.logger.Debug("Session API key authenticated successfully for session: " + session.SessionID().Value() + " (" + session.Name().Value() + ")")
-		c.Locals("auth_type", "session")
-		c.Locals("api_key", apiKey)
-		c.Locals("session_id", session.SessionID())
-		c.Locals("session", session)
 		return c.Next()
 	}
 }
@@ -95,26 +96,74 @@ func (a *AuthMiddleware) AuthenticateAny() fiber.Handler {
 			return c.Next()
 		}
 
-		session, err := a.sessionRepo.GetByApiKey(context.Background(), apiKey)
-		if err != nil {
-			if err == fmt.Errorf("session not found") {
-				a.logger.Warn("Invalid API key provided")
-				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid API key"})
-			} else {
-				a.logger.Error("Error validating API key: " + err.Error())
-				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Authentication error"})
-			}
+		if ok, err := a.authenticateSessionKey(c, apiKey); !ok {
+			return err
 		}
 
-		a.logger.Debug("Session API key authenticated for session: " + session.SessionID().Value() + " (" + session.Name().Value() + ")")
-		c.Locals("auth_type", "session")
-		c.Locals("api_key", apiKey)
-		c.Locals("session_id", session.SessionID())
-		c.Locals("session", session)
 		return c.Next()
 	}
 }
 
+// authenticateSessionKey valida uma chave de sessão e preenche os Locals da requisição. Quando a chave
+// não autentica, envia a resposta de erro e retorna false.
+func (a *AuthMiddleware) authenticateSessionKey(c *fiber.Ctx, apiKey string) (bool, error) {
+	ctx := context.Background()
+
+	key, err := a.apiKeyRepo.GetByKey(ctx, apiKey)
+	if err != nil {
+		a.logger.Error("Error validating session API key: " + err.Error())
+		return false, c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Authentication error"})
+	}
+
+	// As chaves originais das sessões também estão em zpApiKeys (migração 0026), com todos os escopos
+	if key == nil {
+		a.logger.Warn("Invalid session API key provided")
+		return false, c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid API key"})
+	}
+
+	if !key.IsActive(time.Now()) {
+		a.logger.Warn("Expired or revoked API key provided: " + key.KeyPrefix)
+		return false, c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "API key expired or revoked"})
+	}
+
+	session, err := a.sessionRepo.GetByID(ctx, key.SessionId)
+	if err != nil {
+		a.logger.Error("Error loading session of API key " + key.KeyPrefix + ": " + err.Error())
+		return false, c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid API key"})
+	}
+
+	if err := a.apiKeyRepo.TouchLastUsed(ctx, key.ID); err != nil {
+		a.logger.Warn("Failed to record API key use: " + err.Error())
+	}
+
+	a.logger.Debug("API key " + key.KeyPrefix + " authenticated for session: " + session.SessionID().Value() + " (" + session.Name().Value() + ")")
+	a.setSessionLocals(c, apiKey, session, key.ID, key.Scopes)
+	return true, nil
+}
+
+func (a *AuthMiddleware) setSessionLocals(c *fiber.Ctx, apiKey string, session *session.Session, apiKeyID string, scopes []string) {
+	c.Locals("auth_type", "session")
+	c.Locals("api_key", apiKey)
+	c.Locals("api_key_id", apiKeyID)
+	c.Locals("scopes", scopes)
+	c.Locals("session_id", session.SessionID())
+	c.Locals("session", session)
+}
+
+// AuthenticateStream autentica como AuthenticateSession, aceitando também a chave no parâmetro
+// api_key, já que EventSource e WebSocket do navegador não enviam headers customizados
+func (a *AuthMiddleware) AuthenticateStream() fiber.Handler {
+	authenticate := a.AuthenticateSession()
+	return func(c *fiber.Ctx) error {
+		if a.extractAPIKey(c) == "" {
+			if apiKey := c.Query("api_key"); apiKey != "" {
+				c.Request().Header.Set("X-API-Key", apiKey)
+			}
+		}
+		return authenticate(c)
+	}
+}
+
 func (a *AuthMiddleware) extractAPIKey(c *fiber.Ctx) string {
 	apiKey := c.Get("X-API-Key")
 	if apiKey != "" {
@@ -154,3 +203,11 @@ func IsGlobalAuth(c *fiber.Ctx) bool {
 func IsSessionAuth(c *fiber.Ctx) bool {
 	return GetAuthType(c) == "session"
 }
+
+// GetAPIKeyID retorna o ID em zpApiKeys da chave de sessão autenticada; vazio só para a chave global
+func GetAPIKeyID(c *fiber.Ctx) string {
+	if id, ok := c.Locals("api_key_id").(string); ok {
+		return id
+	}
+	return ""
+}
@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"sort"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Escopos das chaves de API de sessão. Os escopos *:manage incluem a leitura correspondente.
+const (
+	ScopeAll               = "*"
+	ScopeMessagesSend      = "messages:send"
+	ScopeMessagesRead      = "messages:read"
+	ScopeChatsRead         = "chats:read"
+	ScopeChatsManage       = "chats:manage"
+	ScopeContactsRead      = "contacts:read"
+	ScopeGroupsRead        = "groups:read"
+	ScopeGroupsManage      = "groups:manage"
+	ScopeNewslettersRead   = "newsletters:read"
+	ScopeNewslettersManage = "newsletters:manage"
+	ScopeMediaManage       = "media:manage"
+	ScopeCallsManage       = "calls:manage"
+	ScopePrivacyManage     = "privacy:manage"
+	ScopeWebhooksManage    = "webhooks:manage"
+	ScopeEventsRead        = "events:read"
+	ScopeKeysManage        = "keys:manage"
+)
+
+// scopeDescriptions lista os escopos aceitos na criação das chaves
+var scopeDescriptions = map[string]string{
+	ScopeAll:               "Every scope, same access as the default key created with the session",
+	ScopeMessagesSend:      "Send, edit, delete, react and mark messages as read; schedules, templates, campaigns and statuses",
+	ScopeMessagesRead:      "Message status, chat history, search, media download and received statuses",
+	ScopeChatsRead:         "Chat list, chat info, history sync status and labels",
+	ScopeChatsManage:       "Pin, mute, archive, read state, disappearing timer, presence and labels",
+	ScopeContactsRead:      "Contact list, contact info, avatars and presence",
+	ScopeGroupsRead:        "Group list, info and invite links",
+	ScopeGroupsManage:      "Create, join, leave and configure groups and communities",
+	ScopeNewslettersRead:   "Newsletter list, info and messages",
+	ScopeNewslettersManage: "Create, follow and post to newsletters",
+	ScopeMediaManage:       "Media storage and media policy",
+	ScopeCallsManage:       "Call policy, call rejection and call log",
+	ScopePrivacyManage:     "Privacy settings and blocklist",
+	ScopeWebhooksManage:    "Webhooks, webhook deliveries and Chatwoot",
+	ScopeEventsRead:        "SSE and WebSocket event stream",
+	ScopeKeysManage:        "Create, rotate and revoke API keys (only with scopes the key itself has)",
+}
+
+// IsValidScope indica se o escopo pode ser concedido a uma chave
+func IsValidScope(scope string) bool {
+	_, ok := scopeDescriptions[scope]
+	return ok
+}
+
+// Scopes retorna os escopos aceitos, em ordem alfabética, com a descrição de cada um
+func Scopes() []ScopeInfo {
+	scopes := make([]ScopeInfo, 0, len(scopeDescriptions))
+	for name, description := range scopeDescriptions {
+		scopes = append(scopes, ScopeInfo{Name: name, Description: description})
+	}
+	sort.Slice(scopes, func(i, j int) bool { return scopes[i].Name < scopes[j].Name })
+	return scopes
+}
+
+type ScopeInfo struct {
+	Name        string
+	Description string
+}
+
+// RequireScope permite a requisição quando a chave tem algum dos escopos informados. A chave global tem
+// todos os escopos.
+func (a *AuthMiddleware) RequireScope(scopes ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if IsGlobalAuth(c) || HasAnyScope(c, scopes...) {
+			return c.Next()
+		}
+
+		a.logger.Warn("API key without the required scope for " + c.Method() + " " + c.Path())
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error":           "API key does not have the required scope",
+			"required_scopes": scopes,
+		})
+	}
+}
+
+// GetScopes retorna os escopos da chave de sessão autenticada; nil para a chave global
+func GetScopes(c *fiber.Ctx) []string {
+	if scopes, ok := c.Locals("scopes").([]string); ok {
+		return scopes
+	}
+	return nil
+}
+
+// HasScope indica se a chave autenticada tem o escopo, considerando * e a leitura incluída no :manage
+func HasScope(c *fiber.Ctx, scope string) bool {
+	if IsGlobalAuth(c) {
+		return true
+	}
+	return scopesInclude(GetScopes(c), scope)
+}
+
+// HasAnyScope indica se a chave autenticada tem algum dos escopos
+func HasAnyScope(c *fiber.Ctx, scopes ...string) bool {
+	for _, scope := range scopes {
+		if HasScope(c, scope) {
+			return true
+		}
+	}
+	return false
+}
+
+func scopesInclude(granted []string, scope string) bool {
+	for _, g := range granted {
+		if g == ScopeAll || g == scope || impliedScopes[g] == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// impliedScopes são as leituras incluídas em cada escopo :manage
+var impliedScopes = map[string]string{
+	ScopeChatsManage:       ScopeChatsRead,
+	ScopeGroupsManage:      ScopeGroupsRead,
+	ScopeNewslettersManage: ScopeNewslettersRead,
+}
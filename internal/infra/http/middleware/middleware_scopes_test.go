@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"zpmeow/internal/infra/logging"
+)
+
+func TestScopesInclude(t *testing.T) {
+	tests := []struct {
+		name    string
+		granted []string
+		scope   string
+		want    bool
+	}{
+		{name: "no scopes", granted: nil, scope: ScopeMessagesRead, want: false},
+		{name: "wildcard", granted: []string{ScopeAll}, scope: ScopeKeysManage, want: true},
+		{name: "exact match", granted: []string{ScopeMessagesSend}, scope: ScopeMessagesSend, want: true},
+		{name: "other scope", granted: []string{ScopeMessagesSend}, scope: ScopeMessagesRead, want: false},
+		{name: "any of the granted scopes", granted: []string{ScopeContactsRead, ScopeWebhooksManage}, scope: ScopeWebhooksManage, want: true},
+		{name: "chats manage implies read", granted: []string{ScopeChatsManage}, scope: ScopeChatsRead, want: true},
+		{name: "groups manage implies read", granted: []string{ScopeGroupsManage}, scope: ScopeGroupsRead, want: true},
+		{name: "newsletters manage implies read", granted: []string{ScopeNewslettersManage}, scope: ScopeNewslettersRead, want: true},
+		{name: "read does not imply manage", granted: []string{ScopeChatsRead}, scope: ScopeChatsManage, want: false},
+		{name: "manage does not imply other reads", granted: []string{ScopeChatsManage}, scope: ScopeGroupsRead, want: false},
+		{name: "media manage has no implied read", granted: []string{ScopeMediaManage}, scope: ScopeMessagesRead, want: false},
+		{name: "prefix is not a match", granted: []string{"chats"}, scope: ScopeChatsRead, want: false},
+		{name: "empty scope", granted: []string{ScopeChatsManage}, scope: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scopesInclude(tt.granted, tt.scope); got != tt.want {
+				t.Errorf("scopesInclude(%v, %q) = %v, want %v", tt.granted, tt.scope, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsValidScope(t *testing.T) {
+	for _, scope := range Scopes() {
+		if !IsValidScope(scope.Name) {
+			t.Errorf("IsValidScope(%q) = false for a listed scope", scope.Name)
+		}
+	}
+
+	for _, scope := range []string{"", "messages", "messages:write", "MESSAGES:SEND", " *"} {
+		if IsValidScope(scope) {
+			t.Errorf("IsValidScope(%q) = true, want false", scope)
+		}
+	}
+}
+
+func TestRequireScope(t *testing.T) {
+	tests := []struct {
+		name     string
+		authType string
+		scopes   []string
+		required []string
+		want     int
+	}{
+		{name: "global key", authType: "global", required: []string{ScopeKeysManage}, want: fiber.StatusOK},
+		{name: "session key with the scope", authType: "session", scopes: []string{ScopeMessagesSend}, required: []string{ScopeMessagesSend}, want: fiber.StatusOK},
+		{name: "session key with one of the scopes", authType: "session", scopes: []string{ScopeChatsManage}, required: []string{ScopeMessagesRead, ScopeChatsRead}, want: fiber.StatusOK},
+		{name: "session key without the scope", authType: "session", scopes: []string{ScopeChatsRead}, required: []string{ScopeChatsManage}, want: fiber.StatusForbidden},
+		{name: "session key without scopes", authType: "session", required: []string{ScopeEventsRead}, want: fiber.StatusForbidden},
+	}
+
+	auth := &AuthMiddleware{logger: logging.GetLogger()}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(func(c *fiber.Ctx) error {
+				c.Locals("auth_type", tt.authType)
+				if tt.scopes != nil {
+					c.Locals("scopes", tt.scopes)
+				}
+				return c.Next()
+			})
+			app.Get("/", auth.RequireScope(tt.required...), func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
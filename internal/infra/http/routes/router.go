@@ -13,6 +13,9 @@ type HandlerDependencies struct {
 	HealthHandler     *handlers.HealthHandler
 	SessionHandler    *handlers.SessionHandler
 	MessageHandler    *handlers.MessageHandler
+	ScheduleHandler   *handlers.ScheduleHandler
+	CampaignHandler   *handlers.CampaignHandler
+	TemplateHandler   *handlers.TemplateHandler
 	PrivacyHandler    *handlers.PrivacyHandler
 	ChatHandler       *handlers.ChatHandler
 	ContactHandler    *handlers.ContactHandler
@@ -20,7 +23,13 @@ type HandlerDependencies struct {
 	CommunityHandler  *handlers.CommunityHandler
 	NewsletterHandler *handlers.NewsletterHandler
 	WebhookHandler    *handlers.WebhookHandler
+	MediaHandler      *handlers.MediaHandler
 	ChatwootHandler   *handlers.ChatwootHandler
+	StreamHandler     *handlers.EventStreamHandler
+	CallHandler       *handlers.CallHandler
+	LabelHandler      *handlers.LabelHandler
+	StatusHandler     *handlers.StatusHandler
+	APIKeyHandler     *handlers.APIKeyHandler
 }
 
 func SetupRoutes(
@@ -50,35 +59,102 @@ func SetupRoutes(
 	sessionGroup.Post("/:sessionId/pair", handlers.SessionHandler.PairPhone)
 	sessionGroup.Get("/:sessionId/status", handlers.SessionHandler.GetSessionStatus)
 	sessionGroup.Put("/:sessionId/webhook", handlers.SessionHandler.UpdateSessionWebhook)
+	sessionGroup.Put("/:sessionId/proxy", handlers.SessionHandler.UpdateSessionProxy)
+
+	// Registrado antes do sessionAPIGroup: aceita a chave também no parâmetro api_key
+	events := app.Group("/session/:sessionId/events", authMiddleware.AuthenticateStream(), authMiddleware.RequireScope(middleware.ScopeEventsRead))
+	events.Get("/stream", handlers.StreamHandler.StreamEvents)
+	events.Get("/ws", handlers.StreamHandler.UpgradeEventSocket, handlers.StreamHandler.EventSocket())
 
 	sessionAPIGroup := app.Group("/session/:sessionId")
 	sessionAPIGroup.Use(authMiddleware.AuthenticateSession())
 
-	sessionAPIGroup.Post("/message/send/text", handlers.MessageHandler.SendText)
-	sessionAPIGroup.Post("/message/send/image", handlers.MessageHandler.SendImage)
-	sessionAPIGroup.Post("/message/send/video", handlers.MessageHandler.SendVideo)
-	sessionAPIGroup.Post("/message/send/audio", handlers.MessageHandler.SendAudio)
-	sessionAPIGroup.Post("/message/send/document", handlers.MessageHandler.SendDocument)
-	sessionAPIGroup.Post("/message/send/sticker", handlers.MessageHandler.SendSticker)
-	sessionAPIGroup.Post("/message/send/contact", handlers.MessageHandler.SendContact)
-	sessionAPIGroup.Post("/message/send/location", handlers.MessageHandler.SendLocation)
-	sessionAPIGroup.Post("/message/send/media", handlers.MessageHandler.SendMedia)
-	sessionAPIGroup.Post("/message/send/buttons", handlers.MessageHandler.SendButton)
-	sessionAPIGroup.Post("/message/send/list", handlers.MessageHandler.SendList)
-	sessionAPIGroup.Post("/message/send/poll", handlers.MessageHandler.SendPoll)
-
-	sessionAPIGroup.Post("/message/markread", handlers.MessageHandler.MarkAsRead)
-	sessionAPIGroup.Post("/message/react", handlers.MessageHandler.ReactToMessage)
-	sessionAPIGroup.Post("/message/edit", handlers.MessageHandler.EditMessage)
-	sessionAPIGroup.Post("/message/delete", handlers.MessageHandler.DeleteMessage)
-
-	privacy := sessionAPIGroup.Group("/privacy")
+	// Escopos exigidos das chaves de sessão; a chave global e as chaves com escopo * têm todos.
+	// Grupos com rotas de leitura e escrita conferem o escopo em cada rota, porque o middleware de um
+	// grupo vale para todas as rotas com o mesmo prefixo.
+	messagesSend := authMiddleware.RequireScope(middleware.ScopeMessagesSend)
+	messagesRead := authMiddleware.RequireScope(middleware.ScopeMessagesRead)
+	chatsRead := authMiddleware.RequireScope(middleware.ScopeChatsRead)
+	chatsManage := authMiddleware.RequireScope(middleware.ScopeChatsManage)
+	contactsRead := authMiddleware.RequireScope(middleware.ScopeContactsRead)
+	groupsRead := authMiddleware.RequireScope(middleware.ScopeGroupsRead)
+	groupsManage := authMiddleware.RequireScope(middleware.ScopeGroupsManage)
+	newslettersRead := authMiddleware.RequireScope(middleware.ScopeNewslettersRead)
+	newslettersManage := authMiddleware.RequireScope(middleware.ScopeNewslettersManage)
+
+	send := sessionAPIGroup.Group("/message/send", messagesSend)
+	send.Post("/text", handlers.MessageHandler.SendText)
+	send.Post("/image", handlers.MessageHandler.SendImage)
+	send.Post("/video", handlers.MessageHandler.SendVideo)
+	send.Post("/audio", handlers.MessageHandler.SendAudio)
+	send.Post("/document", handlers.MessageHandler.SendDocument)
+	send.Post("/sticker", handlers.MessageHandler.SendSticker)
+	send.Post("/contact", handlers.MessageHandler.SendContact)
+	send.Post("/location", handlers.MessageHandler.SendLocation)
+	send.Post("/media", handlers.MessageHandler.SendMedia)
+	send.Post("/buttons", handlers.MessageHandler.SendButton)
+	send.Post("/list", handlers.MessageHandler.SendList)
+	send.Post("/poll", handlers.MessageHandler.SendPoll)
+	send.Post("/template", handlers.TemplateHandler.SendTemplate)
+
+	sessionAPIGroup.Post("/message/markread", messagesSend, handlers.MessageHandler.MarkAsRead)
+	sessionAPIGroup.Post("/message/react", messagesSend, handlers.MessageHandler.ReactToMessage)
+	sessionAPIGroup.Post("/message/edit", messagesSend, handlers.MessageHandler.EditMessage)
+	sessionAPIGroup.Post("/message/delete", messagesSend, handlers.MessageHandler.DeleteMessage)
+	sessionAPIGroup.Get("/message/:messageId/status", messagesRead, handlers.MessageHandler.GetMessageStatus)
+
+	schedule := sessionAPIGroup.Group("/message/schedule", messagesSend)
+	schedule.Post("", handlers.ScheduleHandler.ScheduleMessage)
+	schedule.Get("", handlers.ScheduleHandler.ListScheduledMessages)
+	schedule.Get("/:scheduleId", handlers.ScheduleHandler.GetScheduledMessage)
+	schedule.Put("/:scheduleId", handlers.ScheduleHandler.RescheduleMessage)
+	schedule.Delete("/:scheduleId", handlers.ScheduleHandler.CancelScheduledMessage)
+
+	template := sessionAPIGroup.Group("/templates", messagesSend)
+	template.Post("", handlers.TemplateHandler.CreateTemplate)
+	template.Get("", handlers.TemplateHandler.ListTemplates)
+	template.Get("/:templateId", handlers.TemplateHandler.GetTemplate)
+	template.Put("/:templateId", handlers.TemplateHandler.UpdateTemplate)
+	template.Delete("/:templateId", handlers.TemplateHandler.DeleteTemplate)
+
+	campaign := sessionAPIGroup.Group("/campaigns", messagesSend)
+	campaign.Post("", handlers.CampaignHandler.CreateCampaign)
+	campaign.Get("", handlers.CampaignHandler.ListCampaigns)
+	campaign.Get("/:campaignId", handlers.CampaignHandler.GetCampaign)
+	campaign.Get("/:campaignId/recipients", handlers.CampaignHandler.ListCampaignRecipients)
+	campaign.Post("/:campaignId/pause", handlers.CampaignHandler.PauseCampaign)
+	campaign.Post("/:campaignId/resume", handlers.CampaignHandler.ResumeCampaign)
+	campaign.Post("/:campaignId/cancel", handlers.CampaignHandler.CancelCampaign)
+
+	call := sessionAPIGroup.Group("/call", authMiddleware.RequireScope(middleware.ScopeCallsManage))
+	call.Get("/policy", handlers.CallHandler.GetCallPolicy)
+	call.Put("/policy", handlers.CallHandler.UpdateCallPolicy)
+	call.Post("/reject", handlers.CallHandler.RejectCall)
+	call.Get("/log", handlers.CallHandler.ListCalls)
+
+	label := sessionAPIGroup.Group("/labels")
+	label.Get("", chatsRead, handlers.LabelHandler.ListLabels)
+	label.Post("", chatsManage, handlers.LabelHandler.CreateLabel)
+	label.Put("/:labelId", chatsManage, handlers.LabelHandler.EditLabel)
+	label.Delete("/:labelId", chatsManage, handlers.LabelHandler.DeleteLabel)
+	label.Post("/:labelId/chats", chatsManage, handlers.LabelHandler.LabelChat)
+	label.Post("/:labelId/messages", chatsManage, handlers.LabelHandler.LabelMessage)
+
+	// GET /session/:sessionId/status é o status da sessão; a lista de status fica em /status/list
+	status := sessionAPIGroup.Group("/status")
+	status.Post("/text", messagesSend, handlers.StatusHandler.PostTextStatus)
+	status.Post("/image", messagesSend, handlers.StatusHandler.PostImageStatus)
+	status.Post("/video", messagesSend, handlers.StatusHandler.PostVideoStatus)
+	status.Get("/privacy", messagesRead, handlers.StatusHandler.GetStatusAudience)
+	status.Get("/list", messagesRead, handlers.StatusHandler.ListStatuses)
+
+	privacy := sessionAPIGroup.Group("/privacy", authMiddleware.RequireScope(middleware.ScopePrivacyManage))
 	privacy.Put("/set", handlers.PrivacyHandler.SetAllPrivacySettings)
 	privacy.Post("/find", handlers.PrivacyHandler.FindPrivacySettings)
 	privacy.Get("/blocklist", handlers.PrivacyHandler.GetBlocklist)
 	privacy.Put("/blocklist", handlers.PrivacyHandler.UpdateBlocklist)
 
-	contacts := sessionAPIGroup.Group("/contacts")
+	contacts := sessionAPIGroup.Group("/contacts", contactsRead)
 	contacts.Post("/check", handlers.ContactHandler.CheckUser)
 	contacts.Post("/info", handlers.ContactHandler.GetUserInfo)
 	contacts.Post("/avatar", handlers.ContactHandler.GetAvatar)
@@ -86,47 +162,50 @@ func SetupRoutes(
 	contacts.Post("/sync", handlers.ContactHandler.GetContacts)
 
 	presence := sessionAPIGroup.Group("/presences")
-	presence.Put("/set", handlers.ContactHandler.SetPresence)
-	presence.Get("/get", handlers.ContactHandler.GetUserInfo)
-	presence.Post("/contact", handlers.ContactHandler.GetUserInfo)
-	presence.Post("/subscribe", handlers.ContactHandler.CheckUser)
+	presence.Put("/set", chatsManage, handlers.ContactHandler.SetPresence)
+	presence.Get("/get", contactsRead, handlers.ContactHandler.GetUserInfo)
+	presence.Post("/contact", contactsRead, handlers.ContactHandler.GetUserInfo)
+	presence.Post("/subscribe", contactsRead, handlers.ContactHandler.CheckUser)
 
 	chat := sessionAPIGroup.Group("/chat")
-	chat.Post("/presence", handlers.ChatHandler.SetPresence)
-	chat.Get("/history", handlers.ChatHandler.GetChatHistory)
+	chat.Post("/presence", chatsManage, handlers.ChatHandler.SetPresence)
+	chat.Get("/history", messagesRead, handlers.ChatHandler.GetChatHistory)
+	chat.Get("/history/sync", chatsRead, handlers.ChatHandler.GetHistorySyncStatus)
+	chat.Get("/search", messagesRead, handlers.ChatHandler.SearchMessages)
 
-	download := chat.Group("/download")
+	download := chat.Group("/download", messagesRead)
 	download.Post("/image", handlers.ChatHandler.DownloadImage)
 	download.Post("/video", handlers.ChatHandler.DownloadVideo)
 	download.Post("/audio", handlers.ChatHandler.DownloadAudio)
 	download.Post("/document", handlers.ChatHandler.DownloadDocument)
 
-	chat.Post("/list", handlers.ChatHandler.ListChats)
-	chat.Post("/info", handlers.ChatHandler.GetChatInfo)
-	chat.Post("/pin", handlers.ChatHandler.PinChat)
-	chat.Post("/mute", handlers.ChatHandler.MuteChat)
-	chat.Post("/archive", handlers.ChatHandler.ArchiveChat)
-	chat.Post("/disappearing-timer", handlers.ChatHandler.SetDisappearingTimer)
+	chat.Post("/list", chatsRead, handlers.ChatHandler.ListChats)
+	chat.Post("/info", chatsRead, handlers.ChatHandler.GetChatInfo)
+	chat.Post("/pin", chatsManage, handlers.ChatHandler.PinChat)
+	chat.Post("/mute", chatsManage, handlers.ChatHandler.MuteChat)
+	chat.Post("/archive", chatsManage, handlers.ChatHandler.ArchiveChat)
+	chat.Post("/read", chatsManage, handlers.ChatHandler.MarkChatRead)
+	chat.Post("/disappearing-timer", chatsManage, handlers.ChatHandler.SetDisappearingTimer)
 
 	chatPresence := sessionAPIGroup.Group("/presences")
-	chatPresence.Post("/typing", handlers.ChatHandler.SetPresence)
-	chatPresence.Post("/recording", handlers.ChatHandler.SetPresence)
+	chatPresence.Post("/typing", chatsManage, handlers.ChatHandler.SetPresence)
+	chatPresence.Post("/recording", chatsManage, handlers.ChatHandler.SetPresence)
 
 	group := sessionAPIGroup.Group("/group")
-	group.Post("/create", handlers.GroupHandler.CreateGroup)
-	group.Get("/list", handlers.GroupHandler.ListGroups)
-	group.Post("/info", handlers.GroupHandler.GetGroupInfo)
-	group.Post("/join", handlers.GroupHandler.JoinGroup)
-	group.Post("/join-with-invite", handlers.GroupHandler.JoinGroupWithInvite)
-	group.Post("/leave", handlers.GroupHandler.LeaveGroup)
-	group.Post("/invitelink", handlers.GroupHandler.GetInviteLink)
-	group.Post("/inviteinfo", handlers.GroupHandler.GetInviteInfo)
-	group.Post("/inviteinfo-specific", handlers.GroupHandler.GetGroupInfoFromInvite)
-
-	participants := group.Group("/participants")
+	group.Post("/create", groupsManage, handlers.GroupHandler.CreateGroup)
+	group.Get("/list", groupsRead, handlers.GroupHandler.ListGroups)
+	group.Post("/info", groupsRead, handlers.GroupHandler.GetGroupInfo)
+	group.Post("/join", groupsManage, handlers.GroupHandler.JoinGroup)
+	group.Post("/join-with-invite", groupsManage, handlers.GroupHandler.JoinGroupWithInvite)
+	group.Post("/leave", groupsManage, handlers.GroupHandler.LeaveGroup)
+	group.Post("/invitelink", groupsRead, handlers.GroupHandler.GetInviteLink)
+	group.Post("/inviteinfo", groupsRead, handlers.GroupHandler.GetInviteInfo)
+	group.Post("/inviteinfo-specific", groupsRead, handlers.GroupHandler.GetGroupInfoFromInvite)
+
+	participants := group.Group("/participants", groupsManage)
 	participants.Post("/update", handlers.GroupHandler.UpdateParticipants)
 
-	settings := group.Group("/settings")
+	settings := group.Group("/settings", groupsManage)
 	settings.Post("/name", handlers.GroupHandler.SetName)
 	settings.Post("/topic", handlers.GroupHandler.SetTopic)
 	settings.Post("/photo/set", handlers.GroupHandler.SetPhoto)
@@ -137,44 +216,76 @@ func SetupRoutes(
 	settings.Post("/join-approval", handlers.GroupHandler.SetJoinApproval)
 	settings.Post("/member-add-mode", handlers.GroupHandler.SetMemberAddMode)
 
-	requests := group.Group("/requests")
+	requests := group.Group("/requests", groupsManage)
 	requests.Post("/list", handlers.GroupHandler.GetGroupRequestParticipants)
 	requests.Post("/update", handlers.GroupHandler.UpdateGroupRequestParticipants)
 
 	community := sessionAPIGroup.Group("/community")
-	community.Post("/link", handlers.CommunityHandler.LinkGroup)
-	community.Post("/unlink", handlers.CommunityHandler.UnlinkGroup)
-	community.Post("/subgroups", handlers.CommunityHandler.GetSubGroups)
-	community.Post("/participants", handlers.CommunityHandler.GetLinkedGroupsParticipants)
+	community.Post("/link", groupsManage, handlers.CommunityHandler.LinkGroup)
+	community.Post("/unlink", groupsManage, handlers.CommunityHandler.UnlinkGroup)
+	community.Post("/subgroups", groupsRead, handlers.CommunityHandler.GetSubGroups)
+	community.Post("/participants", groupsRead, handlers.CommunityHandler.GetLinkedGroupsParticipants)
 
 	newsletter := sessionAPIGroup.Group("/newsletter")
-	newsletter.Post("", handlers.NewsletterHandler.CreateNewsletter)
-	newsletter.Get("/list", handlers.NewsletterHandler.ListNewsletters)
-	newsletter.Get("/:newsletterId", handlers.NewsletterHandler.GetNewsletter)
-	newsletter.Post("/:newsletterId/subscribe", handlers.NewsletterHandler.SubscribeToNewsletter)
-	newsletter.Post("/:newsletterId/unsubscribe", handlers.NewsletterHandler.UnsubscribeFromNewsletter)
-	newsletter.Post("/:newsletterId/send", handlers.NewsletterHandler.SendNewsletterMessage)
-
-	newsletter.Get("/:newsletterId/messages", handlers.NewsletterHandler.GetNewsletterMessages)
-	newsletter.Get("/:newsletterId/updates", handlers.NewsletterHandler.GetNewsletterMessageUpdates)
-	newsletter.Post("/:newsletterId/mark-viewed", handlers.NewsletterHandler.MarkNewsletterViewed)
-
-	newsletter.Post("/:newsletterId/reaction", handlers.NewsletterHandler.SendNewsletterReaction)
-	newsletter.Post("/:newsletterId/mute", handlers.NewsletterHandler.ToggleNewsletterMute)
-	newsletter.Post("/:newsletterId/live-updates", handlers.NewsletterHandler.SubscribeLiveUpdates)
-
-	newsletter.Post("/upload", handlers.NewsletterHandler.UploadNewsletterMedia)
-	newsletter.Get("/invite/:inviteKey", handlers.NewsletterHandler.GetNewsletterByInvite)
-
-	webhook := sessionAPIGroup.Group("/webhook")
+	newsletter.Post("", newslettersManage, handlers.NewsletterHandler.CreateNewsletter)
+	newsletter.Get("/list", newslettersRead, handlers.NewsletterHandler.ListNewsletters)
+	newsletter.Get("/:newsletterId", newslettersRead, handlers.NewsletterHandler.GetNewsletter)
+	newsletter.Post("/:newsletterId/subscribe", newslettersManage, handlers.NewsletterHandler.SubscribeToNewsletter)
+	newsletter.Post("/:newsletterId/unsubscribe", newslettersManage, handlers.NewsletterHandler.UnsubscribeFromNewsletter)
+	newsletter.Post("/:newsletterId/send", newslettersManage, handlers.NewsletterHandler.SendNewsletterMessage)
+
+	newsletter.Get("/:newsletterId/messages", newslettersRead, handlers.NewsletterHandler.GetNewsletterMessages)
+	newsletter.Get("/:newsletterId/updates", newslettersRead, handlers.NewsletterHandler.GetNewsletterMessageUpdates)
+	newsletter.Post("/:newsletterId/mark-viewed", newslettersManage, handlers.NewsletterHandler.MarkNewsletterViewed)
+
+	newsletter.Post("/:newsletterId/reaction", newslettersManage, handlers.NewsletterHandler.SendNewsletterReaction)
+	newsletter.Post("/:newsletterId/mute", newslettersManage, handlers.NewsletterHandler.ToggleNewsletterMute)
+	newsletter.Post("/:newsletterId/live-updates", newslettersRead, handlers.NewsletterHandler.SubscribeLiveUpdates)
+
+	newsletter.Post("/upload", newslettersManage, handlers.NewsletterHandler.UploadNewsletterMedia)
+	newsletter.Get("/invite/:inviteKey", newslettersRead, handlers.NewsletterHandler.GetNewsletterByInvite)
+
+	media := sessionAPIGroup.Group("/media", authMiddleware.RequireScope(middleware.ScopeMediaManage))
+	media.Post("/upload", handlers.MediaHandler.UploadMedia)
+	media.Get("/list", handlers.MediaHandler.ListMedia)
+	media.Get("/policy", handlers.MediaHandler.GetMediaPolicy)
+	media.Put("/policy", handlers.MediaHandler.UpdateMediaPolicy)
+	media.Get("/:mediaId", handlers.MediaHandler.GetMedia)
+	media.Delete("/:mediaId", handlers.MediaHandler.DeleteMedia)
+	media.Get("/:mediaId/download", handlers.MediaHandler.DownloadMedia)
+	media.Get("/:mediaId/progress", handlers.MediaHandler.GetMediaProgress)
+	media.Get("/:mediaId/metadata", handlers.MediaHandler.GetMediaMetadata)
+	media.Post("/:mediaId/convert", handlers.MediaHandler.ConvertMedia)
+	media.Post("/:mediaId/compress", handlers.MediaHandler.CompressMedia)
+
+	webhooksManage := authMiddleware.RequireScope(middleware.ScopeWebhooksManage)
+
+	webhook := sessionAPIGroup.Group("/webhook", webhooksManage)
 	webhook.Post("", handlers.WebhookHandler.SetWebhook)
 	webhook.Get("", handlers.WebhookHandler.GetWebhook)
-
-	webhooks := sessionAPIGroup.Group("/webhooks")
+	webhook.Post("/secret/rotate", handlers.WebhookHandler.RotateWebhookSecret)
+	webhook.Get("/endpoints", handlers.WebhookHandler.ListWebhookEndpoints)
+	webhook.Post("/endpoints", handlers.WebhookHandler.CreateWebhookEndpoint)
+	webhook.Get("/endpoints/:webhookId", handlers.WebhookHandler.GetWebhookEndpoint)
+	webhook.Put("/endpoints/:webhookId", handlers.WebhookHandler.UpdateWebhookEndpoint)
+	webhook.Delete("/endpoints/:webhookId", handlers.WebhookHandler.DeleteWebhookEndpoint)
+	webhook.Post("/endpoints/:webhookId/secret/rotate", handlers.WebhookHandler.RotateWebhookEndpointSecret)
+	webhook.Get("/deliveries/failed", handlers.WebhookHandler.ListFailedDeliveries)
+	webhook.Get("/deliveries/failed/:deliveryId", handlers.WebhookHandler.GetFailedDelivery)
+	webhook.Post("/deliveries/failed/:deliveryId/replay", handlers.WebhookHandler.ReplayFailedDelivery)
+
+	webhooks := sessionAPIGroup.Group("/webhooks", webhooksManage)
 	webhooks.Get("/events", handlers.WebhookHandler.ListEvents)
 
+	keys := sessionAPIGroup.Group("/keys", authMiddleware.RequireScope(middleware.ScopeKeysManage))
+	keys.Get("", handlers.APIKeyHandler.ListAPIKeys)
+	keys.Post("", handlers.APIKeyHandler.CreateAPIKey)
+	keys.Get("/scopes", handlers.APIKeyHandler.ListScopes)
+	keys.Post("/:keyId/rotate", handlers.APIKeyHandler.RotateAPIKey)
+	keys.Delete("/:keyId", handlers.APIKeyHandler.RevokeAPIKey)
+
 	// Chatwoot integration routes (simplified)
-	chatwoot := sessionAPIGroup.Group("/chatwoot")
+	chatwoot := sessionAPIGroup.Group("/chatwoot", webhooksManage)
 	chatwoot.Post("/set", handlers.ChatwootHandler.SetChatwootConfig)
 	chatwoot.Get("/find", handlers.ChatwootHandler.GetChatwootConfig)
 
@@ -187,6 +187,53 @@ func (w *WALoggerAdapter) Sub(module string) waLog.Logger {
 	return NewWALogger(w.module + "." + module)
 }
 
+// NewUseCaseLogger cria o logger no formato chave/valor esperado pelos use cases (ports.Logger)
+func NewUseCaseLogger(module string) *UseCaseLoggerAdapter {
+	return &UseCaseLoggerAdapter{logger: GetLogger().Sub(module)}
+}
+
+type UseCaseLoggerAdapter struct {
+	logger Logger
+}
+
+func (u *UseCaseLoggerAdapter) Debug(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	u.logger.DebugCtx(ctx, formatKeysAndValues(msg, keysAndValues))
+}
+
+func (u *UseCaseLoggerAdapter) Info(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	u.logger.InfoCtx(ctx, formatKeysAndValues(msg, keysAndValues))
+}
+
+func (u *UseCaseLoggerAdapter) Warn(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	u.logger.WarnCtx(ctx, formatKeysAndValues(msg, keysAndValues))
+}
+
+func (u *UseCaseLoggerAdapter) Error(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	u.logger.ErrorCtx(ctx, formatKeysAndValues(msg, keysAndValues))
+}
+
+func (u *UseCaseLoggerAdapter) Fatal(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	u.logger.Fatal(formatKeysAndValues(msg, keysAndValues))
+}
+
+// formatKeysAndValues anexa os pares chave/valor à mensagem (ex.: "msg key=value")
+func formatKeysAndValues(msg string, keysAndValues []interface{}) string {
+	if len(keysAndValues) == 0 {
+		return msg
+	}
+
+	var builder strings.Builder
+	builder.WriteString(msg)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		if i+1 < len(keysAndValues) {
+			fmt.Fprintf(&builder, " %v=%v", keysAndValues[i], keysAndValues[i+1])
+		} else {
+			fmt.Fprintf(&builder, " %v", keysAndValues[i])
+		}
+	}
+	return builder.String()
+}
+
 func TruncateID(id string) string {
 	if len(id) <= 16 {
 		return id
@@ -0,0 +1,405 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	_ "time/tzdata" // fusos horários disponíveis mesmo em imagens sem tzdata
+
+	"zpmeow/internal/application/common"
+	"zpmeow/internal/application/ports"
+	"zpmeow/internal/domain/session"
+	"zpmeow/internal/infra/database/models"
+	"zpmeow/internal/infra/database/repository"
+	"zpmeow/internal/infra/dispatch"
+	"zpmeow/internal/infra/logging"
+)
+
+type Config struct {
+	Workers      int
+	BatchSize    int
+	PollInterval time.Duration
+	StaleAfter   time.Duration
+	MaxAttempts  int
+	RetryDelay   time.Duration
+}
+
+func defaultConfig() *Config {
+	return &Config{
+		Workers:      2,
+		BatchSize:    50,
+		PollInterval: 5 * time.Second,
+		StaleAfter:   5 * time.Minute,
+		MaxAttempts:  3,
+		RetryDelay:   time.Minute,
+	}
+}
+
+// localLayouts são os formatos aceitos em send_at sem offset, interpretados no timezone informado
+var localLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+}
+
+// Scheduler persists scheduled messages in zpScheduledMessages and sends them when
+// due through the messaging use cases. Messages whose session is offline wait in
+// waiting_session and are re-queued as soon as the session reconnects.
+type Scheduler struct {
+	repo       *repository.ScheduledMessageRepository
+	dispatcher *dispatch.Dispatcher
+	sessions   ports.SessionManager
+	config     *Config
+	logger     logging.Logger
+
+	jobs    chan *models.ScheduledMessageModel
+	wake    chan struct{}
+	stop    chan struct{}
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	running bool
+}
+
+func NewScheduler(repo *repository.ScheduledMessageRepository, sessionRepo session.Repository, whatsappService ports.WhatsAppService, mediaResolver ports.MediaResolver, config *Config) *Scheduler {
+	if config == nil {
+		config = defaultConfig()
+	}
+	defaults := defaultConfig()
+	if config.Workers <= 0 {
+		config.Workers = defaults.Workers
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaults.BatchSize
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaults.PollInterval
+	}
+	if config.StaleAfter <= 0 {
+		config.StaleAfter = defaults.StaleAfter
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaults.MaxAttempts
+	}
+	if config.RetryDelay <= 0 {
+		config.RetryDelay = defaults.RetryDelay
+	}
+
+	return &Scheduler{
+		repo:       repo,
+		dispatcher: dispatch.NewDispatcher(sessionRepo, whatsappService, mediaResolver, "scheduler"),
+		sessions:   whatsappService,
+		config:     config,
+		logger:     logging.GetLogger().Sub("scheduler"),
+		jobs:       make(chan *models.ScheduledMessageModel, config.BatchSize),
+		wake:       make(chan struct{}, 1),
+	}
+}
+
+// ParseSendAt interpreta send_at como RFC3339 ou como horário local no timezone informado (padrão UTC).
+// Retorna o instante de envio e o nome do timezone normalizado.
+func ParseSendAt(sendAt, timezone string) (time.Time, string, error) {
+	sendAt = strings.TrimSpace(sendAt)
+	if sendAt == "" {
+		return time.Time{}, "", fmt.Errorf("%w: send_at is required", common.ErrInvalidInput)
+	}
+
+	timezone = strings.TrimSpace(timezone)
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("%w: invalid timezone %q", common.ErrInvalidInput, timezone)
+	}
+
+	parsed, err := time.Parse(time.RFC3339, sendAt)
+	if err != nil {
+		for _, layout := range localLayouts {
+			if parsed, err = time.ParseInLocation(layout, sendAt, location); err == nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("%w: send_at must be RFC3339 or YYYY-MM-DDTHH:MM[:SS] in the given timezone", common.ErrInvalidInput)
+	}
+
+	if !parsed.After(time.Now()) {
+		return time.Time{}, "", fmt.Errorf("%w: send_at must be in the future", common.ErrInvalidInput)
+	}
+
+	return parsed, timezone, nil
+}
+
+// Schedule valida o payload no formato do endpoint de envio correspondente e persiste o agendamento
+func (s *Scheduler) Schedule(ctx context.Context, sessionID, messageType string, payload []byte, sendAt time.Time, timezone string) (*models.ScheduledMessageModel, error) {
+	_, phone, err := dispatch.Decode(messageType, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	message := &models.ScheduledMessageModel{
+		SessionId:   sessionID,
+		Phone:       phone,
+		MessageType: messageType,
+		Payload:     payload,
+		SendAt:      sendAt,
+		Timezone:    timezone,
+	}
+
+	if err := s.repo.Create(ctx, message); err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("Scheduled %s message %s for %s (session: %s)", messageType, message.ID, sendAt.Format(time.RFC3339), sessionID)
+	s.notify()
+
+	return message, nil
+}
+
+// List returns the scheduled messages of a session ordered by send time
+func (s *Scheduler) List(ctx context.Context, sessionID, status string, limit, offset int) ([]*models.ScheduledMessageModel, int, error) {
+	return s.repo.List(ctx, sessionID, status, limit, offset)
+}
+
+// Get returns a scheduled message, or nil if it does not belong to the session
+func (s *Scheduler) Get(ctx context.Context, sessionID, id string) (*models.ScheduledMessageModel, error) {
+	return s.repo.GetByID(ctx, sessionID, id)
+}
+
+// Cancel cancels a pending scheduled message; it returns nil if the message
+// does not exist or was already sent, failed or canceled
+func (s *Scheduler) Cancel(ctx context.Context, sessionID, id string) (*models.ScheduledMessageModel, error) {
+	message, err := s.repo.Cancel(ctx, sessionID, id)
+	if err != nil {
+		return nil, err
+	}
+	if message != nil {
+		s.logger.Infof("Canceled scheduled message %s (session: %s)", id, sessionID)
+	}
+	return message, nil
+}
+
+// Reschedule moves a pending scheduled message to a new send time with a fresh retry budget
+func (s *Scheduler) Reschedule(ctx context.Context, sessionID, id string, sendAt time.Time, timezone string) (*models.ScheduledMessageModel, error) {
+	message, err := s.repo.Reschedule(ctx, sessionID, id, sendAt, timezone)
+	if err != nil {
+		return nil, err
+	}
+	if message != nil {
+		s.logger.Infof("Rescheduled message %s to %s (session: %s)", id, sendAt.Format(time.RFC3339), sessionID)
+		s.notify()
+	}
+	return message, nil
+}
+
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		return
+	}
+
+	s.stop = make(chan struct{})
+	s.jobs = make(chan *models.ScheduledMessageModel, s.config.BatchSize)
+	s.running = true
+
+	for i := 0; i < s.config.Workers; i++ {
+		s.wg.Add(1)
+		go s.worker(i)
+	}
+
+	s.wg.Add(1)
+	go s.dispatch()
+
+	s.logger.Infof("Message scheduler started with %d workers", s.config.Workers)
+}
+
+// Stop stops claiming due messages and waits for in-flight sends to finish
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return
+	}
+	s.running = false
+	close(s.stop)
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	s.logger.Info("Message scheduler stopped")
+}
+
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Scheduler) dispatch() {
+	defer s.wg.Done()
+	defer close(s.jobs)
+
+	ticker := time.NewTicker(s.config.PollInterval)
+	defer ticker.Stop()
+
+	s.releaseStale()
+	s.requeueReconnected()
+	s.claim()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.releaseStale()
+			s.requeueReconnected()
+			s.claim()
+		case <-s.wake:
+			s.claim()
+		}
+	}
+}
+
+func (s *Scheduler) releaseStale() {
+	released, err := s.repo.ReleaseStale(context.Background(), s.config.StaleAfter)
+	if err != nil {
+		s.logger.Errorf("Failed to release stale scheduled messages: %v", err)
+		return
+	}
+	if released > 0 {
+		s.logger.Warnf("Released %d stale scheduled messages back to the queue", released)
+	}
+}
+
+// requeueReconnected devolve para a fila as mensagens das sessões que voltaram a ficar conectadas
+func (s *Scheduler) requeueReconnected() {
+	ctx := context.Background()
+
+	sessionIDs, err := s.repo.ListWaitingSessionIDs(ctx)
+	if err != nil {
+		s.logger.Errorf("Failed to list sessions waiting for reconnection: %v", err)
+		return
+	}
+
+	for _, sessionID := range sessionIDs {
+		if !s.sessions.IsClientConnected(sessionID) {
+			continue
+		}
+
+		requeued, err := s.repo.RequeueWaiting(ctx, sessionID)
+		if err != nil {
+			s.logger.Errorf("Failed to requeue scheduled messages for session %s: %v", sessionID, err)
+			continue
+		}
+		if requeued > 0 {
+			s.logger.Infof("Session %s reconnected, requeued %d scheduled messages", sessionID, requeued)
+		}
+	}
+}
+
+func (s *Scheduler) claim() {
+	free := cap(s.jobs) - len(s.jobs)
+	if free <= 0 {
+		return
+	}
+
+	messages, err := s.repo.ClaimDue(context.Background(), free)
+	if err != nil {
+		s.logger.Errorf("Failed to claim scheduled messages: %v", err)
+		return
+	}
+
+	for _, message := range messages {
+		select {
+		case s.jobs <- message:
+		case <-s.stop:
+			// As mensagens já reservadas voltam para a fila via releaseStale no próximo start
+			return
+		}
+	}
+}
+
+func (s *Scheduler) worker(id int) {
+	defer s.wg.Done()
+
+	for message := range s.jobs {
+		s.send(message)
+	}
+
+	s.logger.Debugf("Scheduler worker %d finished", id)
+}
+
+func (s *Scheduler) send(message *models.ScheduledMessageModel) {
+	ctx := context.Background()
+
+	if !s.sessions.IsClientConnected(message.SessionId) {
+		s.waitForSession(ctx, message, "session is not connected")
+		return
+	}
+
+	attempt := message.Attempts + 1
+	messageID, err := s.dispatcher.Send(ctx, message.SessionId, message.MessageType, message.Payload)
+	if err == nil {
+		if err := s.repo.MarkSent(ctx, message.ID, attempt, messageID); err != nil {
+			s.logger.Errorf("Failed to mark scheduled message %s as sent: %v", message.ID, err)
+		}
+		s.logger.Infof("Scheduled message %s sent as %s (session: %s)", message.ID, messageID, message.SessionId)
+		return
+	}
+
+	if isSessionUnavailable(err) {
+		s.waitForSession(ctx, message, err.Error())
+		return
+	}
+
+	if isRetryable(err) && attempt < s.config.MaxAttempts {
+		s.logger.Warnf("Scheduled message %s failed (attempt %d/%d), retrying in %v: %v",
+			message.ID, attempt, s.config.MaxAttempts, s.config.RetryDelay, err)
+
+		if err := s.repo.ScheduleRetry(ctx, message.ID, attempt, time.Now().Add(s.config.RetryDelay), err.Error()); err != nil {
+			s.logger.Errorf("Failed to schedule retry for scheduled message %s: %v", message.ID, err)
+		}
+		return
+	}
+
+	s.logger.Errorf("Scheduled message %s failed after %d attempts: %v", message.ID, attempt, err)
+	if err := s.repo.MarkFailed(ctx, message.ID, attempt, err.Error()); err != nil {
+		s.logger.Errorf("Failed to mark scheduled message %s as failed: %v", message.ID, err)
+	}
+}
+
+func (s *Scheduler) waitForSession(ctx context.Context, message *models.ScheduledMessageModel, reason string) {
+	s.logger.Infof("Session %s unavailable, scheduled message %s will be sent on reconnection", message.SessionId, message.ID)
+	if err := s.repo.MarkWaitingSession(ctx, message.ID, reason); err != nil {
+		s.logger.Errorf("Failed to park scheduled message %s until reconnection: %v", message.ID, err)
+	}
+}
+
+// isSessionUnavailable indica falhas que se resolvem com a reconexão da sessão
+func isSessionUnavailable(err error) bool {
+	var businessErr *common.BusinessRuleError
+	if errors.As(err, &businessErr) {
+		return businessErr.Rule == "session_not_connected" || businessErr.Rule == "session_not_authenticated"
+	}
+	return false
+}
+
+// isRetryable separa falhas transitórias de payloads que nunca serão enviados
+func isRetryable(err error) bool {
+	switch {
+	case errors.Is(err, common.ErrInvalidInput),
+		errors.Is(err, common.ErrMediaNotFound),
+		errors.Is(err, common.ErrMediaTooLarge),
+		errors.Is(err, common.ErrMessageNotFound),
+		common.IsValidationError(err):
+		return false
+	}
+	return true
+}
@@ -0,0 +1,74 @@
+package scheduler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"zpmeow/internal/application/common"
+)
+
+func TestParseSendAt(t *testing.T) {
+	tests := []struct {
+		name         string
+		sendAt       string
+		timezone     string
+		want         time.Time
+		wantTimezone string
+		wantErr      bool
+	}{
+		{
+			name:         "RFC3339 keeps its own offset",
+			sendAt:       "2099-01-02T15:04:05-03:00",
+			timezone:     "Asia/Tokyo",
+			want:         time.Date(2099, 1, 2, 18, 4, 5, 0, time.UTC),
+			wantTimezone: "Asia/Tokyo",
+		},
+		{
+			name:         "local time defaults to UTC",
+			sendAt:       "2099-01-02T15:04:05",
+			want:         time.Date(2099, 1, 2, 15, 4, 5, 0, time.UTC),
+			wantTimezone: "UTC",
+		},
+		{
+			name:         "local time in the given timezone",
+			sendAt:       "2099-01-02T15:04",
+			timezone:     "America/Sao_Paulo",
+			want:         time.Date(2099, 1, 2, 18, 4, 0, 0, time.UTC),
+			wantTimezone: "America/Sao_Paulo",
+		},
+		{
+			name:         "space separator and surrounding spaces",
+			sendAt:       " 2099-07-01 09:30 ",
+			timezone:     " Europe/Lisbon ",
+			want:         time.Date(2099, 7, 1, 8, 30, 0, 0, time.UTC),
+			wantTimezone: "Europe/Lisbon",
+		},
+		{name: "empty", sendAt: "  ", wantErr: true},
+		{name: "invalid timezone", sendAt: "2099-01-02T15:04", timezone: "Mars/Olympus", wantErr: true},
+		{name: "invalid format", sendAt: "02/01/2099 15:04", wantErr: true},
+		{name: "past", sendAt: "2000-01-01T00:00:00Z", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, timezone, err := ParseSendAt(tt.sendAt, tt.timezone)
+			if tt.wantErr {
+				if !errors.Is(err, common.ErrInvalidInput) {
+					t.Fatalf("ParseSendAt(%q, %q) error = %v, want ErrInvalidInput", tt.sendAt, tt.timezone, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSendAt(%q, %q) error = %v", tt.sendAt, tt.timezone, err)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseSendAt(%q, %q) = %s, want %s", tt.sendAt, tt.timezone, got.UTC(), tt.want)
+			}
+			if timezone != tt.wantTimezone {
+				t.Errorf("timezone = %q, want %q", timezone, tt.wantTimezone)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+
+	"zpmeow/internal/application/common"
+)
+
+// maxMediaRedirects limita os redirecionamentos seguidos ao baixar mídia de uma URL
+const maxMediaRedirects = 5
+
+// blockedPrefixes são as faixas internas que os métodos de netip.Addr não cobrem
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"), // CGNAT, inclui o metadata da Alibaba Cloud (100.100.100.200)
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"), // NAT64, pode apontar para um IPv4 interno
+}
+
+// newMediaHTTPClient cria o cliente usado para baixar mídia de URLs informadas nas requisições. O endereço
+// é verificado no dial, depois da resolução de DNS, então um nome que resolve para um IP interno também é
+// recusado; cada redirecionamento passa pela mesma verificação.
+func newMediaHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   rejectInternalAddress,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// Com proxy o dial seria para o proxy, e o destino não passaria pela verificação
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:       timeout,
+		Transport:     transport,
+		CheckRedirect: checkMediaRedirect,
+	}
+}
+
+func checkMediaRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxMediaRedirects {
+		return fmt.Errorf("%w: media URL redirected more than %d times", common.ErrInvalidInput, maxMediaRedirects)
+	}
+	return validateMediaURL(req.URL)
+}
+
+// validateMediaURL recusa esquemas que não sejam http(s) e IPs internos escritos na própria URL
+func validateMediaURL(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%w: unsupported media URL scheme %q", common.ErrInvalidInput, u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return fmt.Errorf("%w: media URL without host", common.ErrInvalidInput)
+	}
+	if addr, err := netip.ParseAddr(u.Hostname()); err == nil && isBlockedAddr(addr) {
+		return fmt.Errorf("%w: media URL points to an internal address", common.ErrInvalidInput)
+	}
+	return nil
+}
+
+func rejectInternalAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: invalid media address %q", common.ErrInvalidInput, address)
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("%w: invalid media address %q", common.ErrInvalidInput, address)
+	}
+	if isBlockedAddr(addr) {
+		return fmt.Errorf("%w: media URL resolves to an internal address", common.ErrInvalidInput)
+	}
+
+	return nil
+}
+
+// isBlockedAddr indica se o endereço é loopback, privado, link-local (inclui o metadata 169.254.169.254),
+// multicast, não especificado ou de uma das faixas de blockedPrefixes
+func isBlockedAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() || addr.IsUnspecified() {
+		return true
+	}
+
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
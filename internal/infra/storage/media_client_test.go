@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"testing"
+
+	"zpmeow/internal/application/common"
+	"zpmeow/internal/application/ports"
+)
+
+func TestIsBlockedAddr(t *testing.T) {
+	tests := []struct {
+		addr    string
+		blocked bool
+	}{
+		{"127.0.0.1", true},
+		{"::1", true},
+		{"10.1.2.3", true},
+		{"172.16.0.1", true},
+		{"192.168.1.10", true},
+		{"169.254.169.254", true},
+		{"fd00:ec2::254", true},
+		{"fe80::1", true},
+		{"100.100.100.200", true},
+		{"0.0.0.0", true},
+		{"::", true},
+		{"224.0.0.1", true},
+		{"::ffff:127.0.0.1", true},
+		{"64:ff9b::a00:1", true},
+		{"8.8.8.8", false},
+		{"157.240.0.35", false},
+		{"2a03:2880:f12f:83:face:b00c:0:25de", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			if got := isBlockedAddr(netip.MustParseAddr(tt.addr)); got != tt.blocked {
+				t.Errorf("isBlockedAddr(%s) = %v, want %v", tt.addr, got, tt.blocked)
+			}
+		})
+	}
+}
+
+func TestValidateMediaURL(t *testing.T) {
+	tests := []struct {
+		rawURL string
+		valid  bool
+	}{
+		{"https://example.com/image.jpg", true},
+		{"http://example.com:8080/file", true},
+		{"ftp://example.com/file", false},
+		{"file:///etc/passwd", false},
+		{"http://127.0.0.1:3001/rails/active_storage/x", false},
+		{"http://[::1]/x", false},
+		{"http://169.254.169.254/latest/meta-data/", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rawURL, func(t *testing.T) {
+			u, err := url.Parse(tt.rawURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = validateMediaURL(u)
+			if (err == nil) != tt.valid {
+				t.Errorf("validateMediaURL(%s) error = %v, want valid %v", tt.rawURL, err, tt.valid)
+			}
+			if err != nil && !errors.Is(err, common.ErrInvalidInput) {
+				t.Errorf("validateMediaURL(%s) error = %v, want ErrInvalidInput", tt.rawURL, err)
+			}
+		})
+	}
+}
+
+// Um nome que resolve para loopback precisa ser recusado no dial, depois da resolução de DNS
+func TestFetchRejectsHostResolvingToLoopback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("internal"))
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	rawURL := "http://localhost:" + u.Port() + "/secret"
+
+	resolver := NewMediaResolver(nil, 1024)
+	err := resolver.fetch(context.Background(), rawURL, &ports.ResolvedMedia{})
+	if !errors.Is(err, common.ErrInvalidInput) {
+		t.Fatalf("fetch(%s) error = %v, want ErrInvalidInput", rawURL, err)
+	}
+}
+
+func TestCheckMediaRedirect(t *testing.T) {
+	target, _ := http.NewRequest(http.MethodGet, "https://example.com/next", nil)
+	internal, _ := http.NewRequest(http.MethodGet, "http://10.0.0.5/admin", nil)
+
+	if err := checkMediaRedirect(target, make([]*http.Request, 1)); err != nil {
+		t.Errorf("redirect to a public host: %v", err)
+	}
+	if err := checkMediaRedirect(internal, make([]*http.Request, 1)); err == nil {
+		t.Error("redirect to an internal address was allowed")
+	}
+	if err := checkMediaRedirect(target, make([]*http.Request, maxMediaRedirects)); err == nil {
+		t.Error("redirect past the limit was allowed")
+	}
+}
@@ -0,0 +1,225 @@
+package storage
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"zpmeow/internal/application/common"
+	"zpmeow/internal/application/ports"
+)
+
+var mediaIDPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// defaultMimeTypes é usado quando o tipo detectado não condiz com o tipo de mídia do envio
+var defaultMimeTypes = map[string]string{
+	"image":    "image/jpeg",
+	"video":    "video/mp4",
+	"audio":    "audio/mpeg",
+	"document": "application/octet-stream",
+	"sticker":  "image/webp",
+}
+
+// MediaResolver resolve a mídia dos envios a partir de bytes, data URL, base64, URL http(s) ou media ID
+type MediaResolver struct {
+	mediaManager ports.MediaManager
+	maxSize      int64
+	httpClient   *http.Client
+}
+
+func NewMediaResolver(mediaManager ports.MediaManager, maxSize int64) *MediaResolver {
+	return &MediaResolver{
+		mediaManager: mediaManager,
+		maxSize:      maxSize,
+		httpClient:   newMediaHTTPClient(60 * time.Second),
+	}
+}
+
+func (r *MediaResolver) Resolve(ctx context.Context, sessionID, mediaType string, source ports.MediaSource) (*ports.ResolvedMedia, error) {
+	if source.IsEmpty() {
+		return nil, fmt.Errorf("%w: %s is required", common.ErrInvalidInput, mediaType)
+	}
+
+	resolved := &ports.ResolvedMedia{FileName: source.FileName}
+	var err error
+
+	reference := strings.TrimSpace(source.Reference)
+	switch {
+	case len(source.Data) > 0:
+		resolved.Data = source.Data
+	case strings.HasPrefix(reference, "http://"), strings.HasPrefix(reference, "https://"):
+		err = r.fetch(ctx, reference, resolved)
+	case strings.HasPrefix(reference, "data:"):
+		err = decodeDataURL(reference, resolved)
+	case mediaIDPattern.MatchString(reference):
+		err = r.loadStored(ctx, sessionID, reference, resolved)
+	default:
+		resolved.Data, err = base64.StdEncoding.DecodeString(reference)
+		if err != nil {
+			err = fmt.Errorf("%w: failed to decode base64 data: %v", common.ErrInvalidInput, err)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resolved.Data) == 0 {
+		return nil, fmt.Errorf("%w: %s is empty", common.ErrInvalidInput, mediaType)
+	}
+	if err := r.checkSize(int64(len(resolved.Data))); err != nil {
+		return nil, err
+	}
+
+	if source.MimeType != "" {
+		resolved.MimeType = source.MimeType
+	}
+	resolved.MimeType = resolveMimeType(mediaType, resolved)
+
+	return resolved, nil
+}
+
+func (r *MediaResolver) checkSize(size int64) error {
+	if r.maxSize > 0 && size > r.maxSize {
+		return fmt.Errorf("%w: %d bytes exceeds the limit of %d bytes", common.ErrMediaTooLarge, size, r.maxSize)
+	}
+	return nil
+}
+
+// fetch baixa a mídia da URL respeitando o limite de tamanho, sem carregar mais que maxSize+1 bytes
+func (r *MediaResolver) fetch(ctx context.Context, rawURL string, resolved *ports.ResolvedMedia) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("%w: invalid media URL: %v", common.ErrInvalidInput, err)
+	}
+	if err := validateMediaURL(req.URL); err != nil {
+		return err
+	}
+
+	req.Header.Set("User-Agent", "zpmeow/1.0")
+	req.Header.Set("Accept", "*/*")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download media from URL: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download media from URL: status %d", resp.StatusCode)
+	}
+	if err := r.checkSize(resp.ContentLength); err != nil {
+		return err
+	}
+
+	body := io.Reader(resp.Body)
+	if r.maxSize > 0 {
+		body = io.LimitReader(resp.Body, r.maxSize+1)
+	}
+
+	resolved.Data, err = io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("failed to read media from URL: %w", err)
+	}
+
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" {
+		resolved.MimeType = contentType
+	}
+	if resolved.FileName == "" {
+		if parsed, err := url.Parse(rawURL); err == nil {
+			if name := path.Base(parsed.Path); name != "." && name != "/" {
+				resolved.FileName = name
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *MediaResolver) loadStored(ctx context.Context, sessionID, mediaID string, resolved *ports.ResolvedMedia) error {
+	if r.mediaManager == nil {
+		return fmt.Errorf("media storage not configured")
+	}
+
+	data, info, err := r.mediaManager.GetMediaContent(ctx, sessionID, mediaID)
+	if err != nil {
+		return err
+	}
+
+	resolved.Data = data
+	if mimeType, ok := info["mime_type"].(string); ok {
+		resolved.MimeType = mimeType
+	}
+	if fileName, ok := info["filename"].(string); ok && resolved.FileName == "" {
+		resolved.FileName = fileName
+	}
+
+	return nil
+}
+
+func decodeDataURL(dataURL string, resolved *ports.ResolvedMedia) error {
+	commaIndex := strings.Index(dataURL, ",")
+	if commaIndex == -1 {
+		return fmt.Errorf("%w: invalid data URL format", common.ErrInvalidInput)
+	}
+
+	header := dataURL[len("data:"):commaIndex]
+	if mediaType := strings.TrimSuffix(header, ";base64"); mediaType != "" {
+		resolved.MimeType = mediaType
+	}
+
+	data, err := base64.StdEncoding.DecodeString(dataURL[commaIndex+1:])
+	if err != nil {
+		return fmt.Errorf("%w: failed to decode base64 data: %v", common.ErrInvalidInput, err)
+	}
+
+	resolved.Data = data
+	return nil
+}
+
+// resolveMimeType escolhe entre o tipo declarado, o detectado pelo conteúdo e a extensão do arquivo,
+// garantindo que o resultado seja compatível com o tipo de mídia do envio
+func resolveMimeType(mediaType string, resolved *ports.ResolvedMedia) string {
+	candidates := []string{resolved.MimeType, http.DetectContentType(resolved.Data)}
+	if ext := path.Ext(resolved.FileName); ext != "" {
+		candidates = append(candidates, mime.TypeByExtension(ext))
+	}
+
+	for _, candidate := range candidates {
+		base, _, err := mime.ParseMediaType(candidate)
+		if err != nil || base == "application/octet-stream" {
+			continue
+		}
+		if mimeMatchesMediaType(mediaType, base) {
+			if mediaType == "audio" && (base == "audio/ogg" || base == "application/ogg") {
+				return "audio/ogg; codecs=opus"
+			}
+			return base
+		}
+	}
+
+	return defaultMimeTypes[mediaType]
+}
+
+func mimeMatchesMediaType(mediaType, mimeType string) bool {
+	switch mediaType {
+	case "image":
+		return strings.HasPrefix(mimeType, "image/")
+	case "video":
+		return strings.HasPrefix(mimeType, "video/")
+	case "audio":
+		return strings.HasPrefix(mimeType, "audio/") || mimeType == "application/ogg"
+	case "sticker":
+		return mimeType == "image/webp"
+	case "document":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,286 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"zpmeow/internal/application/common"
+)
+
+type S3Options struct {
+	Endpoint  string // host[:port], sem esquema (ex: localhost:9000)
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	UseSSL    bool
+	PathStyle bool // MinIO e afins usam path-style (endpoint/bucket/key)
+}
+
+// S3Storage grava as mídias em um bucket compatível com S3, assinando as requisições com SigV4
+type S3Storage struct {
+	opts       S3Options
+	httpClient *http.Client
+}
+
+func NewS3Storage(opts S3Options) (*S3Storage, error) {
+	opts.Endpoint = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(opts.Endpoint, "https://"), "http://"), "/")
+	if opts.Endpoint == "" || opts.Bucket == "" {
+		return nil, fmt.Errorf("S3 endpoint and bucket are required")
+	}
+	if opts.Region == "" {
+		opts.Region = "us-east-1"
+	}
+
+	s := &S3Storage{
+		opts:       opts,
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := s.ensureBucket(ctx); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *S3Storage) Driver() string {
+	return DriverS3
+}
+
+func (s *S3Storage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	headers := map[string]string{}
+	if contentType != "" {
+		headers["Content-Type"] = contentType
+	}
+
+	resp, err := s.do(ctx, http.MethodPut, key, data, headers)
+	if err != nil {
+		return fmt.Errorf("failed to put object %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to put object %s: %s", key, readS3Error(resp))
+	}
+
+	return nil
+}
+
+func (s *S3Storage) Get(ctx context.Context, key string) ([]byte, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read object %s: %w", key, err)
+		}
+		return data, nil
+	case http.StatusNotFound:
+		return nil, common.ErrMediaNotFound
+	default:
+		return nil, fmt.Errorf("failed to get object %s: %s", key, readS3Error(resp))
+	}
+}
+
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete object %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	// S3 responde 204 mesmo quando o objeto não existe
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to delete object %s: %s", key, readS3Error(resp))
+	}
+
+	return nil
+}
+
+func (s *S3Storage) Exists(ctx context.Context, key string) (bool, error) {
+	resp, err := s.do(ctx, http.MethodHead, key, nil, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to head object %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to head object %s: status %d", key, resp.StatusCode)
+	}
+}
+
+// ensureBucket cria o bucket na primeira execução (útil com MinIO local)
+func (s *S3Storage) ensureBucket(ctx context.Context) error {
+	resp, err := s.do(ctx, http.MethodHead, "", nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to reach S3 endpoint %s: %w", s.opts.Endpoint, err)
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+	default:
+		return fmt.Errorf("failed to access bucket %s: status %d", s.opts.Bucket, resp.StatusCode)
+	}
+
+	var body []byte
+	if s.opts.Region != "us-east-1" {
+		body = []byte(fmt.Sprintf(`<CreateBucketConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><LocationConstraint>%s</LocationConstraint></CreateBucketConfiguration>`, s.opts.Region))
+	}
+
+	resp, err = s.do(ctx, http.MethodPut, "", body, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create bucket %s: %w", s.opts.Bucket, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusConflict {
+		return fmt.Errorf("failed to create bucket %s: %s", s.opts.Bucket, readS3Error(resp))
+	}
+
+	return nil
+}
+
+func (s *S3Storage) do(ctx context.Context, method, key string, body []byte, headers map[string]string) (*http.Response, error) {
+	scheme := "http"
+	if s.opts.UseSSL {
+		scheme = "https"
+	}
+
+	host := s.opts.Endpoint
+	path := "/"
+	if s.opts.PathStyle {
+		path += s.opts.Bucket
+		if key != "" {
+			path += "/" + key
+		}
+	} else {
+		host = s.opts.Bucket + "." + s.opts.Endpoint
+		path += key
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, scheme+"://"+host+encodeS3Path(path), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = int64(len(body))
+
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	s.sign(req, host, encodeS3Path(path), body, time.Now().UTC())
+
+	return s.httpClient.Do(req)
+}
+
+// sign aplica a assinatura AWS Signature Version 4 (sem query string)
+func (s *S3Storage) sign(req *http.Request, host, canonicalURI string, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	shortDate := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Host = host
+	req.Header.Set("Host", host)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, strings.ToLower(name))
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(req.Header.Get(name)) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI,
+		"",
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := shortDate + "/" + s.opts.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := deriveSigningKey(s.opts.SecretKey, shortDate, s.opts.Region, "s3")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.opts.AccessKey, scope, signedHeaders, signature))
+}
+
+// deriveSigningKey deriva a chave de assinatura do SigV4 para a data (AAAAMMDD), região e serviço
+func deriveSigningKey(secretKey, shortDate, region, service string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secretKey), shortDate)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	return hmacSHA256(key, "aws4_request")
+}
+
+// encodeS3Path codifica o caminho conforme a RFC 3986 (exigido pelo SigV4), preservando as barras
+func encodeS3Path(path string) string {
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' || c == '/' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func readS3Error(resp *http.Response) string {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Sprintf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"zpmeow/internal/application/ports"
+	"zpmeow/internal/config"
+)
+
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+// NewMediaStorage cria o backend de storage configurado em STORAGE_DRIVER
+func NewMediaStorage(cfg config.StorageConfigProvider) (ports.MediaStorage, error) {
+	switch cfg.GetDriver() {
+	case DriverLocal:
+		return NewLocalStorage(cfg.GetLocalPath())
+	case DriverS3:
+		return NewS3Storage(S3Options{
+			Endpoint:  cfg.GetS3Endpoint(),
+			Region:    cfg.GetS3Region(),
+			Bucket:    cfg.GetS3Bucket(),
+			AccessKey: cfg.GetS3AccessKey(),
+			SecretKey: cfg.GetS3SecretKey(),
+			UseSSL:    cfg.GetS3UseSSL(),
+			PathStyle: cfg.GetS3PathStyle(),
+		})
+	default:
+		return nil, fmt.Errorf("unknown storage driver: %s", cfg.GetDriver())
+	}
+}
+
+// ContentID retorna o identificador endereçado por conteúdo (SHA-256 em hex)
+func ContentID(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// ContentKey distribui os objetos em subdiretórios pelos primeiros bytes do hash (ab/cd/abcd...)
+func ContentKey(contentID string) string {
+	if len(contentID) < 4 {
+		return contentID
+	}
+	return contentID[:2] + "/" + contentID[2:4] + "/" + contentID
+}
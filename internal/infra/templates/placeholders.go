@@ -0,0 +1,100 @@
+package templates
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// placeholderPattern reconhece {{variavel}}, com espaços opcionais dentro das chaves
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]+)\s*\}\}`)
+
+// Placeholders lista as variáveis usadas no texto, sem repetição e na ordem em que aparecem
+func Placeholders(text string) []string {
+	seen := make(map[string]bool)
+	var variables []string
+	for _, match := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			variables = append(variables, match[1])
+		}
+	}
+	return variables
+}
+
+// Render substitui os placeholders pelos valores informados; placeholders sem valor ficam vazios
+func Render(text string, variables map[string]string) string {
+	return placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		return variables[placeholderPattern.FindStringSubmatch(placeholder)[1]]
+	})
+}
+
+// Missing retorna, em ordem alfabética, as variáveis exigidas que não foram informadas
+func Missing(required []string, variables map[string]string) []string {
+	var missing []string
+	for _, name := range required {
+		if _, ok := variables[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// ContentPlaceholders lista as variáveis usadas em qualquer string de um conteúdo JSON
+func ContentPlaceholders(content []byte) ([]string, error) {
+	var value interface{}
+	if err := json.Unmarshal(content, &value); err != nil {
+		return nil, fmt.Errorf("invalid template content: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var variables []string
+	walkStrings(value, func(text string) string {
+		for _, name := range Placeholders(text) {
+			if !seen[name] {
+				seen[name] = true
+				variables = append(variables, name)
+			}
+		}
+		return text
+	})
+
+	return variables, nil
+}
+
+// RenderContent aplica Render em todas as strings de um conteúdo JSON, preservando a estrutura
+func RenderContent(content []byte, variables map[string]string) (map[string]interface{}, error) {
+	var value map[string]interface{}
+	if err := json.Unmarshal(content, &value); err != nil {
+		return nil, fmt.Errorf("invalid template content: %w", err)
+	}
+
+	for key, field := range value {
+		value[key] = walkStrings(field, func(text string) string {
+			return Render(text, variables)
+		})
+	}
+
+	return value, nil
+}
+
+// walkStrings percorre objetos e arrays aplicando fn a cada string
+func walkStrings(value interface{}, fn func(string) string) interface{} {
+	switch v := value.(type) {
+	case string:
+		return fn(v)
+	case map[string]interface{}:
+		for key, field := range v {
+			v[key] = walkStrings(field, fn)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = walkStrings(item, fn)
+		}
+		return v
+	}
+	return value
+}
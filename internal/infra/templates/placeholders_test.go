@@ -0,0 +1,100 @@
+package templates
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlaceholders(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "none", text: "Hello there", want: nil},
+		{name: "single", text: "Hi {{name}}", want: []string{"name"}},
+		{name: "order of appearance without repetition", text: "{{b}} {{a}} {{b}} {{c}}", want: []string{"b", "a", "c"}},
+		{name: "spaces inside braces", text: "{{ name }} {{order_id}} {{  code2 }}", want: []string{"name", "order_id", "code2"}},
+		{name: "invalid names are ignored", text: "{{first-name}} {{ }} {{na me}} {name}", want: nil},
+		{name: "adjacent placeholders", text: "{{a}}{{b}}", want: []string{"a", "b"}},
+		{name: "extra braces", text: "{{{a}}}", want: []string{"a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Placeholders(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Placeholders(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	variables := map[string]string{"name": "Ana", "code": "{{name}}"}
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "substitutes", text: "Hi {{name}}!", want: "Hi Ana!"},
+		{name: "spaces inside braces", text: "Hi {{ name }}!", want: "Hi Ana!"},
+		{name: "missing renders empty", text: "Hi {{surname}}!", want: "Hi !"},
+		{name: "values are not rendered again", text: "{{code}}", want: "{{name}}"},
+		{name: "invalid placeholders are kept", text: "{{first-name}} {name}", want: "{{first-name}} {name}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Render(tt.text, variables); got != tt.want {
+				t.Errorf("Render(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMissing(t *testing.T) {
+	got := Missing([]string{"name", "order", "code"}, map[string]string{"name": "Ana", "code": ""})
+	if want := []string{"order"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Missing() = %v, want %v", got, want)
+	}
+
+	got = Missing([]string{"z", "a"}, nil)
+	if want := []string{"a", "z"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Missing() = %v, want %v", got, want)
+	}
+}
+
+func TestContentPlaceholdersAndRenderContent(t *testing.T) {
+	content := []byte(`{"body":"Hi {{name}}","buttons":[{"text":"Track {{order}}"}],"count":2,"caption":"{{name}}"}`)
+
+	variables, err := ContentPlaceholders(content)
+	if err != nil {
+		t.Fatalf("ContentPlaceholders() error = %v", err)
+	}
+	sorted := map[string]bool{}
+	for _, v := range variables {
+		sorted[v] = true
+	}
+	if len(variables) != 2 || !sorted["name"] || !sorted["order"] {
+		t.Errorf("ContentPlaceholders() = %v, want name and order", variables)
+	}
+
+	rendered, err := RenderContent(content, map[string]string{"name": "Ana", "order": "#42"})
+	if err != nil {
+		t.Fatalf("RenderContent() error = %v", err)
+	}
+	want := map[string]interface{}{
+		"body":    "Hi Ana",
+		"buttons": []interface{}{map[string]interface{}{"text": "Track #42"}},
+		"count":   float64(2),
+		"caption": "Ana",
+	}
+	if !reflect.DeepEqual(rendered, want) {
+		t.Errorf("RenderContent() = %v, want %v", rendered, want)
+	}
+
+	if _, err := ContentPlaceholders([]byte(`{"body":`)); err == nil {
+		t.Error("ContentPlaceholders() accepted invalid JSON")
+	}
+}
@@ -0,0 +1,248 @@
+package templates
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"zpmeow/internal/application/common"
+	"zpmeow/internal/application/payloads"
+	"zpmeow/internal/application/ports"
+	"zpmeow/internal/domain/session"
+	"zpmeow/internal/infra/database/models"
+	"zpmeow/internal/infra/database/repository"
+	"zpmeow/internal/infra/dispatch"
+	"zpmeow/internal/infra/logging"
+)
+
+var (
+	ErrNotFound  = errors.New("message template not found")
+	ErrNameTaken = errors.New("message template name already in use")
+)
+
+// phoneVariable está sempre disponível na renderização e recebe o destinatário do envio
+const phoneVariable = "phone"
+
+// SupportedTypes lista os tipos de mensagem que podem virar template
+func SupportedTypes() []string {
+	return []string{"text", "media", "buttons", "list", "poll"}
+}
+
+// Input descreve o conteúdo de um template: o corpo do endpoint /message/send/{type}, sem o phone
+type Input struct {
+	Name    string
+	Type    string
+	Content json.RawMessage
+}
+
+// Service guarda templates por sessão e envia mensagens renderizadas a partir deles
+type Service struct {
+	repo       *repository.MessageTemplateRepository
+	dispatcher *dispatch.Dispatcher
+	logger     logging.Logger
+}
+
+func NewService(repo *repository.MessageTemplateRepository, sessionRepo session.Repository, whatsappService ports.WhatsAppService, mediaResolver ports.MediaResolver) *Service {
+	return &Service{
+		repo:       repo,
+		dispatcher: dispatch.NewDispatcher(sessionRepo, whatsappService, mediaResolver, "templates"),
+		logger:     logging.GetLogger().Sub("templates"),
+	}
+}
+
+// Create valida e grava um novo template
+func (s *Service) Create(ctx context.Context, sessionID string, input Input) (*models.MessageTemplateModel, error) {
+	template, err := s.build(sessionID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureNameAvailable(ctx, sessionID, template.Name, ""); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, template); err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("Created %s template %q (session: %s)", template.MessageType, template.Name, sessionID)
+	return template, nil
+}
+
+// Update substitui o template; retorna ErrNotFound se ele não existir na sessão
+func (s *Service) Update(ctx context.Context, sessionID, id string, input Input) (*models.MessageTemplateModel, error) {
+	template, err := s.build(sessionID, input)
+	if err != nil {
+		return nil, err
+	}
+	template.ID = id
+
+	if err := s.ensureNameAvailable(ctx, sessionID, template.Name, id); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.Update(ctx, template)
+	if err != nil {
+		return nil, err
+	}
+	if updated == nil {
+		return nil, ErrNotFound
+	}
+
+	return updated, nil
+}
+
+// Get retorna um template da sessão, ou nil se não existir
+func (s *Service) Get(ctx context.Context, sessionID, id string) (*models.MessageTemplateModel, error) {
+	return s.repo.GetByID(ctx, sessionID, id)
+}
+
+// List retorna os templates da sessão ordenados por nome
+func (s *Service) List(ctx context.Context, sessionID, messageType string, limit, offset int) ([]*models.MessageTemplateModel, int, error) {
+	return s.repo.List(ctx, sessionID, messageType, limit, offset)
+}
+
+// Delete remove um template; retorna false se ele não existir
+func (s *Service) Delete(ctx context.Context, sessionID, id string) (bool, error) {
+	return s.repo.Delete(ctx, sessionID, id)
+}
+
+// Render monta o payload de envio do template para o destinatário: as variáveis são aplicadas
+// ao conteúdo e phone e os campos de contexto (resposta, menções, encaminhada) são adicionados.
+// Retorna o tipo de mensagem e o payload no formato do endpoint /message/send/{type}.
+func (s *Service) Render(ctx context.Context, sessionID, id, phone string, variables map[string]string, sendContext payloads.SendContextFields) (string, []byte, error) {
+	template, err := s.repo.GetByID(ctx, sessionID, id)
+	if err != nil {
+		return "", nil, err
+	}
+	if template == nil {
+		return "", nil, ErrNotFound
+	}
+
+	values := make(map[string]string, len(variables)+1)
+	for name, value := range variables {
+		values[name] = value
+	}
+	if _, ok := values[phoneVariable]; !ok {
+		values[phoneVariable] = phone
+	}
+
+	if missing := Missing(template.Variables, values); len(missing) > 0 {
+		return "", nil, fmt.Errorf("%w: missing template variables: %s", common.ErrInvalidInput, strings.Join(missing, ", "))
+	}
+
+	content, err := RenderContent(template.Content, values)
+	if err != nil {
+		return "", nil, err
+	}
+
+	extra, err := json.Marshal(sendContext)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to encode send options: %w", err)
+	}
+	if err := json.Unmarshal(extra, &content); err != nil {
+		return "", nil, fmt.Errorf("failed to encode send options: %w", err)
+	}
+	content[phoneVariable] = phone
+
+	payload, err := json.Marshal(content)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to encode rendered template: %w", err)
+	}
+
+	if _, _, err := dispatch.Decode(template.MessageType, payload); err != nil {
+		return "", nil, err
+	}
+
+	return template.MessageType, payload, nil
+}
+
+// Send renderiza o template e envia a mensagem, retornando o tipo enviado e o ID gerado pelo WhatsApp
+func (s *Service) Send(ctx context.Context, sessionID, id, phone string, variables map[string]string, sendContext payloads.SendContextFields) (string, string, error) {
+	messageType, payload, err := s.Render(ctx, sessionID, id, phone, variables, sendContext)
+	if err != nil {
+		return "", "", err
+	}
+
+	messageID, err := s.dispatcher.Send(ctx, sessionID, messageType, payload)
+	if err != nil {
+		return messageType, "", err
+	}
+
+	return messageType, messageID, nil
+}
+
+// build valida a entrada e extrai as variáveis do conteúdo
+func (s *Service) build(sessionID string, input Input) (*models.MessageTemplateModel, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", common.ErrInvalidInput)
+	}
+	if len(name) > 255 {
+		return nil, fmt.Errorf("%w: name must not exceed 255 characters", common.ErrInvalidInput)
+	}
+
+	if !isSupportedType(input.Type) {
+		return nil, fmt.Errorf("%w: unsupported template type %q, must be one of: %s",
+			common.ErrInvalidInput, input.Type, strings.Join(SupportedTypes(), ", "))
+	}
+
+	var content map[string]interface{}
+	if err := json.Unmarshal(input.Content, &content); err != nil || content == nil {
+		return nil, fmt.Errorf("%w: content must be a JSON object", common.ErrInvalidInput)
+	}
+	if _, ok := content[phoneVariable]; ok {
+		return nil, fmt.Errorf("%w: content must not include phone, it is given when sending", common.ErrInvalidInput)
+	}
+
+	// Valida o conteúdo como um envio real; o phone é preenchido só para a validação
+	content[phoneVariable] = "{{phone}}"
+	sample, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode template content: %w", err)
+	}
+	if _, _, err := dispatch.Decode(input.Type, sample); err != nil {
+		return nil, err
+	}
+	delete(content, phoneVariable)
+
+	normalized, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode template content: %w", err)
+	}
+
+	variables, err := ContentPlaceholders(normalized)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", common.ErrInvalidInput, err)
+	}
+
+	return &models.MessageTemplateModel{
+		SessionId:   sessionID,
+		Name:        name,
+		MessageType: input.Type,
+		Content:     normalized,
+		Variables:   models.StringArray(variables),
+	}, nil
+}
+
+func (s *Service) ensureNameAvailable(ctx context.Context, sessionID, name, currentID string) error {
+	existing, err := s.repo.GetByName(ctx, sessionID, name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != currentID {
+		return fmt.Errorf("%w: %s", ErrNameTaken, name)
+	}
+	return nil
+}
+
+func isSupportedType(messageType string) bool {
+	for _, supported := range SupportedTypes() {
+		if messageType == supported {
+			return true
+		}
+	}
+	return false
+}
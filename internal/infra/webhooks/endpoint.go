@@ -0,0 +1,99 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"zpmeow/internal/infra/database/models"
+)
+
+// EndpointStore resolves the webhook endpoint a delivery targets and keeps its delivery stats.
+// Implemented by repository.WebhookRepository.
+type EndpointStore interface {
+	GetByID(ctx context.Context, id string) (*models.WebhookModel, error)
+	GetBySessionID(ctx context.Context, sessionID string) (*models.WebhookModel, error)
+	ListActiveForEvent(ctx context.Context, sessionID, event string) ([]*models.WebhookModel, error)
+	RecordDelivery(ctx context.Context, id string, delivered bool, statusCode *int, lastError string) error
+}
+
+// SigningSecrets returns the secrets deliveries to endpoint must be signed with, current first.
+// The previous secret is included until its grace window ends.
+func SigningSecrets(endpoint *models.WebhookModel, now time.Time) []string {
+	if endpoint == nil || endpoint.Secret == "" {
+		return nil
+	}
+
+	secrets := []string{endpoint.Secret}
+	if endpoint.PreviousSecret != nil && endpoint.PreviousSecretExpiresAt != nil &&
+		now.Before(*endpoint.PreviousSecretExpiresAt) {
+		secrets = append(secrets, *endpoint.PreviousSecret)
+	}
+
+	return secrets
+}
+
+// EndpointHeaders returns the custom headers configured on endpoint
+func EndpointHeaders(endpoint *models.WebhookModel) map[string]string {
+	if endpoint == nil || len(endpoint.Headers) == 0 {
+		return nil
+	}
+
+	headers := make(map[string]string, len(endpoint.Headers))
+	for key, value := range endpoint.Headers {
+		if s, ok := value.(string); ok {
+			headers[key] = s
+		} else {
+			headers[key] = fmt.Sprint(value)
+		}
+	}
+
+	return headers
+}
+
+// resolveEndpoint loads the endpoint by ID, falling back to the session's default endpoint
+// for deliveries that predate multiple endpoints
+func resolveEndpoint(ctx context.Context, store EndpointStore, sessionID string, webhookID *string) (*models.WebhookModel, error) {
+	if store == nil {
+		return nil, nil
+	}
+	if webhookID != nil && *webhookID != "" {
+		return store.GetByID(ctx, *webhookID)
+	}
+	if sessionID == "" {
+		return nil, nil
+	}
+	return store.GetBySessionID(ctx, sessionID)
+}
+
+// buildHeaders merges custom endpoint headers, the given system headers and the signature headers.
+// System and signature headers always win over custom ones.
+func buildHeaders(endpoint *models.WebhookModel, system map[string]string, body []byte, now time.Time) map[string]string {
+	headers := make(map[string]string)
+	for key, value := range EndpointHeaders(endpoint) {
+		headers[key] = value
+	}
+	for key, value := range system {
+		headers[key] = value
+	}
+	for key, value := range SignatureHeaders(SigningSecrets(endpoint, now), body, now) {
+		headers[key] = value
+	}
+	return headers
+}
+
+// deliveryOutcome extracts the HTTP status and error message of a delivery attempt
+func deliveryOutcome(err error) (*int, string) {
+	if err == nil {
+		return nil, ""
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		code := httpErr.StatusCode
+		return &code, err.Error()
+	}
+
+	return nil, err.Error()
+}
@@ -0,0 +1,97 @@
+package webhooks
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"zpmeow/internal/infra/database/models"
+)
+
+// SchemaVersion is the version of the event envelope and of the typed payloads.
+// Adding fields keeps the version; renaming or removing fields requires a new one.
+const SchemaVersion = "1"
+
+// Envelope is the documented shape of every event delivered to endpoints using
+// the v1 payload format
+type Envelope struct {
+	ID            string      `json:"id"`
+	Type          string      `json:"type"`
+	SchemaVersion string      `json:"schema_version"`
+	SessionID     string      `json:"session_id"`
+	Timestamp     time.Time   `json:"timestamp"`
+	Data          interface{} `json:"data"`
+}
+
+// Event is an event ready to be delivered in any payload format: Data is the
+// stable typed payload and Raw is what raw endpoints receive (the original
+// whatsmeow struct for WhatsApp events)
+type Event struct {
+	Type string
+	Data interface{}
+	Raw  interface{}
+}
+
+// NewEnvelope wraps data in a v1 envelope with a new unique ID
+func NewEnvelope(sessionID, event string, data interface{}) *Envelope {
+	return &Envelope{
+		ID:            uuid.NewString(),
+		Type:          event,
+		SchemaVersion: SchemaVersion,
+		SessionID:     sessionID,
+		Timestamp:     time.Now().UTC(),
+		Data:          data,
+	}
+}
+
+// LegacyPayload builds the payload used before the envelope existed
+func LegacyPayload(sessionID, event string, data interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"event":     event,
+		"sessionID": sessionID,
+		"timestamp": time.Now().Unix(),
+		"data":      data,
+	}
+}
+
+// Payloads builds the event once per format, so every v1 endpoint receives the same envelope ID
+type Payloads struct {
+	sessionID string
+	event     Event
+	envelope  *Envelope
+	raw       map[string]interface{}
+}
+
+func NewPayloads(sessionID string, event Event) *Payloads {
+	return &Payloads{sessionID: sessionID, event: event}
+}
+
+// Type returns the event type
+func (p *Payloads) Type() string {
+	return p.event.Type
+}
+
+// For returns the payload in the format chosen by the endpoint
+func (p *Payloads) For(endpoint *models.WebhookModel) interface{} {
+	if endpoint != nil && endpoint.PayloadFormat == models.WebhookPayloadFormatRaw {
+		if p.raw == nil {
+			raw := p.event.Raw
+			if raw == nil {
+				raw = p.event.Data
+			}
+			p.raw = LegacyPayload(p.sessionID, p.event.Type, raw)
+		}
+		return p.raw
+	}
+
+	return p.Envelope()
+}
+
+// Envelope returns the v1 envelope of the event, built once so the event stream and
+// the webhook endpoints share the same envelope ID
+func (p *Payloads) Envelope() *Envelope {
+	if p.envelope == nil {
+		p.envelope = NewEnvelope(p.sessionID, p.event.Type, p.event.Data)
+	}
+	return p.envelope
+}
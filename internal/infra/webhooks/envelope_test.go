@@ -0,0 +1,27 @@
+package webhooks
+
+import (
+	"testing"
+
+	"zpmeow/internal/infra/database/models"
+)
+
+func TestPayloadsShareEnvelope(t *testing.T) {
+	payloads := NewPayloads("session-a", Event{Type: "Message", Data: "typed", Raw: "raw"})
+
+	envelope := payloads.Envelope()
+	if envelope.Type != "Message" || envelope.SessionID != "session-a" || envelope.Data != "typed" {
+		t.Fatalf("Envelope() = %+v", envelope)
+	}
+
+	for _, endpoint := range []*models.WebhookModel{nil, {PayloadFormat: models.WebhookPayloadFormatV1}} {
+		if got, ok := payloads.For(endpoint).(*Envelope); !ok || got.ID != envelope.ID {
+			t.Errorf("For(%+v) = %+v, want the envelope %s", endpoint, got, envelope.ID)
+		}
+	}
+
+	raw, ok := payloads.For(&models.WebhookModel{PayloadFormat: models.WebhookPayloadFormatRaw}).(map[string]interface{})
+	if !ok || raw["data"] != "raw" || raw["event"] != "Message" {
+		t.Errorf("For(raw) = %v", raw)
+	}
+}
@@ -0,0 +1,353 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"zpmeow/internal/application/ports"
+	"zpmeow/internal/infra/database/models"
+	"zpmeow/internal/infra/database/repository"
+	"zpmeow/internal/infra/logging"
+)
+
+type QueueConfig struct {
+	Workers      int
+	BatchSize    int
+	PollInterval time.Duration
+	StaleAfter   time.Duration
+}
+
+func defaultQueueConfig() *QueueConfig {
+	return &QueueConfig{
+		Workers:      4,
+		BatchSize:    50,
+		PollInterval: 2 * time.Second,
+		StaleAfter:   5 * time.Minute,
+	}
+}
+
+// DeliveryQueue persists webhook deliveries in zpWebhookDeliveries and delivers
+// them from background workers, retrying with the RetryConfig backoff and moving
+// exhausted deliveries to zpWebhookDeadLetters.
+type DeliveryQueue struct {
+	repo          *repository.WebhookDeliveryRepository
+	httpClient    ports.HTTPClient
+	retryStrategy *RetryStrategy
+	endpoints     EndpointStore
+	config        *QueueConfig
+	logger        logging.Logger
+
+	jobs    chan *models.WebhookDeliveryModel
+	wake    chan struct{}
+	stop    chan struct{}
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	running bool
+}
+
+func NewDeliveryQueue(repo *repository.WebhookDeliveryRepository, httpClient ports.HTTPClient, endpoints EndpointStore, retryConfig *RetryConfig, queueConfig *QueueConfig) *DeliveryQueue {
+	if queueConfig == nil {
+		queueConfig = defaultQueueConfig()
+	}
+	defaults := defaultQueueConfig()
+	if queueConfig.Workers <= 0 {
+		queueConfig.Workers = defaults.Workers
+	}
+	if queueConfig.BatchSize <= 0 {
+		queueConfig.BatchSize = defaults.BatchSize
+	}
+	if queueConfig.PollInterval <= 0 {
+		queueConfig.PollInterval = defaults.PollInterval
+	}
+	if queueConfig.StaleAfter <= 0 {
+		queueConfig.StaleAfter = defaults.StaleAfter
+	}
+
+	return &DeliveryQueue{
+		repo:          repo,
+		httpClient:    httpClient,
+		retryStrategy: NewRetryStrategy(retryConfig),
+		endpoints:     endpoints,
+		config:        queueConfig,
+		logger:        logging.GetLogger().Sub("webhook-queue"),
+		jobs:          make(chan *models.WebhookDeliveryModel, queueConfig.BatchSize),
+		wake:          make(chan struct{}, 1),
+	}
+}
+
+// Enqueue persists a delivery so it survives restarts and receiver outages.
+// webhookID identifies the target endpoint; empty means the session's default endpoint.
+func (q *DeliveryQueue) Enqueue(ctx context.Context, sessionID, webhookID, webhookURL, event string, payload interface{}) (string, error) {
+	if webhookURL == "" {
+		return "", fmt.Errorf("webhooks: URL is empty")
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("webhooks: failed to marshal payload: %w", err)
+	}
+
+	delivery := &models.WebhookDeliveryModel{
+		SessionId: sessionID,
+		URL:       webhookURL,
+		Event:     event,
+		Payload:   body,
+	}
+	if webhookID != "" {
+		delivery.WebhookId = &webhookID
+	}
+
+	if err := q.repo.Enqueue(ctx, delivery); err != nil {
+		return "", fmt.Errorf("webhooks: failed to enqueue delivery: %w", err)
+	}
+
+	q.logger.Debugf("Enqueued webhook delivery %s for event %s (session: %s)", delivery.ID, event, sessionID)
+	q.notify()
+
+	return delivery.ID, nil
+}
+
+// Publish enqueues an application event (not originated from WhatsApp) for every
+// active endpoint of the session subscribed to it
+func (q *DeliveryQueue) Publish(ctx context.Context, sessionID, event string, data interface{}) error {
+	if q.endpoints == nil {
+		return fmt.Errorf("webhooks: endpoint store not configured")
+	}
+
+	endpoints, err := q.endpoints.ListActiveForEvent(ctx, sessionID, event)
+	if err != nil {
+		return fmt.Errorf("webhooks: failed to list endpoints for %s: %w", event, err)
+	}
+
+	payloads := NewPayloads(sessionID, Event{Type: event, Data: data})
+
+	var errs []error
+	for _, endpoint := range endpoints {
+		if _, err := q.Enqueue(ctx, sessionID, endpoint.ID, endpoint.URL, event, payloads.For(endpoint)); err != nil {
+			errs = append(errs, fmt.Errorf("endpoint %s: %w", endpoint.URL, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (q *DeliveryQueue) Start() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.running {
+		return
+	}
+
+	q.stop = make(chan struct{})
+	q.jobs = make(chan *models.WebhookDeliveryModel, q.config.BatchSize)
+	q.running = true
+
+	for i := 0; i < q.config.Workers; i++ {
+		q.wg.Add(1)
+		go q.worker(i)
+	}
+
+	q.wg.Add(1)
+	go q.dispatch()
+
+	q.logger.Infof("Webhook delivery queue started with %d workers", q.config.Workers)
+}
+
+// Stop stops claiming new deliveries and waits for in-flight ones to finish
+func (q *DeliveryQueue) Stop() {
+	q.mu.Lock()
+	if !q.running {
+		q.mu.Unlock()
+		return
+	}
+	q.running = false
+	close(q.stop)
+	q.mu.Unlock()
+
+	q.wg.Wait()
+	q.logger.Info("Webhook delivery queue stopped")
+}
+
+func (q *DeliveryQueue) notify() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (q *DeliveryQueue) dispatch() {
+	defer q.wg.Done()
+	defer close(q.jobs)
+
+	ticker := time.NewTicker(q.config.PollInterval)
+	defer ticker.Stop()
+
+	q.releaseStale()
+	q.claim()
+
+	for {
+		select {
+		case <-q.stop:
+			return
+		case <-ticker.C:
+			q.releaseStale()
+			q.claim()
+		case <-q.wake:
+			q.claim()
+		}
+	}
+}
+
+func (q *DeliveryQueue) releaseStale() {
+	released, err := q.repo.ReleaseStale(context.Background(), q.config.StaleAfter)
+	if err != nil {
+		q.logger.Errorf("Failed to release stale webhook deliveries: %v", err)
+		return
+	}
+	if released > 0 {
+		q.logger.Warnf("Released %d stale webhook deliveries back to the queue", released)
+	}
+}
+
+func (q *DeliveryQueue) claim() {
+	free := cap(q.jobs) - len(q.jobs)
+	if free <= 0 {
+		return
+	}
+
+	deliveries, err := q.repo.ClaimDue(context.Background(), free)
+	if err != nil {
+		q.logger.Errorf("Failed to claim webhook deliveries: %v", err)
+		return
+	}
+
+	for _, delivery := range deliveries {
+		select {
+		case q.jobs <- delivery:
+		case <-q.stop:
+			// Remaining claimed deliveries are released by releaseStale on the next start
+			return
+		}
+	}
+}
+
+func (q *DeliveryQueue) worker(id int) {
+	defer q.wg.Done()
+
+	for delivery := range q.jobs {
+		q.deliver(delivery)
+	}
+
+	q.logger.Debugf("Webhook delivery worker %d finished", id)
+}
+
+func (q *DeliveryQueue) deliver(delivery *models.WebhookDeliveryModel) {
+	ctx := context.Background()
+	attempt := delivery.Attempts + 1
+
+	system := map[string]string{
+		"X-Webhook-Event":    delivery.Event,
+		"X-Webhook-Delivery": delivery.ID,
+		"X-Webhook-Attempt":  strconv.Itoa(attempt),
+	}
+
+	body, err := encodeBody(json.RawMessage(delivery.Payload))
+	if err != nil {
+		q.logger.Errorf("Webhook delivery %s has an invalid payload, moving to dead letters: %v", delivery.ID, err)
+		if err := q.repo.MoveToDeadLetter(ctx, delivery, delivery.Attempts, err.Error(), nil); err != nil {
+			q.logger.Errorf("Failed to move webhook delivery %s to dead letters: %v", delivery.ID, err)
+		}
+		return
+	}
+
+	// O endpoint é lido a cada tentativa para que retries usem segredo e headers atuais
+	endpoint, err := resolveEndpoint(ctx, q.endpoints, delivery.SessionId, delivery.WebhookId)
+	if err != nil {
+		q.logger.Warnf("Failed to load webhook endpoint for delivery %s, retrying later: %v", delivery.ID, err)
+		if err := q.repo.ScheduleRetry(ctx, delivery.ID, delivery.Attempts, time.Now().Add(q.config.PollInterval), err.Error(), nil); err != nil {
+			q.logger.Errorf("Failed to schedule retry for webhook delivery %s: %v", delivery.ID, err)
+		}
+		return
+	}
+
+	headers := buildHeaders(endpoint, system, body, time.Now())
+
+	err = q.httpClient.Post(ctx, delivery.URL, body, headers)
+	if endpoint != nil {
+		q.recordDelivery(ctx, endpoint.ID, err)
+	}
+	if err == nil {
+		if err := q.repo.MarkDelivered(ctx, delivery.ID); err != nil {
+			q.logger.Errorf("Failed to mark webhook delivery %s as delivered: %v", delivery.ID, err)
+		}
+		if attempt > 1 {
+			q.logger.Infof("Webhook delivery %s succeeded after %d attempts", delivery.ID, attempt)
+		}
+		return
+	}
+
+	statusCode, _ := deliveryOutcome(err)
+
+	if q.retryStrategy.IsRetryable(err) && attempt <= q.retryStrategy.MaxRetries() {
+		backoff := q.retryStrategy.NextBackoff(attempt)
+		q.logger.Warnf("Webhook delivery %s to %s failed (attempt %d/%d), retrying in %v: %v",
+			delivery.ID, delivery.URL, attempt, q.retryStrategy.MaxRetries()+1, backoff, err)
+
+		if err := q.repo.ScheduleRetry(ctx, delivery.ID, attempt, time.Now().Add(backoff), err.Error(), statusCode); err != nil {
+			q.logger.Errorf("Failed to schedule retry for webhook delivery %s: %v", delivery.ID, err)
+		}
+		return
+	}
+
+	q.logger.Errorf("Webhook delivery %s to %s failed after %d attempts, moving to dead letters: %v",
+		delivery.ID, delivery.URL, attempt, err)
+
+	if err := q.repo.MoveToDeadLetter(ctx, delivery, attempt, err.Error(), statusCode); err != nil {
+		q.logger.Errorf("Failed to move webhook delivery %s to dead letters: %v", delivery.ID, err)
+	}
+}
+
+func (q *DeliveryQueue) recordDelivery(ctx context.Context, webhookID string, sendErr error) {
+	if q.endpoints == nil || webhookID == "" {
+		return
+	}
+
+	statusCode, lastError := deliveryOutcome(sendErr)
+	if err := q.endpoints.RecordDelivery(ctx, webhookID, sendErr == nil, statusCode, lastError); err != nil {
+		q.logger.Warnf("Failed to record webhook delivery stats for %s: %v", webhookID, err)
+	}
+}
+
+// ListDeadLetters returns the failed deliveries of a session, newest first
+func (q *DeliveryQueue) ListDeadLetters(ctx context.Context, sessionID string, limit, offset int) ([]*models.WebhookDeadLetterModel, int, error) {
+	return q.repo.ListDeadLetters(ctx, sessionID, limit, offset)
+}
+
+// GetDeadLetter returns a failed delivery, or nil if it does not belong to the session
+func (q *DeliveryQueue) GetDeadLetter(ctx context.Context, sessionID, id string) (*models.WebhookDeadLetterModel, error) {
+	return q.repo.GetDeadLetter(ctx, sessionID, id)
+}
+
+// ReplayDeadLetter re-enqueues a failed delivery with a fresh retry budget
+func (q *DeliveryQueue) ReplayDeadLetter(ctx context.Context, sessionID, id string) (*models.WebhookDeliveryModel, error) {
+	delivery, err := q.repo.ReplayDeadLetter(ctx, sessionID, id)
+	if err != nil {
+		return nil, err
+	}
+	if delivery != nil {
+		q.logger.Infof("Replaying webhook dead letter %s as delivery %s (session: %s)", id, delivery.ID, sessionID)
+		q.notify()
+	}
+	return delivery, nil
+}
+
+// PendingCount returns how many deliveries of a session are still queued
+func (q *DeliveryQueue) PendingCount(ctx context.Context, sessionID string) (int, error) {
+	return q.repo.CountPending(ctx, sessionID)
+}
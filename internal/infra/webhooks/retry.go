@@ -138,3 +138,21 @@ func IsHTTPStatusRetryable(statusCode int) bool {
 		return false
 	}
 }
+
+// MaxRetries returns how many retries are allowed after the first attempt
+func (r *RetryStrategy) MaxRetries() int {
+	return r.config.MaxRetries
+}
+
+// NextBackoff returns the delay before the given retry attempt (1-based)
+func (r *RetryStrategy) NextBackoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	return r.calculateBackoff(attempt)
+}
+
+// IsRetryable reports whether a failed delivery should be attempted again
+func (r *RetryStrategy) IsRetryable(err error) bool {
+	return r.isRetryableError(err)
+}
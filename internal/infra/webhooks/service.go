@@ -6,12 +6,14 @@ import (
 	"time"
 
 	"zpmeow/internal/application/ports"
+	"zpmeow/internal/infra/database/models"
 	"zpmeow/internal/infra/logging"
 )
 
 type Service struct {
 	httpClient    ports.HTTPClient
 	retryStrategy *RetryStrategy
+	endpoints     EndpointStore
 	logger        logging.Logger
 }
 
@@ -56,6 +58,66 @@ func NewServiceWithHTTPClient(httpClient ports.HTTPClient, retryConfig *RetryCon
 	}
 }
 
+// SetEndpointStore enables HMAC signing, custom headers and delivery stats for sessions with a webhook endpoint
+func (w *Service) SetEndpointStore(store EndpointStore) {
+	w.endpoints = store
+}
+
+// sign serializes data and adds the default endpoint's signature and custom headers
+func (w *Service) sign(ctx context.Context, sessionID string, data interface{}, headers map[string]string) (interface{}, map[string]string, error) {
+	endpoint, err := resolveEndpoint(ctx, w.endpoints, sessionID, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("webhooks: failed to load webhook endpoint: %w", err)
+	}
+	if endpoint == nil {
+		return data, headers, nil
+	}
+
+	body, err := encodeBody(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return body, buildHeaders(endpoint, headers, body, time.Now()), nil
+}
+
+// SendToEndpoint delivers data to a specific webhook endpoint, applying its
+// custom headers and signature and recording the outcome in its stats
+func (w *Service) SendToEndpoint(ctx context.Context, endpoint *models.WebhookModel, event string, data interface{}) error {
+	if endpoint == nil || endpoint.URL == "" {
+		return fmt.Errorf("webhooks: URL is empty")
+	}
+
+	body, err := encodeBody(data)
+	if err != nil {
+		return err
+	}
+
+	headers := buildHeaders(endpoint, map[string]string{"X-Webhook-Event": event}, body, time.Now())
+
+	w.logger.Infof("Sending webhook to %s for event %s (session: %s)", endpoint.URL, event, endpoint.SessionId)
+
+	err = w.httpClient.Post(ctx, endpoint.URL, body, headers)
+	w.recordDelivery(ctx, endpoint.ID, err)
+	if err != nil {
+		w.logger.Errorf("Failed to send webhook to %s: %v", endpoint.URL, err)
+		return fmt.Errorf("webhooks: failed to send to %s: %w", endpoint.URL, err)
+	}
+
+	return nil
+}
+
+func (w *Service) recordDelivery(ctx context.Context, webhookID string, sendErr error) {
+	if w.endpoints == nil || webhookID == "" {
+		return
+	}
+
+	statusCode, lastError := deliveryOutcome(sendErr)
+	if err := w.endpoints.RecordDelivery(ctx, webhookID, sendErr == nil, statusCode, lastError); err != nil {
+		w.logger.Warnf("Failed to record webhook delivery stats for %s: %v", webhookID, err)
+	}
+}
+
 func (w *Service) SendWebhook(ctx context.Context, webhookURL, event, sessionID string, data interface{}) error {
 	if webhookURL == "" {
 		return fmt.Errorf("webhooks: URL is empty")
@@ -63,7 +125,12 @@ func (w *Service) SendWebhook(ctx context.Context, webhookURL, event, sessionID
 
 	w.logger.Infof("Sending webhook to %s for event %s (session: %s)", webhookURL, event, sessionID)
 
-	err := w.httpClient.Post(ctx, webhookURL, data, nil)
+	payload, headers, err := w.sign(ctx, sessionID, data, nil)
+	if err != nil {
+		return err
+	}
+
+	err = w.httpClient.Post(ctx, webhookURL, payload, headers)
 	if err != nil {
 		w.logger.Errorf("Failed to send webhook to %s: %v", webhookURL, err)
 		return fmt.Errorf("webhooks: failed to send to %s: %w", webhookURL, err)
@@ -82,7 +149,12 @@ func (w *Service) SendWebhookWithRetry(ctx context.Context, webhookURL, event, s
 
 	err := w.retryStrategy.ExecuteWithRetry(ctx, func() error {
 		w.logger.Debugf("Attempting to send %s (session: %s)", operationName, sessionID)
-		return w.httpClient.Post(ctx, webhookURL, data, nil)
+		// Assina a cada tentativa para que o timestamp acompanhe o envio
+		payload, headers, err := w.sign(ctx, sessionID, data, nil)
+		if err != nil {
+			return err
+		}
+		return w.httpClient.Post(ctx, webhookURL, payload, headers)
 	}, operationName)
 
 	if err != nil {
@@ -112,7 +184,12 @@ func (w *Service) SendWebhookWithHeaders(ctx context.Context, webhookURL, event,
 
 	w.logger.Infof("Sending webhook with headers to %s for event %s (session: %s)", webhookURL, event, sessionID)
 
-	err := w.httpClient.Post(ctx, webhookURL, data, headers)
+	payload, headers, err := w.sign(ctx, sessionID, data, headers)
+	if err != nil {
+		return err
+	}
+
+	err = w.httpClient.Post(ctx, webhookURL, payload, headers)
 	if err != nil {
 		w.logger.Errorf("Failed to send webhook to %s: %v", webhookURL, err)
 		return err
@@ -131,6 +208,10 @@ func (w *Service) SendWebhookWithHeadersAndRetry(ctx context.Context, webhookURL
 
 	return w.retryStrategy.ExecuteWithRetry(ctx, func() error {
 		w.logger.Debugf("Attempting to send %s (session: %s)", operationName, sessionID)
-		return w.httpClient.Post(ctx, webhookURL, data, headers)
+		payload, signedHeaders, err := w.sign(ctx, sessionID, data, headers)
+		if err != nil {
+			return err
+		}
+		return w.httpClient.Post(ctx, webhookURL, payload, signedHeaders)
 	}, operationName)
 }
@@ -0,0 +1,61 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	TimestampHeader = "X-Webhook-Timestamp"
+
+	// DefaultSecretGracePeriod is how long the previous secret keeps signing deliveries after a rotation
+	DefaultSecretGracePeriod = 24 * time.Hour
+	MaxSecretGracePeriod     = 7 * 24 * time.Hour
+)
+
+// SignPayload computes the hex HMAC-SHA256 of "<timestamp>.<body>"
+func SignPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignatureHeaders builds the timestamp and signature headers for body.
+// With more than one secret (rotation grace window) the signature header carries
+// one comma-separated "sha256=<hex>" entry per secret, so receivers still holding
+// the previous secret keep validating.
+func SignatureHeaders(secrets []string, body []byte, now time.Time) map[string]string {
+	if len(secrets) == 0 {
+		return nil
+	}
+
+	timestamp := now.Unix()
+	signatures := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		signatures = append(signatures, "sha256="+SignPayload(secret, timestamp, body))
+	}
+
+	return map[string]string{
+		TimestampHeader: strconv.FormatInt(timestamp, 10),
+		SignatureHeader: strings.Join(signatures, ","),
+	}
+}
+
+// encodeBody serializes payload exactly as the HTTP client will send it, so the
+// signature is computed over the bytes the receiver gets
+func encodeBody(payload interface{}) (json.RawMessage, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("webhooks: failed to marshal payload: %w", err)
+	}
+	return body, nil
+}
@@ -0,0 +1,100 @@
+package webhooks
+
+import (
+	"testing"
+	"time"
+)
+
+var testBody = []byte(`{"event":"Message"}`)
+
+func TestSignPayload(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp int64
+		body      []byte
+		want      string
+	}{
+		{
+			name:      "payload",
+			secret:    "whsec_test",
+			timestamp: 1700000000,
+			body:      testBody,
+			want:      "2fc5679ce86c65d9f6db1111812a3313eb60f66be9e06a6692fd299f00771e72",
+		},
+		{
+			name:      "timestamp is part of the signed content",
+			secret:    "whsec_test",
+			timestamp: 1700000001,
+			body:      testBody,
+			want:      "caf28f7b81af085a99b148662015ec630703f668d4824f2e6f8b6054d147951d",
+		},
+		{
+			name:      "other secret",
+			secret:    "whsec_old",
+			timestamp: 1700000000,
+			body:      testBody,
+			want:      "20a093ece55a7eb4b8108502db41b9b0eba2d33df104a67b7d8c19692248896b",
+		},
+		{
+			name:      "empty body",
+			secret:    "whsec_test",
+			timestamp: 0,
+			body:      nil,
+			want:      "a2fa7a43c6a1cf2e784eaf3327d65c65b3d2b790320ebed9aa5661bc42a8cccd",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SignPayload(tt.secret, tt.timestamp, tt.body); got != tt.want {
+				t.Errorf("SignPayload() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSignatureHeaders(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name      string
+		secrets   []string
+		signature string
+	}{
+		{
+			name:    "no secret",
+			secrets: nil,
+		},
+		{
+			name:      "single secret",
+			secrets:   []string{"whsec_test"},
+			signature: "sha256=2fc5679ce86c65d9f6db1111812a3313eb60f66be9e06a6692fd299f00771e72",
+		},
+		{
+			name:    "current and previous secret during rotation",
+			secrets: []string{"whsec_test", "whsec_old"},
+			signature: "sha256=2fc5679ce86c65d9f6db1111812a3313eb60f66be9e06a6692fd299f00771e72," +
+				"sha256=20a093ece55a7eb4b8108502db41b9b0eba2d33df104a67b7d8c19692248896b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := SignatureHeaders(tt.secrets, testBody, now)
+			if tt.signature == "" {
+				if headers != nil {
+					t.Fatalf("SignatureHeaders() = %v, want nil", headers)
+				}
+				return
+			}
+
+			if got := headers[TimestampHeader]; got != "1700000000" {
+				t.Errorf("%s = %q, want %q", TimestampHeader, got, "1700000000")
+			}
+			if got := headers[SignatureHeader]; got != tt.signature {
+				t.Errorf("%s = %q, want %q", SignatureHeader, got, tt.signature)
+			}
+		})
+	}
+}
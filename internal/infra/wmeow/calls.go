@@ -0,0 +1,322 @@
+package wmeow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	waBinary "go.mau.fi/whatsmeow/binary"
+	waTypes "go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"zpmeow/internal/infra/database/models"
+	"zpmeow/internal/infra/database/repository"
+	"zpmeow/internal/infra/logging"
+)
+
+// Eventos normalizados das chamadas recebidas
+const (
+	EventCallReceived = "call.received"
+	EventCallAccepted = "call.accepted"
+	EventCallRejected = "call.rejected"
+	EventCallEnded    = "call.ended"
+
+	callTimeout = 15 * time.Second
+)
+
+// callEvent é o payload dos webhooks call.*
+type callEvent struct {
+	CallID         string `json:"call_id"`
+	Caller         string `json:"caller"`
+	GroupJID       string `json:"group_jid,omitempty"`
+	IsGroup        bool   `json:"is_group"`
+	IsVideo        bool   `json:"is_video"`
+	Outcome        string `json:"outcome"`
+	Action         string `json:"action,omitempty"` // política aplicada, só em call.received
+	Reason         string `json:"reason,omitempty"`
+	ReplyMessageID string `json:"reply_message_id,omitempty"`
+	OfferedAt      int64  `json:"offered_at,omitempty"`
+	EndedAt        int64  `json:"ended_at,omitempty"`
+	Stored         bool   `json:"stored"` // chamada encontrada no registro de chamadas
+}
+
+// callTracker aplica a política de chamadas das sessões e mantém o registro das chamadas recebidas
+type callTracker struct {
+	policyRepo *repository.CallPolicyRepository
+	callRepo   *repository.CallRepository
+	sendReply  func(ctx context.Context, sessionID, to, text string) (string, error)
+	logger     logging.Logger
+}
+
+func newCallTracker(policyRepo *repository.CallPolicyRepository, callRepo *repository.CallRepository, sendReply func(ctx context.Context, sessionID, to, text string) (string, error)) *callTracker {
+	if policyRepo == nil || callRepo == nil {
+		return nil
+	}
+
+	return &callTracker{
+		policyRepo: policyRepo,
+		callRepo:   callRepo,
+		sendReply:  sendReply,
+		logger:     logging.GetLogger().Sub("calls"),
+	}
+}
+
+// policy retorna a política da sessão; sem política configurada as chamadas são ignoradas
+func (t *callTracker) policy(ctx context.Context, sessionID string) *models.CallPolicyModel {
+	policy, err := t.policyRepo.GetBySessionID(ctx, sessionID)
+	if err != nil {
+		t.logger.Errorf("Failed to load call policy for session %s: %v", sessionID, err)
+	}
+	if policy == nil {
+		policy = &models.CallPolicyModel{SessionId: sessionID, Action: models.CallActionIgnore}
+	}
+	return policy
+}
+
+func (ep *EventProcessor) handleCallOffer(evt interface{}) {
+	offer := evt.(*events.CallOffer)
+	ep.sendGenericEvent("CallOffer", offer)
+	ep.receiveCall(offer.BasicCallMeta, offer.RemotePlatform, isVideoCall(offer.Data))
+}
+
+// handleCallOfferNotice registra chamadas em grupo; a política de rejeição vale só para chamadas individuais
+func (ep *EventProcessor) handleCallOfferNotice(evt interface{}) {
+	notice := evt.(*events.CallOfferNotice)
+	ep.sendGenericEvent("CallOfferNotice", notice)
+	ep.receiveCall(notice.BasicCallMeta, "", notice.Media == "video")
+}
+
+func (ep *EventProcessor) handleCallAccept(evt interface{}) {
+	accept := evt.(*events.CallAccept)
+	ep.sendGenericEvent("CallAccept", accept)
+
+	ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+	defer cancel()
+
+	var call *models.CallModel
+	if ep.callTracker != nil {
+		var err error
+		call, err = ep.callTracker.callRepo.SetOutcome(ctx, ep.sessionID, accept.CallID, models.CallOutcomeAccepted, nil, nil)
+		if err != nil {
+			ep.logger.Errorf("Failed to update call %s in session %s: %v", accept.CallID, ep.sessionID, err)
+		}
+	}
+
+	ep.sendCallUpdate(ctx, EventCallAccepted, accept.BasicCallMeta, call, models.CallOutcomeAccepted, "")
+}
+
+// handleCallReject trata a rejeição feita em outro aparelho da conta
+func (ep *EventProcessor) handleCallReject(evt interface{}) {
+	reject := evt.(*events.CallReject)
+	ep.sendGenericEvent("CallReject", reject)
+
+	ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+	defer cancel()
+
+	var call *models.CallModel
+	if ep.callTracker != nil {
+		var err error
+		now := time.Now()
+		call, err = ep.callTracker.callRepo.SetOutcome(ctx, ep.sessionID, reject.CallID, models.CallOutcomeRejected, nil, &now)
+		if err != nil {
+			ep.logger.Errorf("Failed to update call %s in session %s: %v", reject.CallID, ep.sessionID, err)
+		}
+	}
+
+	ep.sendCallUpdate(ctx, EventCallRejected, reject.BasicCallMeta, call, models.CallOutcomeRejected, "")
+}
+
+func (ep *EventProcessor) handleCallTerminate(evt interface{}) {
+	terminate := evt.(*events.CallTerminate)
+	ep.sendGenericEvent("CallTerminate", terminate)
+
+	ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+	defer cancel()
+
+	var call *models.CallModel
+	if ep.callTracker != nil {
+		var err error
+		call, err = ep.callTracker.callRepo.End(ctx, ep.sessionID, terminate.CallID, optionalString(terminate.Reason), terminate.Timestamp)
+		if err != nil {
+			ep.logger.Errorf("Failed to end call %s in session %s: %v", terminate.CallID, ep.sessionID, err)
+		}
+	}
+
+	ep.sendCallUpdate(ctx, EventCallEnded, terminate.BasicCallMeta, call, models.CallOutcomeMissed, terminate.Reason)
+}
+
+// receiveCall registra a chamada e aplica a política da sessão
+func (ep *EventProcessor) receiveCall(meta waTypes.BasicCallMeta, remotePlatform string, isVideo bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+	defer cancel()
+
+	call := &models.CallModel{
+		SessionId:      ep.sessionID,
+		CallId:         meta.CallID,
+		CallerJid:      callerJID(meta).String(),
+		GroupJid:       optionalString(jidString(meta.GroupJID)),
+		IsGroup:        !meta.GroupJID.IsEmpty(),
+		IsVideo:        isVideo,
+		Outcome:        models.CallOutcomeRinging,
+		RemotePlatform: optionalString(remotePlatform),
+		OfferedAt:      meta.Timestamp,
+	}
+
+	received := newCallEvent(call, false)
+	policy := &models.CallPolicyModel{Action: models.CallActionIgnore}
+
+	if ep.callTracker != nil {
+		created, err := ep.callTracker.callRepo.Create(ctx, call)
+		if err != nil {
+			ep.logger.Errorf("Failed to store call %s in session %s: %v", meta.CallID, ep.sessionID, err)
+		} else if !created {
+			// Mesma chamada já recebida por outro evento (CallOffer e CallOfferNotice)
+			return
+		} else {
+			received = newCallEvent(call, true)
+		}
+		policy = ep.callTracker.policy(ctx, ep.sessionID)
+	}
+
+	if call.IsGroup {
+		policy.Action = models.CallActionIgnore
+	}
+	received.Action = policy.Action
+	ep.sendNormalizedEvent(EventCallReceived, received)
+
+	if policy.Action == models.CallActionIgnore {
+		return
+	}
+
+	rejected, err := ep.rejectCall(ctx, meta.From, meta.CallID, models.CallOutcomeAutoRejected)
+	if err != nil {
+		ep.logger.Errorf("Failed to auto-reject call %s from %s in session %s: %v", meta.CallID, meta.From, ep.sessionID, err)
+		return
+	}
+	ep.logger.Infof("Auto-rejected call %s from %s in session %s", meta.CallID, meta.From, ep.sessionID)
+
+	if policy.Action == models.CallActionRejectWithMessage {
+		ep.replyToCaller(ctx, meta, rejected, getStringValue(policy.RejectMessage))
+	}
+}
+
+// rejectCall rejeita uma chamada recebida e registra o resultado; usado pela política e pela API
+func (ep *EventProcessor) rejectCall(ctx context.Context, from waTypes.JID, callID, outcome string) (*models.CallModel, error) {
+	if ep.waClient == nil {
+		return nil, fmt.Errorf("client not initialized for session %s", ep.sessionID)
+	}
+
+	if err := ep.waClient.RejectCall(from, callID); err != nil {
+		return nil, fmt.Errorf("failed to reject call: %w", err)
+	}
+
+	now := time.Now()
+	var call *models.CallModel
+	if ep.callTracker != nil {
+		var err error
+		call, err = ep.callTracker.callRepo.SetOutcome(ctx, ep.sessionID, callID, outcome, nil, &now)
+		if err != nil {
+			ep.logger.Errorf("Failed to update call %s in session %s: %v", callID, ep.sessionID, err)
+		}
+	}
+
+	rejected := callEvent{CallID: callID, Caller: from.ToNonAD().String(), Outcome: outcome, EndedAt: now.Unix()}
+	if call != nil {
+		rejected = newCallEvent(call, true)
+	}
+	ep.sendNormalizedEvent(EventCallRejected, rejected)
+
+	return call, nil
+}
+
+// replyToCaller envia a mensagem configurada na política a quem ligou
+func (ep *EventProcessor) replyToCaller(ctx context.Context, meta waTypes.BasicCallMeta, call *models.CallModel, text string) {
+	if strings.TrimSpace(text) == "" || ep.callTracker == nil || ep.callTracker.sendReply == nil {
+		return
+	}
+
+	messageID, err := ep.callTracker.sendReply(ctx, ep.sessionID, callerJID(meta).String(), text)
+	if err != nil {
+		ep.logger.Errorf("Failed to reply to call %s from %s in session %s: %v", meta.CallID, meta.From, ep.sessionID, err)
+		return
+	}
+
+	if call != nil {
+		if err := ep.callTracker.callRepo.SetReplyMessageID(ctx, call.ID, messageID); err != nil {
+			ep.logger.Errorf("Failed to store reply to call %s in session %s: %v", meta.CallID, ep.sessionID, err)
+		}
+	}
+}
+
+// sendCallUpdate envia o evento com o registro atualizado da chamada. Sem registro (chamada recebida
+// antes da conexão ou sem banco) envia os dados do próprio evento, mas não repete o evento de uma
+// chamada que já tinha terminado, por exemplo rejeitada antes do CallTerminate.
+func (ep *EventProcessor) sendCallUpdate(ctx context.Context, event string, meta waTypes.BasicCallMeta, call *models.CallModel, outcome, reason string) {
+	if call != nil {
+		ep.sendNormalizedEvent(event, newCallEvent(call, true))
+		return
+	}
+
+	if ep.callTracker != nil {
+		if existing, err := ep.callTracker.callRepo.GetByCallID(ctx, ep.sessionID, meta.CallID); err == nil && existing != nil {
+			return
+		}
+	}
+
+	payload := callEvent{
+		CallID:   meta.CallID,
+		Caller:   callerJID(meta).String(),
+		GroupJID: jidString(meta.GroupJID),
+		IsGroup:  !meta.GroupJID.IsEmpty(),
+		Outcome:  outcome,
+		Reason:   reason,
+	}
+	if event != EventCallAccepted {
+		payload.EndedAt = unixTime(meta.Timestamp)
+	}
+	ep.sendNormalizedEvent(event, payload)
+}
+
+func newCallEvent(call *models.CallModel, stored bool) callEvent {
+	payload := callEvent{
+		CallID:         call.CallId,
+		Caller:         call.CallerJid,
+		GroupJID:       getStringValue(call.GroupJid),
+		IsGroup:        call.IsGroup,
+		IsVideo:        call.IsVideo,
+		Outcome:        call.Outcome,
+		Reason:         getStringValue(call.Reason),
+		ReplyMessageID: getStringValue(call.ReplyMessageId),
+		OfferedAt:      unixTime(call.OfferedAt),
+		Stored:         stored,
+	}
+	if call.EndedAt != nil {
+		payload.EndedAt = call.EndedAt.Unix()
+	}
+	return payload
+}
+
+// callerJID é quem iniciou a chamada; em chamadas individuais é o próprio remetente
+func callerJID(meta waTypes.BasicCallMeta) waTypes.JID {
+	if !meta.CallCreator.IsEmpty() {
+		return meta.CallCreator.ToNonAD()
+	}
+	return meta.From.ToNonAD()
+}
+
+// isVideoCall verifica se a oferta traz o elemento de vídeo
+func isVideoCall(offer *waBinary.Node) bool {
+	if offer == nil {
+		return false
+	}
+	_, ok := offer.GetOptionalChildByTag("video")
+	return ok
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,138 @@
+package wmeow
+
+import (
+	"context"
+	"time"
+
+	"go.mau.fi/whatsmeow/appstate"
+	"go.mau.fi/whatsmeow/proto/waSyncAction"
+	waTypes "go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+
+	"zpmeow/internal/infra/database/models"
+	"zpmeow/internal/infra/database/repository"
+)
+
+// EventChatUpdated é o evento normalizado das alterações de estado do chat (fixar, silenciar, arquivar, lido)
+const EventChatUpdated = "chat.updated"
+
+const chatStateTimeout = 10 * time.Second
+
+// chatUpdated é o payload do webhook chat.updated, com o estado do chat após a alteração
+type chatUpdated struct {
+	ChatJID      string `json:"chat_jid"`
+	Change       string `json:"change"` // pin, mute, archive ou read
+	Pinned       bool   `json:"pinned"`
+	Muted        bool   `json:"muted"`
+	MutedUntil   int64  `json:"muted_until,omitempty"` // ausente com muted = silenciado para sempre
+	Archived     bool   `json:"archived"`
+	UnreadCount  int    `json:"unread_count"`
+	MarkedUnread bool   `json:"marked_unread"`
+	FromFullSync bool   `json:"from_full_sync"`
+	Timestamp    int64  `json:"timestamp"`
+}
+
+func (ep *EventProcessor) handleChatPin(evt interface{}) {
+	pin := evt.(*events.Pin)
+	ep.sendGenericEvent("Pin", pin)
+
+	pinned := pin.Action.GetPinned()
+	ep.applyChatState("pin", pin.JID, pin.Timestamp, pin.FromFullSync, repository.ChatStateUpdate{Pinned: &pinned, PinnedAt: pin.Timestamp})
+}
+
+func (ep *EventProcessor) handleChatMute(evt interface{}) {
+	mute := evt.(*events.Mute)
+	ep.sendGenericEvent("Mute", mute)
+
+	muted := mute.Action.GetMuted()
+	update := repository.ChatStateUpdate{Muted: &muted}
+	// Um timestamp negativo significa silenciado para sempre
+	if end := mute.Action.GetMuteEndTimestamp(); muted && end > 0 {
+		mutedUntil := time.UnixMilli(end)
+		update.MutedUntil = &mutedUntil
+	}
+	ep.applyChatState("mute", mute.JID, mute.Timestamp, mute.FromFullSync, update)
+}
+
+func (ep *EventProcessor) handleChatArchive(evt interface{}) {
+	archive := evt.(*events.Archive)
+	ep.sendGenericEvent("Archive", archive)
+
+	archived := archive.Action.GetArchived()
+	ep.applyChatState("archive", archive.JID, archive.Timestamp, archive.FromFullSync, repository.ChatStateUpdate{Archived: &archived})
+}
+
+func (ep *EventProcessor) handleMarkChatAsRead(evt interface{}) {
+	mark := evt.(*events.MarkChatAsRead)
+	ep.sendGenericEvent("MarkChatAsRead", mark)
+
+	read := mark.Action.GetRead()
+	ep.applyChatState("read", mark.JID, mark.Timestamp, mark.FromFullSync, repository.ChatStateUpdate{Read: &read})
+}
+
+// applyChatState grava em zpChats o estado recebido do app state e emite o chat.updated
+func (ep *EventProcessor) applyChatState(change string, jid waTypes.JID, timestamp time.Time, fromFullSync bool, update repository.ChatStateUpdate) {
+	if ep.chatRepo == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), chatStateTimeout)
+	defer cancel()
+
+	chat, err := ep.chatRepo.UpdateState(ctx, ep.sessionID, jid.String(), jid.Server == waTypes.GroupServer, update)
+	if err != nil {
+		ep.logger.Errorf("Failed to apply %s state to chat %s in session %s: %v", change, jid, ep.sessionID, err)
+		return
+	}
+
+	ep.logger.Debugf("Applied %s state to chat %s in session %s (full sync: %v)", change, jid, ep.sessionID, fromFullSync)
+	ep.sendNormalizedEvent(EventChatUpdated, newChatUpdated(chat, change, fromFullSync, timestamp))
+}
+
+func newChatUpdated(chat *models.ChatModel, change string, fromFullSync bool, timestamp time.Time) chatUpdated {
+	payload := chatUpdated{
+		ChatJID:      chat.ChatJid,
+		Change:       change,
+		Pinned:       chat.IsPinned,
+		Muted:        isChatMuted(chat, time.Now()),
+		Archived:     chat.IsArchived,
+		UnreadCount:  chat.UnreadCount,
+		MarkedUnread: chat.IsMarkedUnread,
+		FromFullSync: fromFullSync,
+		Timestamp:    timestamp.Unix(),
+	}
+	if payload.Muted && chat.MutedUntil != nil {
+		payload.MutedUntil = chat.MutedUntil.Unix()
+	}
+	return payload
+}
+
+// isChatMuted considera expirado o silêncio cujo mutedUntil já passou
+func isChatMuted(chat *models.ChatModel, now time.Time) bool {
+	return chat.IsMuted && (chat.MutedUntil == nil || chat.MutedUntil.After(now))
+}
+
+// buildMarkChatAsRead monta o patch de app state que marca o chat como lido ou não lido;
+// o whatsmeow não oferece um builder para ele
+func buildMarkChatAsRead(target waTypes.JID, read bool, lastMessageTimestamp time.Time) appstate.PatchInfo {
+	if lastMessageTimestamp.IsZero() {
+		lastMessageTimestamp = time.Now()
+	}
+
+	return appstate.PatchInfo{
+		Type: appstate.WAPatchRegularLow,
+		Mutations: []appstate.MutationInfo{{
+			Index:   []string{appstate.IndexMarkChatAsRead, target.String()},
+			Version: 3,
+			Value: &waSyncAction.SyncActionValue{
+				MarkChatAsReadAction: &waSyncAction.MarkChatAsReadAction{
+					Read: proto.Bool(read),
+					MessageRange: &waSyncAction.SyncActionMessageRange{
+						LastMessageTimestamp: proto.Int64(lastMessageTimestamp.Unix()),
+					},
+				},
+			},
+		}},
+	}
+}
@@ -91,7 +91,20 @@ func NewWameowClientWithDeviceJID(sessionID, expectedDeviceJID string, container
 		connManager:    NewConnectionManager(appLogger),
 	}
 
+	if sessionRepo != nil {
+		sessionEntity, err := client.sessionManager.GetSession(sessionID)
+		if err != nil {
+			appLogger.Warnf("Failed to load proxy configuration for session %s: %v", sessionID, err)
+		} else if sessionEntity.HasProxy() {
+			if err := client.connManager.ApplyProxy(waClient, sessionID, sessionEntity.ProxyConfiguration()); err != nil {
+				cancel()
+				return nil, err
+			}
+		}
+	}
+
 	if eventHandler != nil {
+		eventHandler.waClient = waClient
 		client.eventHandlerID = waClient.AddEventHandler(eventHandler.HandleEvent)
 	}
 
@@ -268,6 +281,35 @@ func (c *WameowClient) Reconnect(ctx context.Context) error {
 	return c.Connect()
 }
 
+// ReconnectWithProxy troca o proxy do cliente, reconectando o websocket se ele estava conectado
+func (c *WameowClient) ReconnectWithProxy(proxy session.ProxyConfiguration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	wasConnected := c.client.IsConnected()
+	if wasConnected {
+		c.connManager.SafeDisconnect(c.client, c.sessionID)
+	}
+
+	if err := c.connManager.ApplyProxy(c.client, c.sessionID, proxy); err != nil {
+		return err
+	}
+
+	if !wasConnected {
+		return nil
+	}
+
+	c.setStatus(session.StatusConnecting)
+	if err := c.connManager.SafeConnect(c.client, c.sessionID); err != nil {
+		c.setStatus(session.StatusDisconnected)
+		c.sessionManager.UpdateStatus(c.sessionID, session.StatusDisconnected)
+		return err
+	}
+
+	c.setStatus(session.StatusConnected)
+	return nil
+}
+
 func (c *WameowClient) GetLastActivity() time.Time {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
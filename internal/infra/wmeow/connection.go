@@ -47,6 +47,26 @@ func (c *connectionManager) SafeConnect(client *whatsmeow.Client, sessionID stri
 	return nil
 }
 
+// ApplyProxy configura o proxy da sessão no websocket e nas transferências de mídia.
+// Deve ser chamado com o cliente desconectado; um proxy vazio remove a configuração.
+func (c *connectionManager) ApplyProxy(client *whatsmeow.Client, sessionID string, proxy session.ProxyConfiguration) error {
+	if client == nil {
+		return newConnectionError(sessionID, "proxy", fmt.Errorf("client is nil"))
+	}
+
+	if err := client.SetProxyAddress(proxy.Value()); err != nil {
+		return newConnectionError(sessionID, "proxy", err)
+	}
+
+	if proxy.IsEmpty() {
+		c.logger.Infof("Proxy cleared for session %s", sessionID)
+	} else {
+		c.logger.Infof("Using %s proxy %s for session %s", proxy.Scheme(), proxy.Redacted(), sessionID)
+	}
+
+	return nil
+}
+
 func (c *connectionManager) SafeDisconnect(client *whatsmeow.Client, sessionID string) {
 	if client == nil {
 		c.logger.Warnf("Cannot disconnect nil client for session %s", sessionID)
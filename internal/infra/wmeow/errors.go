@@ -1,5 +1,7 @@
 package wmeow
 
+import "zpmeow/internal/application/common"
+
 type ValidationError struct {
 	Field   string
 	Message string
@@ -9,6 +11,11 @@ func (e ValidationError) Error() string {
 	return e.Field + ": " + e.Message
 }
 
+// Unwrap permite que quem está fora do pacote reconheça a falha com errors.Is(err, common.ErrInvalidInput)
+func (e ValidationError) Unwrap() error {
+	return common.ErrInvalidInput
+}
+
 func newValidationError(field, message string) *ValidationError {
 	return &ValidationError{
 		Field:   field,
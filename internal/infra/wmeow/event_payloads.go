@@ -0,0 +1,803 @@
+package wmeow
+
+import (
+	"time"
+
+	waTypes "go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"zpmeow/internal/infra/database/models"
+)
+
+// Payloads tipados entregues em "data" no envelope v1 (webhooks.SchemaVersion).
+// Os campos são estáveis: adicionar campos mantém a versão, renomear ou remover exige uma nova.
+// JIDs são strings e datas são Unix em segundos, como nos eventos message.*.
+
+type messagePayload struct {
+	ID          string       `json:"id"`
+	ChatJID     string       `json:"chat_jid"`
+	Sender      string       `json:"sender"`
+	SenderAlt   string       `json:"sender_alt,omitempty"` // número quando o remetente veio como LID, e vice-versa
+	PushName    string       `json:"push_name,omitempty"`
+	FromMe      bool         `json:"from_me"`
+	IsGroup     bool         `json:"is_group"`
+	Type        string       `json:"type"`
+	Content     string       `json:"content,omitempty"`
+	Media       models.JSONB `json:"media,omitempty"`
+	QuotedID    string       `json:"quoted_id,omitempty"`
+	Mentions    []string     `json:"mentions,omitempty"`
+	IsForwarded bool         `json:"is_forwarded"`
+	IsEphemeral bool         `json:"is_ephemeral"`
+	IsViewOnce  bool         `json:"is_view_once"`
+	IsEdit      bool         `json:"is_edit"`
+	Timestamp   int64        `json:"timestamp"`
+}
+
+type undecryptableMessagePayload struct {
+	ID              string `json:"id"`
+	ChatJID         string `json:"chat_jid"`
+	Sender          string `json:"sender"`
+	FromMe          bool   `json:"from_me"`
+	IsGroup         bool   `json:"is_group"`
+	IsUnavailable   bool   `json:"is_unavailable"`
+	UnavailableType string `json:"unavailable_type,omitempty"`
+	Hidden          bool   `json:"hidden"`
+	Timestamp       int64  `json:"timestamp"`
+}
+
+type receiptPayload struct {
+	MessageIDs    []string `json:"message_ids"`
+	ChatJID       string   `json:"chat_jid"`
+	Sender        string   `json:"sender"`
+	MessageSender string   `json:"message_sender,omitempty"`
+	FromMe        bool     `json:"from_me"`
+	IsGroup       bool     `json:"is_group"`
+	Type          string   `json:"type"`
+	Timestamp     int64    `json:"timestamp"`
+}
+
+type mediaRetryPayload struct {
+	MessageID string `json:"message_id"`
+	ChatJID   string `json:"chat_jid"`
+	Sender    string `json:"sender,omitempty"`
+	FromMe    bool   `json:"from_me"`
+	ErrorCode int    `json:"error_code,omitempty"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+type presencePayload struct {
+	JID       string `json:"jid"`
+	Available bool   `json:"available"`
+	LastSeen  int64  `json:"last_seen,omitempty"` // ausente quando o contato oculta o visto por último
+}
+
+type chatPresencePayload struct {
+	ChatJID string `json:"chat_jid"`
+	Sender  string `json:"sender"`
+	IsGroup bool   `json:"is_group"`
+	State   string `json:"state"`           // composing ou paused
+	Media   string `json:"media,omitempty"` // audio quando está gravando
+}
+
+type groupParticipantPayload struct {
+	JID          string `json:"jid"`
+	PhoneNumber  string `json:"phone_number,omitempty"`
+	IsAdmin      bool   `json:"is_admin"`
+	IsSuperAdmin bool   `json:"is_super_admin"`
+}
+
+// groupUpdatePayload traz apenas os campos alterados; os ponteiros ausentes não mudaram
+type groupUpdatePayload struct {
+	GroupJID           string   `json:"group_jid"`
+	Sender             string   `json:"sender,omitempty"`
+	Name               *string  `json:"name,omitempty"`
+	Topic              *string  `json:"topic,omitempty"`
+	Locked             *bool    `json:"locked,omitempty"`
+	Announce           *bool    `json:"announce,omitempty"`
+	EphemeralTimer     *uint32  `json:"ephemeral_timer,omitempty"`
+	JoinApproval       *bool    `json:"join_approval,omitempty"`
+	Deleted            bool     `json:"deleted,omitempty"`
+	InviteLink         *string  `json:"invite_link,omitempty"`
+	JoinReason         string   `json:"join_reason,omitempty"`
+	Joined             []string `json:"joined,omitempty"`
+	Left               []string `json:"left,omitempty"`
+	Promoted           []string `json:"promoted,omitempty"`
+	Demoted            []string `json:"demoted,omitempty"`
+	ParticipantVersion string   `json:"participant_version,omitempty"`
+	Timestamp          int64    `json:"timestamp"`
+}
+
+type joinedGroupPayload struct {
+	GroupJID       string                    `json:"group_jid"`
+	Name           string                    `json:"name"`
+	Topic          string                    `json:"topic,omitempty"`
+	Owner          string                    `json:"owner,omitempty"`
+	Reason         string                    `json:"reason,omitempty"` // invite quando entrou por link
+	Type           string                    `json:"type,omitempty"`   // new quando o grupo acabou de ser criado
+	Sender         string                    `json:"sender,omitempty"`
+	Locked         bool                      `json:"locked"`
+	Announce       bool                      `json:"announce"`
+	EphemeralTimer uint32                    `json:"ephemeral_timer,omitempty"`
+	Participants   []groupParticipantPayload `json:"participants"`
+	CreatedAt      int64                     `json:"created_at,omitempty"`
+}
+
+type callPayload struct {
+	CallID         string `json:"call_id"`
+	From           string `json:"from"`
+	Creator        string `json:"creator,omitempty"`
+	GroupJID       string `json:"group_jid,omitempty"`
+	Media          string `json:"media,omitempty"` // audio ou video, só no CallOfferNotice
+	IsGroup        bool   `json:"is_group"`
+	Reason         string `json:"reason,omitempty"`
+	RemotePlatform string `json:"remote_platform,omitempty"`
+	RemoteVersion  string `json:"remote_version,omitempty"`
+	Timestamp      int64  `json:"timestamp"`
+}
+
+type connectionPayload struct {
+	State       string `json:"state"`
+	Reason      string `json:"reason,omitempty"`
+	Code        int    `json:"code,omitempty"`
+	Message     string `json:"message,omitempty"`
+	OnConnect   bool   `json:"on_connect,omitempty"`
+	ExpiresIn   int64  `json:"expires_in,omitempty"` // segundos até o fim do banimento temporário
+	ErrorCount  int    `json:"error_count,omitempty"`
+	LastSuccess int64  `json:"last_success,omitempty"`
+}
+
+type qrPayload struct {
+	Codes []string `json:"codes"`
+}
+
+type pairPayload struct {
+	JID          string `json:"jid,omitempty"`
+	LID          string `json:"lid,omitempty"`
+	BusinessName string `json:"business_name,omitempty"`
+	Platform     string `json:"platform,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+type newsletterPayload struct {
+	NewsletterJID string `json:"newsletter_jid"`
+	Name          string `json:"name,omitempty"`
+	Description   string `json:"description,omitempty"`
+	InviteCode    string `json:"invite_code,omitempty"`
+	Subscribers   int    `json:"subscribers,omitempty"`
+	Verified      bool   `json:"verified"`
+	Role          string `json:"role,omitempty"`
+	Muted         *bool  `json:"muted,omitempty"`
+}
+
+type newsletterMessagePayload struct {
+	ServerID  int            `json:"server_id"`
+	ID        string         `json:"id"`
+	Type      string         `json:"type"`
+	Views     int            `json:"views"`
+	Reactions map[string]int `json:"reactions,omitempty"`
+	Timestamp int64          `json:"timestamp"`
+}
+
+type newsletterUpdatePayload struct {
+	NewsletterJID string                     `json:"newsletter_jid"`
+	Messages      []newsletterMessagePayload `json:"messages"`
+	Timestamp     int64                      `json:"timestamp"`
+}
+
+type contactPayload struct {
+	JID          string `json:"jid"`
+	FullName     string `json:"full_name,omitempty"`
+	FirstName    string `json:"first_name,omitempty"`
+	PreviousName string `json:"previous_name,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Timestamp    int64  `json:"timestamp,omitempty"`
+}
+
+type picturePayload struct {
+	JID       string `json:"jid"`
+	Author    string `json:"author,omitempty"`
+	Removed   bool   `json:"removed"`
+	PictureID string `json:"picture_id,omitempty"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// chatStatePayload descreve alterações de app state de um chat; só o campo do evento é preenchido
+type chatStatePayload struct {
+	ChatJID      string `json:"chat_jid"`
+	Archived     *bool  `json:"archived,omitempty"`
+	Pinned       *bool  `json:"pinned,omitempty"`
+	Muted        *bool  `json:"muted,omitempty"`
+	MutedUntil   int64  `json:"muted_until,omitempty"`
+	Read         *bool  `json:"read,omitempty"`
+	FromFullSync bool   `json:"from_full_sync"`
+	Timestamp    int64  `json:"timestamp"`
+}
+
+type messageStatePayload struct {
+	MessageID    string `json:"message_id"`
+	ChatJID      string `json:"chat_jid"`
+	Sender       string `json:"sender,omitempty"`
+	FromMe       bool   `json:"from_me"`
+	Starred      *bool  `json:"starred,omitempty"`
+	FromFullSync bool   `json:"from_full_sync"`
+	Timestamp    int64  `json:"timestamp"`
+}
+
+type labelPayload struct {
+	LabelID      string `json:"label_id"`
+	Name         string `json:"name,omitempty"`
+	Color        *int32 `json:"color,omitempty"`
+	Deleted      bool   `json:"deleted,omitempty"`
+	ChatJID      string `json:"chat_jid,omitempty"`
+	MessageID    string `json:"message_id,omitempty"`
+	Labeled      *bool  `json:"labeled,omitempty"`
+	FromFullSync bool   `json:"from_full_sync"`
+	Timestamp    int64  `json:"timestamp"`
+}
+
+type blocklistChangePayload struct {
+	JID    string `json:"jid"`
+	Action string `json:"action"` // block ou unblock
+}
+
+type blocklistPayload struct {
+	Action  string                   `json:"action,omitempty"` // vazio quando é a lista completa
+	Changes []blocklistChangePayload `json:"changes"`
+}
+
+type privacySettingsPayload struct {
+	Settings map[string]string `json:"settings"`
+	Changed  []string          `json:"changed"`
+}
+
+type settingPayload struct {
+	JID          string `json:"jid,omitempty"`
+	Value        any    `json:"value"`
+	FromFullSync bool   `json:"from_full_sync"`
+	Timestamp    int64  `json:"timestamp,omitempty"`
+}
+
+type userAboutPayload struct {
+	JID       string `json:"jid"`
+	Status    string `json:"status"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+type identityChangePayload struct {
+	JID       string `json:"jid"`
+	Implicit  bool   `json:"implicit"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+type appStatePayload struct {
+	Name  string   `json:"name,omitempty"`
+	Index []string `json:"index,omitempty"`
+}
+
+type historySyncPayload struct {
+	SyncType      string `json:"sync_type"`
+	ChunkOrder    uint32 `json:"chunk_order"`
+	Progress      uint32 `json:"progress"`
+	Conversations int    `json:"conversations"`
+}
+
+type offlineSyncPayload struct {
+	Total          int `json:"total"`
+	AppDataChanges int `json:"app_data_changes,omitempty"`
+	Messages       int `json:"messages,omitempty"`
+	Notifications  int `json:"notifications,omitempty"`
+	Receipts       int `json:"receipts,omitempty"`
+}
+
+// typedPayload converte o evento do whatsmeow no payload estável do envelope v1.
+// Eventos sem payload próprio mantêm a struct original.
+func typedPayload(evt interface{}) interface{} {
+	switch e := evt.(type) {
+	case *events.Message:
+		return newMessagePayload(e)
+	case *events.FBMessage:
+		return messagePayload{
+			ID:        e.Info.ID,
+			ChatJID:   e.Info.Chat.String(),
+			Sender:    e.Info.Sender.ToNonAD().String(),
+			PushName:  e.Info.PushName,
+			FromMe:    e.Info.IsFromMe,
+			IsGroup:   e.Info.IsGroup,
+			Type:      "fb",
+			Timestamp: e.Info.Timestamp.Unix(),
+		}
+	case *events.UndecryptableMessage:
+		return undecryptableMessagePayload{
+			ID:              e.Info.ID,
+			ChatJID:         e.Info.Chat.String(),
+			Sender:          e.Info.Sender.ToNonAD().String(),
+			FromMe:          e.Info.IsFromMe,
+			IsGroup:         e.Info.IsGroup,
+			IsUnavailable:   e.IsUnavailable,
+			UnavailableType: string(e.UnavailableType),
+			Hidden:          e.DecryptFailMode == events.DecryptFailHide,
+			Timestamp:       e.Info.Timestamp.Unix(),
+		}
+	case *events.Receipt:
+		return newReceiptPayload(e)
+	case *events.MediaRetry:
+		payload := mediaRetryPayload{
+			MessageID: e.MessageID,
+			ChatJID:   e.ChatID.String(),
+			Sender:    jidString(e.SenderID),
+			FromMe:    e.FromMe,
+			Timestamp: e.Timestamp.Unix(),
+		}
+		if e.Error != nil {
+			payload.ErrorCode = e.Error.Code
+		}
+		return payload
+
+	case *events.Presence:
+		return presencePayload{JID: e.From.String(), Available: !e.Unavailable, LastSeen: unixTime(e.LastSeen)}
+	case *events.ChatPresence:
+		return chatPresencePayload{
+			ChatJID: e.Chat.String(),
+			Sender:  e.Sender.ToNonAD().String(),
+			IsGroup: e.IsGroup,
+			State:   string(e.State),
+			Media:   string(e.Media),
+		}
+
+	case *events.GroupInfo:
+		return newGroupUpdatePayload(e)
+	case *events.JoinedGroup:
+		return newJoinedGroupPayload(e)
+
+	case *events.CallOffer:
+		return newCallPayload(e.BasicCallMeta, e.CallRemoteMeta, "", "")
+	case *events.CallAccept:
+		return newCallPayload(e.BasicCallMeta, e.CallRemoteMeta, "", "")
+	case *events.CallPreAccept:
+		return newCallPayload(e.BasicCallMeta, e.CallRemoteMeta, "", "")
+	case *events.CallTransport:
+		return newCallPayload(e.BasicCallMeta, e.CallRemoteMeta, "", "")
+	case *events.CallOfferNotice:
+		payload := newCallPayload(e.BasicCallMeta, waTypes.CallRemoteMeta{}, e.Media, "")
+		payload.IsGroup = payload.IsGroup || e.Type == "group"
+		return payload
+	case *events.CallRelayLatency:
+		return newCallPayload(e.BasicCallMeta, waTypes.CallRemoteMeta{}, "", "")
+	case *events.CallTerminate:
+		return newCallPayload(e.BasicCallMeta, waTypes.CallRemoteMeta{}, "", e.Reason)
+	case *events.CallReject:
+		return newCallPayload(e.BasicCallMeta, waTypes.CallRemoteMeta{}, "", "")
+
+	case *events.Connected:
+		return connectionPayload{State: "connected"}
+	case *events.Disconnected:
+		return connectionPayload{State: "disconnected"}
+	case *events.LoggedOut:
+		return connectionPayload{State: "logged_out", Reason: e.Reason.String(), Code: int(e.Reason), OnConnect: e.OnConnect}
+	case *events.ConnectFailure:
+		return connectionPayload{State: "connect_failure", Reason: e.Reason.String(), Code: int(e.Reason), Message: e.Message}
+	case *events.TemporaryBan:
+		return connectionPayload{State: "temporary_ban", Reason: e.Code.String(), Code: int(e.Code), ExpiresIn: int64(e.Expire.Seconds())}
+	case *events.StreamReplaced:
+		return connectionPayload{State: "stream_replaced"}
+	case *events.StreamError:
+		return connectionPayload{State: "stream_error", Reason: e.Code}
+	case *events.ClientOutdated:
+		return connectionPayload{State: "client_outdated"}
+	case *events.KeepAliveTimeout:
+		return connectionPayload{State: "keepalive_timeout", ErrorCount: e.ErrorCount, LastSuccess: unixTime(e.LastSuccess)}
+	case *events.KeepAliveRestored:
+		return connectionPayload{State: "keepalive_restored"}
+	case *events.CATRefreshError:
+		return connectionPayload{State: "cat_refresh_error", Message: errorString(e.Error)}
+	case *events.ManualLoginReconnect:
+		return connectionPayload{State: "manual_login_reconnect"}
+
+	case *events.QR:
+		return qrPayload{Codes: e.Codes}
+	case *events.PairSuccess:
+		return pairPayload{JID: jidString(e.ID), LID: jidString(e.LID), BusinessName: e.BusinessName, Platform: e.Platform}
+	case *events.PairError:
+		return pairPayload{JID: jidString(e.ID), LID: jidString(e.LID), BusinessName: e.BusinessName, Platform: e.Platform, Error: errorString(e.Error)}
+	case *events.QRScannedWithoutMultidevice:
+		return pairPayload{Error: "qr code scanned without multidevice enabled"}
+
+	case *events.NewsletterJoin:
+		return newNewsletterPayload(e)
+	case *events.NewsletterLeave:
+		return newsletterPayload{NewsletterJID: e.ID.String(), Role: string(e.Role)}
+	case *events.NewsletterMuteChange:
+		muted := e.Mute == waTypes.NewsletterMuteOn
+		return newsletterPayload{NewsletterJID: e.ID.String(), Muted: &muted}
+	case *events.NewsletterLiveUpdate:
+		return newNewsletterUpdatePayload(e)
+
+	case *events.Contact:
+		return contactPayload{
+			JID:       e.JID.String(),
+			FullName:  e.Action.GetFullName(),
+			FirstName: e.Action.GetFirstName(),
+			Timestamp: e.Timestamp.Unix(),
+		}
+	case *events.PushName:
+		return contactPayload{JID: e.JID.String(), PreviousName: e.OldPushName, Name: e.NewPushName}
+	case *events.BusinessName:
+		return contactPayload{JID: e.JID.String(), PreviousName: e.OldBusinessName, Name: e.NewBusinessName}
+	case *events.Picture:
+		return picturePayload{
+			JID:       e.JID.String(),
+			Author:    jidString(e.Author),
+			Removed:   e.Remove,
+			PictureID: e.PictureID,
+			Timestamp: e.Timestamp.Unix(),
+		}
+	case *events.UserAbout:
+		return userAboutPayload{JID: e.JID.String(), Status: e.Status, Timestamp: e.Timestamp.Unix()}
+	case *events.IdentityChange:
+		return identityChangePayload{JID: e.JID.String(), Implicit: e.Implicit, Timestamp: e.Timestamp.Unix()}
+
+	case *events.Archive:
+		archived := e.Action.GetArchived()
+		return chatStatePayload{ChatJID: e.JID.String(), Archived: &archived, FromFullSync: e.FromFullSync, Timestamp: e.Timestamp.Unix()}
+	case *events.Pin:
+		pinned := e.Action.GetPinned()
+		return chatStatePayload{ChatJID: e.JID.String(), Pinned: &pinned, FromFullSync: e.FromFullSync, Timestamp: e.Timestamp.Unix()}
+	case *events.Mute:
+		muted := e.Action.GetMuted()
+		return chatStatePayload{
+			ChatJID:      e.JID.String(),
+			Muted:        &muted,
+			MutedUntil:   e.Action.GetMuteEndTimestamp(),
+			FromFullSync: e.FromFullSync,
+			Timestamp:    e.Timestamp.Unix(),
+		}
+	case *events.MarkChatAsRead:
+		read := e.Action.GetRead()
+		return chatStatePayload{ChatJID: e.JID.String(), Read: &read, FromFullSync: e.FromFullSync, Timestamp: e.Timestamp.Unix()}
+	case *events.ClearChat:
+		return chatStatePayload{ChatJID: e.JID.String(), FromFullSync: e.FromFullSync, Timestamp: e.Timestamp.Unix()}
+	case *events.DeleteChat:
+		return chatStatePayload{ChatJID: e.JID.String(), FromFullSync: e.FromFullSync, Timestamp: e.Timestamp.Unix()}
+	case *events.Star:
+		starred := e.Action.GetStarred()
+		return messageStatePayload{
+			MessageID:    e.MessageID,
+			ChatJID:      e.ChatJID.String(),
+			Sender:       jidString(e.SenderJID),
+			FromMe:       e.IsFromMe,
+			Starred:      &starred,
+			FromFullSync: e.FromFullSync,
+			Timestamp:    e.Timestamp.Unix(),
+		}
+	case *events.DeleteForMe:
+		return messageStatePayload{
+			MessageID:    e.MessageID,
+			ChatJID:      e.ChatJID.String(),
+			Sender:       jidString(e.SenderJID),
+			FromMe:       e.IsFromMe,
+			FromFullSync: e.FromFullSync,
+			Timestamp:    e.Timestamp.Unix(),
+		}
+
+	case *events.LabelEdit:
+		color := e.Action.GetColor()
+		return labelPayload{
+			LabelID:      e.LabelID,
+			Name:         e.Action.GetName(),
+			Color:        &color,
+			Deleted:      e.Action.GetDeleted(),
+			FromFullSync: e.FromFullSync,
+			Timestamp:    e.Timestamp.Unix(),
+		}
+	case *events.LabelAssociationChat:
+		labeled := e.Action.GetLabeled()
+		return labelPayload{LabelID: e.LabelID, ChatJID: e.JID.String(), Labeled: &labeled, FromFullSync: e.FromFullSync, Timestamp: e.Timestamp.Unix()}
+	case *events.LabelAssociationMessage:
+		labeled := e.Action.GetLabeled()
+		return labelPayload{
+			LabelID:      e.LabelID,
+			ChatJID:      e.JID.String(),
+			MessageID:    e.MessageID,
+			Labeled:      &labeled,
+			FromFullSync: e.FromFullSync,
+			Timestamp:    e.Timestamp.Unix(),
+		}
+
+	case *events.Blocklist:
+		payload := blocklistPayload{Action: string(e.Action), Changes: make([]blocklistChangePayload, 0, len(e.Changes))}
+		for _, change := range e.Changes {
+			payload.Changes = append(payload.Changes, blocklistChangePayload{JID: change.JID.String(), Action: string(change.Action)})
+		}
+		return payload
+	case *events.PrivacySettings:
+		return newPrivacySettingsPayload(e)
+	case *events.PushNameSetting:
+		return settingPayload{Value: e.Action.GetName(), FromFullSync: e.FromFullSync, Timestamp: e.Timestamp.Unix()}
+	case *events.UnarchiveChatsSetting:
+		return settingPayload{Value: e.Action.GetUnarchiveChats(), FromFullSync: e.FromFullSync, Timestamp: e.Timestamp.Unix()}
+	case *events.UserStatusMute:
+		return settingPayload{JID: e.JID.String(), Value: e.Action.GetMuted(), FromFullSync: e.FromFullSync, Timestamp: e.Timestamp.Unix()}
+
+	case *events.AppState:
+		return appStatePayload{Index: e.Index}
+	case *events.AppStateSyncComplete:
+		return appStatePayload{Name: string(e.Name)}
+	case *events.HistorySync:
+		return historySyncPayload{
+			SyncType:      e.Data.GetSyncType().String(),
+			ChunkOrder:    e.Data.GetChunkOrder(),
+			Progress:      e.Data.GetProgress(),
+			Conversations: len(e.Data.GetConversations()),
+		}
+	case *events.OfflineSyncPreview:
+		return offlineSyncPayload{
+			Total:          e.Total,
+			AppDataChanges: e.AppDataChanges,
+			Messages:       e.Messages,
+			Notifications:  e.Notifications,
+			Receipts:       e.Receipts,
+		}
+	case *events.OfflineSyncCompleted:
+		return offlineSyncPayload{Total: e.Count}
+	}
+
+	return evt
+}
+
+func newMessagePayload(msg *events.Message) messagePayload {
+	payload := messagePayload{
+		ID:          msg.Info.ID,
+		ChatJID:     msg.Info.Chat.String(),
+		Sender:      msg.Info.Sender.ToNonAD().String(),
+		SenderAlt:   jidString(msg.Info.SenderAlt.ToNonAD()),
+		PushName:    msg.Info.PushName,
+		FromMe:      msg.Info.IsFromMe,
+		IsGroup:     msg.Info.IsGroup,
+		IsEphemeral: msg.IsEphemeral,
+		IsViewOnce:  msg.IsViewOnce,
+		IsEdit:      msg.IsEdit,
+		Timestamp:   msg.Info.Timestamp.Unix(),
+	}
+
+	switch {
+	case msg.Message.GetProtocolMessage() != nil:
+		payload.Type = "protocol"
+	case msg.Message.GetReactionMessage() != nil || msg.Message.GetEncReactionMessage() != nil:
+		payload.Type = "reaction"
+		payload.Content = msg.Message.GetReactionMessage().GetText()
+	case msg.Message.GetPollUpdateMessage() != nil:
+		payload.Type = "poll_vote"
+	default:
+		payload.Type, payload.Content, payload.Media = describeMessage(msg.Message)
+		if len(payload.Media) == 0 {
+			payload.Media = nil
+		}
+	}
+
+	if contextInfo := messageContextInfo(msg.Message); contextInfo != nil {
+		payload.QuotedID = contextInfo.GetStanzaID()
+		payload.Mentions = contextInfo.GetMentionedJID()
+		payload.IsForwarded = contextInfo.GetIsForwarded()
+	}
+
+	return payload
+}
+
+// receiptTypeNames dá nome ao recibo de entrega, que no whatsmeow é o tipo vazio
+var receiptTypeNames = map[waTypes.ReceiptType]string{
+	waTypes.ReceiptTypeDelivered: "delivered",
+}
+
+func newReceiptPayload(receipt *events.Receipt) receiptPayload {
+	payload := receiptPayload{
+		MessageIDs:    make([]string, 0, len(receipt.MessageIDs)),
+		ChatJID:       receipt.Chat.String(),
+		Sender:        receipt.Sender.ToNonAD().String(),
+		MessageSender: jidString(receipt.MessageSender),
+		FromMe:        receipt.IsFromMe,
+		IsGroup:       receipt.IsGroup,
+		Type:          string(receipt.Type),
+		Timestamp:     receipt.Timestamp.Unix(),
+	}
+	if name, ok := receiptTypeNames[receipt.Type]; ok {
+		payload.Type = name
+	}
+	for _, id := range receipt.MessageIDs {
+		payload.MessageIDs = append(payload.MessageIDs, string(id))
+	}
+	return payload
+}
+
+func newGroupUpdatePayload(info *events.GroupInfo) groupUpdatePayload {
+	payload := groupUpdatePayload{
+		GroupJID:           info.JID.String(),
+		JoinReason:         info.JoinReason,
+		Joined:             jidStrings(info.Join),
+		Left:               jidStrings(info.Leave),
+		Promoted:           jidStrings(info.Promote),
+		Demoted:            jidStrings(info.Demote),
+		InviteLink:         info.NewInviteLink,
+		ParticipantVersion: info.ParticipantVersionID,
+		Timestamp:          info.Timestamp.Unix(),
+	}
+	if info.Sender != nil {
+		payload.Sender = info.Sender.ToNonAD().String()
+	}
+	if info.Name != nil {
+		payload.Name = &info.Name.Name
+	}
+	if info.Topic != nil {
+		payload.Topic = &info.Topic.Topic
+	}
+	if info.Locked != nil {
+		payload.Locked = &info.Locked.IsLocked
+	}
+	if info.Announce != nil {
+		payload.Announce = &info.Announce.IsAnnounce
+	}
+	if info.Ephemeral != nil {
+		timer := info.Ephemeral.DisappearingTimer
+		if !info.Ephemeral.IsEphemeral {
+			timer = 0
+		}
+		payload.EphemeralTimer = &timer
+	}
+	if info.MembershipApprovalMode != nil {
+		payload.JoinApproval = &info.MembershipApprovalMode.IsJoinApprovalRequired
+	}
+	if info.Delete != nil {
+		payload.Deleted = info.Delete.Deleted
+	}
+	return payload
+}
+
+func newJoinedGroupPayload(joined *events.JoinedGroup) joinedGroupPayload {
+	payload := joinedGroupPayload{
+		GroupJID:     joined.JID.String(),
+		Name:         joined.Name,
+		Topic:        joined.Topic,
+		Owner:        jidString(joined.OwnerJID),
+		Reason:       joined.Reason,
+		Type:         joined.Type,
+		Locked:       joined.IsLocked,
+		Announce:     joined.IsAnnounce,
+		Participants: make([]groupParticipantPayload, 0, len(joined.Participants)),
+		CreatedAt:    unixTime(joined.GroupCreated),
+	}
+	if joined.IsEphemeral {
+		payload.EphemeralTimer = joined.DisappearingTimer
+	}
+	if joined.Sender != nil {
+		payload.Sender = joined.Sender.ToNonAD().String()
+	}
+	for _, participant := range joined.Participants {
+		payload.Participants = append(payload.Participants, groupParticipantPayload{
+			JID:          participant.JID.String(),
+			PhoneNumber:  jidString(participant.PhoneNumber),
+			IsAdmin:      participant.IsAdmin,
+			IsSuperAdmin: participant.IsSuperAdmin,
+		})
+	}
+	return payload
+}
+
+func newCallPayload(meta waTypes.BasicCallMeta, remote waTypes.CallRemoteMeta, media, reason string) callPayload {
+	return callPayload{
+		CallID:         meta.CallID,
+		From:           meta.From.ToNonAD().String(),
+		Creator:        jidString(meta.CallCreator.ToNonAD()),
+		GroupJID:       jidString(meta.GroupJID),
+		Media:          media,
+		IsGroup:        !meta.GroupJID.IsEmpty(),
+		Reason:         reason,
+		RemotePlatform: remote.RemotePlatform,
+		RemoteVersion:  remote.RemoteVersion,
+		Timestamp:      meta.Timestamp.Unix(),
+	}
+}
+
+func newNewsletterPayload(join *events.NewsletterJoin) newsletterPayload {
+	thread := join.ThreadMeta
+	payload := newsletterPayload{
+		NewsletterJID: join.ID.String(),
+		Name:          thread.Name.Text,
+		Description:   thread.Description.Text,
+		InviteCode:    thread.InviteCode,
+		Subscribers:   thread.SubscriberCount,
+		Verified:      thread.VerificationState == waTypes.NewsletterVerificationStateVerified,
+	}
+	if join.ViewerMeta != nil {
+		muted := join.ViewerMeta.Mute == waTypes.NewsletterMuteOn
+		payload.Role = string(join.ViewerMeta.Role)
+		payload.Muted = &muted
+	}
+	return payload
+}
+
+func newNewsletterUpdatePayload(update *events.NewsletterLiveUpdate) newsletterUpdatePayload {
+	payload := newsletterUpdatePayload{
+		NewsletterJID: update.JID.String(),
+		Messages:      make([]newsletterMessagePayload, 0, len(update.Messages)),
+		Timestamp:     update.Time.Unix(),
+	}
+	for _, msg := range update.Messages {
+		payload.Messages = append(payload.Messages, newsletterMessagePayload{
+			ServerID:  int(msg.MessageServerID),
+			ID:        msg.MessageID,
+			Type:      msg.Type,
+			Views:     msg.ViewsCount,
+			Reactions: msg.ReactionCounts,
+			Timestamp: msg.Timestamp.Unix(),
+		})
+	}
+	return payload
+}
+
+func newPrivacySettingsPayload(evt *events.PrivacySettings) privacySettingsPayload {
+	settings := evt.NewSettings
+	payload := privacySettingsPayload{
+		Settings: map[string]string{
+			"group_add":     string(settings.GroupAdd),
+			"last_seen":     string(settings.LastSeen),
+			"status":        string(settings.Status),
+			"profile":       string(settings.Profile),
+			"read_receipts": string(settings.ReadReceipts),
+			"call_add":      string(settings.CallAdd),
+			"online":        string(settings.Online),
+		},
+		Changed: []string{},
+	}
+
+	changed := []struct {
+		name    string
+		changed bool
+	}{
+		{"group_add", evt.GroupAddChanged},
+		{"last_seen", evt.LastSeenChanged},
+		{"status", evt.StatusChanged},
+		{"profile", evt.ProfileChanged},
+		{"read_receipts", evt.ReadReceiptsChanged},
+		{"call_add", evt.CallAddChanged},
+		{"online", evt.OnlineChanged},
+	}
+	for _, setting := range changed {
+		if setting.changed {
+			payload.Changed = append(payload.Changed, setting.name)
+		}
+	}
+	return payload
+}
+
+// jidString retorna o JID como string, ou vazio quando não informado
+func jidString(jid waTypes.JID) string {
+	if jid.IsEmpty() {
+		return ""
+	}
+	return jid.String()
+}
+
+func jidStrings(jids []waTypes.JID) []string {
+	if len(jids) == 0 {
+		return nil
+	}
+	result := make([]string, 0, len(jids))
+	for _, jid := range jids {
+		result = append(result, jid.String())
+	}
+	return result
+}
+
+// unixTime converte a data em segundos Unix, mantendo zero para datas não informadas
+func unixTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
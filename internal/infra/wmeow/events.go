@@ -2,6 +2,7 @@ package wmeow
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -12,9 +13,12 @@ import (
 	"zpmeow/internal/infra/chatwoot"
 	"zpmeow/internal/infra/database/models"
 	"zpmeow/internal/infra/database/repository"
+	"zpmeow/internal/infra/eventstream"
 	"zpmeow/internal/infra/logging"
 	"zpmeow/internal/infra/webhooks"
 
+	"go.mau.fi/whatsmeow"
+	waTypes "go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
 )
 
@@ -27,9 +31,19 @@ type EventProcessor struct {
 	chatwootIntegration *chatwoot.Integration
 	chatwootRepo        *repository.ChatwootRepository
 	messageRepo         *repository.MessageRepository
+	receiptRepo         *repository.MessageReceiptRepository
+	reactionRepo        *repository.MessageReactionRepository
 	chatRepo            *repository.ChatRepository
+	labelRepo           *repository.LabelRepository
+	statusRepo          *repository.StatusRepository
 	webhookRepo         *repository.WebhookRepository
-	mediaCache          map[string]interface{} // Cache para mensagens de mídia
+	webhookQueue        *webhooks.DeliveryQueue
+	mediaArchiver       *mediaArchiver
+	historyImporter     *historyImporter
+	callTracker         *callTracker
+	privacyCache        *privacyCache
+	eventStream         *eventstream.Hub  // clientes conectados por SSE/WebSocket
+	waClient            *whatsmeow.Client // usado para baixar mídias recebidas
 
 	receiptMutex   sync.Mutex
 	receiptCount   int
@@ -126,6 +140,8 @@ var eventHandlers = map[string]func(*EventProcessor, interface{}){
 	"*events.Message": (*EventProcessor).handleMessage,
 	"*events.Receipt": (*EventProcessor).handleReceipt,
 
+	"*events.HistorySync": (*EventProcessor).handleHistorySync,
+
 	"*events.Connected":    (*EventProcessor).handleConnected,
 	"*events.Disconnected": (*EventProcessor).handleDisconnected,
 	"*events.LoggedOut":    (*EventProcessor).handleLoggedOut,
@@ -136,9 +152,26 @@ var eventHandlers = map[string]func(*EventProcessor, interface{}){
 
 	"*events.Presence":     (*EventProcessor).handlePresence,
 	"*events.ChatPresence": (*EventProcessor).handleChatPresence,
+
+	"*events.CallOffer":       (*EventProcessor).handleCallOffer,
+	"*events.CallOfferNotice": (*EventProcessor).handleCallOfferNotice,
+	"*events.CallAccept":      (*EventProcessor).handleCallAccept,
+	"*events.CallReject":      (*EventProcessor).handleCallReject,
+	"*events.CallTerminate":   (*EventProcessor).handleCallTerminate,
+
+	"*events.PrivacySettings": (*EventProcessor).handlePrivacySettings,
+
+	"*events.Pin":            (*EventProcessor).handleChatPin,
+	"*events.Mute":           (*EventProcessor).handleChatMute,
+	"*events.Archive":        (*EventProcessor).handleChatArchive,
+	"*events.MarkChatAsRead": (*EventProcessor).handleMarkChatAsRead,
+
+	"*events.LabelEdit":               (*EventProcessor).handleLabelEdit,
+	"*events.LabelAssociationChat":    (*EventProcessor).handleLabelAssociationChat,
+	"*events.LabelAssociationMessage": (*EventProcessor).handleLabelAssociationMessage,
 }
 
-func NewEventProcessor(sessionID string, sessionRepo session.Repository, messageRepo *repository.MessageRepository, chatRepo *repository.ChatRepository, webhookRepo *repository.WebhookRepository) *EventProcessor {
+func NewEventProcessor(sessionID string, sessionRepo session.Repository, messageRepo *repository.MessageRepository, receiptRepo *repository.MessageReceiptRepository, reactionRepo *repository.MessageReactionRepository, chatRepo *repository.ChatRepository, labelRepo *repository.LabelRepository, statusRepo *repository.StatusRepository, webhookRepo *repository.WebhookRepository, webhookQueue *webhooks.DeliveryQueue, mediaArchiver *mediaArchiver, historyImporter *historyImporter, callTracker *callTracker, privacyCache *privacyCache, eventStream *eventstream.Hub) *EventProcessor {
 	logger := logging.GetLogger().Sub("events").Sub(sessionID)
 	ep := &EventProcessor{
 		sessionID:        sessionID,
@@ -147,8 +180,18 @@ func NewEventProcessor(sessionID string, sessionRepo session.Repository, message
 		logger:           logger,
 		subscribedEvents: []string{},
 		messageRepo:      messageRepo,
+		receiptRepo:      receiptRepo,
+		reactionRepo:     reactionRepo,
 		chatRepo:         chatRepo,
+		labelRepo:        labelRepo,
+		statusRepo:       statusRepo,
 		webhookRepo:      webhookRepo,
+		webhookQueue:     webhookQueue,
+		mediaArchiver:    mediaArchiver,
+		historyImporter:  historyImporter,
+		callTracker:      callTracker,
+		privacyCache:     privacyCache,
+		eventStream:      eventStream,
 	}
 
 	ep.loadSubscribedEvents()
@@ -156,7 +199,7 @@ func NewEventProcessor(sessionID string, sessionRepo session.Repository, message
 	return ep
 }
 
-func NewEventProcessorWithChatwoot(sessionID string, sessionRepo session.Repository, chatwootIntegration *chatwoot.Integration, chatwootRepo *repository.ChatwootRepository, messageRepo *repository.MessageRepository, chatRepo *repository.ChatRepository, webhookRepo *repository.WebhookRepository) *EventProcessor {
+func NewEventProcessorWithChatwoot(sessionID string, sessionRepo session.Repository, chatwootIntegration *chatwoot.Integration, chatwootRepo *repository.ChatwootRepository, messageRepo *repository.MessageRepository, receiptRepo *repository.MessageReceiptRepository, reactionRepo *repository.MessageReactionRepository, chatRepo *repository.ChatRepository, labelRepo *repository.LabelRepository, statusRepo *repository.StatusRepository, webhookRepo *repository.WebhookRepository, webhookQueue *webhooks.DeliveryQueue, mediaArchiver *mediaArchiver, historyImporter *historyImporter, callTracker *callTracker, privacyCache *privacyCache, eventStream *eventstream.Hub) *EventProcessor {
 	logger := logging.GetLogger().Sub("events").Sub(sessionID)
 	ep := &EventProcessor{
 		sessionID:           sessionID,
@@ -167,8 +210,18 @@ func NewEventProcessorWithChatwoot(sessionID string, sessionRepo session.Reposit
 		chatwootIntegration: chatwootIntegration,
 		chatwootRepo:        chatwootRepo,
 		messageRepo:         messageRepo,
+		receiptRepo:         receiptRepo,
+		reactionRepo:        reactionRepo,
 		chatRepo:            chatRepo,
+		labelRepo:           labelRepo,
+		statusRepo:          statusRepo,
 		webhookRepo:         webhookRepo,
+		webhookQueue:        webhookQueue,
+		mediaArchiver:       mediaArchiver,
+		historyImporter:     historyImporter,
+		callTracker:         callTracker,
+		privacyCache:        privacyCache,
+		eventStream:         eventStream,
 	}
 
 	ep.loadSubscribedEvents()
@@ -176,6 +229,35 @@ func NewEventProcessorWithChatwoot(sessionID string, sessionRepo session.Reposit
 	return ep
 }
 
+// persistedEvents são gravados no banco mesmo quando nenhum webhook está inscrito neles
+var persistedEvents = map[string]bool{
+	"Message":     true,
+	"Receipt":     true,
+	"HistorySync": true,
+
+	// Registro de chamadas e política de rejeição
+	"CallOffer":       true,
+	"CallOfferNotice": true,
+	"CallAccept":      true,
+	"CallReject":      true,
+	"CallTerminate":   true,
+
+	// Cópia em cache das configurações de privacidade, descartada a cada conexão
+	"PrivacySettings": true,
+	"Connected":       true,
+
+	// Estado dos chats em zpChats sincronizado com o app state
+	"Pin":            true,
+	"Mute":           true,
+	"Archive":        true,
+	"MarkChatAsRead": true,
+
+	// Etiquetas do WhatsApp Business e suas associações
+	"LabelEdit":               true,
+	"LabelAssociationChat":    true,
+	"LabelAssociationMessage": true,
+}
+
 func (ep *EventProcessor) shouldProcessEvent(eventType string) bool {
 	if len(ep.subscribedEvents) == 0 {
 		return true // Process all events if none specified
@@ -208,10 +290,10 @@ func (ep *EventProcessor) logEventWithThrottling(eventType string, details strin
 }
 
 func (ep *EventProcessor) loadSubscribedEvents() {
-	// Carregar eventos da nova tabela zpWebhooks
+	// Carregar a união dos eventos dos endpoints da tabela zpWebhooks
 	if ep.webhookRepo != nil {
 		ctx := context.Background()
-		webhookConfig, err := ep.webhookRepo.GetBySessionID(ctx, ep.sessionID)
+		events, err := ep.webhookRepo.SubscribedEvents(ctx, ep.sessionID)
 		if err != nil {
 			ep.logger.Debugf("No webhook configuration found for session %s: %v", ep.sessionID, err)
 			ep.subscribedEvents = []string{"All"}
@@ -219,11 +301,7 @@ func (ep *EventProcessor) loadSubscribedEvents() {
 			return
 		}
 
-		if webhookConfig != nil && len(webhookConfig.Events) > 0 {
-			ep.subscribedEvents = webhookConfig.Events
-		} else {
-			ep.subscribedEvents = []string{"All"}
-		}
+		ep.subscribedEvents = events
 	} else {
 		// Fallback para compatibilidade - buscar da sessão
 		sessionEntity, err := ep.sessionManager.GetSession(ep.sessionID)
@@ -255,24 +333,24 @@ func (ep *EventProcessor) UpdateWebhookURL(webhookURL string) {
 	ep.logger.Infof("Updated webhook URL: %s", webhookURL)
 }
 
-// getWebhookURL carrega a URL do webhook dinamicamente do repositório
-func (ep *EventProcessor) getWebhookURL() string {
+// getWebhookEndpoints carrega dinamicamente do repositório os endpoints ativos inscritos no evento
+func (ep *EventProcessor) getWebhookEndpoints(event string) []*models.WebhookModel {
 	if ep.webhookRepo == nil {
-		return ep.webhookURL // Fallback para compatibilidade
+		// Fallback para compatibilidade
+		if ep.webhookURL == "" {
+			return nil
+		}
+		return []*models.WebhookModel{{SessionId: ep.sessionID, URL: ep.webhookURL, IsActive: true, PayloadFormat: models.WebhookPayloadFormatRaw}}
 	}
 
 	ctx := context.Background()
-	webhookConfig, err := ep.webhookRepo.GetBySessionID(ctx, ep.sessionID)
+	endpoints, err := ep.webhookRepo.ListActiveForEvent(ctx, ep.sessionID, event)
 	if err != nil {
 		ep.logger.Debugf("No webhook configuration found for session %s: %v", ep.sessionID, err)
-		return ""
-	}
-
-	if webhookConfig != nil && webhookConfig.IsActive && webhookConfig.URL != "" {
-		return webhookConfig.URL
+		return nil
 	}
 
-	return ""
+	return endpoints
 }
 
 func (ep *EventProcessor) HandleEvent(evt interface{}) {
@@ -286,12 +364,17 @@ func (ep *EventProcessor) HandleEvent(evt interface{}) {
 		return
 	}
 
-	if !ep.shouldProcessEvent(systemEventType) {
+	if !ep.shouldProcessEvent(systemEventType) && !persistedEvents[systemEventType] {
 		return
 	}
 
 	ep.logEventWithThrottling(systemEventType, fmt.Sprintf("Processing %s", systemEventType))
 
+	// Message é publicado pelo próprio handleMessage, depois de descartar status, edições, revogações e reações
+	if _, isMessage := evt.(*events.Message); !isMessage && ep.eventStream != nil && ep.shouldProcessEvent(systemEventType) {
+		ep.streamEvent(systemEventType, typedPayload(evt))
+	}
+
 	if handler, exists := eventHandlers[eventType]; exists {
 		handler(ep, evt)
 	} else {
@@ -306,32 +389,53 @@ func (ep *EventProcessor) handleMessage(evt interface{}) {
 	// Salvar mensagem no banco de dados zpmeow primeiro
 	ep.logger.Infof("💾 [DATABASE DEBUG] Starting database save for session %s, message ID: %s, from: %s, type: %s",
 		ep.sessionID, msg.Info.ID, msg.Info.Sender.String(), fmt.Sprintf("%T", msg.Message))
+	// Status (stories) vão para zpStatuses em vez do histórico de mensagens e já saem como status.received,
+	// então não seguem para o Chatwoot nem para o webhook Message
+	if msg.Info.Chat == waTypes.StatusBroadcastJID {
+		ep.handleStatusMessage(msg)
+		return
+	}
+
+	// Edições, revogações e reações alteram a mensagem original em vez de gerar uma nova e já saem como
+	// message.edited, message.deleted e message.reaction
+	if ep.applyMessageUpdate(msg) {
+		ep.logger.Debugf("Applied update from message %s to the original message in session %s", msg.Info.ID, ep.sessionID)
+		return
+	}
+
 	if err := ep.saveMessageToDatabase(msg); err != nil {
 		ep.logger.Errorf("💾 [DATABASE ERROR] Failed to save message to database for session %s, message ID: %s, error: %v",
 			ep.sessionID, msg.Info.ID, err)
 	} else {
 		ep.logger.Infof("💾 [DATABASE DEBUG] Successfully saved message to database for session %s, message ID: %s",
 			ep.sessionID, msg.Info.ID)
+
+		// Arquivar a mídia recebida sem bloquear o processamento dos demais eventos
+		if ep.mediaArchiver != nil && !msg.Info.IsFromMe {
+			go ep.archiveInboundMedia(msg)
+		}
 	}
 
 	// Processar integração Chatwoot
 	ep.logger.Infof("📨 [MESSAGE DEBUG] Starting Chatwoot processing for session %s", ep.sessionID)
 	ep.processChatwootMessage(msg)
 
-	// Depois enviar para webhook externo se configurado
-	webhookURL := ep.getWebhookURL()
-	if webhookURL != "" {
-		normalizedMsg := ep.normalizeMessage(msg)
+	// Depois publicar no stream e enviar para webhook externo se configurado, com o mesmo envelope
+	// nos dois canais para que o consumidor possa deduplicar pelo id
+	endpoints := ep.getWebhookEndpoints("Message")
+	event := webhooks.Event{Type: "Message", Data: newMessagePayload(msg)}
+	if len(endpoints) > 0 {
+		event.Raw = ep.normalizeMessage(msg)
+	}
+	payloads := webhooks.NewPayloads(ep.sessionID, event)
 
-		webhookPayload := map[string]interface{}{
-			"event":     "Message",
-			"sessionID": ep.sessionID,
-			"timestamp": time.Now().Unix(),
-			"data":      normalizedMsg,
-		}
+	if ep.shouldProcessEvent("Message") {
+		ep.publishEnvelope(payloads.Envelope())
+	}
 
-		ep.logger.Infof("Sending Message event to webhook: %s", webhookURL)
-		if err := sendWebhook(webhookURL, webhookPayload); err != nil {
+	if len(endpoints) > 0 {
+		ep.logger.Infof("Sending Message event to %d webhook endpoint(s)", len(endpoints))
+		if err := ep.deliverPayloads(endpoints, payloads); err != nil {
 			ep.logger.Errorf("Failed to send Message webhook: %v", err)
 		} else {
 			ep.logger.Infof("Successfully sent Message event")
@@ -341,6 +445,14 @@ func (ep *EventProcessor) handleMessage(evt interface{}) {
 	}
 }
 
+// archiveInboundMedia baixa a mídia da mensagem para o storage quando a política da sessão permite
+func (ep *EventProcessor) archiveInboundMedia(msg *events.Message) {
+	ctx, cancel := context.WithTimeout(context.Background(), archiveTimeout)
+	defer cancel()
+
+	ep.mediaArchiver.archive(ctx, ep.waClient, ep.sessionID, msg)
+}
+
 func (ep *EventProcessor) processChatwootMessage(msg *events.Message) {
 	ep.logger.Infof("🔍 [CHATWOOT DEBUG] Starting processChatwootMessage for session %s", ep.sessionID)
 
@@ -437,10 +549,43 @@ func (ep *EventProcessor) saveMessageToDatabase(msg *events.Message) error {
 		return nil
 	}
 
+	// Demais mensagens de protocolo (ex: configuração de mensagens temporárias) e votos de enquete não têm conteúdo próprio
+	if !isContentMessage(msg.Message) {
+		return nil
+	}
+
 	ctx := context.Background()
 
+	// Criar ou buscar chat
+	chatJID := msg.Info.Chat.String()
+	chat, err := ensureChat(ctx, ep.chatRepo, ep.sessionID, chatJID)
+	if err != nil {
+		ep.logger.Errorf("💾 [DATABASE ERROR] Failed to ensure chat %s: %v", chatJID, err)
+		return err
+	}
+
 	ep.logger.Infof("💾 [DATABASE DEBUG] Converting WhatsApp message to database models for message ID: %s", msg.Info.ID)
+	message := ep.buildMessageModel(msg, chat.ID)
 
+	if err := ep.messageRepo.CreateMessage(ctx, message); err != nil {
+		ep.logger.Errorf("💾 [DATABASE ERROR] Failed to create message: %v", err)
+		return fmt.Errorf("failed to create message: %w", err)
+	}
+
+	ep.logger.Infof("💾 [DATABASE DEBUG] Successfully created message with ID: %s", message.ID)
+
+	// Atualizar última mensagem do chat
+	chat.LastMsgAt = &msg.Info.Timestamp
+	if err := ep.chatRepo.UpdateChat(ctx, chat); err != nil {
+		ep.logger.Warnf("💾 [DATABASE WARN] Failed to update chat last message time: %v", err)
+	}
+
+	return nil
+}
+
+// buildMessageModel converte a mensagem WhatsApp no registro de zpMessages do chat informado;
+// usado tanto nas mensagens recebidas em tempo real quanto nas importadas do HistorySync
+func (ep *EventProcessor) buildMessageModel(msg *events.Message, chatID string) *models.MessageModel {
 	// Extrair informações da mensagem
 	msgType, content, mediaURL, mimeType, _ := ep.extractMessageContent(msg)
 	ep.logger.Debugf("📝 [MESSAGE CONTENT] Type: %s, Content: %s, MediaURL: %s, MimeType: %s",
@@ -459,51 +604,6 @@ func (ep *EventProcessor) saveMessageToDatabase(msg *events.Message) error {
 			}
 		}())
 
-	// Criar ou buscar chat
-	chatJID := msg.Info.Chat.String()
-	chat, err := ep.chatRepo.GetChatBySessionAndJID(ctx, ep.sessionID, chatJID)
-	if err != nil {
-		ep.logger.Errorf("💾 [DATABASE ERROR] Failed to get chat: %v", err)
-		return fmt.Errorf("failed to get chat: %w", err)
-	}
-
-	// Se chat não existe, criar um novo
-	if chat == nil {
-		ep.logger.Infof("💾 [DATABASE DEBUG] Creating new chat for JID: %s", chatJID)
-
-		// Determinar se é grupo
-		isGroup := strings.Contains(chatJID, "@g.us")
-		// chatType removido - usar apenas isGroup
-
-		// Extrair número de telefone (para chats individuais)
-		var phoneNumber *string
-		if !isGroup {
-			phone := ep.extractPhoneNumber(chatJID)
-			phoneNumber = &phone
-		}
-
-		chat = &models.ChatModel{
-			SessionId:   ep.sessionID,
-			ChatJid:     chatJID,
-			ChatName:    nil, // Será preenchido posteriormente se disponível
-			PhoneNumber: phoneNumber,
-			IsGroup:     isGroup,
-			UnreadCount: 0,
-			IsArchived:  false,
-			Metadata:    models.JSONB{},
-		}
-
-		if err := ep.chatRepo.CreateChat(ctx, chat); err != nil {
-			ep.logger.Errorf("💾 [DATABASE ERROR] Failed to create chat: %v", err)
-			return fmt.Errorf("failed to create chat: %w", err)
-		}
-
-		ep.logger.Infof("💾 [DATABASE DEBUG] Successfully created chat with ID: %s", chat.ID)
-	}
-
-	// Criar mensagem
-	ep.logger.Infof("💾 [DATABASE DEBUG] Creating message model")
-
 	// Determinar status da mensagem
 	status := "delivered"
 	if msg.Info.IsFromMe {
@@ -525,13 +625,16 @@ func (ep *EventProcessor) saveMessageToDatabase(msg *events.Message) error {
 		contentPtr = &content
 	}
 
+	// Chaves de download (directPath, mediaKey, hashes) permitem baixar a mídia depois pelo ID da mensagem
+	_, _, mediaInfo := describeMessage(msg.Message)
+
 	var senderNamePtr *string
 	if senderName != "" {
 		senderNamePtr = &senderName
 	}
 
 	message := &models.MessageModel{
-		ChatId:      chat.ID,
+		ChatId:      chatID,
 		SessionId:   ep.sessionID,
 		MsgId:       msg.Info.ID,
 		MsgType:     msgType,
@@ -541,37 +644,14 @@ func (ep *EventProcessor) saveMessageToDatabase(msg *events.Message) error {
 		IsFromMe:    msg.Info.IsFromMe,
 		IsForwarded: detectForwardedMessage(msg),
 		IsBroadcast: detectBroadcastMessage(msg),
+		MediaInfo:   mediaInfo,
 		Status:      status,
 		Timestamp:   msg.Info.Timestamp,
 		Metadata:    models.JSONB{},
 	}
+	fillMessageContext(message, msg.Message)
 
-	if err := ep.messageRepo.CreateMessage(ctx, message); err != nil {
-		ep.logger.Errorf("💾 [DATABASE ERROR] Failed to create message: %v", err)
-		return fmt.Errorf("failed to create message: %w", err)
-	}
-
-	ep.logger.Infof("💾 [DATABASE DEBUG] Successfully created message with ID: %s", message.ID)
-
-	// Atualizar última mensagem do chat
-	chat.LastMsgAt = &msg.Info.Timestamp
-	if err := ep.chatRepo.UpdateChat(ctx, chat); err != nil {
-		ep.logger.Warnf("💾 [DATABASE WARN] Failed to update chat last message time: %v", err)
-	}
-
-	return nil
-}
-
-// extractPhoneNumber extrai o número de telefone do JID
-func (ep *EventProcessor) extractPhoneNumber(jid string) string {
-	// Remove sufixos como @s.whatsapp.net ou @g.us
-	parts := strings.Split(jid, "@")
-	if len(parts) > 0 {
-		// Remove possível sufixo de timestamp (ex: :1234567890)
-		phoneNumber := strings.Split(parts[0], ":")[0]
-		return phoneNumber
-	}
-	return jid
+	return message
 }
 
 // extractMessageContent extrai o tipo e conteúdo da mensagem
@@ -597,9 +677,6 @@ func (ep *EventProcessor) extractMessageContent(msg *events.Message) (msgType, t
 			mimeType = *msg.Message.ImageMessage.Mimetype
 		}
 
-		// Armazena a mensagem de mídia para download posterior
-		ep.storeMediaMessage(msg.Info.ID, msg.Message.ImageMessage)
-
 		return "image", caption, "", mimeType, ""
 	}
 
@@ -611,9 +688,6 @@ func (ep *EventProcessor) extractMessageContent(msg *events.Message) (msgType, t
 			mimeType = *msg.Message.AudioMessage.Mimetype
 		}
 
-		// Armazena a mensagem de mídia para download posterior
-		ep.storeMediaMessage(msg.Info.ID, msg.Message.AudioMessage)
-
 		// Verifica se é PTT (Push to Talk)
 		if msg.Message.AudioMessage.PTT != nil && *msg.Message.AudioMessage.PTT {
 			return "ptt", caption, "", mimeType, ""
@@ -632,9 +706,6 @@ func (ep *EventProcessor) extractMessageContent(msg *events.Message) (msgType, t
 			mimeType = *msg.Message.VideoMessage.Mimetype
 		}
 
-		// Armazena a mensagem de mídia para download posterior
-		ep.storeMediaMessage(msg.Info.ID, msg.Message.VideoMessage)
-
 		return "video", caption, "", mimeType, ""
 	}
 
@@ -653,9 +724,6 @@ func (ep *EventProcessor) extractMessageContent(msg *events.Message) (msgType, t
 			fileName = *msg.Message.DocumentMessage.FileName
 		}
 
-		// Armazena a mensagem de mídia para download posterior
-		ep.storeMediaMessage(msg.Info.ID, msg.Message.DocumentMessage)
-
 		return "document", caption, "", mimeType, fileName
 	}
 
@@ -666,9 +734,6 @@ func (ep *EventProcessor) extractMessageContent(msg *events.Message) (msgType, t
 			mimeType = *msg.Message.StickerMessage.Mimetype
 		}
 
-		// Armazena a mensagem de mídia para download posterior
-		ep.storeMediaMessage(msg.Info.ID, msg.Message.StickerMessage)
-
 		return "sticker", "", "", mimeType, ""
 	}
 
@@ -686,16 +751,6 @@ func (ep *EventProcessor) extractMessageContent(msg *events.Message) (msgType, t
 	return "text", "", "", "", ""
 }
 
-// storeMediaMessage armazena uma mensagem de mídia para download posterior
-func (ep *EventProcessor) storeMediaMessage(messageID string, mediaMsg interface{}) {
-	if ep.mediaCache == nil {
-		ep.mediaCache = make(map[string]interface{})
-	}
-	ep.mediaCache[messageID] = mediaMsg
-
-	ep.logger.Debugf("Stored media message for download: message_id=%s, type=%T", messageID, mediaMsg)
-}
-
 // dbModelToChatwootConfig converte modelo do banco para configuração Chatwoot
 func (ep *EventProcessor) dbModelToChatwootConfig(model *models.ChatwootModel) *chatwoot.ChatwootConfig {
 	// Obtém o host público da variável de ambiente
@@ -771,32 +826,27 @@ func (ep *EventProcessor) normalizeMessage(msg *events.Message) *events.Message
 }
 
 func (ep *EventProcessor) handleConnected(evt interface{}) {
-	webhookURL := ep.getWebhookURL()
-	if webhookURL != "" {
-		webhookPayload := map[string]interface{}{
-			"event":     "Connected",
-			"sessionID": ep.sessionID,
-			"timestamp": time.Now().Unix(),
-			"data":      evt,
-		}
+	// As configurações de privacidade podem ter mudado enquanto a sessão esteve desconectada
+	if ep.privacyCache != nil {
+		ep.privacyCache.invalidate(ep.sessionID)
+	}
 
-		if err := sendWebhook(webhookURL, webhookPayload); err != nil {
+	endpoints := ep.getWebhookEndpoints("Connected")
+	if len(endpoints) > 0 {
+		event := webhooks.Event{Type: "Connected", Data: typedPayload(evt), Raw: evt}
+
+		if err := ep.deliverWebhook(endpoints, event); err != nil {
 			ep.logger.Errorf("Failed to send webhook: %v", err)
 		}
 	}
 }
 
 func (ep *EventProcessor) handleDisconnected(evt interface{}) {
-	webhookURL := ep.getWebhookURL()
-	if webhookURL != "" {
-		webhookPayload := map[string]interface{}{
-			"event":     "Disconnected",
-			"sessionID": ep.sessionID,
-			"timestamp": time.Now().Unix(),
-			"data":      evt,
-		}
+	endpoints := ep.getWebhookEndpoints("Disconnected")
+	if len(endpoints) > 0 {
+		event := webhooks.Event{Type: "Disconnected", Data: typedPayload(evt), Raw: evt}
 
-		if err := sendWebhook(webhookURL, webhookPayload); err != nil {
+		if err := ep.deliverWebhook(endpoints, event); err != nil {
 			ep.logger.Errorf("Failed to send webhook: %v", err)
 		}
 	}
@@ -807,16 +857,11 @@ func (ep *EventProcessor) handleQR(evt interface{}) {
 	ep.logger.Infof("QR code generated for session %s", ep.sessionID)
 
 	// Só tenta enviar webhook se a URL estiver configurada
-	webhookURL := ep.getWebhookURL()
-	if webhookURL != "" {
-		webhookPayload := map[string]interface{}{
-			"event":     "QR",
-			"sessionID": ep.sessionID,
-			"timestamp": time.Now().Unix(),
-			"data":      qr,
-		}
+	endpoints := ep.getWebhookEndpoints("QR")
+	if len(endpoints) > 0 {
+		event := webhooks.Event{Type: "QR", Data: typedPayload(qr), Raw: qr}
 
-		if err := sendWebhook(webhookURL, webhookPayload); err != nil {
+		if err := ep.deliverWebhook(endpoints, event); err != nil {
 			ep.logger.Errorf("Failed to send webhook: %v", err)
 		}
 	} else {
@@ -827,14 +872,9 @@ func (ep *EventProcessor) handleQR(evt interface{}) {
 func (ep *EventProcessor) handlePairSuccess(evt interface{}) {
 	ep.logger.Infof("Pair success for session %s", ep.sessionID)
 
-	webhookPayload := map[string]interface{}{
-		"event":     "PairSuccess",
-		"sessionID": ep.sessionID,
-		"timestamp": time.Now().Unix(),
-		"data":      evt,
-	}
+	event := webhooks.Event{Type: "PairSuccess", Data: typedPayload(evt), Raw: evt}
 
-	if err := sendWebhook(ep.webhookURL, webhookPayload); err != nil {
+	if err := ep.deliverWebhook(ep.getWebhookEndpoints(event.Type), event); err != nil {
 		ep.logger.Errorf("Failed to send webhook: %v", err)
 	}
 }
@@ -843,27 +883,17 @@ func (ep *EventProcessor) handlePairError(evt interface{}) {
 	pairError := evt.(*events.PairError)
 	ep.logger.Errorf("Pair error for session %s: %v", ep.sessionID, pairError.Error)
 
-	webhookPayload := map[string]interface{}{
-		"event":     "PairError",
-		"sessionID": ep.sessionID,
-		"timestamp": time.Now().Unix(),
-		"data":      pairError,
-	}
+	event := webhooks.Event{Type: "PairError", Data: typedPayload(pairError), Raw: pairError}
 
-	if err := sendWebhook(ep.webhookURL, webhookPayload); err != nil {
+	if err := ep.deliverWebhook(ep.getWebhookEndpoints(event.Type), event); err != nil {
 		ep.logger.Errorf("Failed to send webhook: %v", err)
 	}
 }
 
 func (ep *EventProcessor) handleLoggedOut(evt interface{}) {
-	webhookPayload := map[string]interface{}{
-		"event":     "LoggedOut",
-		"sessionID": ep.sessionID,
-		"timestamp": time.Now().Unix(),
-		"data":      evt,
-	}
+	event := webhooks.Event{Type: "LoggedOut", Data: typedPayload(evt), Raw: evt}
 
-	if err := sendWebhook(ep.webhookURL, webhookPayload); err != nil {
+	if err := ep.deliverWebhook(ep.getWebhookEndpoints(event.Type), event); err != nil {
 		ep.logger.Errorf("Failed to send webhook: %v", err)
 	}
 }
@@ -883,34 +913,73 @@ func (ep *EventProcessor) handleReceipt(evt interface{}) {
 	}
 	ep.receiptMutex.Unlock()
 
-	webhookURL := ep.getWebhookURL()
-	if webhookURL != "" {
-		webhookPayload := map[string]interface{}{
-			"event":     "Receipt",
-			"sessionID": ep.sessionID,
-			"timestamp": now.Unix(),
-			"data":      receipt,
-		}
+	ep.saveReceiptToDatabase(receipt)
+
+	endpoints := ep.getWebhookEndpoints("Receipt")
+	if len(endpoints) > 0 {
+		event := webhooks.Event{Type: "Receipt", Data: typedPayload(receipt), Raw: receipt}
 
-		if err := sendWebhook(webhookURL, webhookPayload); err != nil {
+		if err := ep.deliverWebhook(endpoints, event); err != nil {
 			ep.logger.Errorf("Failed to send receipt webhook: %v", err)
 		}
 	}
 }
 
+// receiptStatuses mapeia o tipo de recibo para o status de zpMessages; tipos ausentes não alteram status
+var receiptStatuses = map[waTypes.ReceiptType]string{
+	waTypes.ReceiptTypeDelivered:   models.MessageStatusDelivered,
+	waTypes.ReceiptTypeInactive:    models.MessageStatusDelivered,
+	waTypes.ReceiptTypeSender:      models.MessageStatusSent,
+	waTypes.ReceiptTypeRead:        models.MessageStatusRead,
+	waTypes.ReceiptTypeReadSelf:    models.MessageStatusRead,
+	waTypes.ReceiptTypePlayed:      models.MessageStatusPlayed,
+	waTypes.ReceiptTypePlayedSelf:  models.MessageStatusPlayed,
+	waTypes.ReceiptTypeServerError: models.MessageStatusFailed,
+}
+
+// saveReceiptToDatabase avança o status das mensagens e grava a linha do tempo por destinatário.
+// Recibos dos nossos próprios dispositivos (sender, read-self, played-self) só avançam o status.
+func (ep *EventProcessor) saveReceiptToDatabase(receipt *events.Receipt) {
+	if ep.receiptRepo == nil {
+		return
+	}
+
+	status, ok := receiptStatuses[receipt.Type]
+	if !ok {
+		return
+	}
+
+	msgIDs := make([]string, 0, len(receipt.MessageIDs))
+	for _, id := range receipt.MessageIDs {
+		msgIDs = append(msgIDs, string(id))
+	}
+
+	timestamp := receipt.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	ctx := context.Background()
+	var err error
+	if receipt.IsFromMe {
+		_, err = ep.receiptRepo.AdvanceStatus(ctx, ep.sessionID, msgIDs, status)
+	} else {
+		_, err = ep.receiptRepo.RecordReceipt(ctx, ep.sessionID, msgIDs, receipt.Sender.ToNonAD().String(), status, timestamp)
+	}
+
+	if err != nil {
+		ep.logger.Errorf("Failed to save %s receipt for %d message(s) in session %s: %v", status, len(msgIDs), ep.sessionID, err)
+	}
+}
+
 func (ep *EventProcessor) handlePresence(evt interface{}) {
 	presence := evt.(*events.Presence)
 
-	webhookURL := ep.getWebhookURL()
-	if webhookURL != "" {
-		webhookPayload := map[string]interface{}{
-			"event":     "Presence",
-			"sessionID": ep.sessionID,
-			"timestamp": time.Now().Unix(),
-			"data":      presence,
-		}
+	endpoints := ep.getWebhookEndpoints("Presence")
+	if len(endpoints) > 0 {
+		event := webhooks.Event{Type: "Presence", Data: typedPayload(presence), Raw: presence}
 
-		if err := sendWebhook(webhookURL, webhookPayload); err != nil {
+		if err := ep.deliverWebhook(endpoints, event); err != nil {
 			ep.logger.Errorf("Failed to send webhook: %v", err)
 		}
 	}
@@ -919,16 +988,11 @@ func (ep *EventProcessor) handlePresence(evt interface{}) {
 func (ep *EventProcessor) handleChatPresence(evt interface{}) {
 	chatPresence := evt.(*events.ChatPresence)
 
-	webhookURL := ep.getWebhookURL()
-	if webhookURL != "" {
-		webhookPayload := map[string]interface{}{
-			"event":     "ChatPresence",
-			"sessionID": ep.sessionID,
-			"timestamp": time.Now().Unix(),
-			"data":      chatPresence,
-		}
+	endpoints := ep.getWebhookEndpoints("ChatPresence")
+	if len(endpoints) > 0 {
+		event := webhooks.Event{Type: "ChatPresence", Data: typedPayload(chatPresence), Raw: chatPresence}
 
-		if err := sendWebhook(webhookURL, webhookPayload); err != nil {
+		if err := ep.deliverWebhook(endpoints, event); err != nil {
 			ep.logger.Errorf("Failed to send webhook: %v", err)
 		}
 	}
@@ -942,27 +1006,85 @@ func init() {
 }
 
 func (ep *EventProcessor) sendGenericEvent(eventType string, evt interface{}) {
-	webhookURL := ep.getWebhookURL()
-	if webhookURL == "" {
+	endpoints := ep.getWebhookEndpoints(eventType)
+	if len(endpoints) == 0 {
 		ep.logger.Debugf("No webhook URL configured for session %s, skipping event %s", ep.sessionID, eventType)
 		return
 	}
 
-	webhookPayload := map[string]interface{}{
-		"event":     eventType,
-		"sessionID": ep.sessionID,
-		"timestamp": time.Now().Unix(),
-		"data":      evt,
-	}
+	event := webhooks.Event{Type: eventType, Data: typedPayload(evt), Raw: evt}
 
-	ep.logger.Infof("Sending generic event: %s to webhook: %s", eventType, webhookURL)
-	if err := sendWebhook(webhookURL, webhookPayload); err != nil {
+	ep.logger.Infof("Sending generic event: %s to %d webhook endpoint(s)", eventType, len(endpoints))
+	if err := ep.deliverWebhook(endpoints, event); err != nil {
 		ep.logger.Errorf("Failed to send generic webhook for event %s: %v", eventType, err)
 	} else {
 		ep.logger.Infof("Successfully sent generic event: %s", eventType)
 	}
 }
 
+// sendNormalizedEvent entrega os eventos gerados pelo zpmeow (message.*, call.*, status.received, privacy.updated,
+// chat.updated), que não vêm de um evento do whatsmeow e por isso têm o mesmo payload em todos os formatos
+func (ep *EventProcessor) sendNormalizedEvent(event string, data interface{}) {
+	payloads := webhooks.NewPayloads(ep.sessionID, webhooks.Event{Type: event, Data: data})
+	if ep.shouldProcessEvent(event) {
+		ep.publishEnvelope(payloads.Envelope())
+	}
+
+	endpoints := ep.getWebhookEndpoints(event)
+	if len(endpoints) == 0 {
+		return
+	}
+
+	if err := ep.deliverPayloads(endpoints, payloads); err != nil {
+		ep.logger.Errorf("Failed to send %s webhook: %v", event, err)
+	}
+}
+
+// streamEvent publica o evento no envelope v1 para os clientes de SSE/WebSocket da sessão
+func (ep *EventProcessor) streamEvent(eventType string, data interface{}) {
+	ep.publishEnvelope(webhooks.NewEnvelope(ep.sessionID, eventType, data))
+}
+
+// publishEnvelope publica um envelope já montado, o mesmo entregue aos webhooks v1
+func (ep *EventProcessor) publishEnvelope(envelope *webhooks.Envelope) {
+	if ep.eventStream == nil {
+		return
+	}
+	ep.eventStream.Publish(ep.sessionID, envelope)
+}
+
+// deliverWebhook enfileira o evento para cada endpoint na fila persistente de entregas, no formato
+// de payload escolhido pelo endpoint (envelope v1 ou raw); sem fila configurada, envia de forma síncrona
+func (ep *EventProcessor) deliverWebhook(endpoints []*models.WebhookModel, event webhooks.Event) error {
+	return ep.deliverPayloads(endpoints, webhooks.NewPayloads(ep.sessionID, event))
+}
+
+// deliverPayloads entrega payloads já montados, para reaproveitar o envelope publicado no stream
+func (ep *EventProcessor) deliverPayloads(endpoints []*models.WebhookModel, payloads *webhooks.Payloads) error {
+	var errs []error
+	for _, endpoint := range endpoints {
+		payload := payloads.For(endpoint)
+		if ep.webhookQueue == nil {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			err := globalWebhookService.SendToEndpoint(ctx, endpoint, payloads.Type(), payload)
+			cancel()
+			if err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		_, err := ep.webhookQueue.Enqueue(ctx, ep.sessionID, endpoint.ID, endpoint.URL, payloads.Type(), payload)
+		cancel()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("endpoint %s: %w", endpoint.URL, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 func isCommonUnmappedEvent(eventType string) bool {
 	commonUnmappedEvents := map[string]bool{
 		"*events.QR":                   true,
@@ -983,7 +1105,6 @@ func isCommonUnmappedEvent(eventType string) bool {
 		"*events.Picture":              true,
 		"*events.PushNameSetting":      true,
 		"*events.AppStateSyncComplete": true,
-		"*events.HistorySync":          true,
 		"*events.AppState":             true,
 		"*events.MarkChatAsRead":       true,
 		"*events.Mute":                 true,
@@ -999,27 +1120,6 @@ func isCommonUnmappedEvent(eventType string) bool {
 	return commonUnmappedEvents[eventType]
 }
 
-func sendWebhook(url string, data interface{}) error {
-	if url == "" {
-		return fmt.Errorf("webhook URL is empty")
-	}
-
-	logger := logging.GetLogger().Sub("webhook-sender")
-	logger.Infof("Attempting to send webhook to: %s", url)
-
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	err := globalWebhookService.SendWebhook(ctx, url, "whatsapp_event", "", data)
-	if err != nil {
-		logger.Errorf("Failed to send webhook to %s: %v", url, err)
-		return err
-	}
-
-	logger.Infof("Successfully sent webhook to: %s", url)
-	return nil
-}
-
 // Helper functions for message detection
 func detectForwardedMessage(msg *events.Message) bool {
 	// Check if message has forwarded context
@@ -114,8 +114,10 @@ func (b *MessageBuilder) BuildTextMessage(text string) (*waE2E.Message, error) {
 	}, nil
 }
 
-func (b *MessageBuilder) BuildImageMessage(uploaded *whatsmeow.UploadResponse, caption string) *waE2E.Message {
-	mimeType := b.mimeHelper.GetDefaultImageMimeType()
+func (b *MessageBuilder) BuildImageMessage(uploaded *whatsmeow.UploadResponse, caption, mimeType string) *waE2E.Message {
+	if mimeType == "" {
+		mimeType = b.mimeHelper.GetDefaultImageMimeType()
+	}
 	return &waE2E.Message{
 		ImageMessage: &waE2E.ImageMessage{
 			Caption:       &caption,
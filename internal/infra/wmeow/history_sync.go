@@ -0,0 +1,296 @@
+package wmeow
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	waTypes "go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"zpmeow/internal/infra/database/models"
+	"zpmeow/internal/infra/database/repository"
+	"zpmeow/internal/infra/logging"
+	"zpmeow/internal/infra/webhooks"
+)
+
+const (
+	// EventHistorySyncProgress é publicado nos webhooks a cada chunk de histórico importado
+	EventHistorySyncProgress = "HistorySyncProgress"
+
+	historySyncTimeout = 10 * time.Minute
+)
+
+// HistorySyncOptions configura a importação do histórico enviado pelo celular após o pareamento
+type HistorySyncOptions struct {
+	Enabled bool
+	MaxDays int // mensagens mais antigas são ignoradas; 0 = sem limite
+}
+
+// historySyncProgress é o payload do webhook HistorySyncProgress
+type historySyncProgress struct {
+	SyncType        string     `json:"sync_type"`
+	ChunkOrder      uint32     `json:"chunk_order"`
+	Progress        int        `json:"progress"`
+	Conversations   int        `json:"conversations"`
+	MessagesStored  int        `json:"messages_stored"`
+	MessagesSkipped int        `json:"messages_skipped"`
+	Status          string     `json:"status"`
+	Totals          any        `json:"totals"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty"`
+}
+
+type historySyncTotals struct {
+	Chunks          int `json:"chunks"`
+	Conversations   int `json:"conversations"`
+	MessagesStored  int `json:"messages_stored"`
+	MessagesSkipped int `json:"messages_skipped"`
+}
+
+// historyImporter grava em zpChats/zpMessages as conversas recebidas no HistorySync e acompanha o progresso.
+// A importação roda fora do handler de eventos do whatsmeow, em um worker por sessão que processa os chunks
+// na ordem em que chegaram e termina quando a fila da sessão esvazia.
+type historyImporter struct {
+	repo    *repository.HistorySyncRepository
+	queue   *webhooks.DeliveryQueue
+	options HistorySyncOptions
+	logger  logging.Logger
+
+	mu   sync.Mutex
+	jobs map[string][]historyJob // chunks pendentes por sessão; a chave existe enquanto o worker roda
+}
+
+type historyJob struct {
+	processor *EventProcessor
+	evt       *events.HistorySync
+}
+
+func newHistoryImporter(repo *repository.HistorySyncRepository, queue *webhooks.DeliveryQueue, options HistorySyncOptions) *historyImporter {
+	return &historyImporter{
+		repo:    repo,
+		queue:   queue,
+		options: options,
+		logger:  logging.GetLogger().Sub("history-sync"),
+		jobs:    make(map[string][]historyJob),
+	}
+}
+
+// enqueue agenda o chunk na fila da sessão e inicia o worker quando ele não está rodando
+func (h *historyImporter) enqueue(ep *EventProcessor, evt *events.HistorySync) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	pending, running := h.jobs[ep.sessionID]
+	h.jobs[ep.sessionID] = append(pending, historyJob{processor: ep, evt: evt})
+	if !running {
+		go h.work(ep.sessionID)
+	}
+}
+
+// next retira o próximo chunk da sessão; sem chunks pendentes, encerra o worker
+func (h *historyImporter) next(sessionID string) (historyJob, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	pending := h.jobs[sessionID]
+	if len(pending) == 0 {
+		delete(h.jobs, sessionID)
+		return historyJob{}, false
+	}
+
+	job := pending[0]
+	pending[0] = historyJob{}
+	h.jobs[sessionID] = pending[1:]
+	return job, true
+}
+
+func (h *historyImporter) work(sessionID string) {
+	for {
+		job, ok := h.next(sessionID)
+		if !ok {
+			return
+		}
+		h.run(job)
+	}
+}
+
+func (h *historyImporter) run(job historyJob) {
+	defer func() {
+		if r := recover(); r != nil {
+			h.logger.Errorf("Panic importing history sync for session %s: %v", job.processor.sessionID, r)
+		}
+	}()
+
+	job.processor.importHistory(job.evt)
+}
+
+// cutoff retorna o limite de antiguidade das mensagens importadas, ou zero quando não há limite
+func (h *historyImporter) cutoff() time.Time {
+	if h.options.MaxDays <= 0 {
+		return time.Time{}
+	}
+	return time.Now().AddDate(0, 0, -h.options.MaxDays)
+}
+
+// record soma o chunk ao progresso da sessão e publica o webhook HistorySyncProgress
+func (h *historyImporter) record(ctx context.Context, sessionID string, evt *events.HistorySync, chunk repository.HistorySyncChunk) {
+	sync, err := h.repo.RecordChunk(ctx, sessionID, chunk)
+	if err != nil {
+		h.logger.Errorf("Failed to record history sync progress for session %s: %v", sessionID, err)
+		return
+	}
+
+	if h.queue == nil {
+		return
+	}
+
+	progress := historySyncProgress{
+		SyncType:        chunk.SyncType,
+		ChunkOrder:      evt.Data.GetChunkOrder(),
+		Progress:        chunk.Progress,
+		Conversations:   chunk.Conversations,
+		MessagesStored:  chunk.MessagesStored,
+		MessagesSkipped: chunk.MessagesSkipped,
+		Status:          sync.Status,
+		Totals: historySyncTotals{
+			Chunks:          sync.Chunks,
+			Conversations:   sync.Conversations,
+			MessagesStored:  sync.MessagesStored,
+			MessagesSkipped: sync.MessagesSkipped,
+		},
+		CompletedAt: sync.CompletedAt,
+	}
+
+	if err := h.queue.Publish(ctx, sessionID, EventHistorySyncProgress, progress); err != nil {
+		h.logger.Warnf("Failed to publish %s for session %s: %v", EventHistorySyncProgress, sessionID, err)
+	}
+}
+
+// handleHistorySync entrega o evento bruto e agenda a importação do chunk no worker da sessão, sem
+// segurar o handler de eventos do whatsmeow
+func (ep *EventProcessor) handleHistorySync(evt interface{}) {
+	historySync := evt.(*events.HistorySync)
+
+	// Mantém o evento bruto para quem já assinava HistorySync
+	if ep.shouldProcessEvent("HistorySync") {
+		ep.sendGenericEvent("HistorySync", evt)
+	}
+
+	importer := ep.historyImporter
+	if importer == nil || !importer.options.Enabled || ep.messageRepo == nil || ep.chatRepo == nil || ep.waClient == nil {
+		return
+	}
+
+	// Chunks sem conversas (push names, status, configurações) não têm nada a importar
+	if len(historySync.Data.GetConversations()) == 0 {
+		return
+	}
+
+	importer.enqueue(ep, historySync)
+}
+
+// importHistory importa as conversas do chunk com a mesma extração das mensagens recebidas, gravando as
+// mensagens de cada conversa em uma única transação. A importação é idempotente: mensagens já gravadas são
+// ignoradas pelo índice único ("sessionId", "msgId").
+func (ep *EventProcessor) importHistory(historySync *events.HistorySync) {
+	importer := ep.historyImporter
+
+	ctx, cancel := context.WithTimeout(context.Background(), historySyncTimeout)
+	defer cancel()
+
+	chunk := repository.HistorySyncChunk{
+		SyncType: historySync.Data.GetSyncType().String(),
+		Progress: int(historySync.Data.GetProgress()),
+	}
+	cutoff := importer.cutoff()
+
+	for _, conversation := range historySync.Data.GetConversations() {
+		chatJID, err := waTypes.ParseJID(conversation.GetID())
+		if err != nil {
+			ep.logger.Warnf("Skipping history conversation with invalid JID %q: %v", conversation.GetID(), err)
+			chunk.MessagesSkipped += len(conversation.GetMessages())
+			continue
+		}
+
+		chat, err := ensureChat(ctx, ep.chatRepo, ep.sessionID, chatJID.String())
+		if err != nil {
+			ep.logger.Errorf("Failed to ensure history chat %s: %v", chatJID, err)
+			chunk.MessagesSkipped += len(conversation.GetMessages())
+			chunk.Error = err.Error()
+			continue
+		}
+		chunk.Conversations++
+
+		var lastMsgAt time.Time
+		batch := make([]*models.MessageModel, 0, len(conversation.GetMessages()))
+		for _, historyMsg := range conversation.GetMessages() {
+			msg, err := ep.waClient.ParseWebMessage(chatJID, historyMsg.GetMessage())
+			if err != nil {
+				chunk.MessagesSkipped++
+				continue
+			}
+
+			if (!cutoff.IsZero() && msg.Info.Timestamp.Before(cutoff)) || !isContentMessage(msg.Message) {
+				chunk.MessagesSkipped++
+				continue
+			}
+
+			model := ep.buildMessageModel(msg, chat.ID)
+			if model.MsgType == "text" && model.Content == nil {
+				chunk.MessagesSkipped++
+				continue
+			}
+
+			batch = append(batch, model)
+			if msg.Info.Timestamp.After(lastMsgAt) {
+				lastMsgAt = msg.Info.Timestamp
+			}
+		}
+
+		created, err := ep.messageRepo.CreateMessagesIfNotExist(ctx, batch)
+		if err != nil {
+			ep.logger.Warnf("Failed to store history messages of chat %s: %v", chatJID, err)
+			chunk.MessagesSkipped += len(batch)
+			chunk.Error = fmt.Sprintf("chat %s: %v", chatJID, err)
+			continue
+		}
+		chunk.MessagesStored += created
+		chunk.MessagesSkipped += len(batch) - created
+
+		ep.updateHistoryChat(ctx, chat, conversation.GetName(), conversation.GetDisplayName(), lastMsgAt)
+	}
+
+	ep.logger.Infof("History sync %s chunk %d for session %s: %d conversations, %d messages stored, %d skipped (progress %d%%)",
+		chunk.SyncType, historySync.Data.GetChunkOrder(), ep.sessionID, chunk.Conversations, chunk.MessagesStored, chunk.MessagesSkipped, chunk.Progress)
+
+	importer.record(ctx, ep.sessionID, historySync, chunk)
+}
+
+// updateHistoryChat preenche o nome do chat quando ainda não conhecido e avança a data da última mensagem
+func (ep *EventProcessor) updateHistoryChat(ctx context.Context, chat *models.ChatModel, name, displayName string, lastMsgAt time.Time) {
+	changed := false
+
+	if chat.ChatName == nil {
+		if name == "" {
+			name = displayName
+		}
+		if name != "" {
+			chat.ChatName = &name
+			changed = true
+		}
+	}
+
+	if !lastMsgAt.IsZero() && (chat.LastMsgAt == nil || lastMsgAt.After(*chat.LastMsgAt)) {
+		chat.LastMsgAt = &lastMsgAt
+		changed = true
+	}
+
+	if !changed {
+		return
+	}
+
+	if err := ep.chatRepo.UpdateChat(ctx, chat); err != nil {
+		ep.logger.Warnf("Failed to update history chat %s: %v", chat.ChatJid, err)
+	}
+}
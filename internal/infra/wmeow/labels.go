@@ -0,0 +1,99 @@
+package wmeow
+
+import (
+	"context"
+	"time"
+
+	"go.mau.fi/whatsmeow/types/events"
+
+	"zpmeow/internal/application/ports"
+	"zpmeow/internal/infra/database/models"
+)
+
+// labelColors é o número de cores da paleta de etiquetas do WhatsApp Business (índices 0 a 19)
+const labelColors = 20
+
+const labelSyncTimeout = 10 * time.Second
+
+// handleLabelEdit grava em zpLabels a etiqueta criada, alterada ou removida em qualquer aparelho
+func (ep *EventProcessor) handleLabelEdit(evt interface{}) {
+	edit := evt.(*events.LabelEdit)
+	ep.sendGenericEvent("LabelEdit", edit)
+
+	if ep.labelRepo == nil || edit.Action == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), labelSyncTimeout)
+	defer cancel()
+
+	if edit.Action.GetDeleted() {
+		if err := ep.labelRepo.Delete(ctx, ep.sessionID, edit.LabelID); err != nil {
+			ep.logger.Errorf("Failed to delete label %s in session %s: %v", edit.LabelID, ep.sessionID, err)
+		}
+		return
+	}
+
+	label := &models.LabelModel{
+		SessionId: ep.sessionID,
+		LabelId:   edit.LabelID,
+		Name:      edit.Action.GetName(),
+		Color:     int(edit.Action.GetColor()),
+	}
+	if edit.Action.PredefinedID != nil {
+		predefinedID := int(edit.Action.GetPredefinedID())
+		label.PredefinedId = &predefinedID
+	}
+
+	if err := ep.labelRepo.Upsert(ctx, label); err != nil {
+		ep.logger.Errorf("Failed to save label %s in session %s: %v", edit.LabelID, ep.sessionID, err)
+		return
+	}
+
+	ep.logger.Debugf("Saved label %s (%s) in session %s (full sync: %v)", edit.LabelID, label.Name, ep.sessionID, edit.FromFullSync)
+}
+
+func (ep *EventProcessor) handleLabelAssociationChat(evt interface{}) {
+	association := evt.(*events.LabelAssociationChat)
+	ep.sendGenericEvent("LabelAssociationChat", association)
+
+	if ep.labelRepo == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), labelSyncTimeout)
+	defer cancel()
+
+	labeled := association.Action.GetLabeled()
+	if err := ep.labelRepo.SetChatLabel(ctx, ep.sessionID, association.LabelID, association.JID.String(), labeled); err != nil {
+		ep.logger.Errorf("Failed to set label %s on chat %s in session %s: %v", association.LabelID, association.JID, ep.sessionID, err)
+	}
+}
+
+func (ep *EventProcessor) handleLabelAssociationMessage(evt interface{}) {
+	association := evt.(*events.LabelAssociationMessage)
+	ep.sendGenericEvent("LabelAssociationMessage", association)
+
+	if ep.labelRepo == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), labelSyncTimeout)
+	defer cancel()
+
+	labeled := association.Action.GetLabeled()
+	if err := ep.labelRepo.SetMessageLabel(ctx, ep.sessionID, association.LabelID, association.JID.String(), association.MessageID, labeled); err != nil {
+		ep.logger.Errorf("Failed to set label %s on message %s in session %s: %v", association.LabelID, association.MessageID, ep.sessionID, err)
+	}
+}
+
+func toLabel(label *models.LabelModel, chatCount int) ports.Label {
+	return ports.Label{
+		ID:           label.LabelId,
+		Name:         label.Name,
+		Color:        label.Color,
+		PredefinedID: label.PredefinedId,
+		ChatCount:    chatCount,
+		UpdatedAt:    label.UpdatedAt,
+	}
+}
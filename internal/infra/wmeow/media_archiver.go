@@ -0,0 +1,235 @@
+package wmeow
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"zpmeow/internal/application/ports"
+	"zpmeow/internal/infra/database/models"
+	"zpmeow/internal/infra/database/repository"
+	"zpmeow/internal/infra/logging"
+	"zpmeow/internal/infra/storage"
+)
+
+const (
+	archiveStatusStored  = "stored"
+	archiveStatusSkipped = "skipped"
+	archiveStatusFailed  = "failed"
+
+	archiveTimeout = 5 * time.Minute
+)
+
+// MediaLimits agrupa os limites de tamanho do storage de mídia (em bytes; 0 = sem limite)
+type MediaLimits struct {
+	MaxFileSize int64
+	PerType     map[string]int64
+}
+
+// maxFor retorna o menor limite entre o global e o do tipo de mídia
+func (l MediaLimits) maxFor(mediaType string) int64 {
+	return minLimit(l.MaxFileSize, l.PerType[mediaType])
+}
+
+func minLimit(a, b int64) int64 {
+	switch {
+	case a <= 0:
+		return b
+	case b <= 0:
+		return a
+	case a < b:
+		return a
+	default:
+		return b
+	}
+}
+
+// storeMediaContent grava o conteúdo no storage (endereçado pelo SHA-256) e registra a mídia da sessão em zpMedia
+func storeMediaContent(ctx context.Context, mediaStorage ports.MediaStorage, mediaRepo *repository.MediaRepository, sessionID string, data []byte, mediaType, mimeType, fileName string, metadata models.JSONB) (*models.MediaModel, error) {
+	mediaID := storage.ContentID(data)
+	key := storage.ContentKey(mediaID)
+	if mimeType == "" {
+		mimeType = http.DetectContentType(data)
+	}
+
+	var fileNamePtr *string
+	if fileName != "" {
+		fileNamePtr = &fileName
+	}
+
+	media := &models.MediaModel{
+		SessionId:     sessionID,
+		MediaId:       mediaID,
+		MediaType:     mediaType,
+		MimeType:      mimeType,
+		FileName:      fileNamePtr,
+		Size:          int64(len(data)),
+		StorageDriver: mediaStorage.Driver(),
+		StorageKey:    key,
+		Metadata:      metadata,
+	}
+
+	err := mediaRepo.Store(ctx, media, func() error {
+		exists, err := mediaStorage.Exists(ctx, key)
+		if err != nil {
+			return fmt.Errorf("failed to check media %s: %w", mediaID, err)
+		}
+		if exists {
+			return nil
+		}
+		if err := mediaStorage.Put(ctx, key, data, mimeType); err != nil {
+			return fmt.Errorf("failed to store media %s: %w", mediaID, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return media, nil
+}
+
+// mediaArchiver baixa as mídias recebidas para o storage conforme a política de cada sessão
+type mediaArchiver struct {
+	storage     ports.MediaStorage
+	mediaRepo   *repository.MediaRepository
+	policyRepo  *repository.MediaPolicyRepository
+	messageRepo *repository.MessageRepository
+	limits      MediaLimits
+	logger      logging.Logger
+}
+
+func newMediaArchiver(mediaStorage ports.MediaStorage, mediaRepo *repository.MediaRepository, policyRepo *repository.MediaPolicyRepository, messageRepo *repository.MessageRepository, limits MediaLimits) *mediaArchiver {
+	if mediaStorage == nil || mediaRepo == nil || policyRepo == nil || messageRepo == nil {
+		return nil
+	}
+
+	return &mediaArchiver{
+		storage:     mediaStorage,
+		mediaRepo:   mediaRepo,
+		policyRepo:  policyRepo,
+		messageRepo: messageRepo,
+		limits:      limits,
+		logger:      logging.GetLogger().Sub("media-archiver"),
+	}
+}
+
+// archive baixa a mídia da mensagem e aponta o "mediaInfo" da mensagem para o objeto armazenado
+func (a *mediaArchiver) archive(ctx context.Context, client *whatsmeow.Client, sessionID string, msg *events.Message) {
+	mediaType, downloadable, fileName := inboundMedia(msg.Message)
+	if downloadable == nil {
+		return
+	}
+
+	policy, err := a.policyRepo.GetBySessionID(ctx, sessionID)
+	if err != nil {
+		a.logger.Errorf("Failed to load media policy for session %s: %v", sessionID, err)
+		return
+	}
+	if policy == nil || !policy.AutoDownload || !containsString(policy.MediaTypes, mediaType) {
+		return
+	}
+
+	limit := minLimit(a.limits.maxFor(mediaType), policyMaxSize(policy, mediaType))
+	if limit > 0 && int64(downloadable.GetFileLength()) > limit {
+		a.logger.Debugf("Skipping %s %s for session %s: %d bytes exceeds the limit of %d", mediaType, msg.Info.ID, sessionID, downloadable.GetFileLength(), limit)
+		a.markMessage(ctx, sessionID, msg.Info.ID, models.JSONB{"archiveStatus": archiveStatusSkipped, "archiveError": "media exceeds the size limit"})
+		return
+	}
+
+	if client == nil {
+		a.markMessage(ctx, sessionID, msg.Info.ID, models.JSONB{"archiveStatus": archiveStatusFailed, "archiveError": "client not available"})
+		return
+	}
+
+	data, err := client.Download(ctx, downloadable)
+	if err != nil {
+		a.logger.Warnf("Failed to download %s %s for session %s: %v", mediaType, msg.Info.ID, sessionID, err)
+		a.markMessage(ctx, sessionID, msg.Info.ID, models.JSONB{"archiveStatus": archiveStatusFailed, "archiveError": err.Error()})
+		return
+	}
+	if limit > 0 && int64(len(data)) > limit {
+		a.markMessage(ctx, sessionID, msg.Info.ID, models.JSONB{"archiveStatus": archiveStatusSkipped, "archiveError": "media exceeds the size limit"})
+		return
+	}
+
+	metadata := models.JSONB{
+		"source":    "inbound",
+		"messageId": msg.Info.ID,
+		"chatJid":   msg.Info.Chat.String(),
+	}
+
+	media, err := storeMediaContent(ctx, a.storage, a.mediaRepo, sessionID, data, mediaType, downloadable.GetMimetype(), fileName, metadata)
+	if err != nil {
+		a.logger.Errorf("Failed to archive %s %s for session %s: %v", mediaType, msg.Info.ID, sessionID, err)
+		a.markMessage(ctx, sessionID, msg.Info.ID, models.JSONB{"archiveStatus": archiveStatusFailed, "archiveError": err.Error()})
+		return
+	}
+
+	a.markMessage(ctx, sessionID, msg.Info.ID, models.JSONB{
+		"archiveStatus": archiveStatusStored,
+		"mediaId":       media.MediaId,
+		"storageDriver": media.StorageDriver,
+		"storageKey":    media.StorageKey,
+		"storedAt":      time.Now().UTC(),
+	})
+
+	a.logger.Debugf("Archived %s %s for session %s as %s (%d bytes)", mediaType, msg.Info.ID, sessionID, media.MediaId, media.Size)
+}
+
+func (a *mediaArchiver) markMessage(ctx context.Context, sessionID, messageID string, info models.JSONB) {
+	if err := a.messageRepo.MergeMediaInfo(ctx, sessionID, messageID, info); err != nil {
+		a.logger.Warnf("Failed to update media info of message %s: %v", messageID, err)
+	}
+}
+
+// archivableMessage é a parte comum das mensagens de mídia que o archiver precisa
+type archivableMessage interface {
+	whatsmeow.DownloadableMessage
+	GetFileLength() uint64
+	GetMimetype() string
+}
+
+// inboundMedia identifica a mídia baixável da mensagem; retorna nil para mensagens sem mídia
+func inboundMedia(message *waProto.Message) (mediaType string, media archivableMessage, fileName string) {
+	switch {
+	case message.GetImageMessage() != nil:
+		return MediaTypeImage, message.GetImageMessage(), ""
+	case message.GetVideoMessage() != nil:
+		return MediaTypeVideo, message.GetVideoMessage(), ""
+	case message.GetAudioMessage() != nil:
+		return MediaTypeAudio, message.GetAudioMessage(), ""
+	case message.GetDocumentMessage() != nil:
+		return MediaTypeDocument, message.GetDocumentMessage(), message.GetDocumentMessage().GetFileName()
+	case message.GetStickerMessage() != nil:
+		return MediaTypeSticker, message.GetStickerMessage(), ""
+	}
+	return "", nil, ""
+}
+
+// policyMaxSize lê o limite do tipo na política (JSONB decodifica números como float64)
+func policyMaxSize(policy *models.MediaPolicyModel, mediaType string) int64 {
+	switch v := policy.MaxSizes[mediaType].(type) {
+	case float64:
+		return int64(v)
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	}
+	return 0
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,187 @@
+package wmeow
+
+import (
+	"context"
+	"fmt"
+
+	waProto "go.mau.fi/whatsmeow/proto/waE2E"
+	waTypes "go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+
+	"zpmeow/internal/application/common"
+	"zpmeow/internal/application/ports"
+	"zpmeow/internal/infra/database/models"
+)
+
+// buildContextInfo monta o ContextInfo do envio: citação (a partir de zpMessages), menções e encaminhamento
+func (m *MeowService) buildContextInfo(ctx context.Context, sessionID string, client *WameowClient, chat waTypes.JID, opts ports.SendOptions) (*waProto.ContextInfo, error) {
+	contextInfo := &waProto.ContextInfo{}
+
+	if opts.QuotedMessageID != "" {
+		if err := m.fillQuotedMessage(ctx, sessionID, client, chat, opts.QuotedMessageID, contextInfo); err != nil {
+			return nil, err
+		}
+	}
+
+	mentioned := make(map[string]bool)
+	for _, mention := range opts.Mentions {
+		jid, err := m.parseRecipient(mention)
+		if err != nil {
+			return nil, newValidationError("mentions", err.Error())
+		}
+		jidStr := jid.ToNonAD().String()
+		if !mentioned[jidStr] {
+			mentioned[jidStr] = true
+			contextInfo.MentionedJID = append(contextInfo.MentionedJID, jidStr)
+		}
+	}
+
+	if opts.MentionAll {
+		if chat.Server != waTypes.GroupServer {
+			return nil, newValidationError("mention_all", "only allowed in group chats")
+		}
+		group, err := client.GetClient().GetGroupInfo(chat)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get group participants: %w", err)
+		}
+		ownJID := client.GetJID().ToNonAD().String()
+		for _, participant := range group.Participants {
+			jidStr := participant.JID.ToNonAD().String()
+			if jidStr == ownJID || mentioned[jidStr] {
+				continue
+			}
+			mentioned[jidStr] = true
+			contextInfo.MentionedJID = append(contextInfo.MentionedJID, jidStr)
+		}
+	}
+
+	if opts.Forwarded {
+		contextInfo.IsForwarded = proto.Bool(true)
+		contextInfo.ForwardingScore = proto.Uint32(1)
+	}
+
+	return contextInfo, nil
+}
+
+// fillQuotedMessage busca a mensagem citada em zpMessages e reconstrói o trecho exibido na resposta
+func (m *MeowService) fillQuotedMessage(ctx context.Context, sessionID string, client *WameowClient, chat waTypes.JID, quotedMessageID string, contextInfo *waProto.ContextInfo) error {
+	if m.messageRepo == nil {
+		return fmt.Errorf("message store not available to resolve quoted message")
+	}
+
+	quoted, err := m.messageRepo.GetMessageByWhatsAppID(ctx, sessionID, quotedMessageID)
+	if err != nil {
+		return err
+	}
+	if quoted == nil {
+		return fmt.Errorf("%w: quoted message %s", common.ErrMessageNotFound, quotedMessageID)
+	}
+
+	participant := quoted.SenderJid
+	if quoted.IsFromMe {
+		participant = client.GetJID().ToNonAD().String()
+	}
+
+	contextInfo.StanzaID = proto.String(quoted.MsgId)
+	contextInfo.Participant = proto.String(participant)
+	contextInfo.QuotedMessage = quotedMessageFromModel(quoted)
+
+	// Citação de outro chat precisa indicar o chat de origem
+	if m.chatRepo != nil {
+		quotedChat, err := m.chatRepo.GetChatByID(ctx, quoted.ChatId)
+		if err == nil && quotedChat != nil && quotedChat.ChatJid != chat.ToNonAD().String() {
+			contextInfo.RemoteJID = proto.String(quotedChat.ChatJid)
+		}
+	}
+
+	return nil
+}
+
+// quotedMessageFromModel reconstrói a prévia da mensagem citada a partir do que foi gravado em zpMessages
+func quotedMessageFromModel(quoted *models.MessageModel) *waProto.Message {
+	content := ""
+	if quoted.Content != nil {
+		content = *quoted.Content
+	}
+	mimeType, _ := quoted.MediaInfo["mimeType"].(string)
+
+	switch quoted.MsgType {
+	case "image":
+		return &waProto.Message{ImageMessage: &waProto.ImageMessage{Caption: proto.String(content), Mimetype: proto.String(mimeType)}}
+	case "video":
+		return &waProto.Message{VideoMessage: &waProto.VideoMessage{Caption: proto.String(content), Mimetype: proto.String(mimeType)}}
+	case "audio", "ptt":
+		return &waProto.Message{AudioMessage: &waProto.AudioMessage{Mimetype: proto.String(mimeType), PTT: proto.Bool(quoted.MsgType == "ptt")}}
+	case "document":
+		fileName, _ := quoted.MediaInfo["fileName"].(string)
+		return &waProto.Message{DocumentMessage: &waProto.DocumentMessage{Caption: proto.String(content), FileName: proto.String(fileName), Mimetype: proto.String(mimeType)}}
+	case "sticker":
+		return &waProto.Message{StickerMessage: &waProto.StickerMessage{Mimetype: proto.String(mimeType)}}
+	case "location":
+		latitude, _ := quoted.MediaInfo["latitude"].(float64)
+		longitude, _ := quoted.MediaInfo["longitude"].(float64)
+		return &waProto.Message{LocationMessage: &waProto.LocationMessage{DegreesLatitude: proto.Float64(latitude), DegreesLongitude: proto.Float64(longitude), Name: proto.String(content)}}
+	case "contact":
+		return &waProto.Message{ContactMessage: &waProto.ContactMessage{DisplayName: proto.String(content)}}
+	case "poll":
+		return &waProto.Message{PollCreationMessage: &waProto.PollCreationMessage{Name: proto.String(content)}}
+	}
+
+	return &waProto.Message{Conversation: proto.String(content)}
+}
+
+// applyContextInfo anexa o ContextInfo à mensagem; texto simples vira ExtendedTextMessage, que é o único formato de texto com contexto
+func applyContextInfo(message *waProto.Message, contextInfo *waProto.ContextInfo) {
+	switch {
+	case message.Conversation != nil:
+		message.ExtendedTextMessage = &waProto.ExtendedTextMessage{Text: message.Conversation, ContextInfo: contextInfo}
+		message.Conversation = nil
+	case message.ExtendedTextMessage != nil:
+		message.ExtendedTextMessage.ContextInfo = contextInfo
+	case message.ImageMessage != nil:
+		message.ImageMessage.ContextInfo = contextInfo
+	case message.VideoMessage != nil:
+		message.VideoMessage.ContextInfo = contextInfo
+	case message.AudioMessage != nil:
+		message.AudioMessage.ContextInfo = contextInfo
+	case message.DocumentMessage != nil:
+		message.DocumentMessage.ContextInfo = contextInfo
+	case message.StickerMessage != nil:
+		message.StickerMessage.ContextInfo = contextInfo
+	case message.LocationMessage != nil:
+		message.LocationMessage.ContextInfo = contextInfo
+	case message.ContactMessage != nil:
+		message.ContactMessage.ContextInfo = contextInfo
+	case message.ContactsArrayMessage != nil:
+		message.ContactsArrayMessage.ContextInfo = contextInfo
+	case message.PollCreationMessage != nil:
+		message.PollCreationMessage.ContextInfo = contextInfo
+	}
+}
+
+// messageContextInfo retorna o ContextInfo da mensagem, qualquer que seja o tipo
+func messageContextInfo(message *waProto.Message) *waProto.ContextInfo {
+	switch {
+	case message.GetExtendedTextMessage() != nil:
+		return message.GetExtendedTextMessage().GetContextInfo()
+	case message.GetImageMessage() != nil:
+		return message.GetImageMessage().GetContextInfo()
+	case message.GetVideoMessage() != nil:
+		return message.GetVideoMessage().GetContextInfo()
+	case message.GetAudioMessage() != nil:
+		return message.GetAudioMessage().GetContextInfo()
+	case message.GetDocumentMessage() != nil:
+		return message.GetDocumentMessage().GetContextInfo()
+	case message.GetStickerMessage() != nil:
+		return message.GetStickerMessage().GetContextInfo()
+	case message.GetLocationMessage() != nil:
+		return message.GetLocationMessage().GetContextInfo()
+	case message.GetContactMessage() != nil:
+		return message.GetContactMessage().GetContextInfo()
+	case message.GetContactsArrayMessage() != nil:
+		return message.GetContactsArrayMessage().GetContextInfo()
+	case message.GetPollCreationMessage() != nil:
+		return message.GetPollCreationMessage().GetContextInfo()
+	}
+	return nil
+}
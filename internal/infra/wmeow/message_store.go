@@ -0,0 +1,229 @@
+package wmeow
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/proto/waE2E"
+	waTypes "go.mau.fi/whatsmeow/types"
+
+	"zpmeow/internal/infra/database/models"
+	"zpmeow/internal/infra/database/repository"
+)
+
+// ensureChat busca o chat da sessão pelo JID, criando-o quando ainda não existe
+func ensureChat(ctx context.Context, chatRepo *repository.ChatRepository, sessionID, chatJID string) (*models.ChatModel, error) {
+	chat, err := chatRepo.GetChatBySessionAndJID(ctx, sessionID, chatJID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chat: %w", err)
+	}
+	if chat != nil {
+		return chat, nil
+	}
+
+	isGroup := strings.Contains(chatJID, "@g.us")
+
+	// Número de telefone só faz sentido para chats individuais
+	var phoneNumber *string
+	if !isGroup {
+		phone := phoneFromJID(chatJID)
+		phoneNumber = &phone
+	}
+
+	chat = &models.ChatModel{
+		SessionId:   sessionID,
+		ChatJid:     chatJID,
+		ChatName:    nil, // Será preenchido posteriormente se disponível
+		PhoneNumber: phoneNumber,
+		IsGroup:     isGroup,
+		UnreadCount: 0,
+		IsArchived:  false,
+		Metadata:    models.JSONB{},
+	}
+
+	if err := chatRepo.CreateChat(ctx, chat); err != nil {
+		return nil, fmt.Errorf("failed to create chat: %w", err)
+	}
+
+	return chat, nil
+}
+
+// phoneFromJID remove o servidor e o sufixo de device do JID (ex: 5511999999999:12@s.whatsapp.net)
+func phoneFromJID(jid string) string {
+	user := strings.Split(jid, "@")[0]
+	return strings.Split(user, ":")[0]
+}
+
+// persistOutgoingMessage grava em zpMessages a mensagem enviada pela API com status pending;
+// os receipts posteriores avançam o status a partir daqui
+func (m *MeowService) persistOutgoingMessage(ctx context.Context, sessionID string, client *WameowClient, to waTypes.JID, message *waProto.Message, resp *whatsmeow.SendResponse) error {
+	if m.messageRepo == nil || m.chatRepo == nil {
+		return nil
+	}
+
+	chat, err := ensureChat(ctx, m.chatRepo, sessionID, to.ToNonAD().String())
+	if err != nil {
+		return err
+	}
+
+	msgType, content, mediaInfo := describeMessage(message)
+
+	var contentPtr *string
+	if content != "" {
+		contentPtr = &content
+	}
+
+	model := &models.MessageModel{
+		ChatId:      chat.ID,
+		SessionId:   sessionID,
+		MsgId:       resp.ID,
+		MsgType:     msgType,
+		Content:     contentPtr,
+		MediaInfo:   mediaInfo,
+		SenderJid:   client.GetJID().ToNonAD().String(),
+		IsFromMe:    true,
+		IsBroadcast: to.Server == waTypes.BroadcastServer,
+		Status:      models.MessageStatusPending,
+		Timestamp:   resp.Timestamp,
+		Metadata:    models.JSONB{},
+	}
+
+	fillMessageContext(model, message)
+
+	created, err := m.messageRepo.CreateMessageIfNotExists(ctx, model)
+	if err != nil {
+		return err
+	}
+	if !created {
+		m.logger.Debugf("Outgoing message %s already stored for session %s", resp.ID, sessionID)
+		return nil
+	}
+
+	chat.LastMsgAt = &resp.Timestamp
+	if err := m.chatRepo.UpdateChat(ctx, chat); err != nil {
+		m.logger.Warnf("Failed to update chat last message time for %s: %v", chat.ChatJid, err)
+	}
+
+	return nil
+}
+
+// fillMessageContext grava no modelo o encaminhamento, a citação e as menções do ContextInfo da mensagem
+func fillMessageContext(model *models.MessageModel, message *waProto.Message) {
+	contextInfo := messageContextInfo(message)
+	if contextInfo == nil {
+		return
+	}
+
+	if contextInfo.GetIsForwarded() {
+		model.IsForwarded = true
+	}
+	if contextInfo.GetStanzaID() != "" {
+		quotedID := contextInfo.GetStanzaID()
+		model.QuotedMsgId = &quotedID
+		if contextInfo.GetQuotedMessage() != nil {
+			if _, quotedContent, _ := describeMessage(contextInfo.GetQuotedMessage()); quotedContent != "" {
+				model.QuotedContent = &quotedContent
+			}
+		}
+	}
+	if len(contextInfo.GetMentionedJID()) > 0 {
+		if model.Metadata == nil {
+			model.Metadata = models.JSONB{}
+		}
+		model.Metadata["mentions"] = contextInfo.GetMentionedJID()
+	}
+}
+
+// isContentMessage indica se a mensagem tem conteúdo próprio para o histórico;
+// mensagens de protocolo (revoke, edição), reações e votos de enquete só alteram outras mensagens
+func isContentMessage(message *waProto.Message) bool {
+	if message == nil {
+		return false
+	}
+	return message.GetProtocolMessage() == nil &&
+		message.GetReactionMessage() == nil &&
+		message.GetEncReactionMessage() == nil &&
+		message.GetPollUpdateMessage() == nil
+}
+
+// describeMessage extrai tipo, conteúdo e informações de mídia de uma mensagem
+func describeMessage(message *waProto.Message) (msgType, content string, mediaInfo models.JSONB) {
+	mediaInfo = models.JSONB{}
+
+	switch {
+	case message.GetConversation() != "":
+		return "text", message.GetConversation(), mediaInfo
+	case message.GetExtendedTextMessage() != nil:
+		return "text", message.GetExtendedTextMessage().GetText(), mediaInfo
+	case message.GetImageMessage() != nil:
+		img := message.GetImageMessage()
+		fillMediaInfo(mediaInfo, img.GetMimetype(), "", img.GetFileLength(), img.GetURL(), img.GetDirectPath(), img.GetMediaKey(), img.GetFileSHA256(), img.GetFileEncSHA256())
+		return "image", img.GetCaption(), mediaInfo
+	case message.GetAudioMessage() != nil:
+		audio := message.GetAudioMessage()
+		fillMediaInfo(mediaInfo, audio.GetMimetype(), "", audio.GetFileLength(), audio.GetURL(), audio.GetDirectPath(), audio.GetMediaKey(), audio.GetFileSHA256(), audio.GetFileEncSHA256())
+		if audio.GetPTT() {
+			return "ptt", "", mediaInfo
+		}
+		return "audio", "", mediaInfo
+	case message.GetVideoMessage() != nil:
+		video := message.GetVideoMessage()
+		fillMediaInfo(mediaInfo, video.GetMimetype(), "", video.GetFileLength(), video.GetURL(), video.GetDirectPath(), video.GetMediaKey(), video.GetFileSHA256(), video.GetFileEncSHA256())
+		return "video", video.GetCaption(), mediaInfo
+	case message.GetDocumentMessage() != nil:
+		doc := message.GetDocumentMessage()
+		fillMediaInfo(mediaInfo, doc.GetMimetype(), doc.GetFileName(), doc.GetFileLength(), doc.GetURL(), doc.GetDirectPath(), doc.GetMediaKey(), doc.GetFileSHA256(), doc.GetFileEncSHA256())
+		return "document", doc.GetCaption(), mediaInfo
+	case message.GetStickerMessage() != nil:
+		sticker := message.GetStickerMessage()
+		fillMediaInfo(mediaInfo, sticker.GetMimetype(), "", sticker.GetFileLength(), sticker.GetURL(), sticker.GetDirectPath(), sticker.GetMediaKey(), sticker.GetFileSHA256(), sticker.GetFileEncSHA256())
+		return "sticker", "", mediaInfo
+	case message.GetLocationMessage() != nil:
+		loc := message.GetLocationMessage()
+		mediaInfo["latitude"] = loc.GetDegreesLatitude()
+		mediaInfo["longitude"] = loc.GetDegreesLongitude()
+		if loc.GetAddress() != "" {
+			mediaInfo["address"] = loc.GetAddress()
+		}
+		return "location", loc.GetName(), mediaInfo
+	case message.GetContactMessage() != nil:
+		contact := message.GetContactMessage()
+		mediaInfo["vcard"] = contact.GetVcard()
+		return "contact", contact.GetDisplayName(), mediaInfo
+	case message.GetPollCreationMessage() != nil:
+		poll := message.GetPollCreationMessage()
+		options := make([]string, 0, len(poll.GetOptions()))
+		for _, option := range poll.GetOptions() {
+			options = append(options, option.GetOptionName())
+		}
+		mediaInfo["options"] = options
+		mediaInfo["selectableCount"] = poll.GetSelectableOptionsCount()
+		return "poll", poll.GetName(), mediaInfo
+	case message.GetContactsArrayMessage() != nil:
+		contacts := message.GetContactsArrayMessage()
+		vcards := make([]string, 0, len(contacts.GetContacts()))
+		for _, contact := range contacts.GetContacts() {
+			vcards = append(vcards, contact.GetVcard())
+		}
+		mediaInfo["vcards"] = vcards
+		return "contact", contacts.GetDisplayName(), mediaInfo
+	}
+
+	return "text", "", mediaInfo
+}
+
+func fillMediaInfo(info models.JSONB, mimeType, fileName string, fileLength uint64, url, directPath string, mediaKey, fileSHA256, fileEncSHA256 []byte) {
+	info["mimeType"] = mimeType
+	info["fileLength"] = fileLength
+	info["url"] = url
+	info["directPath"] = directPath
+	info["mediaKey"] = base64.StdEncoding.EncodeToString(mediaKey)
+	info["fileSHA256"] = base64.StdEncoding.EncodeToString(fileSHA256)
+	info["fileEncSHA256"] = base64.StdEncoding.EncodeToString(fileEncSHA256)
+	if fileName != "" {
+		info["fileName"] = fileName
+	}
+}
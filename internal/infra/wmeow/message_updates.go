@@ -0,0 +1,207 @@
+package wmeow
+
+import (
+	"context"
+	"time"
+
+	waProto "go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"zpmeow/internal/infra/database/models"
+)
+
+// Eventos normalizados das alterações em mensagens já enviadas
+const (
+	EventMessageEdited   = "message.edited"
+	EventMessageDeleted  = "message.deleted"
+	EventMessageReaction = "message.reaction"
+
+	messageUpdateTimeout = 10 * time.Second
+)
+
+// messageEdited é o payload do webhook message.edited
+type messageEdited struct {
+	MessageID       string `json:"message_id"`
+	ChatJID         string `json:"chat_jid"`
+	Sender          string `json:"sender"`
+	FromMe          bool   `json:"from_me"`
+	Type            string `json:"type"`
+	Content         string `json:"content"`
+	PreviousContent string `json:"previous_content,omitempty"`
+	EditedAt        int64  `json:"edited_at"`
+	Stored          bool   `json:"stored"` // mensagem original encontrada e atualizada no banco
+}
+
+// messageDeleted é o payload do webhook message.deleted
+type messageDeleted struct {
+	MessageID string `json:"message_id"`
+	ChatJID   string `json:"chat_jid"`
+	Sender    string `json:"sender"`
+	FromMe    bool   `json:"from_me"`
+	DeletedAt int64  `json:"deleted_at"`
+	Stored    bool   `json:"stored"`
+}
+
+// messageReaction é o payload do webhook message.reaction; emoji vazio indica reação removida
+type messageReaction struct {
+	MessageID string `json:"message_id"`
+	ChatJID   string `json:"chat_jid"`
+	Sender    string `json:"sender"`
+	FromMe    bool   `json:"from_me"`
+	Emoji     string `json:"emoji"`
+	Removed   bool   `json:"removed"`
+	Timestamp int64  `json:"timestamp"`
+	Stored    bool   `json:"stored"`
+}
+
+// applyMessageUpdate trata edições, revogações e reações, que alteram a mensagem original
+// em vez de gerar uma nova linha em zpMessages. Retorna false para as demais mensagens.
+func (ep *EventProcessor) applyMessageUpdate(msg *events.Message) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), messageUpdateTimeout)
+	defer cancel()
+
+	if protocol := msg.Message.GetProtocolMessage(); protocol != nil {
+		switch protocol.GetType() {
+		case waProto.ProtocolMessage_MESSAGE_EDIT:
+			ep.applyEdit(ctx, msg, protocol)
+			return true
+		case waProto.ProtocolMessage_REVOKE:
+			ep.applyRevoke(ctx, msg, protocol)
+			return true
+		}
+		return false
+	}
+
+	if reaction := msg.Message.GetReactionMessage(); reaction != nil {
+		ep.applyReaction(ctx, msg, reaction.GetKey().GetID(), reaction)
+		return true
+	}
+
+	if encReaction := msg.Message.GetEncReactionMessage(); encReaction != nil {
+		if ep.waClient == nil {
+			return true
+		}
+		reaction, err := ep.waClient.DecryptReaction(ctx, msg)
+		if err != nil {
+			ep.logger.Warnf("Failed to decrypt reaction %s in session %s: %v", msg.Info.ID, ep.sessionID, err)
+			return true
+		}
+		ep.applyReaction(ctx, msg, encReaction.GetTargetMessageKey().GetID(), reaction)
+		return true
+	}
+
+	return false
+}
+
+func (ep *EventProcessor) applyEdit(ctx context.Context, msg *events.Message, protocol *waProto.ProtocolMessage) {
+	targetID := protocol.GetKey().GetID()
+	msgType, content, _ := describeMessage(protocol.GetEditedMessage())
+
+	edited := messageEdited{
+		MessageID: targetID,
+		ChatJID:   msg.Info.Chat.String(),
+		Sender:    msg.Info.Sender.ToNonAD().String(),
+		FromMe:    msg.Info.IsFromMe,
+		Type:      msgType,
+		Content:   content,
+		EditedAt:  msg.Info.Timestamp.Unix(),
+	}
+
+	original := ep.loadMessage(ctx, targetID)
+	if original != nil && !sameSender(original, msg) {
+		ep.logger.Warnf("Ignoring edit of message %s in session %s: sent by %s, edited by %s", targetID, ep.sessionID, original.SenderJid, msg.Info.Sender)
+		original = nil
+	}
+	if original != nil {
+		edited.Type = original.MsgType
+		edited.PreviousContent = getStringValue(original.Content)
+		if err := ep.messageRepo.EditMessage(ctx, original.ID, content); err != nil {
+			ep.logger.Errorf("Failed to apply edit of message %s in session %s: %v", targetID, ep.sessionID, err)
+		} else {
+			edited.Stored = true
+		}
+	}
+
+	ep.sendNormalizedEvent(EventMessageEdited, edited)
+}
+
+func (ep *EventProcessor) applyRevoke(ctx context.Context, msg *events.Message, protocol *waProto.ProtocolMessage) {
+	targetID := protocol.GetKey().GetID()
+
+	deleted := messageDeleted{
+		MessageID: targetID,
+		ChatJID:   msg.Info.Chat.String(),
+		Sender:    msg.Info.Sender.ToNonAD().String(),
+		FromMe:    msg.Info.IsFromMe,
+		DeletedAt: msg.Info.Timestamp.Unix(),
+	}
+
+	// Em grupos, administradores também podem apagar mensagens de outros participantes
+	original := ep.loadMessage(ctx, targetID)
+	if original != nil && !msg.Info.IsGroup && !sameSender(original, msg) {
+		ep.logger.Warnf("Ignoring revoke of message %s in session %s: sent by %s, revoked by %s", targetID, ep.sessionID, original.SenderJid, msg.Info.Sender)
+		original = nil
+	}
+
+	if original != nil && !original.IsDeleted {
+		if err := ep.messageRepo.DeleteMessage(ctx, original.ID); err != nil {
+			ep.logger.Errorf("Failed to apply revoke of message %s in session %s: %v", targetID, ep.sessionID, err)
+		} else {
+			deleted.Stored = true
+		}
+	}
+
+	ep.sendNormalizedEvent(EventMessageDeleted, deleted)
+}
+
+func (ep *EventProcessor) applyReaction(ctx context.Context, msg *events.Message, targetID string, reaction *waProto.ReactionMessage) {
+	timestamp := msg.Info.Timestamp
+	if ms := reaction.GetSenderTimestampMS(); ms > 0 {
+		timestamp = time.UnixMilli(ms)
+	}
+
+	update := messageReaction{
+		MessageID: targetID,
+		ChatJID:   msg.Info.Chat.String(),
+		Sender:    msg.Info.Sender.ToNonAD().String(),
+		FromMe:    msg.Info.IsFromMe,
+		Emoji:     reaction.GetText(),
+		Removed:   reaction.GetText() == "",
+		Timestamp: timestamp.Unix(),
+	}
+
+	if ep.reactionRepo != nil {
+		stored, err := ep.reactionRepo.SetReaction(ctx, ep.sessionID, targetID, update.Sender, update.Emoji, timestamp)
+		if err != nil {
+			ep.logger.Errorf("Failed to save reaction to message %s in session %s: %v", targetID, ep.sessionID, err)
+		}
+		update.Stored = stored
+	}
+
+	ep.sendNormalizedEvent(EventMessageReaction, update)
+}
+
+// loadMessage busca a mensagem alterada pelo ID do WhatsApp; nil quando não está armazenada
+func (ep *EventProcessor) loadMessage(ctx context.Context, targetID string) *models.MessageModel {
+	if ep.messageRepo == nil || targetID == "" {
+		return nil
+	}
+
+	original, err := ep.messageRepo.GetMessageByWhatsAppID(ctx, ep.sessionID, targetID)
+	if err != nil {
+		ep.logger.Warnf("Failed to load message %s in session %s: %v", targetID, ep.sessionID, err)
+		return nil
+	}
+	return original
+}
+
+// sameSender indica se a alteração foi feita por quem enviou a mensagem original;
+// o remetente pode ter sido gravado pelo número ou pelo LID
+func sameSender(original *models.MessageModel, msg *events.Message) bool {
+	if original.IsFromMe || msg.Info.IsFromMe {
+		return original.IsFromMe == msg.Info.IsFromMe
+	}
+
+	sender := phoneFromJID(original.SenderJid)
+	return sender == msg.Info.Sender.User || (!msg.Info.SenderAlt.IsEmpty() && sender == msg.Info.SenderAlt.User)
+}
@@ -0,0 +1,219 @@
+package wmeow
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	waTypes "go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"zpmeow/internal/application/common"
+	"zpmeow/internal/application/ports"
+)
+
+// EventPrivacyUpdated é o evento normalizado das alterações de privacidade notificadas pelo WhatsApp
+const EventPrivacyUpdated = "privacy.updated"
+
+// privacySettingTypes traduz os nomes da API (e os nomes do WhatsApp) para o tipo da configuração
+var privacySettingTypes = map[string]waTypes.PrivacySettingType{
+	"last_seen":     waTypes.PrivacySettingTypeLastSeen,
+	"last":          waTypes.PrivacySettingTypeLastSeen,
+	"profile_photo": waTypes.PrivacySettingTypeProfile,
+	"profile":       waTypes.PrivacySettingTypeProfile,
+	"status":        waTypes.PrivacySettingTypeStatus,
+	"read_receipts": waTypes.PrivacySettingTypeReadReceipts,
+	"readreceipts":  waTypes.PrivacySettingTypeReadReceipts,
+	"groups_add_me": waTypes.PrivacySettingTypeGroupAdd,
+	"groupadd":      waTypes.PrivacySettingTypeGroupAdd,
+	"calls_add_me":  waTypes.PrivacySettingTypeCallAdd,
+	"calladd":       waTypes.PrivacySettingTypeCallAdd,
+	"online":        waTypes.PrivacySettingTypeOnline,
+}
+
+// privacySettingValues são os valores aceitos pelo WhatsApp em cada configuração
+var privacySettingValues = map[waTypes.PrivacySettingType][]waTypes.PrivacySetting{
+	waTypes.PrivacySettingTypeLastSeen:     {waTypes.PrivacySettingAll, waTypes.PrivacySettingContacts, waTypes.PrivacySettingContactBlacklist, waTypes.PrivacySettingNone},
+	waTypes.PrivacySettingTypeProfile:      {waTypes.PrivacySettingAll, waTypes.PrivacySettingContacts, waTypes.PrivacySettingContactBlacklist, waTypes.PrivacySettingNone},
+	waTypes.PrivacySettingTypeStatus:       {waTypes.PrivacySettingAll, waTypes.PrivacySettingContacts, waTypes.PrivacySettingContactBlacklist, waTypes.PrivacySettingNone},
+	waTypes.PrivacySettingTypeGroupAdd:     {waTypes.PrivacySettingAll, waTypes.PrivacySettingContacts, waTypes.PrivacySettingContactBlacklist, waTypes.PrivacySettingNone},
+	waTypes.PrivacySettingTypeReadReceipts: {waTypes.PrivacySettingAll, waTypes.PrivacySettingNone},
+	waTypes.PrivacySettingTypeOnline:       {waTypes.PrivacySettingAll, waTypes.PrivacySettingMatchLastSeen},
+	waTypes.PrivacySettingTypeCallAdd:      {waTypes.PrivacySettingAll, waTypes.PrivacySettingKnown},
+}
+
+// privacyValueAliases mantém os valores aceitos pelas versões anteriores da API
+var privacyValueAliases = map[string]waTypes.PrivacySetting{
+	"everyone": waTypes.PrivacySettingAll,
+	"nobody":   waTypes.PrivacySettingNone,
+}
+
+// defaultDisappearingTimers são as durações aceitas para o timer padrão de mensagens temporárias
+var defaultDisappearingTimers = map[time.Duration]bool{
+	0:                   true,
+	24 * time.Hour:      true,
+	7 * 24 * time.Hour:  true,
+	90 * 24 * time.Hour: true,
+}
+
+// parsePrivacySetting valida o nome e o valor de uma configuração de privacidade
+func parsePrivacySetting(setting, value string) (waTypes.PrivacySettingType, waTypes.PrivacySetting, error) {
+	settingType, ok := privacySettingTypes[strings.ToLower(strings.TrimSpace(setting))]
+	if !ok {
+		return "", "", fmt.Errorf("%w: unknown privacy setting %q", common.ErrInvalidInput, setting)
+	}
+
+	value = strings.ToLower(strings.TrimSpace(value))
+	settingValue, ok := privacyValueAliases[value]
+	if !ok {
+		settingValue = waTypes.PrivacySetting(value)
+	}
+
+	valid := privacySettingValues[settingType]
+	for _, allowed := range valid {
+		if settingValue == allowed {
+			return settingType, settingValue, nil
+		}
+	}
+
+	names := make([]string, 0, len(valid))
+	for _, allowed := range valid {
+		names = append(names, string(allowed))
+	}
+	return "", "", fmt.Errorf("%w: invalid value %q for %s, must be one of: %s", common.ErrInvalidInput, value, setting, strings.Join(names, ", "))
+}
+
+// privacyCache guarda a cópia das configurações de privacidade de cada sessão, atualizada pelas
+// consultas, pelas alterações feitas pela API e pelos eventos PrivacySettings
+type privacyCache struct {
+	mu       sync.RWMutex
+	sessions map[string]*cachedPrivacy
+}
+
+type cachedPrivacy struct {
+	settings  *waTypes.PrivacySettings // nil até a primeira consulta após conectar
+	updatedAt time.Time
+
+	// O WhatsApp não permite consultar o timer padrão; ele só é conhecido depois de definido por esta API
+	disappearingTimer *time.Duration
+}
+
+func newPrivacyCache() *privacyCache {
+	return &privacyCache{sessions: make(map[string]*cachedPrivacy)}
+}
+
+// get retorna uma cópia das configurações da sessão; ok é falso se elas ainda não foram consultadas
+func (c *privacyCache) get(sessionID string) (cachedPrivacy, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	cached, exists := c.sessions[sessionID]
+	if !exists || cached.settings == nil {
+		return cachedPrivacy{}, false
+	}
+
+	settings := *cached.settings
+	return cachedPrivacy{settings: &settings, updatedAt: cached.updatedAt, disappearingTimer: cached.disappearingTimer}, true
+}
+
+func (c *privacyCache) storeSettings(sessionID string, settings waTypes.PrivacySettings) cachedPrivacy {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached := c.session(sessionID)
+	cached.settings = &settings
+	cached.updatedAt = time.Now()
+	return *cached
+}
+
+func (c *privacyCache) storeDisappearingTimer(sessionID string, timer time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached := c.session(sessionID)
+	cached.disappearingTimer = &timer
+	cached.updatedAt = time.Now()
+}
+
+// invalidate descarta as configurações consultadas, que podem ter mudado enquanto a sessão esteve desconectada
+func (c *privacyCache) invalidate(sessionID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cached, exists := c.sessions[sessionID]; exists {
+		cached.settings = nil
+	}
+}
+
+func (c *privacyCache) session(sessionID string) *cachedPrivacy {
+	cached, exists := c.sessions[sessionID]
+	if !exists {
+		cached = &cachedPrivacy{}
+		c.sessions[sessionID] = cached
+	}
+	return cached
+}
+
+// toPrivacySettings converte a cópia em cache para o formato da API
+func (p cachedPrivacy) toPrivacySettings() *ports.PrivacySettings {
+	settings := &ports.PrivacySettings{UpdatedAt: p.updatedAt}
+	if p.settings != nil {
+		settings.LastSeen = string(p.settings.LastSeen)
+		settings.ProfilePhoto = string(p.settings.Profile)
+		settings.Status = string(p.settings.Status)
+		settings.ReadReceipts = p.settings.ReadReceipts == waTypes.PrivacySettingAll
+		settings.GroupsAddMe = string(p.settings.GroupAdd)
+		settings.CallsAddMe = string(p.settings.CallAdd)
+		settings.Online = string(p.settings.Online)
+	}
+	if p.disappearingTimer != nil {
+		seconds := int(p.disappearingTimer.Seconds())
+		settings.DisappearingTimer = &seconds
+		settings.DisappearingMessages = "off"
+		if seconds > 0 {
+			settings.DisappearingMessages = "on"
+		}
+	}
+	return settings
+}
+
+// privacyUpdated é o payload do webhook privacy.updated
+type privacyUpdated struct {
+	Settings *ports.PrivacySettings `json:"settings"`
+	Changed  []string               `json:"changed"` // nomes da API das configurações alteradas
+}
+
+// handlePrivacySettings atualiza a cópia em cache com as alterações recebidas do WhatsApp
+func (ep *EventProcessor) handlePrivacySettings(evt interface{}) {
+	change := evt.(*events.PrivacySettings)
+	ep.sendGenericEvent("PrivacySettings", change)
+
+	if ep.privacyCache == nil {
+		return
+	}
+
+	cached := ep.privacyCache.storeSettings(ep.sessionID, change.NewSettings)
+
+	changed := []struct {
+		name    string
+		changed bool
+	}{
+		{"last_seen", change.LastSeenChanged},
+		{"profile_photo", change.ProfileChanged},
+		{"status", change.StatusChanged},
+		{"read_receipts", change.ReadReceiptsChanged},
+		{"groups_add_me", change.GroupAddChanged},
+		{"calls_add_me", change.CallAddChanged},
+		{"online", change.OnlineChanged},
+	}
+	payload := privacyUpdated{Settings: cached.toPrivacySettings(), Changed: []string{}}
+	for _, setting := range changed {
+		if setting.changed {
+			payload.Changed = append(payload.Changed, setting.name)
+		}
+	}
+
+	ep.logger.Infof("Privacy settings changed in session %s: %s", ep.sessionID, strings.Join(payload.Changed, ", "))
+	ep.sendNormalizedEvent(EventPrivacyUpdated, payload)
+}
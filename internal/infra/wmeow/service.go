@@ -11,7 +11,9 @@ import (
 	"zpmeow/internal/domain/session"
 	"zpmeow/internal/infra/chatwoot"
 	"zpmeow/internal/infra/database/repository"
+	"zpmeow/internal/infra/eventstream"
 	"zpmeow/internal/infra/logging"
+	"zpmeow/internal/infra/webhooks"
 
 	"github.com/jmoiron/sqlx"
 	"go.mau.fi/whatsmeow/store/sqlstore"
@@ -29,55 +31,121 @@ type MeowService struct {
 	waLogger            waLog.Logger
 	mu                  sync.RWMutex
 	messageSender       *messageSender
+	messageBuilder      *MessageBuilder
 	mimeHelper          *mimeTypeHelper
 	chatwootIntegration *chatwoot.Integration
 	chatwootRepo        *repository.ChatwootRepository
 	messageRepo         *repository.MessageRepository
+	receiptRepo         *repository.MessageReceiptRepository
+	reactionRepo        *repository.MessageReactionRepository
 	chatRepo            *repository.ChatRepository
+	labelRepo           *repository.LabelRepository
+	statusRepo          *repository.StatusRepository
 	webhookRepo         *repository.WebhookRepository
+	webhookQueue        *webhooks.DeliveryQueue
+	mediaRepo           *repository.MediaRepository
+	mediaStorage        ports.MediaStorage
+	mediaLimits         MediaLimits
+	mediaArchiver       *mediaArchiver
+	historyImporter     *historyImporter
+	callTracker         *callTracker
+	privacyCache        *privacyCache
+	eventStream         *eventstream.Hub
 }
 
 // Construtores
-func NewMeowService(container *sqlstore.Container, waLogger waLog.Logger, sessionRepo session.Repository, db *sqlx.DB) WameowService {
-	// Criar repositórios de mensagem, chat e webhook
+func NewMeowService(container *sqlstore.Container, waLogger waLog.Logger, sessionRepo session.Repository, db *sqlx.DB, webhookQueue *webhooks.DeliveryQueue, mediaStorage ports.MediaStorage, mediaLimits MediaLimits, historySync HistorySyncOptions, eventStream *eventstream.Hub) WameowService {
+	// Criar repositórios de mensagem, chat, webhook e mídia
 	messageRepo := repository.NewMessageRepository(db)
+	receiptRepo := repository.NewMessageReceiptRepository(db)
+	reactionRepo := repository.NewMessageReactionRepository(db)
 	chatRepo := repository.NewChatRepository(db)
+	labelRepo := repository.NewLabelRepository(db)
+	statusRepo := repository.NewStatusRepository(db)
 	webhookRepo := repository.NewWebhookRepository(db)
-
-	return &MeowService{
-		clients:       make(map[string]*WameowClient),
-		sessions:      sessionRepo,
-		logger:        logging.GetLogger().Sub("wameow"),
-		container:     container,
-		waLogger:      waLogger,
-		messageSender: NewMessageSender(),
-		mimeHelper:    NewMimeTypeHelper(),
-		messageRepo:   messageRepo,
-		chatRepo:      chatRepo,
-		webhookRepo:   webhookRepo,
+	mediaRepo := repository.NewMediaRepository(db)
+	mediaPolicyRepo := repository.NewMediaPolicyRepository(db)
+	historySyncRepo := repository.NewHistorySyncRepository(db)
+	callPolicyRepo := repository.NewCallPolicyRepository(db)
+	callRepo := repository.NewCallRepository(db)
+	globalWebhookService.SetEndpointStore(webhookRepo)
+
+	service := &MeowService{
+		clients:         make(map[string]*WameowClient),
+		sessions:        sessionRepo,
+		logger:          logging.GetLogger().Sub("wameow"),
+		container:       container,
+		waLogger:        waLogger,
+		messageSender:   NewMessageSender(),
+		messageBuilder:  NewMessageBuilder(),
+		mimeHelper:      NewMimeTypeHelper(),
+		messageRepo:     messageRepo,
+		receiptRepo:     receiptRepo,
+		reactionRepo:    reactionRepo,
+		chatRepo:        chatRepo,
+		labelRepo:       labelRepo,
+		statusRepo:      statusRepo,
+		webhookRepo:     webhookRepo,
+		webhookQueue:    webhookQueue,
+		mediaRepo:       mediaRepo,
+		mediaStorage:    mediaStorage,
+		mediaLimits:     mediaLimits,
+		mediaArchiver:   newMediaArchiver(mediaStorage, mediaRepo, mediaPolicyRepo, messageRepo, mediaLimits),
+		historyImporter: newHistoryImporter(historySyncRepo, webhookQueue, historySync),
+		privacyCache:    newPrivacyCache(),
+		eventStream:     eventStream,
 	}
+	service.callTracker = newCallTracker(callPolicyRepo, callRepo, service.sendCallReply)
+
+	return service
 }
 
-func NewMeowServiceWithChatwoot(container *sqlstore.Container, waLogger waLog.Logger, sessionRepo session.Repository, chatwootIntegration *chatwoot.Integration, chatwootRepo *repository.ChatwootRepository, db *sqlx.DB) WameowService {
-	// Criar repositórios de mensagem, chat e webhook
+func NewMeowServiceWithChatwoot(container *sqlstore.Container, waLogger waLog.Logger, sessionRepo session.Repository, chatwootIntegration *chatwoot.Integration, chatwootRepo *repository.ChatwootRepository, db *sqlx.DB, webhookQueue *webhooks.DeliveryQueue, mediaStorage ports.MediaStorage, mediaLimits MediaLimits, historySync HistorySyncOptions, eventStream *eventstream.Hub) WameowService {
+	// Criar repositórios de mensagem, chat, webhook e mídia
 	messageRepo := repository.NewMessageRepository(db)
+	receiptRepo := repository.NewMessageReceiptRepository(db)
+	reactionRepo := repository.NewMessageReactionRepository(db)
 	chatRepo := repository.NewChatRepository(db)
+	labelRepo := repository.NewLabelRepository(db)
+	statusRepo := repository.NewStatusRepository(db)
 	webhookRepo := repository.NewWebhookRepository(db)
-
-	return &MeowService{
+	mediaRepo := repository.NewMediaRepository(db)
+	mediaPolicyRepo := repository.NewMediaPolicyRepository(db)
+	historySyncRepo := repository.NewHistorySyncRepository(db)
+	callPolicyRepo := repository.NewCallPolicyRepository(db)
+	callRepo := repository.NewCallRepository(db)
+	globalWebhookService.SetEndpointStore(webhookRepo)
+
+	service := &MeowService{
 		clients:             make(map[string]*WameowClient),
 		sessions:            sessionRepo,
 		logger:              logging.GetLogger().Sub("wameow"),
 		container:           container,
 		waLogger:            waLogger,
 		messageSender:       NewMessageSender(),
+		messageBuilder:      NewMessageBuilder(),
 		mimeHelper:          NewMimeTypeHelper(),
 		chatwootIntegration: chatwootIntegration,
 		chatwootRepo:        chatwootRepo,
 		messageRepo:         messageRepo,
+		receiptRepo:         receiptRepo,
+		reactionRepo:        reactionRepo,
 		chatRepo:            chatRepo,
+		labelRepo:           labelRepo,
+		statusRepo:          statusRepo,
 		webhookRepo:         webhookRepo,
+		webhookQueue:        webhookQueue,
+		mediaRepo:           mediaRepo,
+		mediaStorage:        mediaStorage,
+		mediaLimits:         mediaLimits,
+		mediaArchiver:       newMediaArchiver(mediaStorage, mediaRepo, mediaPolicyRepo, messageRepo, mediaLimits),
+		historyImporter:     newHistoryImporter(historySyncRepo, webhookQueue, historySync),
+		privacyCache:        newPrivacyCache(),
+		eventStream:         eventStream,
 	}
+	service.callTracker = newCallTracker(callPolicyRepo, callRepo, service.sendCallReply)
+
+	return service
 }
 
 // Métodos de coordenação e helpers internos (não duplicados)
@@ -119,16 +187,36 @@ func (m *MeowService) createNewClient(sessionID string) *WameowClient {
 			m.chatwootIntegration,
 			m.chatwootRepo,
 			m.messageRepo,
+			m.receiptRepo,
+			m.reactionRepo,
 			m.chatRepo,
+			m.labelRepo,
+			m.statusRepo,
 			m.webhookRepo,
+			m.webhookQueue,
+			m.mediaArchiver,
+			m.historyImporter,
+			m.callTracker,
+			m.privacyCache,
+			m.eventStream,
 		)
 	} else {
 		eventProcessor = NewEventProcessor(
 			sessionID,
 			m.sessions,
 			m.messageRepo,
+			m.receiptRepo,
+			m.reactionRepo,
 			m.chatRepo,
+			m.labelRepo,
+			m.statusRepo,
 			m.webhookRepo,
+			m.webhookQueue,
+			m.mediaArchiver,
+			m.historyImporter,
+			m.callTracker,
+			m.privacyCache,
+			m.eventStream,
 		)
 	}
 
@@ -310,22 +398,6 @@ func (m *MeowService) GetBlocklist(ctx context.Context, sessionID string) ([]str
 	return []string{}, nil
 }
 
-// GetMediaMetadata - método faltante da interface
-func (m *MeowService) GetMediaMetadata(ctx context.Context, sessionID, messageID string) (map[string]interface{}, error) {
-	// Delegate to GetMediaInfo
-	return m.GetMediaInfo(ctx, sessionID, messageID)
-}
-
-// GetMediaProgress - método faltante da interface
-func (m *MeowService) GetMediaProgress(ctx context.Context, sessionID, messageID string) (map[string]interface{}, error) {
-	// For now, return empty progress
-	m.logger.Debugf("GetMediaProgress for message %s in session %s (returning empty for now)", messageID, sessionID)
-	return map[string]interface{}{
-		"progress": 0,
-		"status":   "not_implemented",
-	}, nil
-}
-
 // GetNewsletterInfoWithInvite - método faltante da interface
 func (m *MeowService) GetNewsletterInfoWithInvite(ctx context.Context, sessionID, inviteCode string) (*ports.NewsletterInfo, error) {
 	// Delegate to GetNewsletterInfo
@@ -362,13 +434,6 @@ func (m *MeowService) LinkGroup(ctx context.Context, sessionID, communityJID, gr
 	return nil
 }
 
-// ListMedia - método faltante da interface
-func (m *MeowService) ListMedia(ctx context.Context, sessionID string, limit int, offset int) ([]map[string]interface{}, error) {
-	// For now, return empty list
-	m.logger.Debugf("ListMedia for session %s (returning empty for now)", sessionID)
-	return []map[string]interface{}{}, nil
-}
-
 // GetGroupInfoFromInvite - método faltante da interface
 func (m *MeowService) GetGroupInfoFromInvite(ctx context.Context, sessionID, inviteCode, name, description string, timestamp int64) (*ports.GroupInfo, error) {
 	// For now, return mock data
@@ -480,13 +545,16 @@ func (m *MeowService) RemoveProfilePicture(ctx context.Context, sessionID string
 }
 
 // SendAudioMessageWithPTT - método faltante da interface
-func (m *MeowService) SendAudioMessageWithPTT(ctx context.Context, sessionID, to string, data []byte, mimeType string, ptt bool) (*whatsmeow.SendResponse, error) {
-	// For now, send as text message
-	return m.SendTextMessage(ctx, sessionID, to, "Audio message with PTT")
+func (m *MeowService) SendAudioMessageWithPTT(ctx context.Context, sessionID, to string, data []byte, mimeType string, ptt bool, opts ...ports.SendOptions) (*whatsmeow.SendResponse, error) {
+	uploaded, err := m.uploadMedia(sessionID, data, whatsmeow.MediaAudio)
+	if err != nil {
+		return nil, err
+	}
+	return m.sendMessage(ctx, sessionID, to, m.messageBuilder.BuildAudioMessage(uploaded, mimeType, ptt), opts...)
 }
 
 // SendContactsMessage - método faltante da interface
-func (m *MeowService) SendContactsMessage(ctx context.Context, sessionID, to string, contacts []ports.ContactData) (*whatsmeow.SendResponse, error) {
+func (m *MeowService) SendContactsMessage(ctx context.Context, sessionID, to string, contacts []ports.ContactData, opts ...ports.SendOptions) (*whatsmeow.SendResponse, error) {
 	// Convert ContactData to ContactInfo
 	contactInfos := make([]ports.ContactInfo, len(contacts))
 	for i, contact := range contacts {
@@ -495,23 +563,25 @@ func (m *MeowService) SendContactsMessage(ctx context.Context, sessionID, to str
 			Name:  contact.Name,
 		}
 	}
-	return m.SendContactMessage(ctx, sessionID, to, contactInfos)
+	return m.SendContactMessage(ctx, sessionID, to, contactInfos, opts...)
 }
 
 // SendMediaMessage - método faltante da interface
-func (m *MeowService) SendMediaMessage(ctx context.Context, sessionID, to string, media ports.MediaMessage) (*whatsmeow.SendResponse, error) {
+func (m *MeowService) SendMediaMessage(ctx context.Context, sessionID, to string, media ports.MediaMessage, opts ...ports.SendOptions) (*whatsmeow.SendResponse, error) {
 	// Route to appropriate media message method based on type
 	switch media.Type {
 	case "image":
-		return m.SendImageMessage(ctx, sessionID, to, media.Data, media.Caption, media.MimeType)
+		return m.SendImageMessage(ctx, sessionID, to, media.Data, media.Caption, media.MimeType, opts...)
 	case "video":
-		return m.SendVideoMessage(ctx, sessionID, to, media.Data, media.Caption, media.MimeType)
+		return m.SendVideoMessage(ctx, sessionID, to, media.Data, media.Caption, media.MimeType, opts...)
 	case "audio":
-		return m.SendAudioMessage(ctx, sessionID, to, media.Data, media.MimeType)
+		return m.SendAudioMessage(ctx, sessionID, to, media.Data, media.MimeType, opts...)
 	case "document":
-		return m.SendDocumentMessage(ctx, sessionID, to, media.Data, media.Filename, media.MimeType, media.Caption)
+		return m.SendDocumentMessage(ctx, sessionID, to, media.Data, media.Filename, media.Caption, media.MimeType, opts...)
+	case "sticker":
+		return m.SendStickerMessage(ctx, sessionID, to, media.Data, media.MimeType, opts...)
 	default:
-		return m.SendTextMessage(ctx, sessionID, to, "Media message: "+media.Caption)
+		return m.SendTextMessage(ctx, sessionID, to, "Media message: "+media.Caption, opts...)
 	}
 }
 
@@ -556,13 +626,6 @@ func (m *MeowService) SetGroupPhoto(ctx context.Context, sessionID, groupJID str
 	return m.SetGroupPicture(ctx, sessionID, groupJID, imageData)
 }
 
-// SetPrivacySetting - método faltante da interface
-func (m *MeowService) SetPrivacySetting(ctx context.Context, sessionID, setting, value string) error {
-	// For now, just log
-	m.logger.Debugf("SetPrivacySetting: %s = %s for session %s", setting, value, sessionID)
-	return nil
-}
-
 // SetProfilePicture - método faltante da interface
 func (m *MeowService) SetProfilePicture(ctx context.Context, sessionID string, imageData []byte) error {
 	// For now, just log
@@ -570,9 +633,17 @@ func (m *MeowService) SetProfilePicture(ctx context.Context, sessionID string, i
 	return nil
 }
 
-// SetStatus - método faltante da interface
+// SetStatus altera o recado (about) do perfil; status (stories) são publicados por PostTextStatus e afins
 func (m *MeowService) SetStatus(ctx context.Context, sessionID, status string) error {
-	// For now, just log
+	client, err := m.getConnectedClient(sessionID)
+	if err != nil {
+		return err
+	}
+
+	if err := client.GetClient().SetStatusMessage(status); err != nil {
+		return fmt.Errorf("failed to set status message: %w", err)
+	}
+
 	m.logger.Debugf("SetStatus: %s for session %s", status, sessionID)
 	return nil
 }
@@ -613,8 +684,12 @@ func (m *MeowService) UpdateParticipants(ctx context.Context, sessionID, groupJI
 
 // UpdateSessionSubscriptions - método faltante da interface
 func (m *MeowService) UpdateSessionSubscriptions(sessionID string, subscriptions []string) error {
-	// For now, just log
 	m.logger.Debugf("UpdateSessionSubscriptions: %d subscriptions for session %s", len(subscriptions), sessionID)
+
+	// Atualiza o filtro do cliente em execução; novos clientes carregam do repositório
+	if client := m.getClient(sessionID); client != nil && client.eventHandler != nil {
+		client.eventHandler.UpdateSubscribedEvents(subscriptions)
+	}
 	return nil
 }
 
@@ -63,14 +63,19 @@ func (m *MeowService) DeleteMessage(ctx context.Context, sessionID, chatJID, mes
 		// Revoke for everyone using the new BuildRevoke method
 		revokeMsg := client.GetClient().BuildRevoke(jid, waTypes.EmptyJID, messageID)
 		_, err = client.GetClient().SendMessage(ctx, jid, revokeMsg)
-	} else {
-		// Delete for me only - this is handled locally
-		err = m.messageRepo.DeleteMessage(ctx, messageID)
 	}
 	if err != nil {
 		return fmt.Errorf("failed to delete message: %w", err)
 	}
 
+	// Apagar para mim é só local; nos dois casos o histórico armazenado passa a mostrar a mensagem como apagada
+	if err := m.markMessageDeleted(ctx, sessionID, messageID); err != nil {
+		if !forEveryone {
+			return fmt.Errorf("failed to delete message: %w", err)
+		}
+		m.logger.Warnf("Failed to mark message %s as deleted in database: %v", messageID, err)
+	}
+
 	deleteType := "for me"
 	if forEveryone {
 		deleteType = "for everyone"
@@ -80,6 +85,20 @@ func (m *MeowService) DeleteMessage(ctx context.Context, sessionID, chatJID, mes
 	return nil
 }
 
+// markMessageDeleted marca como apagada a mensagem armazenada com o ID do WhatsApp informado
+func (m *MeowService) markMessageDeleted(ctx context.Context, sessionID, messageID string) error {
+	if m.messageRepo == nil {
+		return nil
+	}
+
+	message, err := m.messageRepo.GetMessageByWhatsAppID(ctx, sessionID, messageID)
+	if err != nil || message == nil || message.IsDeleted {
+		return err
+	}
+
+	return m.messageRepo.DeleteMessage(ctx, message.ID)
+}
+
 func (m *MeowService) EditMessage(ctx context.Context, sessionID, chatJID, messageID, newText string) (*whatsmeow.SendResponse, error) {
 	client := m.getClient(sessionID)
 	if client == nil {
@@ -133,10 +152,10 @@ func (m *MeowService) ReactToMessage(ctx context.Context, sessionID, chatJID, me
 		return fmt.Errorf("failed to send reaction: %w", err)
 	}
 
-	// Store reaction in database if message repository is available
-	if m.messageRepo != nil {
-		// Try to update the message with the reaction
-		if err := m.messageRepo.UpdateMessageReaction(ctx, messageID, emoji); err != nil {
+	// Store reaction in database if reaction repository is available
+	if m.reactionRepo != nil && client.GetClient().Store.ID != nil {
+		sender := client.GetClient().Store.ID.ToNonAD().String()
+		if _, err := m.reactionRepo.SetReaction(ctx, sessionID, messageID, sender, emoji, time.Now()); err != nil {
 			m.logger.Warnf("Failed to store reaction in database: %v", err)
 			// Don't fail the operation if database update fails
 		}
@@ -0,0 +1,58 @@
+package wmeow
+
+import (
+	"context"
+	"fmt"
+
+	waTypes "go.mau.fi/whatsmeow/types"
+
+	"zpmeow/internal/application/common"
+	"zpmeow/internal/infra/database/models"
+)
+
+// CallManager methods - chamadas recebidas
+
+func (m *MeowService) RejectCall(ctx context.Context, sessionID, callID, from string) error {
+	client, err := m.getConnectedClient(sessionID)
+	if err != nil {
+		return err
+	}
+	if client.eventHandler == nil {
+		return fmt.Errorf("event processor not initialized for session %s", sessionID)
+	}
+
+	var caller waTypes.JID
+	if from != "" {
+		caller, err = m.parseRecipient(from)
+		if err != nil {
+			return fmt.Errorf("%w: %v", common.ErrInvalidInput, err)
+		}
+	} else {
+		if m.callTracker == nil {
+			return fmt.Errorf("%w: from is required", common.ErrInvalidInput)
+		}
+		call, err := m.callTracker.callRepo.GetByCallID(ctx, sessionID, callID)
+		if err != nil {
+			return err
+		}
+		if call == nil {
+			return fmt.Errorf("%w: %s", common.ErrCallNotFound, callID)
+		}
+		caller, err = waTypes.ParseJID(call.CallerJid)
+		if err != nil {
+			return fmt.Errorf("invalid caller JID %s: %w", call.CallerJid, err)
+		}
+	}
+
+	_, err = client.eventHandler.rejectCall(ctx, caller, callID, models.CallOutcomeRejected)
+	return err
+}
+
+// sendCallReply envia a resposta automática a quem ligou; fica no histórico como as demais mensagens enviadas
+func (m *MeowService) sendCallReply(ctx context.Context, sessionID, to, text string) (string, error) {
+	resp, err := m.SendTextMessage(ctx, sessionID, to, text)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
@@ -5,55 +5,142 @@ import (
 	"fmt"
 	"time"
 
+	"zpmeow/internal/application/common"
 	"zpmeow/internal/application/ports"
 	"zpmeow/internal/infra/database/models"
+	"zpmeow/internal/infra/database/repository"
 
+	"go.mau.fi/whatsmeow/appstate"
 	waTypes "go.mau.fi/whatsmeow/types"
 )
 
 // ChatManager methods - gestão de conversas e chats
 
-func (m *MeowService) ListChats(ctx context.Context, sessionID, chatType string) ([]ports.ChatInfo, error) {
+func (m *MeowService) ListChats(ctx context.Context, sessionID, chatType, labelID string) ([]ports.ChatInfo, error) {
 	if err := m.validateClientConnection(sessionID); err != nil {
 		return nil, err
 	}
 
-	result, err := m.processChatsFromDatabase(ctx, sessionID, chatType)
+	var labeled map[string]bool
+	if labelID != "" {
+		var err error
+		if labeled, err = m.getLabeledChats(ctx, sessionID, labelID); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := m.processChatsFromDatabase(ctx, sessionID, chatType, labeled)
 	if err != nil {
 		m.logger.Debugf("ListChats for session %s, chatType: %s (returning empty - database error)", sessionID, chatType)
 		return []ports.ChatInfo{}, nil
 	}
 
-	m.logger.Debugf("ListChats for session %s, chatType: %s - found %d chats", sessionID, chatType, len(result))
+	m.logger.Debugf("ListChats for session %s, chatType: %s, label: %s - found %d chats", sessionID, chatType, labelID, len(result))
 	return result, nil
 }
 
-func (m *MeowService) GetChatHistory(ctx context.Context, sessionID, chatJID string, limit int, offset int) ([]ports.ChatMessage, error) {
-	if err := m.validateClientConnection(sessionID); err != nil {
+// getLabeledChats retorna o conjunto dos chats que têm a etiqueta
+func (m *MeowService) getLabeledChats(ctx context.Context, sessionID, labelID string) (map[string]bool, error) {
+	if _, err := m.getLabel(ctx, sessionID, labelID); err != nil {
 		return nil, err
 	}
 
-	limit = m.normalizeLimit(limit)
-	chatId := m.getChatIdFromJID(ctx, sessionID, chatJID)
-	result, err := m.getMessagesFromDatabase(ctx, chatId, limit, offset)
+	chatJIDs, err := m.labelRepo.ListChatJIDs(ctx, sessionID, labelID)
 	if err != nil {
 		return nil, err
 	}
 
-	m.logger.Debugf("GetChatHistory: %s for session %s (limit: %d) - retrieved %d messages", chatJID, sessionID, limit, len(result))
-	return result, nil
+	labeled := make(map[string]bool, len(chatJIDs))
+	for _, chatJID := range chatJIDs {
+		labeled[chatJID] = true
+	}
+	return labeled, nil
 }
 
-func (m *MeowService) ArchiveChat(ctx context.Context, sessionID, chatJID string, archive bool) error {
+func (m *MeowService) GetChatHistory(ctx context.Context, sessionID string, query ports.ChatHistoryQuery) (*ports.ChatHistoryPage, error) {
 	if err := m.validateClientConnection(sessionID); err != nil {
-		return err
+		return nil, err
 	}
 
-	if m.chatRepo == nil {
-		return m.logArchiveAction(chatJID, archive, sessionID)
+	if query.Before != "" && query.After != "" {
+		return nil, fmt.Errorf("%w: before and after cannot be used together", common.ErrInvalidInput)
+	}
+
+	limit := m.normalizeLimit(query.Limit)
+	chatId := m.getChatIdFromJID(ctx, sessionID, query.ChatJID)
+	if m.messageRepo == nil || chatId == "" {
+		return &ports.ChatHistoryPage{Messages: []ports.ChatMessage{}}, nil
 	}
 
-	return m.updateChatArchiveStatus(ctx, sessionID, chatJID, archive)
+	filter := repository.MessageHistoryFilter{
+		ChatID: chatId,
+		Types:  query.Types,
+		Sender: query.Sender,
+		Limit:  limit,
+	}
+
+	var err error
+	if query.Before != "" {
+		if filter.Before, err = m.resolveHistoryCursor(ctx, sessionID, chatId, query.Before); err != nil {
+			return nil, err
+		}
+	}
+	if query.After != "" {
+		if filter.After, err = m.resolveHistoryCursor(ctx, sessionID, chatId, query.After); err != nil {
+			return nil, err
+		}
+	}
+
+	messages, hasMore, err := m.messageRepo.ListChatMessages(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	reactions := m.loadMessageReactions(ctx, messages)
+
+	page := &ports.ChatHistoryPage{
+		Messages: m.formatChatMessages(messages, reactions, query.ChatJID),
+		HasMore:  hasMore,
+	}
+
+	// O cursor "after" da mensagem mais nova está sempre presente para buscar mensagens que chegarem depois;
+	// o "before" só quando ainda há mensagens mais antigas
+	if len(messages) > 0 {
+		newest, oldest := messages[0], messages[len(messages)-1]
+		page.AfterCursor = repository.EncodeMessageCursor(newest.Timestamp, newest.ID)
+		if hasMore || filter.After != nil {
+			page.BeforeCursor = repository.EncodeMessageCursor(oldest.Timestamp, oldest.ID)
+		}
+	} else if filter.After != nil {
+		page.AfterCursor = repository.EncodeMessageCursor(filter.After.Timestamp, filter.After.ID)
+	}
+
+	m.logger.Debugf("GetChatHistory: %s for session %s (limit: %d) - retrieved %d messages", query.ChatJID, sessionID, limit, len(messages))
+	return page, nil
+}
+
+// resolveHistoryCursor aceita o cursor retornado pelo histórico ou o ID do WhatsApp de uma mensagem do chat
+func (m *MeowService) resolveHistoryCursor(ctx context.Context, sessionID, chatId, value string) (*repository.MessageCursor, error) {
+	if cursor, err := repository.DecodeMessageCursor(value); err == nil {
+		return cursor, nil
+	}
+
+	message, err := m.messageRepo.GetMessageByWhatsAppID(ctx, sessionID, value)
+	if err != nil {
+		return nil, err
+	}
+	if message == nil || message.ChatId != chatId {
+		return nil, fmt.Errorf("%w: %s is neither a cursor nor a message of this chat", common.ErrMessageNotFound, value)
+	}
+
+	return &repository.MessageCursor{Timestamp: message.Timestamp, ID: message.ID}, nil
+}
+
+// ArchiveChat arquiva ou desarquiva o chat no WhatsApp; arquivar também desafixa o chat
+func (m *MeowService) ArchiveChat(ctx context.Context, sessionID, chatJID string, archive bool) error {
+	return m.sendChatState(ctx, sessionID, chatJID, repository.ChatStateUpdate{Archived: &archive}, func(jid waTypes.JID, lastMessageAt time.Time) appstate.PatchInfo {
+		return appstate.BuildArchive(jid, archive, lastMessageAt, nil)
+	})
 }
 
 func (m *MeowService) DeleteChat(ctx context.Context, sessionID, chatJID string) error {
@@ -90,42 +177,70 @@ func (m *MeowService) DeleteChat(ctx context.Context, sessionID, chatJID string)
 	return nil
 }
 
+// MuteChat silencia o chat no WhatsApp pela duração informada; duração zero silencia para sempre
 func (m *MeowService) MuteChat(ctx context.Context, sessionID, chatJID string, mute bool, duration time.Duration) error {
-	if err := m.validateClientConnection(sessionID); err != nil {
-		return err
-	}
-
-	if m.chatRepo == nil {
-		return m.logMuteAction(chatJID, mute, duration, sessionID)
+	update := repository.ChatStateUpdate{Muted: &mute}
+	if mute && duration > 0 {
+		mutedUntil := time.Now().Add(duration)
+		update.MutedUntil = &mutedUntil
 	}
 
-	return m.updateChatMuteStatus(ctx, sessionID, chatJID, mute, duration)
+	return m.sendChatState(ctx, sessionID, chatJID, update, func(jid waTypes.JID, _ time.Time) appstate.PatchInfo {
+		return appstate.BuildMute(jid, mute, duration)
+	})
 }
 
 func (m *MeowService) UnmuteChat(ctx context.Context, sessionID, chatJID string) error {
-	client := m.getClient(sessionID)
-	if client == nil {
-		return fmt.Errorf("client not found for session %s", sessionID)
-	}
-
-	if !client.IsConnected() {
-		return fmt.Errorf("client not connected for session %s", sessionID)
-	}
-
-	// Unmute functionality - remove mute status locally
 	return m.MuteChat(ctx, sessionID, chatJID, false, 0)
 }
 
 func (m *MeowService) PinChat(ctx context.Context, sessionID, chatJID string, pin bool) error {
-	if err := m.validateClientConnection(sessionID); err != nil {
+	return m.sendChatState(ctx, sessionID, chatJID, repository.ChatStateUpdate{Pinned: &pin, PinnedAt: time.Now()}, func(jid waTypes.JID, _ time.Time) appstate.PatchInfo {
+		return appstate.BuildPin(jid, pin)
+	})
+}
+
+// MarkChatRead marca o chat inteiro como lido ou não lido no WhatsApp
+func (m *MeowService) MarkChatRead(ctx context.Context, sessionID, chatJID string, read bool) error {
+	return m.sendChatState(ctx, sessionID, chatJID, repository.ChatStateUpdate{Read: &read}, func(jid waTypes.JID, lastMessageAt time.Time) appstate.PatchInfo {
+		return buildMarkChatAsRead(jid, read, lastMessageAt)
+	})
+}
+
+// sendChatState envia o patch de app state ao WhatsApp, que o repassa aos demais aparelhos, e atualiza zpChats
+func (m *MeowService) sendChatState(ctx context.Context, sessionID, chatJID string, update repository.ChatStateUpdate, buildPatch func(jid waTypes.JID, lastMessageAt time.Time) appstate.PatchInfo) error {
+	client, err := m.getConnectedClient(sessionID)
+	if err != nil {
 		return err
 	}
 
-	if m.chatRepo == nil {
-		return m.logPinAction(chatJID, pin, sessionID)
+	jid, err := m.parseRecipient(chatJID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", common.ErrInvalidInput, err)
+	}
+
+	var lastMessageAt time.Time
+	if m.chatRepo != nil {
+		if chat, err := m.chatRepo.GetChatBySessionAndJID(ctx, sessionID, jid.String()); err != nil {
+			m.logger.Warnf("Failed to get chat %s from database: %v", jid, err)
+		} else if chat != nil && chat.LastMsgAt != nil {
+			lastMessageAt = *chat.LastMsgAt
+		}
+	}
+
+	if err := client.GetClient().SendAppState(ctx, buildPatch(jid, lastMessageAt)); err != nil {
+		return fmt.Errorf("failed to send app state patch: %w", err)
 	}
 
-	return m.updateChatPinStatus(ctx, sessionID, chatJID, pin)
+	// O patch já foi aceito pelo WhatsApp; o evento de app state correspondente também atualiza zpChats
+	if m.chatRepo != nil {
+		if _, err := m.chatRepo.UpdateState(ctx, sessionID, jid.String(), jid.Server == waTypes.GroupServer, update); err != nil {
+			m.logger.Errorf("Failed to update state of chat %s in session %s: %v", jid, sessionID, err)
+		}
+	}
+
+	m.logger.Debugf("Sent app state patch for chat %s in session %s", jid, sessionID)
+	return nil
 }
 
 func (m *MeowService) SetDisappearingTimer(ctx context.Context, sessionID, chatJID string, timer time.Duration) error {
@@ -155,7 +270,7 @@ func (m *MeowService) SetDisappearingTimer(ctx context.Context, sessionID, chatJ
 // Additional methods required by ChatManager interface
 
 func (m *MeowService) GetChats(ctx context.Context, sessionID string, limit, offset int) ([]ports.ChatInfo, error) {
-	return m.ListChats(ctx, sessionID, "")
+	return m.ListChats(ctx, sessionID, "", "")
 }
 
 func (m *MeowService) GetChatInfo(ctx context.Context, sessionID, chatJID string) (*ports.ChatInfo, error) {
@@ -192,6 +307,26 @@ func (m *MeowService) GetChatInfo(ctx context.Context, sessionID, chatJID string
 		}
 	}
 
+	// Estado do chat sincronizado com o app state (fixado, silenciado, arquivado, não lido)
+	if m.chatRepo != nil {
+		chat, err := m.chatRepo.GetChatBySessionAndJID(ctx, sessionID, jid.String())
+		if err != nil {
+			m.logger.Warnf("Failed to get chat %s from database: %v", jid, err)
+		} else if chat != nil {
+			stored := m.formatChatInfo(chat)
+			chatInfo.IsPinned = stored.IsPinned
+			chatInfo.IsMuted = stored.IsMuted
+			chatInfo.MutedUntil = stored.MutedUntil
+			chatInfo.IsArchived = stored.IsArchived
+			chatInfo.MarkedUnread = stored.MarkedUnread
+			chatInfo.UnreadCount = stored.UnreadCount
+			chatInfo.LastMessageAt = stored.LastMessageAt
+			if chatInfo.Name == "" {
+				chatInfo.Name = stored.Name
+			}
+		}
+	}
+
 	return chatInfo, nil
 }
 
@@ -208,246 +343,117 @@ func (m *MeowService) validateClientConnection(sessionID string) error {
 	return nil
 }
 
-func (m *MeowService) logMuteAction(chatJID string, mute bool, duration time.Duration, sessionID string) error {
-	action := "muted"
-	if !mute {
-		action = "unmuted"
-	}
-	m.logger.Debugf("Chat %s %s for %v in session %s", chatJID, action, duration, sessionID)
-	return nil
-}
-
-func (m *MeowService) updateChatMuteStatus(ctx context.Context, sessionID, chatJID string, mute bool, duration time.Duration) error {
-	chat, err := m.chatRepo.GetChatBySessionAndJID(ctx, sessionID, chatJID)
-	if err != nil {
-		m.logger.Warnf("Failed to get chat from database: %v", err)
-		return m.logMuteAction(chatJID, mute, duration, sessionID)
-	}
-
-	if chat != nil {
-		return m.updateExistingChatMute(ctx, chat, mute, duration, chatJID, sessionID)
-	}
-
-	return m.createChatWithMuteStatus(ctx, sessionID, chatJID, mute, duration)
-}
-
-func (m *MeowService) updateExistingChatMute(ctx context.Context, chat *models.ChatModel, mute bool, duration time.Duration, chatJID, sessionID string) error {
-	if chat.Metadata == nil {
-		chat.Metadata = make(models.JSONB)
-	}
-
-	chat.Metadata["muted"] = mute
-	if mute && duration > 0 {
-		muteUntil := time.Now().Add(duration)
-		chat.Metadata["muteUntil"] = muteUntil.Unix()
-	} else {
-		delete(chat.Metadata, "muteUntil")
-	}
-
-	if err := m.chatRepo.UpdateChat(ctx, chat); err != nil {
-		m.logger.Errorf("Failed to update chat mute status: %v", err)
-		return fmt.Errorf("failed to update chat mute status: %w", err)
-	}
-
-	return m.logMuteAction(chatJID, mute, duration, sessionID)
-}
-
-func (m *MeowService) createChatWithMuteStatus(ctx context.Context, sessionID, chatJID string, mute bool, duration time.Duration) error {
-	jid, err := waTypes.ParseJID(chatJID)
-	if err != nil {
-		return fmt.Errorf("invalid chat JID %s: %w", chatJID, err)
-	}
-
-	metadata := make(models.JSONB)
-	metadata["muted"] = mute
-	if mute && duration > 0 {
-		muteUntil := time.Now().Add(duration)
-		metadata["muteUntil"] = muteUntil.Unix()
-	}
-
-	newChat := &models.ChatModel{
-		SessionId: sessionID,
-		ChatJid:   chatJID,
-		IsGroup:   jid.Server == waTypes.GroupServer,
-		Metadata:  metadata,
-	}
-
-	if err := m.chatRepo.CreateChat(ctx, newChat); err != nil {
-		m.logger.Errorf("Failed to create chat with mute status: %v", err)
-		return fmt.Errorf("failed to create chat with mute status: %w", err)
-	}
-
-	return m.logMuteAction(chatJID, mute, duration, sessionID)
-}
-
-func (m *MeowService) logPinAction(chatJID string, pin bool, sessionID string) error {
-	action := "pinned"
-	if !pin {
-		action = "unpinned"
+func (m *MeowService) getChatIdFromJID(ctx context.Context, sessionID, chatJID string) string {
+	if m.chatRepo == nil {
+		return ""
 	}
-	m.logger.Debugf("Chat %s %s for session %s", chatJID, action, sessionID)
-	return nil
-}
 
-func (m *MeowService) updateChatPinStatus(ctx context.Context, sessionID, chatJID string, pin bool) error {
 	chat, err := m.chatRepo.GetChatBySessionAndJID(ctx, sessionID, chatJID)
 	if err != nil {
 		m.logger.Warnf("Failed to get chat from database: %v", err)
-		return m.logPinAction(chatJID, pin, sessionID)
+		return ""
 	}
 
 	if chat != nil {
-		return m.updateExistingChatPin(ctx, chat, pin, chatJID, sessionID)
-	}
-
-	return m.createChatWithPinStatus(ctx, sessionID, chatJID, pin)
-}
-
-func (m *MeowService) updateExistingChatPin(ctx context.Context, chat *models.ChatModel, pin bool, chatJID, sessionID string) error {
-	if chat.Metadata == nil {
-		chat.Metadata = make(models.JSONB)
-	}
-
-	chat.Metadata["pinned"] = pin
-	if pin {
-		chat.Metadata["pinnedAt"] = time.Now().Unix()
-	} else {
-		delete(chat.Metadata, "pinnedAt")
-	}
-
-	if err := m.chatRepo.UpdateChat(ctx, chat); err != nil {
-		m.logger.Errorf("Failed to update chat pin status: %v", err)
-		return fmt.Errorf("failed to update chat pin status: %w", err)
+		return chat.ID
 	}
 
-	return m.logPinAction(chatJID, pin, sessionID)
+	return ""
 }
 
-func (m *MeowService) createChatWithPinStatus(ctx context.Context, sessionID, chatJID string, pin bool) error {
-	jid, err := waTypes.ParseJID(chatJID)
-	if err != nil {
-		return fmt.Errorf("invalid chat JID %s: %w", chatJID, err)
+// loadMessageReactions busca as reações por remetente da página; falhas apenas omitem as reações
+func (m *MeowService) loadMessageReactions(ctx context.Context, messages []*models.MessageModel) map[string][]*models.MessageReactionModel {
+	if m.reactionRepo == nil || len(messages) == 0 {
+		return nil
 	}
 
-	metadata := make(models.JSONB)
-	metadata["pinned"] = pin
-	if pin {
-		metadata["pinnedAt"] = time.Now().Unix()
-	}
-
-	newChat := &models.ChatModel{
-		SessionId: sessionID,
-		ChatJid:   chatJID,
-		IsGroup:   jid.Server == waTypes.GroupServer,
-		Metadata:  metadata,
-	}
-
-	if err := m.chatRepo.CreateChat(ctx, newChat); err != nil {
-		m.logger.Errorf("Failed to create chat with pin status: %v", err)
-		return fmt.Errorf("failed to create chat with pin status: %w", err)
-	}
-
-	return m.logPinAction(chatJID, pin, sessionID)
-}
-
-func (m *MeowService) logArchiveAction(chatJID string, archive bool, sessionID string) error {
-	action := "archived"
-	if !archive {
-		action = "unarchived"
+	ids := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		ids = append(ids, msg.ID)
 	}
-	m.logger.Debugf("Chat %s %s for session %s", chatJID, action, sessionID)
-	return nil
-}
 
-func (m *MeowService) updateChatArchiveStatus(ctx context.Context, sessionID, chatJID string, archive bool) error {
-	chat, err := m.chatRepo.GetChatBySessionAndJID(ctx, sessionID, chatJID)
+	reactions, err := m.reactionRepo.ListByMessageIDs(ctx, ids)
 	if err != nil {
-		m.logger.Warnf("Failed to get chat from database: %v", err)
-		return m.logArchiveAction(chatJID, archive, sessionID)
+		m.logger.Warnf("Failed to load message reactions: %v", err)
+		return nil
 	}
-
-	if chat != nil {
-		return m.updateExistingChatArchive(ctx, chat, archive, chatJID, sessionID)
-	}
-
-	return m.createChatWithArchiveStatus(ctx, sessionID, chatJID, archive)
-}
-
-func (m *MeowService) updateExistingChatArchive(ctx context.Context, chat *models.ChatModel, archive bool, chatJID, sessionID string) error {
-	chat.IsArchived = archive
-	if err := m.chatRepo.UpdateChat(ctx, chat); err != nil {
-		m.logger.Errorf("Failed to update chat archive status: %v", err)
-		return fmt.Errorf("failed to update chat archive status: %w", err)
-	}
-	return m.logArchiveAction(chatJID, archive, sessionID)
+	return reactions
 }
 
-func (m *MeowService) createChatWithArchiveStatus(ctx context.Context, sessionID, chatJID string, archive bool) error {
-	jid, err := waTypes.ParseJID(chatJID)
-	if err != nil {
-		return fmt.Errorf("invalid chat JID %s: %w", chatJID, err)
-	}
-
-	newChat := &models.ChatModel{
-		SessionId:  sessionID,
-		ChatJid:    chatJID,
-		IsGroup:    jid.Server == waTypes.GroupServer,
-		IsArchived: archive,
-	}
-
-	if err := m.chatRepo.CreateChat(ctx, newChat); err != nil {
-		m.logger.Errorf("Failed to create chat with archive status: %v", err)
-		return fmt.Errorf("failed to create chat with archive status: %w", err)
-	}
+func (m *MeowService) formatChatMessages(messages []*models.MessageModel, reactions map[string][]*models.MessageReactionModel, chatJID string) []ports.ChatMessage {
+	result := make([]ports.ChatMessage, 0, len(messages))
+	for _, msg := range messages {
+		chatMessage := ports.ChatMessage{
+			ID:          msg.MsgId,
+			ChatJID:     chatJID,
+			FromJID:     msg.SenderJid,
+			SenderName:  getStringValue(msg.SenderName),
+			Text:        getStringValue(msg.Content),
+			Content:     getStringValue(msg.Content),
+			Type:        msg.MsgType,
+			Timestamp:   msg.Timestamp,
+			IsFromMe:    msg.IsFromMe,
+			IsForwarded: msg.IsForwarded,
+			Status:      msg.Status,
+			Media:       chatMessageMedia(msg.MediaInfo),
+			Mentions:    metadataStrings(msg.Metadata, "mentions"),
+			EditedAt:    msg.EditTimestamp,
+			IsDeleted:   msg.IsDeleted,
+			DeletedAt:   msg.DeletedAt,
+			Cursor:      repository.EncodeMessageCursor(msg.Timestamp, msg.ID),
+		}
 
-	return m.logArchiveAction(chatJID, archive, sessionID)
-}
+		if msg.QuotedMsgId != nil && *msg.QuotedMsgId != "" {
+			chatMessage.Quoted = &ports.ChatMessageQuote{
+				MessageID: *msg.QuotedMsgId,
+				Content:   getStringValue(msg.QuotedContent),
+			}
+		}
 
-func (m *MeowService) getChatIdFromJID(ctx context.Context, sessionID, chatJID string) string {
-	if m.chatRepo == nil {
-		return ""
-	}
+		for _, reaction := range reactions[msg.ID] {
+			chatMessage.Reactions = append(chatMessage.Reactions, ports.ChatMessageReaction{
+				Emoji:     reaction.Reaction,
+				SenderJID: reaction.SenderJid,
+			})
+		}
 
-	chat, err := m.chatRepo.GetChatBySessionAndJID(ctx, sessionID, chatJID)
-	if err != nil {
-		m.logger.Warnf("Failed to get chat from database: %v", err)
-		return ""
-	}
+		// Reação registrada antes de zpMessageReactions existir, sem remetente
+		if reaction := getStringValue(msg.Reaction); reaction != "" && len(chatMessage.Reactions) == 0 {
+			chatMessage.Reactions = []ports.ChatMessageReaction{{Emoji: reaction}}
+		}
 
-	if chat != nil {
-		return chat.ID
+		result = append(result, chatMessage)
 	}
-
-	return ""
+	return result
 }
 
-func (m *MeowService) getMessagesFromDatabase(ctx context.Context, chatId string, limit, offset int) ([]ports.ChatMessage, error) {
-	if m.messageRepo == nil || chatId == "" {
-		return []ports.ChatMessage{}, nil
+// chatMessageMedia expõe apenas os dados descritivos de "mediaInfo"; as chaves de download ficam internas
+func chatMessageMedia(info models.JSONB) *ports.ChatMessageMedia {
+	mimeType, _ := info["mimeType"].(string)
+	if mimeType == "" {
+		return nil
 	}
 
-	messages, err := m.messageRepo.GetMessagesByChatId(ctx, chatId, limit, offset)
-	if err != nil {
-		m.logger.Warnf("Failed to get messages from database: %v", err)
-		return []ports.ChatMessage{}, nil
+	media := &ports.ChatMessageMedia{MimeType: mimeType}
+	media.FileName, _ = info["fileName"].(string)
+	media.MediaID, _ = info["mediaId"].(string)
+	if fileLength, ok := info["fileLength"].(float64); ok {
+		media.FileLength = int64(fileLength)
 	}
-
-	return m.formatChatMessages(messages, chatId), nil
+	return media
 }
 
-func (m *MeowService) formatChatMessages(messages []*models.MessageModel, chatId string) []ports.ChatMessage {
-	var result []ports.ChatMessage
-	for _, msg := range messages {
-		chatMessage := ports.ChatMessage{
-			ID:        msg.MsgId,
-			ChatJID:   chatId,
-			FromJID:   msg.SenderJid,
-			Text:      getStringValue(msg.Content),
-			Content:   getStringValue(msg.Content),
-			Type:      msg.MsgType,
-			Timestamp: msg.Timestamp,
+// metadataStrings lê uma lista de strings gravada em "metadata"
+func metadataStrings(metadata models.JSONB, key string) []string {
+	values, _ := metadata[key].([]interface{})
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if str, ok := value.(string); ok {
+			result = append(result, str)
 		}
-		result = append(result, chatMessage)
+	}
+	if len(result) == 0 {
+		return nil
 	}
 	return result
 }
@@ -479,14 +485,21 @@ func (m *MeowService) formatChatInfo(chat *models.ChatModel) ports.ChatInfo {
 		lastMessageAt = chat.LastMsgAt.Format(time.RFC3339)
 	}
 
-	return ports.ChatInfo{
+	info := ports.ChatInfo{
 		JID:           chat.ChatJid,
 		Name:          getStringValue(chat.ChatName),
 		IsGroup:       chat.IsGroup,
+		IsPinned:      chat.IsPinned,
+		IsMuted:       isChatMuted(chat, time.Now()),
+		IsArchived:    chat.IsArchived,
+		MarkedUnread:  chat.IsMarkedUnread,
 		UnreadCount:   chat.UnreadCount,
 		LastMessageAt: lastMessageAt,
-		IsArchived:    chat.IsArchived,
 	}
+	if info.IsMuted && chat.MutedUntil != nil {
+		info.MutedUntil = chat.MutedUntil.Format(time.RFC3339)
+	}
+	return info
 }
 
 func (m *MeowService) shouldIncludeChat(chat *models.ChatModel, chatType string) bool {
@@ -505,7 +518,8 @@ func (m *MeowService) shouldIncludeChat(chat *models.ChatModel, chatType string)
 	return true
 }
 
-func (m *MeowService) processChatsFromDatabase(ctx context.Context, sessionID, chatType string) ([]ports.ChatInfo, error) {
+// processChatsFromDatabase formata os chats guardados; com labeled não nil, só os chats do conjunto
+func (m *MeowService) processChatsFromDatabase(ctx context.Context, sessionID, chatType string, labeled map[string]bool) ([]ports.ChatInfo, error) {
 	chats, err := m.getChatsFromDatabase(ctx, sessionID)
 	if err != nil {
 		return []ports.ChatInfo{}, nil // Return empty on error, don't fail
@@ -516,6 +530,9 @@ func (m *MeowService) processChatsFromDatabase(ctx context.Context, sessionID, c
 		if !m.shouldIncludeChat(chat, chatType) {
 			continue
 		}
+		if labeled != nil && !labeled[chat.ChatJid] {
+			continue
+		}
 
 		chatInfo := m.formatChatInfo(chat)
 		result = append(result, chatInfo)
@@ -0,0 +1,207 @@
+package wmeow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.mau.fi/whatsmeow/appstate"
+
+	"zpmeow/internal/application/common"
+	"zpmeow/internal/application/ports"
+	"zpmeow/internal/infra/database/models"
+)
+
+// ListLabels retorna as etiquetas sincronizadas da sessão com o número de chats de cada uma
+func (m *MeowService) ListLabels(ctx context.Context, sessionID string) ([]ports.Label, error) {
+	if m.labelRepo == nil {
+		return nil, fmt.Errorf("label repository not available")
+	}
+
+	labels, err := m.labelRepo.List(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := m.labelRepo.CountChats(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]ports.Label, 0, len(labels))
+	for _, label := range labels {
+		result = append(result, toLabel(label, counts[label.LabelId]))
+	}
+	return result, nil
+}
+
+func (m *MeowService) CreateLabel(ctx context.Context, sessionID, name string, color int) (*ports.Label, error) {
+	if m.labelRepo == nil {
+		return nil, fmt.Errorf("label repository not available")
+	}
+
+	name, err := validateLabel(name, color)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := m.getConnectedClient(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	labelID, err := m.labelRepo.NextLabelID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	label := &models.LabelModel{SessionId: sessionID, LabelId: labelID, Name: name, Color: color}
+	if err := m.saveLabel(ctx, client, label); err != nil {
+		return nil, err
+	}
+
+	m.logger.Infof("Created label %s (%s) in session %s", labelID, name, sessionID)
+	result := toLabel(label, 0)
+	return &result, nil
+}
+
+func (m *MeowService) EditLabel(ctx context.Context, sessionID, labelID, name string, color *int) (*ports.Label, error) {
+	label, err := m.getLabel(ctx, sessionID, labelID)
+	if err != nil {
+		return nil, err
+	}
+
+	if color != nil {
+		label.Color = *color
+	}
+	if label.Name, err = validateLabel(name, label.Color); err != nil {
+		return nil, err
+	}
+
+	client, err := m.getConnectedClient(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.saveLabel(ctx, client, label); err != nil {
+		return nil, err
+	}
+
+	counts, err := m.labelRepo.CountChats(ctx, sessionID)
+	if err != nil {
+		m.logger.Warnf("Failed to count chats of label %s: %v", labelID, err)
+	}
+
+	result := toLabel(label, counts[label.LabelId])
+	return &result, nil
+}
+
+// DeleteLabel remove a etiqueta no WhatsApp, o que também a retira de todos os chats e mensagens
+func (m *MeowService) DeleteLabel(ctx context.Context, sessionID, labelID string) error {
+	label, err := m.getLabel(ctx, sessionID, labelID)
+	if err != nil {
+		return err
+	}
+
+	client, err := m.getConnectedClient(sessionID)
+	if err != nil {
+		return err
+	}
+
+	patch := appstate.BuildLabelEdit(label.LabelId, label.Name, int32(label.Color), true)
+	if err := client.GetClient().SendAppState(ctx, patch); err != nil {
+		return fmt.Errorf("failed to delete label: %w", err)
+	}
+
+	if err := m.labelRepo.Delete(ctx, sessionID, labelID); err != nil {
+		return err
+	}
+
+	m.logger.Infof("Deleted label %s in session %s", labelID, sessionID)
+	return nil
+}
+
+func (m *MeowService) LabelChat(ctx context.Context, sessionID, labelID, chatJID string, labeled bool) error {
+	if _, err := m.getLabel(ctx, sessionID, labelID); err != nil {
+		return err
+	}
+
+	client, err := m.getConnectedClient(sessionID)
+	if err != nil {
+		return err
+	}
+
+	jid, err := m.parseRecipient(chatJID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", common.ErrInvalidInput, err)
+	}
+
+	if err := client.GetClient().SendAppState(ctx, appstate.BuildLabelChat(jid, labelID, labeled)); err != nil {
+		return fmt.Errorf("failed to set chat label: %w", err)
+	}
+
+	return m.labelRepo.SetChatLabel(ctx, sessionID, labelID, jid.String(), labeled)
+}
+
+func (m *MeowService) LabelMessage(ctx context.Context, sessionID, labelID, chatJID, messageID string, labeled bool) error {
+	if strings.TrimSpace(messageID) == "" {
+		return fmt.Errorf("%w: message ID is required", common.ErrInvalidInput)
+	}
+
+	if _, err := m.getLabel(ctx, sessionID, labelID); err != nil {
+		return err
+	}
+
+	client, err := m.getConnectedClient(sessionID)
+	if err != nil {
+		return err
+	}
+
+	jid, err := m.parseRecipient(chatJID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", common.ErrInvalidInput, err)
+	}
+
+	if err := client.GetClient().SendAppState(ctx, appstate.BuildLabelMessage(jid, labelID, messageID, labeled)); err != nil {
+		return fmt.Errorf("failed to set message label: %w", err)
+	}
+
+	return m.labelRepo.SetMessageLabel(ctx, sessionID, labelID, jid.String(), messageID, labeled)
+}
+
+// getLabel busca a etiqueta sincronizada; ErrLabelNotFound se ela não existir
+func (m *MeowService) getLabel(ctx context.Context, sessionID, labelID string) (*models.LabelModel, error) {
+	if m.labelRepo == nil {
+		return nil, fmt.Errorf("label repository not available")
+	}
+
+	label, err := m.labelRepo.GetByLabelID(ctx, sessionID, labelID)
+	if err != nil {
+		return nil, err
+	}
+	if label == nil {
+		return nil, fmt.Errorf("%w: %s", common.ErrLabelNotFound, labelID)
+	}
+	return label, nil
+}
+
+// saveLabel envia a etiqueta ao WhatsApp e grava a cópia em zpLabels
+func (m *MeowService) saveLabel(ctx context.Context, client *WameowClient, label *models.LabelModel) error {
+	patch := appstate.BuildLabelEdit(label.LabelId, label.Name, int32(label.Color), false)
+	if err := client.GetClient().SendAppState(ctx, patch); err != nil {
+		return fmt.Errorf("failed to save label: %w", err)
+	}
+
+	return m.labelRepo.Upsert(ctx, label)
+}
+
+func validateLabel(name string, color int) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("%w: label name is required", common.ErrInvalidInput)
+	}
+	if color < 0 || color >= labelColors {
+		return "", fmt.Errorf("%w: label color must be between 0 and %d", common.ErrInvalidInput, labelColors-1)
+	}
+	return name, nil
+}
@@ -2,41 +2,279 @@ package wmeow
 
 import (
 	"context"
-)
+	"encoding/base64"
+	"fmt"
+	"net/http"
+
+	"go.mau.fi/whatsmeow"
 
-// MediaManager methods - upload/download de mídia
+	"zpmeow/internal/application/common"
+	"zpmeow/internal/infra/database/models"
+)
 
-func (m *MeowService) UploadMedia(ctx context.Context, sessionID string, data []byte, mimeType string) (string, error) {
-	// For now, return mock URL
-	m.logger.Debugf("UploadMedia: %d bytes of %s for session %s", len(data), mimeType, sessionID)
+// MediaManager methods - armazenamento de mídia (conteúdo no storage, metadados em zpMedia)
+
+// UploadMedia grava a mídia no storage e retorna o ID endereçado por conteúdo (SHA-256).
+// O mesmo conteúdo enviado por várias sessões ocupa um único objeto no storage
+func (m *MeowService) UploadMedia(ctx context.Context, sessionID string, data []byte, mediaType, fileName string) (string, error) {
+	if err := m.requireMediaStorage(); err != nil {
+		return "", err
+	}
+	if len(data) == 0 {
+		return "", newValidationError("data", "cannot be empty")
+	}
+	if !isValidMediaType(mediaType) {
+		return "", newValidationError("media_type", "must be one of: image, audio, video, document, sticker")
+	}
+	if m.mediaLimits.MaxFileSize > 0 && int64(len(data)) > m.mediaLimits.MaxFileSize {
+		return "", fmt.Errorf("%w: %d bytes exceeds the limit of %d bytes", common.ErrMediaTooLarge, len(data), m.mediaLimits.MaxFileSize)
+	}
+
+	media, err := storeMediaContent(ctx, m.mediaStorage, m.mediaRepo, sessionID, data, mediaType, "", fileName, nil)
+	if err != nil {
+		return "", err
+	}
+
+	m.logger.Debugf("Stored media %s (%s, %d bytes) for session %s", media.MediaId, media.MimeType, media.Size, sessionID)
+	return media.MediaId, nil
+}
+
+// DownloadMedia retorna a mídia de uma mensagem pelo ID do WhatsApp: do storage quando ela foi arquivada,
+// senão direto do CDN do WhatsApp com as chaves gravadas em "mediaInfo"
+func (m *MeowService) DownloadMedia(ctx context.Context, sessionID, messageID string) ([]byte, string, error) {
+	if m.messageRepo == nil {
+		return nil, "", fmt.Errorf("message repository not available")
+	}
+
+	message, err := m.messageRepo.GetMessageByWhatsAppID(ctx, sessionID, messageID)
+	if err != nil {
+		return nil, "", err
+	}
+	if message == nil {
+		return nil, "", fmt.Errorf("%w: message %s", common.ErrMediaNotFound, messageID)
+	}
+
+	info := message.MediaInfo
+	mimeType, _ := info["mimeType"].(string)
+
+	if mediaID, ok := info["mediaId"].(string); ok && mediaID != "" && m.mediaStorage != nil {
+		data, media, err := m.GetMediaContent(ctx, sessionID, mediaID)
+		if err == nil {
+			if storedMime, ok := media["mime_type"].(string); ok && mimeType == "" {
+				mimeType = storedMime
+			}
+			return data, mimeType, nil
+		}
+		m.logger.Warnf("Archived media %s of message %s unavailable, falling back to WhatsApp: %v", mediaID, messageID, err)
+	}
+
+	directPath, _ := info["directPath"].(string)
+	if directPath == "" {
+		return nil, "", fmt.Errorf("%w: message %s has no downloadable media", common.ErrMediaNotFound, messageID)
+	}
+
+	mediaType, ok := whatsmeowMediaTypes[message.MsgType]
+	if !ok {
+		return nil, "", fmt.Errorf("%w: message %s has no downloadable media", common.ErrMediaNotFound, messageID)
+	}
+
+	client, err := m.getConnectedClient(sessionID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	fileLength := -1
+	if length, ok := info["fileLength"].(float64); ok {
+		fileLength = int(length)
+	}
+
+	data, err := client.GetClient().DownloadMediaWithPath(ctx, directPath,
+		decodeMediaInfoBytes(info, "fileEncSHA256"), decodeMediaInfoBytes(info, "fileSHA256"), decodeMediaInfoBytes(info, "mediaKey"),
+		fileLength, mediaType, "")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to download media of message %s: %w", messageID, err)
+	}
+
+	if mimeType == "" {
+		mimeType = http.DetectContentType(data)
+	}
+	return data, mimeType, nil
+}
+
+func (m *MeowService) GetMediaInfo(ctx context.Context, sessionID, mediaID string) (map[string]interface{}, error) {
+	media, err := m.getStoredMedia(ctx, sessionID, mediaID)
+	if err != nil {
+		return nil, err
+	}
+	return mediaModelToMap(media), nil
+}
+
+// GetMediaContent lê o conteúdo da mídia no storage junto com seus metadados
+func (m *MeowService) GetMediaContent(ctx context.Context, sessionID, mediaID string) ([]byte, map[string]interface{}, error) {
+	media, err := m.getStoredMedia(ctx, sessionID, mediaID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := m.mediaStorage.Get(ctx, media.StorageKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read media %s: %w", mediaID, err)
+	}
+
+	return data, mediaModelToMap(media), nil
+}
+
+func (m *MeowService) GetMediaMetadata(ctx context.Context, sessionID, mediaID string) (map[string]interface{}, error) {
+	media, err := m.getStoredMedia(ctx, sessionID, mediaID)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := mediaModelToMap(media)
+	metadata["storage_driver"] = media.StorageDriver
+	metadata["storage_key"] = media.StorageKey
+	metadata["metadata"] = media.Metadata
+	metadata["updated_at"] = media.UpdatedAt
+	return metadata, nil
+}
+
+// GetMediaProgress reporta o estado do upload; uploads são síncronos, então a mídia existe ou não
+func (m *MeowService) GetMediaProgress(ctx context.Context, sessionID, mediaID string) (map[string]interface{}, error) {
+	if _, err := m.getStoredMedia(ctx, sessionID, mediaID); err != nil {
+		return nil, err
+	}
 
-	return "https://mock-media-url.com/media", nil
+	return map[string]interface{}{
+		"media_id": mediaID,
+		"status":   "completed",
+		"progress": 100,
+	}, nil
 }
 
-func (m *MeowService) DownloadMedia(ctx context.Context, sessionID, mediaURL string) ([]byte, string, error) {
-	// For now, return empty data
-	m.logger.Debugf("DownloadMedia: %s for session %s (returning empty for now)", mediaURL, sessionID)
-	return []byte{}, "application/octet-stream", nil
+func (m *MeowService) ListMedia(ctx context.Context, sessionID string, limit int, offset int) ([]map[string]interface{}, error) {
+	if err := m.requireMediaStorage(); err != nil {
+		return nil, err
+	}
+
+	media, err := m.mediaRepo.ListBySession(ctx, sessionID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]map[string]interface{}, 0, len(media))
+	for _, item := range media {
+		result = append(result, mediaModelToMap(item))
+	}
+	return result, nil
+}
+
+// DeleteMedia remove a mídia da sessão; o objeto só sai do storage quando nenhuma sessão o referencia
+func (m *MeowService) DeleteMedia(ctx context.Context, sessionID, mediaID string) error {
+	if _, err := m.getStoredMedia(ctx, sessionID, mediaID); err != nil {
+		return err
+	}
+
+	_, err := m.mediaRepo.Delete(ctx, sessionID, mediaID, func(storageDriver, storageKey string) error {
+		if storageDriver != m.mediaStorage.Driver() {
+			return nil
+		}
+		if err := m.mediaStorage.Delete(ctx, storageKey); err != nil {
+			return fmt.Errorf("failed to delete media %s from storage: %w", mediaID, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	m.logger.Debugf("Deleted media %s for session %s", mediaID, sessionID)
+	return nil
 }
 
-func (m *MeowService) GetMediaInfo(ctx context.Context, sessionID, mediaURL string) (map[string]interface{}, error) {
-	// For now, return mock data
-	m.logger.Debugf("GetMediaInfo: %s for session %s", mediaURL, sessionID)
+func (m *MeowService) CompressMedia(ctx context.Context, sessionID, mediaID string, quality int) (string, error) {
+	if _, err := m.getStoredMedia(ctx, sessionID, mediaID); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("%w: compression", common.ErrUnsupportedMediaOp)
+}
 
-	return map[string]interface{}{
-		"url":      mediaURL,
-		"mimeType": "application/octet-stream",
-		"size":     0,
-		"filename": "mock-file",
-	}, nil
+func (m *MeowService) ConvertMedia(ctx context.Context, sessionID, mediaID, toMimeType string) (string, error) {
+	if _, err := m.getStoredMedia(ctx, sessionID, mediaID); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("%w: conversion to %s", common.ErrUnsupportedMediaOp, toMimeType)
 }
 
-func (m *MeowService) DeleteMedia(ctx context.Context, sessionID, mediaURL string) error {
-	// For now, just log
-	m.logger.Debugf("DeleteMedia: %s for session %s", mediaURL, sessionID)
+func (m *MeowService) GetMediaStorageUsage(ctx context.Context, sessionID string) (int64, error) {
+	if err := m.requireMediaStorage(); err != nil {
+		return 0, err
+	}
+	return m.mediaRepo.TotalSizeBySession(ctx, sessionID)
+}
+
+func (m *MeowService) getStoredMedia(ctx context.Context, sessionID, mediaID string) (*models.MediaModel, error) {
+	if err := m.requireMediaStorage(); err != nil {
+		return nil, err
+	}
+
+	media, err := m.mediaRepo.GetByMediaID(ctx, sessionID, mediaID)
+	if err != nil {
+		return nil, err
+	}
+	if media == nil {
+		return nil, fmt.Errorf("%w: %s", common.ErrMediaNotFound, mediaID)
+	}
+	return media, nil
+}
+
+func (m *MeowService) requireMediaStorage() error {
+	if m.mediaStorage == nil || m.mediaRepo == nil {
+		return fmt.Errorf("media storage not configured")
+	}
 	return nil
 }
 
+// whatsmeowMediaTypes mapeia o msgType gravado em zpMessages para o tipo usado no download
+var whatsmeowMediaTypes = map[string]whatsmeow.MediaType{
+	"image":    whatsmeow.MediaImage,
+	"sticker":  whatsmeow.MediaImage,
+	"video":    whatsmeow.MediaVideo,
+	"audio":    whatsmeow.MediaAudio,
+	"ptt":      whatsmeow.MediaAudio,
+	"document": whatsmeow.MediaDocument,
+}
+
+func decodeMediaInfoBytes(info models.JSONB, key string) []byte {
+	value, _ := info[key].(string)
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil
+	}
+	return decoded
+}
+
+func isValidMediaType(mediaType string) bool {
+	switch mediaType {
+	case MediaTypeImage, MediaTypeAudio, MediaTypeVideo, MediaTypeDocument, MediaTypeSticker:
+		return true
+	}
+	return false
+}
+
+func mediaModelToMap(media *models.MediaModel) map[string]interface{} {
+	info := map[string]interface{}{
+		"media_id":   media.MediaId,
+		"media_type": media.MediaType,
+		"mime_type":  media.MimeType,
+		"size":       media.Size,
+		"created_at": media.CreatedAt,
+	}
+	if media.FileName != nil {
+		info["filename"] = *media.FileName
+	}
+	return info
+}
+
 func (m *MeowService) GetMediaThumbnail(ctx context.Context, sessionID, mediaURL string) ([]byte, error) {
 	// For now, return empty data
 	m.logger.Debugf("GetMediaThumbnail: %s for session %s (returning empty for now)", mediaURL, sessionID)
@@ -55,20 +293,6 @@ func (m *MeowService) ValidateMedia(ctx context.Context, sessionID string, data
 	return nil
 }
 
-func (m *MeowService) CompressMedia(ctx context.Context, sessionID, mediaURL string, quality int) (string, error) {
-	// For now, return original URL
-	m.logger.Debugf("CompressMedia: %s (quality: %d) for session %s", mediaURL, quality, sessionID)
-	return mediaURL, nil
-}
-
-func (m *MeowService) ConvertMedia(ctx context.Context, sessionID, mediaURL, toMimeType string) (string, error) {
-	// For now, return original URL
-	m.logger.Debugf("ConvertMedia: %s to %s for session %s", mediaURL, toMimeType, sessionID)
-	return mediaURL, nil
-}
-
-// GetMediaMetadata is implemented in service.go
-
 func (m *MeowService) ExtractMediaText(ctx context.Context, sessionID string, data []byte, mimeType string) (string, error) {
 	// For now, return empty text
 	m.logger.Debugf("ExtractMediaText: %d bytes of %s for session %s (returning empty for now)", len(data), mimeType, sessionID)
@@ -81,12 +305,6 @@ func (m *MeowService) ScanMediaForVirus(ctx context.Context, sessionID string, d
 	return true, nil
 }
 
-func (m *MeowService) GetMediaStorageUsage(ctx context.Context, sessionID string) (int64, error) {
-	// For now, return 0
-	m.logger.Debugf("GetMediaStorageUsage for session %s (returning 0 for now)", sessionID)
-	return 0, nil
-}
-
 func (m *MeowService) CleanupMediaStorage(ctx context.Context, sessionID string, olderThanDays int) error {
 	// For now, just log
 	m.logger.Debugf("CleanupMediaStorage: older than %d days for session %s", olderThanDays, sessionID)
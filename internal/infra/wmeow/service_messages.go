@@ -3,6 +3,7 @@ package wmeow
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"zpmeow/internal/application/ports"
 
@@ -13,68 +14,76 @@ import (
 
 // MessageSender methods - envio de mensagens
 
-func (m *MeowService) SendTextMessage(ctx context.Context, sessionID, to, text string) (*whatsmeow.SendResponse, error) {
-	client := m.getClient(sessionID)
-	if client == nil {
-		return nil, fmt.Errorf("client not found for session %s", sessionID)
-	}
-
-	if !client.IsConnected() {
-		return nil, fmt.Errorf("client not connected for session %s", sessionID)
-	}
-
-	jid, err := waTypes.ParseJID(to)
+func (m *MeowService) SendTextMessage(ctx context.Context, sessionID, to, text string, opts ...ports.SendOptions) (*whatsmeow.SendResponse, error) {
+	message, err := m.messageBuilder.BuildTextMessage(text)
 	if err != nil {
-		return nil, fmt.Errorf("invalid JID %s: %w", to, err)
-	}
-
-	message := &waProto.Message{
-		Conversation: &text,
+		return nil, err
 	}
+	return m.sendMessage(ctx, sessionID, to, message, opts...)
+}
 
-	resp, err := client.GetClient().SendMessage(ctx, jid, message)
+func (m *MeowService) SendImageMessage(ctx context.Context, sessionID, to string, data []byte, caption, mimeType string, opts ...ports.SendOptions) (*whatsmeow.SendResponse, error) {
+	uploaded, err := m.uploadMedia(sessionID, data, whatsmeow.MediaImage)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send message: %w", err)
+		return nil, err
 	}
-
-	return &resp, nil
+	return m.sendMessage(ctx, sessionID, to, m.messageBuilder.BuildImageMessage(uploaded, caption, mimeType), opts...)
 }
 
-func (m *MeowService) SendImageMessage(ctx context.Context, sessionID, to string, data []byte, caption, mimeType string) (*whatsmeow.SendResponse, error) {
-	// For now, send as text message
-	return m.SendTextMessage(ctx, sessionID, to, caption)
+func (m *MeowService) SendAudioMessage(ctx context.Context, sessionID, to string, data []byte, mimeType string, opts ...ports.SendOptions) (*whatsmeow.SendResponse, error) {
+	return m.SendAudioMessageWithPTT(ctx, sessionID, to, data, mimeType, false, opts...)
 }
 
-func (m *MeowService) SendAudioMessage(ctx context.Context, sessionID, to string, data []byte, mimeType string) (*whatsmeow.SendResponse, error) {
-	// For now, send as text message
-	return m.SendTextMessage(ctx, sessionID, to, "Audio message")
+func (m *MeowService) SendVideoMessage(ctx context.Context, sessionID, to string, data []byte, caption, mimeType string, opts ...ports.SendOptions) (*whatsmeow.SendResponse, error) {
+	uploaded, err := m.uploadMedia(sessionID, data, whatsmeow.MediaVideo)
+	if err != nil {
+		return nil, err
+	}
+	return m.sendMessage(ctx, sessionID, to, m.messageBuilder.BuildVideoMessage(uploaded, caption, mimeType), opts...)
 }
 
-func (m *MeowService) SendVideoMessage(ctx context.Context, sessionID, to string, data []byte, caption, mimeType string) (*whatsmeow.SendResponse, error) {
-	// For now, send as text message
-	return m.SendTextMessage(ctx, sessionID, to, caption)
+func (m *MeowService) SendDocumentMessage(ctx context.Context, sessionID, to string, data []byte, filename, caption, mimeType string, opts ...ports.SendOptions) (*whatsmeow.SendResponse, error) {
+	uploaded, err := m.uploadMedia(sessionID, data, whatsmeow.MediaDocument)
+	if err != nil {
+		return nil, err
+	}
+	return m.sendMessage(ctx, sessionID, to, m.messageBuilder.BuildDocumentMessage(uploaded, filename, caption, mimeType), opts...)
 }
 
-func (m *MeowService) SendDocumentMessage(ctx context.Context, sessionID, to string, data []byte, filename, mimetype, caption string) (*whatsmeow.SendResponse, error) {
-	// For now, send as text message
-	return m.SendTextMessage(ctx, sessionID, to, "Document: "+filename)
+func (m *MeowService) SendStickerMessage(ctx context.Context, sessionID, to string, data []byte, mimeType string, opts ...ports.SendOptions) (*whatsmeow.SendResponse, error) {
+	uploaded, err := m.uploadMedia(sessionID, data, whatsmeow.MediaImage)
+	if err != nil {
+		return nil, err
+	}
+	return m.sendMessage(ctx, sessionID, to, m.messageBuilder.BuildStickerMessage(uploaded, mimeType), opts...)
 }
 
-func (m *MeowService) SendStickerMessage(ctx context.Context, sessionID, to string, data []byte, mimeType string) (*whatsmeow.SendResponse, error) {
-	// For now, send as text message
-	return m.SendTextMessage(ctx, sessionID, to, "Sticker")
-}
+func (m *MeowService) SendContactMessage(ctx context.Context, sessionID, to string, contacts []ports.ContactInfo, opts ...ports.SendOptions) (*whatsmeow.SendResponse, error) {
+	if len(contacts) == 0 {
+		return nil, newValidationError("contacts", "cannot be empty")
+	}
 
-func (m *MeowService) SendContactMessage(ctx context.Context, sessionID, to string, contacts []ports.ContactInfo) (*whatsmeow.SendResponse, error) {
-	// For now, send as text message
-	contactText := fmt.Sprintf("Contact: %d contacts", len(contacts))
-	return m.SendTextMessage(ctx, sessionID, to, contactText)
+	if len(contacts) == 1 {
+		return m.sendMessage(ctx, sessionID, to, m.messageBuilder.BuildContactMessage(contacts[0].Name, contacts[0].Phone), opts...)
+	}
+
+	// Vários contatos vão em um único ContactsArrayMessage
+	cards := make([]*waProto.ContactMessage, 0, len(contacts))
+	for _, contact := range contacts {
+		cards = append(cards, m.messageBuilder.BuildContactMessage(contact.Name, contact.Phone).GetContactMessage())
+	}
+	displayName := fmt.Sprintf("%d contacts", len(contacts))
+	message := &waProto.Message{
+		ContactsArrayMessage: &waProto.ContactsArrayMessage{
+			DisplayName: &displayName,
+			Contacts:    cards,
+		},
+	}
+	return m.sendMessage(ctx, sessionID, to, message, opts...)
 }
 
-func (m *MeowService) SendLocationMessage(ctx context.Context, sessionID, to string, latitude, longitude float64, name, address string) (*whatsmeow.SendResponse, error) {
-	// For now, send as text message
-	locationText := fmt.Sprintf("Location: %s at %f,%f", name, latitude, longitude)
-	return m.SendTextMessage(ctx, sessionID, to, locationText)
+func (m *MeowService) SendLocationMessage(ctx context.Context, sessionID, to string, latitude, longitude float64, name, address string, opts ...ports.SendOptions) (*whatsmeow.SendResponse, error) {
+	return m.sendMessage(ctx, sessionID, to, m.messageBuilder.BuildLocationMessage(latitude, longitude, name, address), opts...)
 }
 
 func (m *MeowService) SendTemplateMessage(ctx context.Context, sessionID, to string, template map[string]interface{}) (*whatsmeow.SendResponse, error) {
@@ -94,10 +103,92 @@ func (m *MeowService) SendListMessage(ctx context.Context, sessionID, to, text,
 	return m.SendTextMessage(ctx, sessionID, to, listText)
 }
 
-func (m *MeowService) SendPollMessage(ctx context.Context, sessionID, to, question string, options []string, maxSelections int) (*whatsmeow.SendResponse, error) {
-	// For now, send as text message
-	pollText := fmt.Sprintf("Poll: %s (with %d options, max %d selections)", question, len(options), maxSelections)
-	return m.SendTextMessage(ctx, sessionID, to, pollText)
+func (m *MeowService) SendPollMessage(ctx context.Context, sessionID, to, question string, options []string, maxSelections int, opts ...ports.SendOptions) (*whatsmeow.SendResponse, error) {
+	client, err := m.getConnectedClient(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return m.sendMessage(ctx, sessionID, to, client.GetClient().BuildPollCreation(question, options, maxSelections), opts...)
+}
+
+// sendMessage envia a mensagem e registra o envio em zpMessages.
+// Todo envio da API passa por aqui para que histórico e status fiquem completos desde o início
+func (m *MeowService) sendMessage(ctx context.Context, sessionID, to string, message *waProto.Message, opts ...ports.SendOptions) (*whatsmeow.SendResponse, error) {
+	client, err := m.getConnectedClient(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	jid, err := m.parseRecipient(to)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, opt := range opts {
+		if opt.IsEmpty() {
+			continue
+		}
+		contextInfo, err := m.buildContextInfo(ctx, sessionID, client, jid, opt)
+		if err != nil {
+			return nil, err
+		}
+		applyContextInfo(message, contextInfo)
+	}
+
+	resp, err := client.GetClient().SendMessage(ctx, jid, message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send message: %w", err)
+	}
+
+	// A mensagem já foi entregue ao servidor: falha ao persistir não deve falhar o envio
+	if err := m.persistOutgoingMessage(ctx, sessionID, client, jid, message, &resp); err != nil {
+		m.logger.Errorf("Failed to persist outgoing message %s for session %s: %v", resp.ID, sessionID, err)
+	}
+
+	return &resp, nil
+}
+
+func (m *MeowService) getConnectedClient(sessionID string) (*WameowClient, error) {
+	client := m.getClient(sessionID)
+	if client == nil {
+		return nil, fmt.Errorf("client not found for session %s", sessionID)
+	}
+
+	if !client.IsConnected() {
+		return nil, fmt.Errorf("client not connected for session %s", sessionID)
+	}
+
+	return client, nil
+}
+
+// parseRecipient aceita JID completo (grupos, broadcast) ou apenas o número de telefone
+func (m *MeowService) parseRecipient(to string) (waTypes.JID, error) {
+	if strings.Contains(to, "@") {
+		jid, err := waTypes.ParseJID(to)
+		if err != nil {
+			return waTypes.EmptyJID, fmt.Errorf("invalid JID %s: %w", to, err)
+		}
+		return jid, nil
+	}
+
+	jid, err := m.messageSender.parser.ParseToJID(to)
+	if err != nil {
+		return waTypes.EmptyJID, fmt.Errorf("invalid phone number %s: %w", to, err)
+	}
+	return jid, nil
+}
+
+func (m *MeowService) uploadMedia(sessionID string, data []byte, mediaType whatsmeow.MediaType) (*whatsmeow.UploadResponse, error) {
+	client, err := m.getConnectedClient(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	uploaded, err := m.messageSender.CreateMediaMessage(client.GetClient(), data, mediaType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload media: %w", err)
+	}
+	return uploaded, nil
 }
 
 // ForwardMessage is already implemented in service_actions.go
@@ -2,64 +2,120 @@ package wmeow
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	waTypes "go.mau.fi/whatsmeow/types"
+
+	"zpmeow/internal/application/common"
 	"zpmeow/internal/application/ports"
 )
 
 // PrivacyManager methods - gestão de privacidade
 
 func (m *MeowService) SetPrivacySettings(ctx context.Context, sessionID string, settings ports.PrivacySettings) error {
-	// For now, just log
-	m.logger.Debugf("SetPrivacySettings for session %s", sessionID)
-	return nil
-}
-
+	changes := []struct {
+		setting string
+		value   string
+	}{
+		{"last_seen", settings.LastSeen},
+		{"profile_photo", settings.ProfilePhoto},
+		{"status", settings.Status},
+		{"groups_add_me", settings.GroupsAddMe},
+		{"calls_add_me", settings.CallsAddMe},
+		{"online", settings.Online},
+	}
+
+	// Valida tudo antes de alterar qualquer configuração
+	for _, change := range changes {
+		if change.value == "" {
+			continue
+		}
+		if _, _, err := parsePrivacySetting(change.setting, change.value); err != nil {
+			return err
+		}
+	}
+
+	for _, change := range changes {
+		if change.value == "" {
+			continue
+		}
+		if err := m.SetPrivacySetting(ctx, sessionID, change.setting, change.value); err != nil {
+			return err
+		}
+	}
+
+	return m.SetReadReceiptsPrivacy(ctx, sessionID, settings.ReadReceipts)
+}
+
+// GetPrivacySettings retorna a cópia em cache, consultando o WhatsApp na primeira vez após conectar
 func (m *MeowService) GetPrivacySettings(ctx context.Context, sessionID string) (*ports.PrivacySettings, error) {
-	// For now, return default settings
-	m.logger.Debugf("GetPrivacySettings for session %s (returning defaults for now)", sessionID)
-
-	return &ports.PrivacySettings{
-		LastSeen:     "everyone",
-		ProfilePhoto: "everyone",
-		Status:       "everyone",
-		ReadReceipts: true,
-	}, nil
+	if cached, ok := m.privacyCache.get(sessionID); ok {
+		return cached.toPrivacySettings(), nil
+	}
+
+	client, err := m.getConnectedClient(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := client.GetClient().TryFetchPrivacySettings(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch privacy settings: %w", err)
+	}
+
+	cached := m.privacyCache.storeSettings(sessionID, *settings)
+	return cached.toPrivacySettings(), nil
+}
+
+// SetPrivacySetting altera uma configuração de privacidade; aceita os nomes da API (last_seen) e do WhatsApp (last)
+func (m *MeowService) SetPrivacySetting(ctx context.Context, sessionID, setting, value string) error {
+	settingType, settingValue, err := parsePrivacySetting(setting, value)
+	if err != nil {
+		return err
+	}
+
+	client, err := m.getConnectedClient(sessionID)
+	if err != nil {
+		return err
+	}
+
+	settings, err := client.GetClient().SetPrivacySetting(ctx, settingType, settingValue)
+	if err != nil {
+		return fmt.Errorf("failed to set privacy setting %s: %w", setting, err)
+	}
+
+	m.privacyCache.storeSettings(sessionID, settings)
+	m.logger.Infof("Privacy setting %s set to %s in session %s", settingType, settingValue, sessionID)
+	return nil
 }
 
 func (m *MeowService) SetLastSeenPrivacy(ctx context.Context, sessionID, setting string) error {
-	// For now, just log
-	m.logger.Debugf("SetLastSeenPrivacy: %s for session %s", setting, sessionID)
-	return nil
+	return m.SetPrivacySetting(ctx, sessionID, "last_seen", setting)
 }
 
 func (m *MeowService) SetProfilePhotoPrivacy(ctx context.Context, sessionID, setting string) error {
-	// For now, just log
-	m.logger.Debugf("SetProfilePhotoPrivacy: %s for session %s", setting, sessionID)
-	return nil
+	return m.SetPrivacySetting(ctx, sessionID, "profile_photo", setting)
 }
 
 func (m *MeowService) SetStatusPrivacy(ctx context.Context, sessionID, setting string) error {
-	// For now, just log
-	m.logger.Debugf("SetStatusPrivacy: %s for session %s", setting, sessionID)
-	return nil
+	return m.SetPrivacySetting(ctx, sessionID, "status", setting)
 }
 
 func (m *MeowService) SetReadReceiptsPrivacy(ctx context.Context, sessionID string, enabled bool) error {
-	// For now, just log
-	m.logger.Debugf("SetReadReceiptsPrivacy: %v for session %s", enabled, sessionID)
-	return nil
+	value := string(waTypes.PrivacySettingNone)
+	if enabled {
+		value = string(waTypes.PrivacySettingAll)
+	}
+	return m.SetPrivacySetting(ctx, sessionID, "read_receipts", value)
 }
 
 func (m *MeowService) SetGroupsPrivacy(ctx context.Context, sessionID, setting string) error {
-	// For now, just log
-	m.logger.Debugf("SetGroupsPrivacy: %s for session %s", setting, sessionID)
-	return nil
+	return m.SetPrivacySetting(ctx, sessionID, "groups_add_me", setting)
 }
 
 func (m *MeowService) SetCallsPrivacy(ctx context.Context, sessionID, setting string) error {
-	// For now, just log
-	m.logger.Debugf("SetCallsPrivacy: %s for session %s", setting, sessionID)
-	return nil
+	return m.SetPrivacySetting(ctx, sessionID, "calls_add_me", setting)
 }
 
 func (m *MeowService) BlockUser(ctx context.Context, sessionID, userJID string) error {
@@ -122,16 +178,37 @@ func (m *MeowService) GetTwoStepVerificationStatus(ctx context.Context, sessionI
 	return false, nil
 }
 
+// SetDisappearingMessagesDefault define o timer padrão das novas conversas, em segundos
 func (m *MeowService) SetDisappearingMessagesDefault(ctx context.Context, sessionID string, duration int) error {
-	// For now, just log
-	m.logger.Debugf("SetDisappearingMessagesDefault: %d for session %s", duration, sessionID)
+	timer := time.Duration(duration) * time.Second
+	if !defaultDisappearingTimers[timer] {
+		return fmt.Errorf("%w: invalid disappearing timer %ds, must be 0 (off), 86400 (24h), 604800 (7d) or 7776000 (90d)", common.ErrInvalidInput, duration)
+	}
+
+	client, err := m.getConnectedClient(sessionID)
+	if err != nil {
+		return err
+	}
+
+	if err := client.GetClient().SetDefaultDisappearingTimer(timer); err != nil {
+		return fmt.Errorf("failed to set default disappearing timer: %w", err)
+	}
+
+	m.privacyCache.storeDisappearingTimer(sessionID, timer)
+	m.logger.Infof("Default disappearing timer set to %v in session %s", timer, sessionID)
 	return nil
 }
 
+// GetDisappearingMessagesDefault retorna o timer padrão definido por esta API, ou 0 se ele não for conhecido
 func (m *MeowService) GetDisappearingMessagesDefault(ctx context.Context, sessionID string) (int, error) {
-	// For now, return 0
-	m.logger.Debugf("GetDisappearingMessagesDefault for session %s (returning 0 for now)", sessionID)
-	return 0, nil
+	settings, err := m.GetPrivacySettings(ctx, sessionID)
+	if err != nil {
+		return 0, err
+	}
+	if settings.DisappearingTimer == nil {
+		return 0, nil
+	}
+	return *settings.DisappearingTimer, nil
 }
 
 func (m *MeowService) SetAutoDownloadSettings(ctx context.Context, sessionID string, settings map[string]interface{}) error {
@@ -3,6 +3,8 @@ package wmeow
 import (
 	"context"
 	"fmt"
+
+	"zpmeow/internal/domain/session"
 )
 
 // SessionManager methods - gestão de sessões e conexões
@@ -121,5 +123,30 @@ func (m *MeowService) DisconnectSession(ctx context.Context, sessionID string) e
 	return nil
 }
 
+func (m *MeowService) UpdateSessionProxy(ctx context.Context, sessionID string, proxy session.ProxyConfiguration) error {
+	m.logger.Infof("Updating proxy for session %s", sessionID)
+
+	client := m.getClient(sessionID)
+	if client == nil {
+		m.logger.Debugf("No client for session %s, proxy will be applied on next connect", sessionID)
+		return nil
+	}
+
+	// Sem login não há estado a preservar: recria o cliente para reiniciar o fluxo de QR pelo novo proxy
+	if !client.IsLoggedIn() && client.IsConnected() {
+		if err := m.StopClient(sessionID); err != nil {
+			return fmt.Errorf("failed to stop client for session %s: %w", sessionID, err)
+		}
+		return m.StartClient(sessionID)
+	}
+
+	if err := client.ReconnectWithProxy(proxy); err != nil {
+		return fmt.Errorf("failed to reconnect session %s with new proxy: %w", sessionID, err)
+	}
+
+	m.logger.Infof("Proxy updated for session %s", sessionID)
+	return nil
+}
+
 // Internal helper for session configuration (different from service.go)
 // Note: loadSessionConfigurationInternal was removed as it was unused
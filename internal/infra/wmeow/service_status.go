@@ -0,0 +1,164 @@
+package wmeow
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/proto/waE2E"
+	waTypes "go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+
+	"zpmeow/internal/application/common"
+	"zpmeow/internal/application/ports"
+)
+
+// Cores padrão dos status de texto, as mesmas do aplicativo
+const (
+	defaultStatusBackground = 0xFF075E54
+	defaultStatusTextColor  = 0xFFFFFFFF
+)
+
+func (m *MeowService) PostTextStatus(ctx context.Context, sessionID string, status ports.TextStatus) (*whatsmeow.SendResponse, error) {
+	text := strings.TrimSpace(status.Text)
+	if text == "" {
+		return nil, fmt.Errorf("%w: status text is required", common.ErrInvalidInput)
+	}
+
+	background, err := parseStatusColor(status.BackgroundColor, defaultStatusBackground)
+	if err != nil {
+		return nil, err
+	}
+
+	textColor, err := parseStatusColor(status.TextColor, defaultStatusTextColor)
+	if err != nil {
+		return nil, err
+	}
+
+	font, err := parseStatusFont(status.Font)
+	if err != nil {
+		return nil, err
+	}
+
+	message := &waProto.Message{
+		ExtendedTextMessage: &waProto.ExtendedTextMessage{
+			Text:           proto.String(text),
+			BackgroundArgb: proto.Uint32(background),
+			TextArgb:       proto.Uint32(textColor),
+			Font:           font.Enum(),
+		},
+	}
+	return m.postStatus(ctx, sessionID, message)
+}
+
+func (m *MeowService) PostImageStatus(ctx context.Context, sessionID string, data []byte, caption, mimeType string) (*whatsmeow.SendResponse, error) {
+	uploaded, err := m.uploadMedia(sessionID, data, whatsmeow.MediaImage)
+	if err != nil {
+		return nil, err
+	}
+	return m.postStatus(ctx, sessionID, m.messageBuilder.BuildImageMessage(uploaded, caption, mimeType))
+}
+
+func (m *MeowService) PostVideoStatus(ctx context.Context, sessionID string, data []byte, caption, mimeType string) (*whatsmeow.SendResponse, error) {
+	uploaded, err := m.uploadMedia(sessionID, data, whatsmeow.MediaVideo)
+	if err != nil {
+		return nil, err
+	}
+	return m.postStatus(ctx, sessionID, m.messageBuilder.BuildVideoMessage(uploaded, caption, mimeType))
+}
+
+// GetStatusAudience consulta a privacidade de status da conta, que define quem recebe os status publicados
+func (m *MeowService) GetStatusAudience(ctx context.Context, sessionID string) (*ports.StatusAudience, error) {
+	client, err := m.getConnectedClient(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := client.GetClient().GetStatusPrivacy()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status privacy: %w", err)
+	}
+
+	// A primeira configuração é sempre a padrão, usada nas publicações
+	audience := &ports.StatusAudience{Type: string(waTypes.StatusPrivacyTypeContacts)}
+	if len(settings) > 0 {
+		audience.Type = string(settings[0].Type)
+		for _, jid := range settings[0].List {
+			audience.List = append(audience.List, jid.String())
+		}
+	}
+	return audience, nil
+}
+
+// postStatus publica a mensagem em status@broadcast e guarda a cópia em zpStatuses.
+// Não passa por sendMessage para que o status não entre no histórico de mensagens.
+func (m *MeowService) postStatus(ctx context.Context, sessionID string, message *waProto.Message) (*whatsmeow.SendResponse, error) {
+	client, err := m.getConnectedClient(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetClient().SendMessage(ctx, waTypes.StatusBroadcastJID, message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to post status: %w", err)
+	}
+
+	if m.statusRepo != nil {
+		postedAt := resp.Timestamp
+		if postedAt.IsZero() {
+			postedAt = time.Now()
+		}
+		sender := client.GetJID().ToNonAD().String()
+		status := newStatusModel(sessionID, resp.ID, sender, "", message, true, postedAt)
+		if status != nil {
+			if _, err := m.statusRepo.Create(ctx, status); err != nil {
+				m.logger.Errorf("Failed to save posted status %s for session %s: %v", resp.ID, sessionID, err)
+			}
+		}
+	}
+
+	m.logger.Infof("Posted status %s for session %s", resp.ID, sessionID)
+	return &resp, nil
+}
+
+// parseStatusColor aceita #RRGGBB (opaca) ou #AARRGGBB; vazio usa a cor padrão
+func parseStatusColor(color string, fallback uint32) (uint32, error) {
+	color = strings.TrimPrefix(strings.TrimSpace(color), "#")
+	if color == "" {
+		return fallback, nil
+	}
+
+	if len(color) != 6 && len(color) != 8 {
+		return 0, fmt.Errorf("%w: invalid color %q, use #RRGGBB or #AARRGGBB", common.ErrInvalidInput, color)
+	}
+
+	value, err := strconv.ParseUint(color, 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid color %q, use #RRGGBB or #AARRGGBB", common.ErrInvalidInput, color)
+	}
+	if len(color) == 6 {
+		value |= 0xFF000000
+	}
+	return uint32(value), nil
+}
+
+// parseStatusFont converte o nome da fonte (system, fb_script, system_bold...) no tipo do WhatsApp
+func parseStatusFont(font string) (waProto.ExtendedTextMessage_FontType, error) {
+	font = strings.TrimSpace(font)
+	if font == "" {
+		return waProto.ExtendedTextMessage_SYSTEM, nil
+	}
+
+	value, ok := waProto.ExtendedTextMessage_FontType_value[strings.ToUpper(font)]
+	if !ok {
+		names := make([]string, 0, len(waProto.ExtendedTextMessage_FontType_value))
+		for name := range waProto.ExtendedTextMessage_FontType_value {
+			names = append(names, strings.ToLower(name))
+		}
+		return 0, fmt.Errorf("%w: invalid font %q, must be one of: %s", common.ErrInvalidInput, font, strings.Join(names, ", "))
+	}
+	return waProto.ExtendedTextMessage_FontType(value), nil
+}
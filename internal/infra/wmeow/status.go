@@ -0,0 +1,166 @@
+package wmeow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	waProto "go.mau.fi/whatsmeow/proto/waE2E"
+	waTypes "go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"zpmeow/internal/infra/database/models"
+)
+
+// EventStatusReceived é o evento normalizado dos status (stories) publicados pelos contatos
+const EventStatusReceived = "status.received"
+
+// StatusLifetime é quanto tempo um status fica visível no WhatsApp
+const StatusLifetime = 24 * time.Hour
+
+const statusStoreTimeout = 10 * time.Second
+
+// statusTypes são os tipos de mensagem que podem ser publicados como status
+var statusTypes = map[string]bool{
+	"text":  true,
+	"image": true,
+	"video": true,
+	"audio": true,
+	"ptt":   true,
+}
+
+// statusReceived é o payload do webhook status.received
+type statusReceived struct {
+	ID              string `json:"id"`
+	Sender          string `json:"sender"`
+	SenderName      string `json:"sender_name,omitempty"`
+	Type            string `json:"type"`
+	Content         string `json:"content,omitempty"` // texto do status ou legenda da mídia
+	MimeType        string `json:"mime_type,omitempty"`
+	BackgroundColor string `json:"background_color,omitempty"`
+	TextColor       string `json:"text_color,omitempty"`
+	Font            string `json:"font,omitempty"`
+	Timestamp       int64  `json:"timestamp"`
+	ExpiresAt       int64  `json:"expires_at"`
+}
+
+// handleStatusMessage grava em zpStatuses o status recebido e emite o status.received;
+// revogações em status@broadcast marcam o status como apagado pelo autor
+func (ep *EventProcessor) handleStatusMessage(msg *events.Message) {
+	if ep.statusRepo == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), statusStoreTimeout)
+	defer cancel()
+
+	sender := statusSender(msg.Info)
+
+	if protocol := msg.Message.GetProtocolMessage(); protocol != nil {
+		if protocol.GetType() != waProto.ProtocolMessage_REVOKE {
+			return
+		}
+		if _, err := ep.statusRepo.Revoke(ctx, ep.sessionID, sender, protocol.GetKey().GetID(), msg.Info.Timestamp); err != nil {
+			ep.logger.Errorf("Failed to revoke status %s from %s in session %s: %v", protocol.GetKey().GetID(), sender, ep.sessionID, err)
+		}
+		return
+	}
+
+	status := newStatusModel(ep.sessionID, msg.Info.ID, sender, msg.Info.PushName, msg.Message, msg.Info.IsFromMe, msg.Info.Timestamp)
+	if status == nil {
+		ep.logger.Debugf("Ignoring unsupported status message %s from %s in session %s", msg.Info.ID, sender, ep.sessionID)
+		return
+	}
+
+	created, err := ep.statusRepo.Create(ctx, status)
+	if err != nil {
+		ep.logger.Errorf("Failed to save status %s from %s in session %s: %v", msg.Info.ID, sender, ep.sessionID, err)
+		return
+	}
+
+	// Os próprios status, publicados em outro aparelho, ficam guardados mas não são notificados
+	if created && !status.IsFromMe {
+		ep.logger.Infof("Status %s (%s) received from %s in session %s", status.StatusId, status.Type, sender, ep.sessionID)
+		ep.sendNormalizedEvent(EventStatusReceived, newStatusReceived(status))
+	}
+}
+
+// statusSender prefere o JID do telefone ao LID, para que os status possam ser consultados pelo número
+func statusSender(info waTypes.MessageInfo) string {
+	if info.Sender.Server == waTypes.HiddenUserServer && !info.SenderAlt.IsEmpty() {
+		return info.SenderAlt.ToNonAD().String()
+	}
+	return info.Sender.ToNonAD().String()
+}
+
+// newStatusModel converte a mensagem publicada em status@broadcast; retorna nil para os tipos
+// que não são status (reações, votos, mensagens de protocolo)
+func newStatusModel(sessionID, statusID, sender, senderName string, message *waProto.Message, fromMe bool, postedAt time.Time) *models.StatusModel {
+	if message.GetReactionMessage() != nil || message.GetEncReactionMessage() != nil {
+		return nil
+	}
+
+	msgType, content, mediaInfo := describeMessage(message)
+	if !statusTypes[msgType] || (msgType == "text" && content == "") {
+		return nil
+	}
+
+	status := &models.StatusModel{
+		SessionId: sessionID,
+		StatusId:  statusID,
+		SenderJid: sender,
+		Type:      msgType,
+		MediaInfo: mediaInfo,
+		IsFromMe:  fromMe,
+		PostedAt:  postedAt,
+		ExpiresAt: postedAt.Add(StatusLifetime),
+	}
+	if senderName != "" {
+		status.SenderName = &senderName
+	}
+	if content != "" {
+		status.Content = &content
+	}
+
+	if text := message.GetExtendedTextMessage(); text != nil {
+		if text.BackgroundArgb != nil {
+			color := formatStatusColor(text.GetBackgroundArgb())
+			status.BackgroundColor = &color
+		}
+		if text.TextArgb != nil {
+			color := formatStatusColor(text.GetTextArgb())
+			status.TextColor = &color
+		}
+		if text.Font != nil {
+			font := strings.ToLower(text.GetFont().String())
+			status.Font = &font
+		}
+	}
+
+	return status
+}
+
+func newStatusReceived(status *models.StatusModel) statusReceived {
+	payload := statusReceived{
+		ID:              status.StatusId,
+		Sender:          status.SenderJid,
+		SenderName:      getStringValue(status.SenderName),
+		Type:            status.Type,
+		Content:         getStringValue(status.Content),
+		BackgroundColor: getStringValue(status.BackgroundColor),
+		TextColor:       getStringValue(status.TextColor),
+		Font:            getStringValue(status.Font),
+		Timestamp:       status.PostedAt.Unix(),
+		ExpiresAt:       status.ExpiresAt.Unix(),
+	}
+	if mimeType, ok := status.MediaInfo["mimeType"].(string); ok {
+		payload.MimeType = mimeType
+	}
+	return payload
+}
+
+// formatStatusColor formata a cor ARGB do WhatsApp como #AARRGGBB
+func formatStatusColor(argb uint32) string {
+	return fmt.Sprintf("#%08X", argb)
+}